@@ -149,6 +149,10 @@ type Agent struct {
 	// created for executing the current task.
 	currentTaskDir string
 
+	// taskGroup holds the task group whose working directory is being kept
+	// for the group's next task, if any.
+	taskGroup *taskGroupState
+
 	// agent's runtime configuration options.
 	opts Options
 }
@@ -188,8 +192,15 @@ func (agt *Agent) finishAndAwaitCleanup(status string) (*apimodels.EndTaskRespon
 	}
 	agt.cleanup(agt.GetCurrentTaskId())
 
-	err := agt.removeTaskDirectory()
-	if err != nil {
+	if agt.taskGroup != nil {
+		tg := agt.taskConfig.Project.FindTaskGroup(agt.taskGroup.name)
+		if tg != nil && tg.NextTaskOnVariant(agt.taskConfig.Task.DisplayName, agt.taskConfig.BuildVariant) != "" {
+			agt.logger.LogExecution(slogger.INFO, "Keeping task directory for the next task in task group '%v'.",
+				agt.taskGroup.name)
+		} else {
+			agt.teardownTaskGroup()
+		}
+	} else if err := agt.removeTaskDirectory(); err != nil {
 		agt.logger.LogExecution(slogger.ERROR, "Error removing task directory: %v", err)
 	}
 
@@ -486,6 +497,8 @@ func (agt *Agent) Reset() error {
 // It returns an exit code when the agent needs to exit
 func (agt *Agent) Run() error {
 	var currentTask string
+	// a kept task group directory won't be used again once the agent exits
+	defer agt.endTaskGroup()
 	// this loop continues until the agent exits
 	for {
 		var err error
@@ -501,6 +514,9 @@ func (agt *Agent) Run() error {
 			if hasTask {
 				break nextTaskLoop
 			}
+			// the group's next task isn't ready to run, so don't keep
+			// its directory around while waiting
+			agt.endTaskGroup()
 			grip.Infof("Agent sleeping %v", DefaultAgentSleepInterval)
 			time.Sleep(DefaultAgentSleepInterval)
 		}
@@ -587,13 +603,14 @@ func (agt *Agent) RunTask() (*apimodels.EndTaskResponse, error) {
 		agt.logger.LogExecution(slogger.ERROR, "error fetching project expansion variables: %v", err)
 		return nil, err
 	}
-	taskConfig.Expansions.Update(*expVars)
+	taskConfig.Expansions.Update(expVars.Vars)
+	agt.APILogger.SetRedactedValues(privateValues(expVars))
 	agt.taskConfig = taskConfig
 
 	// start the heartbeater, timeout watcher, system stats collector, and signal listener
 	agt.StartBackgroundActions(agt.signalHandler)
 
-	err = agt.createTaskDirectory(taskConfig)
+	newTaskGroup, err := agt.prepareTaskDirectory(taskConfig)
 	if err != nil {
 		agt.signalHandler.directoryChan <- comm.DirectoryFailure
 		return nil, err
@@ -607,6 +624,18 @@ func (agt *Agent) RunTask() (*apimodels.EndTaskResponse, error) {
 		return agt.finishAndAwaitCleanup(evergreen.TaskFailed)
 	}
 
+	if newTaskGroup {
+		tg := taskConfig.Project.FindTaskGroup(taskConfig.Task.TaskGroup)
+		if tg != nil && tg.SetupGroup != nil {
+			agt.logger.LogExecution(slogger.INFO, "Running setup commands for task group '%v'.", tg.Name)
+			err = agt.RunCommands(tg.SetupGroup.List(), false, agt.callbackTimeoutSignal())
+			if err != nil {
+				agt.logger.LogExecution(slogger.ERROR, "Running task group setup failed: %v", err)
+			}
+			agt.logger.LogExecution(slogger.INFO, "Finished running setup commands for task group '%v'.", tg.Name)
+		}
+	}
+
 	if taskConfig.Project.Pre != nil {
 		agt.logger.LogExecution(slogger.INFO, "Running pre-task commands.")
 		err = agt.RunCommands(taskConfig.Project.Pre.List(), false, agt.callbackTimeoutSignal())
@@ -753,6 +782,18 @@ func registerPlugins(registry plugin.Registry, plugins []plugin.CommandPlugin, l
 	return nil
 }
 
+// privateValues returns the values of a project's private variables, which
+// must be masked in the task's logs.
+func privateValues(expVars *apimodels.ExpansionVars) []string {
+	values := []string{}
+	for name, private := range expVars.PrivateVars {
+		if private {
+			values = append(values, expVars.Vars[name])
+		}
+	}
+	return values
+}
+
 // callbackTimeoutSignal creates a stop channel that closes after
 // the the project's CallbackTimeout has passed. Uses the default
 // value if none is set.
@@ -822,6 +863,92 @@ func (agt *Agent) createTaskDirectory(taskConfig *model.TaskConfig) error {
 	return nil
 }
 
+// taskGroupState identifies the task group, and the build it was run for,
+// that a kept task directory belongs to.
+type taskGroupState struct {
+	name    string
+	buildId string
+	dir     string
+
+	// config is the configuration of the group's most recent task, used to
+	// run the group's teardown commands.
+	config *model.TaskConfig
+}
+
+// prepareTaskDirectory changes into the directory the task should run in. A
+// task that follows another member of its task group in the same build reuses
+// the group's directory; otherwise any kept directory is torn down and a new
+// one is created. It returns true if the task starts a new task group, in
+// which case the group's setup commands should be run.
+func (agt *Agent) prepareTaskDirectory(taskConfig *model.TaskConfig) (bool, error) {
+	if agt.taskGroup != nil {
+		if agt.taskGroup.name == taskConfig.Task.TaskGroup && agt.taskGroup.buildId == taskConfig.Task.BuildId {
+			agt.logger.LogExecution(slogger.INFO, "Changing into task group directory: %v", agt.taskGroup.dir)
+			if err := os.Chdir(agt.taskGroup.dir); err != nil {
+				agt.logger.LogExecution(slogger.ERROR, "Error changing into task group directory: %v", err)
+				return false, err
+			}
+			agt.currentTaskDir = agt.taskGroup.dir
+			agt.taskGroup.config = taskConfig
+			taskConfig.WorkDir = agt.currentTaskDir
+			return false, nil
+		}
+		agt.teardownTaskGroup()
+	}
+
+	if err := agt.createTaskDirectory(taskConfig); err != nil {
+		return false, err
+	}
+	if taskConfig.Task.TaskGroup == "" {
+		return false, nil
+	}
+	agt.taskGroup = &taskGroupState{
+		name:    taskConfig.Task.TaskGroup,
+		buildId: taskConfig.Task.BuildId,
+		dir:     agt.currentTaskDir,
+		config:  taskConfig,
+	}
+	return true, nil
+}
+
+// teardownTaskGroup runs the teardown commands of the kept task group and
+// removes its directory.
+func (agt *Agent) teardownTaskGroup() {
+	state := agt.taskGroup
+	agt.taskGroup = nil
+
+	tg := state.config.Project.FindTaskGroup(state.name)
+	if tg != nil && tg.TeardownGroup != nil {
+		agt.logger.LogExecution(slogger.INFO, "Running teardown commands for task group '%v'.", state.name)
+		// the teardown commands run in the directory and with the
+		// configuration of the group, which may belong to an earlier task
+		currentConfig := agt.taskConfig
+		agt.taskConfig = state.config
+		if err := os.Chdir(state.dir); err != nil {
+			agt.logger.LogExecution(slogger.ERROR, "Error changing into task group directory: %v", err)
+		} else if err = agt.RunCommands(tg.TeardownGroup.List(), false, agt.callbackTimeoutSignal()); err != nil {
+			agt.logger.LogExecution(slogger.ERROR, "Running task group teardown failed: %v", err)
+		}
+		agt.taskConfig = currentConfig
+		agt.logger.LogExecution(slogger.INFO, "Finished running teardown commands for task group '%v'.", state.name)
+	}
+
+	agt.currentTaskDir = state.dir
+	if err := agt.removeTaskDirectory(); err != nil {
+		agt.logger.LogExecution(slogger.ERROR, "Error removing task group directory: %v", err)
+	}
+}
+
+// endTaskGroup tears down the kept task group, if there is one, and flushes
+// the teardown's logs.
+func (agt *Agent) endTaskGroup() {
+	if agt.taskGroup == nil {
+		return
+	}
+	agt.teardownTaskGroup()
+	agt.APILogger.FlushAndWait()
+}
+
 // stop is only called in deferred statements in testing, but makes it
 // possible to kill the background process in an agent
 func (agt *Agent) stop() {
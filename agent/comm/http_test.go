@@ -259,17 +259,19 @@ func TestCommunicatorServerUp(t *testing.T) {
 		})
 
 		Convey("fetching expansions should work", func() {
-			test_vars := apimodels.ExpansionVars{}
-			test_vars["test_key"] = "test_value"
-			test_vars["second_fetch"] = "more_one"
+			test_vars := apimodels.ExpansionVars{Vars: map[string]string{}}
+			test_vars.Vars["test_key"] = "test_value"
+			test_vars.Vars["second_fetch"] = "more_one"
+			test_vars.PrivateVars = map[string]bool{"second_fetch": true}
 			serveMux.HandleFunc("/task/mocktaskid/fetch_vars", func(w http.ResponseWriter, req *http.Request) {
 				util.WriteJSON(&w, test_vars, http.StatusOK)
 			})
 			resultingVars, err := agentCommunicator.FetchExpansionVars()
 			So(err, ShouldBeNil)
-			So(len(*resultingVars), ShouldEqual, 2)
-			So((*resultingVars)["test_key"], ShouldEqual, "test_value")
-			So((*resultingVars)["second_fetch"], ShouldEqual, "more_one")
+			So(len(resultingVars.Vars), ShouldEqual, 2)
+			So(resultingVars.Vars["test_key"], ShouldEqual, "test_value")
+			So(resultingVars.Vars["second_fetch"], ShouldEqual, "more_one")
+			So(resultingVars.PrivateVars["second_fetch"], ShouldBeTrue)
 
 		})
 	})
@@ -2,6 +2,7 @@ package comm
 
 import (
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +17,9 @@ import (
 	"github.com/mongodb/grip/slogger"
 )
 
+// RedactedValue replaces secret values in log messages sent to the server.
+const RedactedValue = "<REDACTED>"
+
 // StreamLogger holds a set of stream-delineated loggers. Each logger is used
 // to communicate different kinds of logs to the API Server or local file system.
 // StreamLogger is used to distinguish logs of system statistics, shell output,
@@ -206,6 +210,11 @@ type APILogger struct {
 	// if both a flush/append happen concurrently
 	appendLock sync.Mutex
 
+	// values that must never reach the server, such as private project
+	// variables, which are replaced in messages before they're buffered.
+	// Protected by appendLock.
+	redactedValues []string
+
 	// last time flush actually flushed lines
 	lastFlush time.Time
 
@@ -244,6 +253,15 @@ func NewAPILogger(tc TaskCommunicator) *APILogger {
 func (apiLgr *APILogger) Append(log *slogger.Log) error {
 	message := strings.TrimRight(log.Message(), "\r\t")
 
+	apiLgr.appendLock.Lock()
+	defer apiLgr.appendLock.Unlock()
+
+	// redact before quoting, since quoting escapes characters that the
+	// secret values may contain
+	for _, value := range apiLgr.redactedValues {
+		message = strings.Replace(message, value, RedactedValue, -1)
+	}
+
 	// MCI-972: ensure message is valid UTF-8
 	if !utf8.ValidString(message) {
 		message = strconv.QuoteToASCII(message)
@@ -257,8 +275,6 @@ func (apiLgr *APILogger) Append(log *slogger.Log) error {
 		Message:   message,
 	}
 
-	apiLgr.appendLock.Lock()
-	defer apiLgr.appendLock.Unlock()
 	apiLgr.messages = append(apiLgr.messages, *logMessage)
 
 	if len(apiLgr.messages) < apiLgr.SendAfterLines ||
@@ -271,6 +287,42 @@ func (apiLgr *APILogger) Append(log *slogger.Log) error {
 	return nil
 }
 
+// SetRedactedValues sets the values that are masked with RedactedValue in
+// every message appended to the logger from now on. Empty values are ignored.
+// Each line of a multi-line value is masked on its own as well, since
+// commands usually log output one line at a time.
+func (apiLgr *APILogger) SetRedactedValues(values []string) {
+	redacted := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		redacted = append(redacted, value)
+		if !strings.Contains(value, "\n") {
+			continue
+		}
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimRight(line, "\r\t")
+			if line != "" {
+				redacted = append(redacted, line)
+			}
+		}
+	}
+	// replace longer values first so that a value containing another is
+	// masked entirely
+	sort.Sort(byDescendingLength(redacted))
+
+	apiLgr.appendLock.Lock()
+	defer apiLgr.appendLock.Unlock()
+	apiLgr.redactedValues = redacted
+}
+
+type byDescendingLength []string
+
+func (s byDescendingLength) Len() int           { return len(s) }
+func (s byDescendingLength) Less(i, j int) bool { return len(s[i]) > len(s[j]) }
+func (s byDescendingLength) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
 func (apiLgr *APILogger) sendLogs(flushMsgs []model.LogMessage) int {
 	start := time.Now()
 	apiLgr.flushLock.Lock()
@@ -67,6 +67,43 @@ func TestLogging(t *testing.T) {
 			}
 		})
 
+		Convey("Redacted values should be masked before being sent", func() {
+			apiLogger.SetRedactedValues([]string{"secret", "", "secret2"})
+			testLogger.Logf(slogger.INFO, "the password is secret2, not secret")
+			apiLogger.Flush()
+
+			receivedMsgs, ok := <-taskCommunicator.LogChan
+			So(ok, ShouldBeTrue)
+			So(len(receivedMsgs), ShouldEqual, 1)
+			So(receivedMsgs[0].Message, ShouldEqual,
+				"the password is "+RedactedValue+", not "+RedactedValue)
+		})
+
+		Convey("Redacted values should be masked before invalid UTF-8 is quoted", func() {
+			apiLogger.SetRedactedValues([]string{"pass\"word\xff"})
+			testLogger.Logf(slogger.INFO, "the password is pass\"word\xff\xfe")
+			apiLogger.Flush()
+
+			receivedMsgs, ok := <-taskCommunicator.LogChan
+			So(ok, ShouldBeTrue)
+			So(len(receivedMsgs), ShouldEqual, 1)
+			So(receivedMsgs[0].Message, ShouldContainSubstring, RedactedValue)
+			So(receivedMsgs[0].Message, ShouldNotContainSubstring, "word")
+		})
+
+		Convey("Each line of a multi-line redacted value should be masked", func() {
+			apiLogger.SetRedactedValues([]string{"-----BEGIN KEY-----\r\nabc123\n-----END KEY-----\n"})
+			testLogger.Logf(slogger.INFO, "abc123")
+			testLogger.Logf(slogger.INFO, "-----END KEY-----")
+			apiLogger.Flush()
+
+			receivedMsgs, ok := <-taskCommunicator.LogChan
+			So(ok, ShouldBeTrue)
+			So(len(receivedMsgs), ShouldEqual, 2)
+			So(receivedMsgs[0].Message, ShouldEqual, RedactedValue)
+			So(receivedMsgs[1].Message, ShouldEqual, RedactedValue)
+		})
+
 	})
 }
 
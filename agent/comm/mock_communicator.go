@@ -156,5 +156,5 @@ func (mc *MockCommunicator) Heartbeat() (bool, error) {
 }
 
 func (*MockCommunicator) FetchExpansionVars() (*apimodels.ExpansionVars, error) {
-	return &apimodels.ExpansionVars{}, nil
+	return &apimodels.ExpansionVars{Vars: map[string]string{}}, nil
 }
@@ -7,12 +7,15 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/subscription"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/notify"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/render"
 	"github.com/mongodb/grip"
@@ -21,8 +24,9 @@ import (
 )
 
 const (
-	EmailProvider = "email"
-	JiraProvider  = "jira"
+	EmailProvider   = "email"
+	JiraProvider    = "jira"
+	WebhookProvider = "webhook"
 )
 
 // QueueProcessor handles looping over any unprocessed alerts in the queue and delivers them
@@ -31,6 +35,7 @@ type QueueProcessor struct {
 	superUsersConfigs []model.AlertConfig
 	projectsCache     map[string]*model.ProjectRef
 	render            *render.Render
+	mailer            notify.Mailer
 }
 
 // Deliverer is an interface which handles the actual delivery of an alert.
@@ -50,6 +55,7 @@ type AlertContext struct {
 	Patch        *patch.Patch
 	Host         *host.Host
 	FailedTests  []task.TestResult
+	FailedTasks  []task.Task
 	Settings     *evergreen.Settings
 }
 
@@ -150,6 +156,13 @@ func (qp *QueueProcessor) loadAlertContext(a *alert.AlertRequest) (*AlertContext
 		}
 	}
 
+	if a.Trigger == alertrecord.PatchFinishedId && aCtx.Patch != nil {
+		aCtx.FailedTasks, err = findFailedPatchTasks(aCtx.Patch.Version)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	if len(projectId) > 0 {
 		aCtx.ProjectRef, err = qp.findProject(projectId)
 		if err != nil {
@@ -226,6 +239,8 @@ func (qp *QueueProcessor) getDeliverer(alertConf model.AlertConfig) (Deliverer,
 	switch alertConf.Provider {
 	case JiraProvider:
 		return qp.newJIRAProvider(alertConf)
+	case WebhookProvider:
+		return qp.newWebhookProvider(alertConf)
 	case EmailProvider:
 		return &EmailDeliverer{
 			SMTPSettings{
@@ -244,15 +259,36 @@ func (qp *QueueProcessor) getDeliverer(alertConf model.AlertConfig) (Deliverer,
 }
 
 func (qp *QueueProcessor) Deliver(req *alert.AlertRequest, ctx *AlertContext) error {
+	if req.Trigger == alertrecord.PatchFinishedId {
+		// Patch alerts go to the patch's author
+		if ctx.Patch == nil {
+			return errors.Errorf("patch for alert %v not found", req.Id.Hex())
+		}
+		deliverers, alertConfigs, err := qp.patchAuthorDeliverers(ctx.Patch)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get notifications for patch %v", ctx.Patch.Id.Hex())
+		}
+		for i, deliverer := range deliverers {
+			if err = deliverer.Deliver(*ctx, alertConfigs[i]); err != nil {
+				return errors.Wrap(err, "Failed to send alert")
+			}
+		}
+		return nil
+	}
+
 	var alertConfigs []model.AlertConfig
 	if ctx.ProjectRef != nil {
 		// Project-specific alert - use alert configs defined on the project
-		// TODO(EVG-223) patch alerts should go to patch owner
 		alertConfigs = ctx.ProjectRef.Alerts[req.Trigger]
 	} else if ctx.Host != nil {
 		// Host-specific alert - use superuser alert configs for now
 		// TODO(EVG-224) spawnhost alerts should go to spawnhost owner
 		alertConfigs = qp.superUsersConfigs
+		ownerConfigs, err := spawnHostOwnerConfigs(ctx.Host)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to get subscriptions for host %v", ctx.Host.Id)
+		}
+		alertConfigs = append(ownerConfigs, alertConfigs...)
 	}
 
 	for _, alertConfig := range alertConfigs {
@@ -268,6 +304,29 @@ func (qp *QueueProcessor) Deliver(req *alert.AlertRequest, ctx *AlertContext) er
 	return nil
 }
 
+// spawnHostOwnerConfigs returns alert configs to email the owner of a spawn
+// host, if they have subscribed to its expiration warnings.
+func spawnHostOwnerConfigs(h *host.Host) ([]model.AlertConfig, error) {
+	if h.StartedBy == "" || h.StartedBy == evergreen.User {
+		return nil, nil
+	}
+	subs, err := subscription.Find(subscription.ByPersonAndTrigger(h.StartedBy, subscription.SpawnHostExpiring))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(subs) == 0 {
+		return nil, nil
+	}
+	owner, err := user.FindOne(user.ById(h.StartedBy))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if owner == nil {
+		return nil, errors.Errorf("user %v not found", h.StartedBy)
+	}
+	return []model.AlertConfig{{EmailProvider, bson.M{"recipient": owner.Email()}}}, nil
+}
+
 // Run loops while there are any unprocessed alerts and attempts to deliver them.
 func (qp *QueueProcessor) Run(config *evergreen.Settings) error {
 	grip.Info("Starting alert queue processor run")
@@ -280,6 +339,7 @@ func (qp *QueueProcessor) Run(config *evergreen.Settings) error {
 		TextFuncs:    nil,
 		HtmlFuncs:    nil,
 	})
+	qp.mailer = notify.ConstructMailer(config.Notify)
 
 	if len(qp.config.SuperUsers) == 0 {
 		grip.Warning("no superusers configured, some alerts may have no recipient")
@@ -291,7 +351,7 @@ func (qp *QueueProcessor) Run(config *evergreen.Settings) error {
 	}
 	qp.superUsersConfigs = []model.AlertConfig{}
 	for _, u := range superUsers {
-		qp.superUsersConfigs = append(qp.superUsersConfigs, model.AlertConfig{"email", bson.M{"rcpt": u.Email()}})
+		qp.superUsersConfigs = append(qp.superUsersConfigs, model.AlertConfig{"email", bson.M{"recipient": u.Email()}})
 	}
 
 	grip.Info("Running alert queue processing")
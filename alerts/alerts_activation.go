@@ -44,6 +44,11 @@ func RunTaskFailureTriggers(taskId string) error {
 	if err != nil {
 		return err
 	}
+	// a task that was reset when it finished, such as to be automatically
+	// retried, has no failure to alert on yet
+	if t != nil && !task.IsFinished(*t) {
+		return nil
+	}
 	ctx, err := getTaskTriggerContext(t)
 	if err != nil {
 		return err
@@ -134,6 +134,8 @@ func getTemplate(alertCtx AlertContext) string {
 		fallthrough
 	case alertrecord.SpawnHostTwelveHourWarning:
 		return "email/host_spawn.html"
+	case alertrecord.PatchFinishedId:
+		return "email/patch_finished.html"
 	default:
 		return "email/task_fail.html"
 	}
@@ -214,6 +216,14 @@ func getSubject(alertCtx AlertContext) string {
 		return fmt.Sprintf("Your %s host (%s) will expire in twelve hours.",
 			alertCtx.Host.Distro, alertCtx.Host.Id)
 		// TODO(EVG-224) alertrecord.SpawnHostExpired:
+	case alertrecord.PatchFinishedId:
+		return patchFinishedSubject(alertCtx)
+	case alertrecord.BisectCulpritFoundId:
+		return fmt.Sprintf("Bisect Found Culprit: %s on %s // %s @ %s",
+			alertCtx.Task.DisplayName,
+			alertCtx.Build.DisplayName,
+			alertCtx.ProjectRef.DisplayName,
+			alertCtx.Version.Revision[0:8])
 	}
 	return taskFailureSubject(alertCtx)
 }
@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/notify"
+	"github.com/evergreen-ci/render"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// patchEmailDeliverer is an implementation of Deliverer that emails the
+// author of a patch through the notifications mailer.
+type patchEmailDeliverer struct {
+	mailer notify.Mailer
+	render *render.Render
+}
+
+func (pd *patchEmailDeliverer) Deliver(alertCtx AlertContext, alertConf model.AlertConfig) error {
+	rcpt, err := stringSetting(alertConf, "recipient")
+	if err != nil {
+		return err
+	}
+	if rcpt == "" {
+		return errors.New("missing email address")
+	}
+	grip.Infof("Sending patch notification to %v", rcpt)
+
+	body := &bytes.Buffer{}
+	if err = pd.render.HTML(body, alertCtx, "content", getTemplate(alertCtx)); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(notify.TrySendNotification([]string{rcpt},
+		fmt.Sprintf("%s %s", EmailSubjectPrologue, getSubject(alertCtx)), body.String(), pd.mailer))
+}
+
+// patchAuthorDeliverers returns the deliverers that notify the author of a
+// finished patch, along with the configs to deliver with: an email, and a
+// webhook if the author has set one.
+func (qp *QueueProcessor) patchAuthorDeliverers(p *patch.Patch) ([]Deliverer, []model.AlertConfig, error) {
+	author, err := user.FindOne(user.ById(p.Author))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if author == nil {
+		return nil, nil, errors.Errorf("user %v not found", p.Author)
+	}
+	if !p.ShouldNotifyAuthor(author.Settings.PatchNotifications) {
+		grip.Debugf("Not notifying %v about patch %v", author.Id, p.Id.Hex())
+		return nil, nil, nil
+	}
+
+	var deliverers []Deliverer
+	var configs []model.AlertConfig
+	if author.Email() != "" {
+		deliverers = append(deliverers, &patchEmailDeliverer{qp.mailer, qp.render})
+		configs = append(configs, model.AlertConfig{EmailProvider, bson.M{"recipient": author.Email()}})
+	}
+	if url := author.Settings.PatchNotifications.WebhookURL; url != "" {
+		conf := model.AlertConfig{WebhookProvider, bson.M{"url": url}}
+		deliverer, err := qp.newWebhookProvider(conf)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid patch webhook for user %v", author.Id)
+		}
+		deliverers = append(deliverers, deliverer)
+		configs = append(configs, conf)
+	}
+	return deliverers, configs, nil
+}
+
+// findFailedPatchTasks returns the failed tasks of a patch's version, along
+// with their test results.
+func findFailedPatchTasks(versionId string) ([]task.Task, error) {
+	tasks, err := task.Find(task.ByVersion(versionId).WithFields(task.IdKey, task.DisplayNameKey,
+		task.BuildVariantKey, task.StatusKey, task.ExecutionKey, task.TestResultsKey))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	failed := []task.Task{}
+	for _, t := range tasks {
+		if t.Status == evergreen.TaskFailed {
+			failed = append(failed, t)
+		}
+	}
+	return failed, nil
+}
+
+// patchFinishedSubject creates an email subject for a finished patch in the style of
+//  Patch Failed: description (2 failed tasks) // ProjectName
+func patchFinishedSubject(ctx AlertContext) string {
+	subj := &bytes.Buffer{}
+	if ctx.Patch.Status == evergreen.PatchFailed {
+		subj.WriteString("Patch Failed: ")
+	} else {
+		subj.WriteString("Patch Succeeded: ")
+	}
+
+	description := ctx.Patch.Description
+	if description == "" {
+		description = ctx.Patch.Id.Hex()
+	}
+	fmt.Fprintf(subj, "'%s'", description)
+	switch len(ctx.FailedTasks) {
+	case 0:
+	case 1:
+		subj.WriteString(" (1 failed task)")
+	default:
+		fmt.Fprintf(subj, " (%v failed tasks)", len(ctx.FailedTasks))
+	}
+
+	project := ctx.Patch.Project
+	if ctx.ProjectRef != nil && ctx.ProjectRef.DisplayName != "" {
+		project = ctx.ProjectRef.DisplayName
+	}
+	fmt.Fprintf(subj, " // %s", project)
+	return subj.String()
+}
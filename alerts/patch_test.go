@@ -0,0 +1,43 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestPatchFinishedSubject(t *testing.T) {
+	Convey("With a patch finished alert", t, func() {
+		ctx := AlertContext{
+			AlertRequest: &alert.AlertRequest{Trigger: alertrecord.PatchFinishedId},
+			ProjectRef:   &model.ProjectRef{DisplayName: ProjectName},
+			Patch: &patch.Patch{
+				Id:          bson.NewObjectId(),
+				Description: "my patch",
+				Status:      evergreen.PatchSucceeded,
+			},
+		}
+
+		Convey("a successful patch's subject should say so", func() {
+			So(getSubject(ctx), ShouldEqual, "Patch Succeeded: 'my patch' // "+ProjectName)
+		})
+		Convey("a failed patch's subject should count its failed tasks", func() {
+			ctx.Patch.Status = evergreen.PatchFailed
+			ctx.FailedTasks = []task.Task{{Id: "t1"}}
+			So(getSubject(ctx), ShouldEqual, "Patch Failed: 'my patch' (1 failed task) // "+ProjectName)
+			ctx.FailedTasks = append(ctx.FailedTasks, task.Task{Id: "t2"})
+			So(getSubject(ctx), ShouldEqual, "Patch Failed: 'my patch' (2 failed tasks) // "+ProjectName)
+		})
+		Convey("a patch without a description should be named by its id", func() {
+			ctx.Patch.Description = ""
+			So(getSubject(ctx), ShouldContainSubstring, ctx.Patch.Id.Hex())
+		})
+	})
+}
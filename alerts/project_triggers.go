@@ -199,6 +199,24 @@ func reachedFailureLimit(taskId string) (bool, error) {
 
 }
 
+// BisectCulpritFound is a trigger that queues an alert when bisecting a task's failures finds the
+// revision that caused them. The alert is queued by the bisection itself rather than by the task
+// failure triggers, since the culprit is only known once the last bisected task finishes.
+type BisectCulpritFound struct{}
+
+func (trig BisectCulpritFound) Id() string { return alertrecord.BisectCulpritFoundId }
+func (trig BisectCulpritFound) Display() string {
+	return "bisecting a failure finds the revision that caused it"
+}
+
+func (trig BisectCulpritFound) ShouldExecute(ctx triggerContext) (bool, error) {
+	return ctx.task != nil && ctx.task.BisectCulprit != "", nil
+}
+
+func (trig BisectCulpritFound) CreateAlertRecord(_ triggerContext) *alertrecord.AlertRecord {
+	return nil
+}
+
 type LastRevisionNotFound struct{}
 
 func (lrnf LastRevisionNotFound) Id() string      { return alertrecord.TaskFailedId }
@@ -52,6 +52,10 @@ var (
 		TaskFailTransition{},
 	}
 
+	// AvailableBisectTriggers are the triggers, alongside the task failure triggers, that
+	// projects can configure alerts for, whose alerts are queued when bisection finishes.
+	AvailableBisectTriggers = []Trigger{BisectCulpritFound{}}
+
 	AvailableProjectTriggers = []Trigger{
 		LastRevisionNotFound{},
 	}
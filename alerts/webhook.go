@@ -0,0 +1,347 @@
+package alerts
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// WebhookFormatJSON posts a JSON document describing the alert.
+	WebhookFormatJSON = "json"
+	// WebhookFormatSlack posts a message that Slack incoming webhooks and
+	// Slack-compatible chat services accept.
+	WebhookFormatSlack = "slack"
+
+	// WebhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the request
+	// body, keyed with the alert's secret, so receivers can verify the sender.
+	WebhookSignatureHeader = "X-Evergreen-Signature"
+
+	webhookPostRetries   = 3
+	webhookRetrySleep    = 5 * time.Second
+	webhookPostTimeout   = 30 * time.Second
+	webhookSlackUsername = "Evergreen"
+)
+
+// WebhookMessageTemplateString is the default text of a webhook alert.
+const WebhookMessageTemplateString = `{{.Subject}}{{if .URL}} <{{.URL}}>{{end}}`
+
+// WebhookMessageTemplate is the default template for webhook alert messages.
+// Panics at start if invalid.
+var WebhookMessageTemplate = template.Must(template.New("Webhook").Parse(WebhookMessageTemplateString))
+
+// webhookMessageData is what webhook message templates are executed with.
+// Templates are written by project admins, so it only carries fields that are
+// safe to post anywhere, never the alert context's settings or secrets.
+type webhookMessageData struct {
+	Trigger    string
+	Subject    string
+	URL        string
+	UIRoot     string
+	HostId     string
+	ProjectRef webhookProjectData
+	Version    webhookVersionData
+	Build      webhookBuildData
+	Task       webhookTaskData
+}
+
+type webhookProjectData struct {
+	Identifier  string
+	DisplayName string
+	Owner       string
+	Repo        string
+	Branch      string
+}
+
+type webhookVersionData struct {
+	Id       string
+	Revision string
+	Author   string
+	Message  string
+	Status   string
+}
+
+type webhookBuildData struct {
+	Id           string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+}
+
+type webhookTaskData struct {
+	Id           string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+	Execution    int
+}
+
+// webhookPayload is the body posted by webhooks in the JSON format.
+type webhookPayload struct {
+	Trigger   string `json:"trigger"`
+	Subject   string `json:"subject"`
+	Message   string `json:"message"`
+	URL       string `json:"url,omitempty"`
+	ProjectId string `json:"project_id,omitempty"`
+	VersionId string `json:"version_id,omitempty"`
+	BuildId   string `json:"build_id,omitempty"`
+	TaskId    string `json:"task_id,omitempty"`
+	HostId    string `json:"host_id,omitempty"`
+}
+
+// slackPayload is the body posted by webhooks in the Slack format.
+type slackPayload struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// webhookDeliverer is an implementation of Deliverer that posts alerts to an
+// HTTP endpoint.
+type webhookDeliverer struct {
+	url      string
+	format   string
+	secret   string
+	channel  string
+	template *template.Template
+	uiRoot   string
+
+	client     *http.Client
+	maxTries   int
+	retrySleep time.Duration
+}
+
+// newWebhookProvider creates a webhook Deliverer from an alert config. The
+// config's settings must contain a "url", and may contain a "format" (json or
+// slack), a "secret" to sign requests with, a "channel" for Slack messages
+// and a "template" overriding the default message text.
+func (qp *QueueProcessor) newWebhookProvider(alertConf model.AlertConfig) (Deliverer, error) {
+	wd := &webhookDeliverer{
+		format:     WebhookFormatJSON,
+		template:   WebhookMessageTemplate,
+		client:     &http.Client{Timeout: webhookPostTimeout},
+		maxTries:   webhookPostRetries,
+		retrySleep: webhookRetrySleep,
+	}
+	if qp.config != nil {
+		wd.uiRoot = qp.config.Ui.Url
+	}
+
+	var err error
+	if wd.url, err = stringSetting(alertConf, "url"); err != nil {
+		return nil, err
+	}
+	if wd.url == "" {
+		return nil, errors.New("missing webhook url field")
+	}
+	if format, err := stringSetting(alertConf, "format"); err != nil {
+		return nil, err
+	} else if format != "" {
+		wd.format = format
+	}
+	if wd.format != WebhookFormatJSON && wd.format != WebhookFormatSlack {
+		return nil, errors.Errorf("invalid webhook format '%v'", wd.format)
+	}
+	if wd.secret, err = stringSetting(alertConf, "secret"); err != nil {
+		return nil, err
+	}
+	if wd.channel, err = stringSetting(alertConf, "channel"); err != nil {
+		return nil, err
+	}
+	tmpl, err := stringSetting(alertConf, "template")
+	if err != nil {
+		return nil, err
+	}
+	if tmpl != "" {
+		if wd.template, err = template.New("Webhook").Parse(tmpl); err != nil {
+			return nil, errors.Wrap(err, "invalid webhook template")
+		}
+		// catch references to fields the template can't see before any alert
+		if err = wd.template.Execute(ioutil.Discard, webhookMessageData{}); err != nil {
+			return nil, errors.Wrap(err, "invalid webhook template")
+		}
+	}
+	return wd, nil
+}
+
+// stringSetting returns the given setting of an alert config, or the empty
+// string if it is not set.
+func stringSetting(alertConf model.AlertConfig, name string) (string, error) {
+	raw, ok := alertConf.Settings[name]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", errors.Errorf("webhook %v must be a string", name)
+	}
+	return value, nil
+}
+
+// Deliver posts the alert defined by the AlertContext to the webhook,
+// retrying on network errors and server errors.
+func (wd *webhookDeliverer) Deliver(ctx AlertContext, alertConf model.AlertConfig) error {
+	body, err := wd.getPayload(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error creating webhook payload")
+	}
+
+	grip.Infof("Posting %v webhook alert to %v", wd.format, wd.url)
+	retriablePost := util.RetriableFunc(
+		func() error {
+			err := wd.post(body)
+			if err != nil {
+				grip.Warningf("Error posting webhook alert to %v: %v", wd.url, err)
+			}
+			return err
+		},
+	)
+	_, err = util.RetryArithmeticBackoff(retriablePost, wd.maxTries, wd.retrySleep)
+	return errors.Wrapf(err, "error posting webhook alert to %v", wd.url)
+}
+
+// post makes a single signed request to the webhook.
+func (wd *webhookDeliverer) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, wd.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if wd.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, signWebhookBody(wd.secret, body))
+	}
+
+	resp, err := wd.client.Do(req)
+	if err != nil {
+		return util.RetriableError{errors.WithStack(err)}
+	}
+	defer resp.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return util.RetriableError{errors.Errorf("webhook returned %v: %s", resp.StatusCode, msg)}
+	default:
+		return errors.Errorf("webhook returned %v: %s", resp.StatusCode, msg)
+	}
+}
+
+// signWebhookBody returns the signature sent with a request body, in the form
+// "sha256=<hex digest>".
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookMessageData returns the fields of the alert context that webhook
+// message templates may use.
+func newWebhookMessageData(ctx AlertContext, uiRoot string) webhookMessageData {
+	data := webhookMessageData{
+		Subject: getSubject(ctx),
+		URL:     alertURL(ctx, uiRoot),
+		UIRoot:  uiRoot,
+	}
+	if ctx.AlertRequest != nil {
+		data.Trigger = ctx.AlertRequest.Trigger
+	}
+	if ctx.Host != nil {
+		data.HostId = ctx.Host.Id
+	}
+	if ctx.ProjectRef != nil {
+		data.ProjectRef = webhookProjectData{
+			Identifier:  ctx.ProjectRef.Identifier,
+			DisplayName: ctx.ProjectRef.DisplayName,
+			Owner:       ctx.ProjectRef.Owner,
+			Repo:        ctx.ProjectRef.Repo,
+			Branch:      ctx.ProjectRef.Branch,
+		}
+	}
+	if ctx.Version != nil {
+		data.Version = webhookVersionData{
+			Id:       ctx.Version.Id,
+			Revision: ctx.Version.Revision,
+			Author:   ctx.Version.Author,
+			Message:  ctx.Version.Message,
+			Status:   ctx.Version.Status,
+		}
+	}
+	if ctx.Build != nil {
+		data.Build = webhookBuildData{
+			Id:           ctx.Build.Id,
+			DisplayName:  ctx.Build.DisplayName,
+			BuildVariant: ctx.Build.BuildVariant,
+			Status:       ctx.Build.Status,
+		}
+	}
+	if ctx.Task != nil {
+		data.Task = webhookTaskData{
+			Id:           ctx.Task.Id,
+			DisplayName:  ctx.Task.DisplayName,
+			BuildVariant: ctx.Task.BuildVariant,
+			Status:       ctx.Task.Status,
+			Execution:    ctx.Task.Execution,
+		}
+	}
+	return data
+}
+
+// getPayload renders the alert's message and wraps it in the webhook's format.
+func (wd *webhookDeliverer) getPayload(ctx AlertContext) ([]byte, error) {
+	data := newWebhookMessageData(ctx, wd.uiRoot)
+	buf := &bytes.Buffer{}
+	if err := wd.template.Execute(buf, data); err != nil {
+		return nil, errors.Wrap(err, "error executing webhook template")
+	}
+
+	if wd.format == WebhookFormatSlack {
+		return json.Marshal(slackPayload{
+			Text:     buf.String(),
+			Channel:  wd.channel,
+			Username: webhookSlackUsername,
+		})
+	}
+
+	return json.Marshal(webhookPayload{
+		Trigger:   data.Trigger,
+		Subject:   data.Subject,
+		Message:   buf.String(),
+		URL:       data.URL,
+		ProjectId: data.ProjectRef.Identifier,
+		VersionId: data.Version.Id,
+		BuildId:   data.Build.Id,
+		TaskId:    data.Task.Id,
+		HostId:    data.HostId,
+	})
+}
+
+// alertURL returns a link to the UI page of the alert's task, host or patch.
+func alertURL(ctx AlertContext, uiRoot string) string {
+	switch {
+	case uiRoot == "":
+		return ""
+	case ctx.Task != nil:
+		return fmt.Sprintf("%v/task/%v/%v", uiRoot, ctx.Task.Id, ctx.Task.Execution)
+	case ctx.Host != nil:
+		return fmt.Sprintf("%v/host/%v", uiRoot, ctx.Host.Id)
+	case ctx.Patch != nil && ctx.Patch.Version != "":
+		return fmt.Sprintf("%v/version/%v", uiRoot, ctx.Patch.Version)
+	default:
+		return ""
+	}
+}
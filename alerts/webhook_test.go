@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"text/template"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestWebhookDeliverer(t *testing.T) {
+	Convey("With a webhook alert for a failed task", t, func() {
+		var bodies [][]byte
+		var signatures []string
+		statuses := []int{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, body)
+			signatures = append(signatures, r.Header.Get(WebhookSignatureHeader))
+			status := http.StatusOK
+			if len(statuses) > 0 {
+				status, statuses = statuses[0], statuses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		qp := &QueueProcessor{config: &evergreen.Settings{Ui: evergreen.UIConfig{Url: "http://evergreen"}}}
+		alertConf := model.AlertConfig{
+			Provider: WebhookProvider,
+			Settings: bson.M{"url": server.URL, "secret": "shh"},
+		}
+		ctx := AlertContext{
+			AlertRequest: &alert.AlertRequest{Trigger: alertrecord.TaskFailedId},
+			ProjectRef:   &model.ProjectRef{Identifier: "proj", DisplayName: ProjectName},
+			Task:         &task.Task{Id: "t1", DisplayName: TaskName},
+			Build:        &build.Build{Id: "b1", DisplayName: BuildName},
+			Version:      &version.Version{Id: "v1", Revision: VersionRevision},
+		}
+		newDeliverer := func() *webhookDeliverer {
+			deliverer, err := qp.getDeliverer(alertConf)
+			So(err, ShouldBeNil)
+			wd := deliverer.(*webhookDeliverer)
+			wd.retrySleep = 0
+			return wd
+		}
+
+		Convey("a signed JSON payload should be posted", func() {
+			So(newDeliverer().Deliver(ctx, alertConf), ShouldBeNil)
+			So(len(bodies), ShouldEqual, 1)
+			So(signatures[0], ShouldEqual, signWebhookBody("shh", bodies[0]))
+
+			payload := webhookPayload{}
+			So(json.Unmarshal(bodies[0], &payload), ShouldBeNil)
+			So(payload.Trigger, ShouldEqual, alertrecord.TaskFailedId)
+			So(payload.TaskId, ShouldEqual, "t1")
+			So(payload.ProjectId, ShouldEqual, "proj")
+			So(payload.URL, ShouldEqual, "http://evergreen/task/t1/0")
+			So(payload.Subject, ShouldContainSubstring, TaskName)
+			So(payload.Message, ShouldEqual, payload.Subject+" <http://evergreen/task/t1/0>")
+		})
+
+		Convey("a Slack message should be rendered from the template", func() {
+			alertConf.Settings["format"] = WebhookFormatSlack
+			alertConf.Settings["channel"] = "#builds"
+			alertConf.Settings["template"] = "{{.Task.DisplayName}} failed in {{.ProjectRef.Identifier}}"
+			So(newDeliverer().Deliver(ctx, alertConf), ShouldBeNil)
+
+			payload := slackPayload{}
+			So(json.Unmarshal(bodies[0], &payload), ShouldBeNil)
+			So(payload.Text, ShouldEqual, TaskName+" failed in proj")
+			So(payload.Channel, ShouldEqual, "#builds")
+		})
+
+		Convey("templates should not be able to read the settings", func() {
+			qp.config.ProjectVarsKey = "topsecret"
+			ctx.Settings = qp.config
+			alertConf.Settings["template"] = "{{.Settings.ProjectVarsKey}}"
+			_, err := qp.getDeliverer(alertConf)
+			So(err, ShouldNotBeNil)
+
+			delete(alertConf.Settings, "template")
+			wd := newDeliverer()
+			wd.template = template.Must(template.New("Webhook").Parse("{{.Settings.ProjectVarsKey}}"))
+			So(wd.Deliver(ctx, alertConf), ShouldNotBeNil)
+			So(bodies, ShouldBeEmpty)
+		})
+
+		Convey("server errors should be retried", func() {
+			statuses = []int{http.StatusInternalServerError, http.StatusBadGateway}
+			So(newDeliverer().Deliver(ctx, alertConf), ShouldBeNil)
+			So(len(bodies), ShouldEqual, 3)
+		})
+
+		Convey("client errors should not be retried", func() {
+			statuses = []int{http.StatusNotFound}
+			So(newDeliverer().Deliver(ctx, alertConf), ShouldNotBeNil)
+			So(len(bodies), ShouldEqual, 1)
+		})
+
+		Convey("invalid settings should be rejected", func() {
+			_, err := qp.getDeliverer(model.AlertConfig{Provider: WebhookProvider, Settings: bson.M{}})
+			So(err, ShouldNotBeNil)
+			alertConf.Settings["format"] = "xml"
+			_, err = qp.getDeliverer(alertConf)
+			So(err, ShouldNotBeNil)
+			alertConf.Settings["format"] = WebhookFormatSlack
+			alertConf.Settings["template"] = "{{.Task"
+			_, err = qp.getDeliverer(alertConf)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
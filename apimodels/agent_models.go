@@ -36,8 +36,12 @@ type TaskEndResponse struct {
 	RunNext    bool   `json:"run_next,omitempty"`
 }
 
-// ExpansionVars is a map of expansion variables for a project.
-type ExpansionVars map[string]string
+// ExpansionVars holds the expansion variables for a project, along with the
+// names of the private variables whose values must be masked in task logs.
+type ExpansionVars struct {
+	Vars        map[string]string `json:"vars"`
+	PrivateVars map[string]bool   `json:"private_vars"`
+}
 
 // NextTaskResponse represents the response sent back when an agent asks for a next task
 type NextTaskResponse struct {
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/build"
+)
+
+// APIBuild is the model to be returned by the API whenever builds are fetched.
+type APIBuild struct {
+	Id            APIString     `json:"build_id"`
+	ProjectId     APIString     `json:"project_id"`
+	CreateTime    APITime       `json:"create_time"`
+	StartTime     APITime       `json:"start_time"`
+	FinishTime    APITime       `json:"finish_time"`
+	Version       APIString     `json:"version_id"`
+	Revision      APIString     `json:"revision"`
+	BuildVariant  APIString     `json:"build_variant"`
+	DisplayName   APIString     `json:"display_name"`
+	Status        APIString     `json:"status"`
+	Activated     bool          `json:"activated"`
+	ActivatedBy   APIString     `json:"activated_by"`
+	ActivatedTime APITime       `json:"activated_time"`
+	Order         int           `json:"order"`
+	Requester     APIString     `json:"requester"`
+	Tasks         []string      `json:"tasks"`
+	TimeTaken     time.Duration `json:"time_taken_ms"`
+}
+
+// BuildFromService converts from a service level build by loading the data
+// into the appropriate fields of the APIBuild.
+func (ab *APIBuild) BuildFromService(b interface{}) error {
+	v, ok := b.(*build.Build)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling build",
+		}
+	}
+	(*ab) = APIBuild{
+		Id:            APIString(v.Id),
+		ProjectId:     APIString(v.Project),
+		CreateTime:    APITime(v.CreateTime),
+		StartTime:     APITime(v.StartTime),
+		FinishTime:    APITime(v.FinishTime),
+		Version:       APIString(v.Version),
+		Revision:      APIString(v.Revision),
+		BuildVariant:  APIString(v.BuildVariant),
+		DisplayName:   APIString(v.DisplayName),
+		Status:        APIString(v.Status),
+		Activated:     v.Activated,
+		ActivatedBy:   APIString(v.ActivatedBy),
+		ActivatedTime: APITime(v.ActivatedTime),
+		Order:         v.RevisionOrderNumber,
+		Requester:     APIString(v.Requester),
+		TimeTaken:     v.TimeTaken,
+	}
+
+	tasks := make([]string, len(v.Tasks))
+	for i, t := range v.Tasks {
+		tasks[i] = t.Id
+	}
+	ab.Tasks = tasks
+	return nil
+}
+
+// ToService returns a service layer build using the data from the APIBuild.
+func (ab *APIBuild) ToService() (interface{}, error) {
+	b := &build.Build{
+		Id:                  string(ab.Id),
+		Project:             string(ab.ProjectId),
+		CreateTime:          time.Time(ab.CreateTime),
+		StartTime:           time.Time(ab.StartTime),
+		FinishTime:          time.Time(ab.FinishTime),
+		Version:             string(ab.Version),
+		Revision:            string(ab.Revision),
+		BuildVariant:        string(ab.BuildVariant),
+		DisplayName:         string(ab.DisplayName),
+		Status:              string(ab.Status),
+		Activated:           ab.Activated,
+		ActivatedBy:         string(ab.ActivatedBy),
+		ActivatedTime:       time.Time(ab.ActivatedTime),
+		RevisionOrderNumber: ab.Order,
+		Requester:           string(ab.Requester),
+		TimeTaken:           ab.TimeTaken,
+	}
+
+	tasks := make([]build.TaskCache, len(ab.Tasks))
+	for i, taskId := range ab.Tasks {
+		tasks[i].Id = taskId
+	}
+	b.Tasks = tasks
+	return interface{}(b), nil
+}
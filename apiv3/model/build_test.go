@@ -0,0 +1,103 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/build"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type buildCompare struct {
+	ab APIBuild
+	sb build.Build
+}
+
+func TestBuildBuildFromService(t *testing.T) {
+	Convey("With a list of models to compare", t, func() {
+		timeNow := time.Now()
+		cTime := timeNow.Add(10 * time.Minute)
+		sTime := timeNow.Add(11 * time.Minute)
+		fTime := timeNow.Add(12 * time.Minute)
+		aTime := timeNow.Add(13 * time.Minute)
+		modelPairs := []buildCompare{
+			{
+				ab: APIBuild{
+					Id:            APIString("testId"),
+					ProjectId:     APIString("testProject"),
+					CreateTime:    APITime(cTime),
+					StartTime:     APITime(sTime),
+					FinishTime:    APITime(fTime),
+					Version:       APIString("testVersion"),
+					Revision:      APIString("testRevision"),
+					BuildVariant:  APIString("testBuildVariant"),
+					DisplayName:   APIString("testDisplayName"),
+					Status:        APIString("testStatus"),
+					Activated:     true,
+					ActivatedBy:   APIString("testActivator"),
+					ActivatedTime: APITime(aTime),
+					Order:         12,
+					Requester:     APIString("testRequester"),
+					Tasks: []string{
+						"testTask1",
+						"testTask2",
+					},
+					TimeTaken: 5 * time.Minute,
+				},
+				sb: build.Build{
+					Id:                  "testId",
+					Project:             "testProject",
+					CreateTime:          cTime,
+					StartTime:           sTime,
+					FinishTime:          fTime,
+					Version:             "testVersion",
+					Revision:            "testRevision",
+					BuildVariant:        "testBuildVariant",
+					DisplayName:         "testDisplayName",
+					Status:              "testStatus",
+					Activated:           true,
+					ActivatedBy:         "testActivator",
+					ActivatedTime:       aTime,
+					RevisionOrderNumber: 12,
+					Requester:           "testRequester",
+					Tasks: []build.TaskCache{
+						{
+							Id: "testTask1",
+						},
+						{
+							Id: "testTask2",
+						},
+					},
+					TimeTaken: 5 * time.Minute,
+				},
+			},
+			{
+				ab: APIBuild{
+					Tasks: []string{},
+				},
+				sb: build.Build{
+					Tasks: []build.TaskCache{},
+				},
+			},
+		}
+		Convey("running BuildFromService(), should produce the equivalent model", func() {
+			for _, tc := range modelPairs {
+				apiBuild := &APIBuild{}
+				err := apiBuild.BuildFromService(&tc.sb)
+				So(err, ShouldBeNil)
+				So(apiBuild, ShouldResemble, &tc.ab)
+			}
+		})
+		Convey("running ToService(), should produce the equivalent build", func() {
+			for _, tc := range modelPairs {
+				b, err := tc.ab.ToService()
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, &tc.sb)
+			}
+		})
+		Convey("running BuildFromService() with the wrong type should error", func() {
+			apiBuild := &APIBuild{}
+			So(apiBuild.BuildFromService("testId"), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/distro"
+)
+
+// APIDistro is the model to be returned by the API whenever distros are
+// fetched, and to be sent to the API to create or replace a distro.
+type APIDistro struct {
+	Id                    APIString                    `json:"distro_id"`
+	Arch                  APIString                    `json:"arch"`
+	WorkDir               APIString                    `json:"work_dir"`
+	PoolSize              int                          `json:"pool_size"`
+	Provider              APIString                    `json:"provider"`
+	ProviderSettings      map[string]interface{}       `json:"settings"`
+	SetupAsSudo           bool                         `json:"setup_as_sudo"`
+	Setup                 APIString                    `json:"setup"`
+	Teardown              APIString                    `json:"teardown"`
+	User                  APIString                    `json:"user"`
+	SSHKey                APIString                    `json:"ssh_key"`
+	SSHOptions            []string                     `json:"ssh_options"`
+	UserData              distro.UserData              `json:"user_data"`
+	SpawnAllowed          bool                         `json:"spawn_allowed"`
+	Expansions            []distro.Expansion           `json:"expansions"`
+	PrioritizerSettings   distro.PrioritizerSettings   `json:"prioritizer_settings"`
+	HostAllocatorSettings distro.HostAllocatorSettings `json:"host_allocator_settings"`
+}
+
+// BuildFromService converts from a service level distro by loading the data
+// into the appropriate fields of the APIDistro.
+func (ad *APIDistro) BuildFromService(d interface{}) error {
+	v, ok := d.(*distro.Distro)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling distro",
+		}
+	}
+	(*ad) = APIDistro{
+		Id:                    APIString(v.Id),
+		Arch:                  APIString(v.Arch),
+		WorkDir:               APIString(v.WorkDir),
+		PoolSize:              v.PoolSize,
+		Provider:              APIString(v.Provider),
+		SetupAsSudo:           v.SetupAsSudo,
+		Setup:                 APIString(v.Setup),
+		Teardown:              APIString(v.Teardown),
+		User:                  APIString(v.User),
+		SSHKey:                APIString(v.SSHKey),
+		SSHOptions:            v.SSHOptions,
+		UserData:              v.UserData,
+		SpawnAllowed:          v.SpawnAllowed,
+		Expansions:            v.Expansions,
+		PrioritizerSettings:   v.PrioritizerSettings,
+		HostAllocatorSettings: v.HostAllocatorSettings,
+	}
+	if v.ProviderSettings != nil {
+		ad.ProviderSettings = *v.ProviderSettings
+	}
+	return nil
+}
+
+// ToService returns a service layer distro using the data from the APIDistro.
+func (ad *APIDistro) ToService() (interface{}, error) {
+	d := &distro.Distro{
+		Id:                    string(ad.Id),
+		Arch:                  string(ad.Arch),
+		WorkDir:               string(ad.WorkDir),
+		PoolSize:              ad.PoolSize,
+		Provider:              string(ad.Provider),
+		SetupAsSudo:           ad.SetupAsSudo,
+		Setup:                 string(ad.Setup),
+		Teardown:              string(ad.Teardown),
+		User:                  string(ad.User),
+		SSHKey:                string(ad.SSHKey),
+		SSHOptions:            ad.SSHOptions,
+		UserData:              ad.UserData,
+		SpawnAllowed:          ad.SpawnAllowed,
+		Expansions:            ad.Expansions,
+		PrioritizerSettings:   ad.PrioritizerSettings,
+		HostAllocatorSettings: ad.HostAllocatorSettings,
+	}
+	if ad.ProviderSettings != nil {
+		settings := ad.ProviderSettings
+		d.ProviderSettings = &settings
+	}
+	return interface{}(d), nil
+}
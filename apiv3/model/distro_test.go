@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/distro"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDistroBuildFromService(t *testing.T) {
+	Convey("With a distro with provider settings", t, func() {
+		settings := map[string]interface{}{
+			"ami":           "ami-12345",
+			"instance_type": "m3.large",
+		}
+		d := distro.Distro{
+			Id:               "testDistro",
+			Arch:             "linux_amd64",
+			PoolSize:         10,
+			Provider:         "ec2",
+			ProviderSettings: &settings,
+			SSHOptions:       []string{"StrictHostKeyChecking=no"},
+			Expansions: []distro.Expansion{
+				{Key: "python", Value: "/usr/bin/python3"},
+			},
+		}
+		Convey("running BuildFromService(), should produce the equivalent model", func() {
+			apiDistro := &APIDistro{}
+			So(apiDistro.BuildFromService(&d), ShouldBeNil)
+			So(apiDistro.Id, ShouldEqual, APIString("testDistro"))
+			So(apiDistro.Arch, ShouldEqual, APIString("linux_amd64"))
+			So(apiDistro.PoolSize, ShouldEqual, 10)
+			So(apiDistro.ProviderSettings, ShouldResemble, settings)
+			So(apiDistro.Expansions, ShouldResemble, d.Expansions)
+
+			Convey("and running ToService(), should produce the original distro", func() {
+				serviceDistro, err := apiDistro.ToService()
+				So(err, ShouldBeNil)
+				So(serviceDistro, ShouldResemble, &d)
+			})
+		})
+		Convey("a distro without provider settings should have none", func() {
+			d.ProviderSettings = nil
+			apiDistro := &APIDistro{}
+			So(apiDistro.BuildFromService(&d), ShouldBeNil)
+			So(apiDistro.ProviderSettings, ShouldBeNil)
+			serviceDistro, err := apiDistro.ToService()
+			So(err, ShouldBeNil)
+			So(serviceDistro.(*distro.Distro).ProviderSettings, ShouldBeNil)
+		})
+	})
+}
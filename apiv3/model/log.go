@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+)
+
+const (
+	// LogMessageTimeFormat is the format of the timestamps of log messages
+	// when they are written out as plain text, matching the raw task logs of
+	// the UI.
+	LogMessageTimeFormat = "[2006/01/02 15:04:05.000] "
+)
+
+// APILogMessage is the model to be returned by the API whenever the log
+// messages of a task are fetched.
+type APILogMessage struct {
+	Type      APIString `json:"type"`
+	Severity  APIString `json:"severity"`
+	Message   APIString `json:"message"`
+	Timestamp APITime   `json:"timestamp"`
+	Version   int       `json:"version"`
+}
+
+// BuildFromService converts from a service level log message by loading the
+// data into the appropriate fields of the APILogMessage.
+func (alm *APILogMessage) BuildFromService(m interface{}) error {
+	v, ok := m.(*serviceModel.LogMessage)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling log message",
+		}
+	}
+	(*alm) = APILogMessage{
+		Type:      APIString(v.Type),
+		Severity:  APIString(v.Severity),
+		Message:   APIString(v.Message),
+		Timestamp: NewTime(v.Timestamp),
+		Version:   v.Version,
+	}
+	return nil
+}
+
+// ToService returns a service layer log message using the data from the
+// APILogMessage.
+func (alm *APILogMessage) ToService() (interface{}, error) {
+	return interface{}(&serviceModel.LogMessage{
+		Type:      string(alm.Type),
+		Severity:  string(alm.Severity),
+		Message:   string(alm.Message),
+		Timestamp: time.Time(alm.Timestamp),
+		Version:   alm.Version,
+	}), nil
+}
+
+// String returns the log message as a line of a plain text log, prefixed by
+// its timestamp if it has one.
+func (alm *APILogMessage) String() string {
+	t := time.Time(alm.Timestamp)
+	if t.IsZero() {
+		return string(alm.Message)
+	}
+	return fmt.Sprintf("%s%s", t.Format(LogMessageTimeFormat), alm.Message)
+}
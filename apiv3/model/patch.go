@@ -0,0 +1,80 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/patch"
+)
+
+// APIPatch is the model to be returned by the API whenever patches are
+// fetched.
+type APIPatch struct {
+	Id          APIString `json:"patch_id"`
+	Description APIString `json:"description"`
+	ProjectId   APIString `json:"project_id"`
+	Githash     APIString `json:"git_hash"`
+	PatchNumber int       `json:"patch_number"`
+	Author      APIString `json:"author"`
+	Version     APIString `json:"version_id"`
+	Status      APIString `json:"status"`
+	CreateTime  APITime   `json:"create_time"`
+	StartTime   APITime   `json:"start_time"`
+	FinishTime  APITime   `json:"finish_time"`
+	Variants    []string  `json:"variants"`
+	Tasks       []string  `json:"tasks"`
+	Activated   bool      `json:"activated"`
+}
+
+// BuildFromService converts from a service level patch by loading the data
+// into the appropriate fields of the APIPatch.
+func (ap *APIPatch) BuildFromService(p interface{}) error {
+	v, ok := p.(*patch.Patch)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling patch",
+		}
+	}
+	(*ap) = APIPatch{
+		Id:          APIString(v.Id.Hex()),
+		Description: APIString(v.Description),
+		ProjectId:   APIString(v.Project),
+		Githash:     APIString(v.Githash),
+		PatchNumber: v.PatchNumber,
+		Author:      APIString(v.Author),
+		Version:     APIString(v.Version),
+		Status:      APIString(v.Status),
+		CreateTime:  APITime(v.CreateTime),
+		StartTime:   APITime(v.StartTime),
+		FinishTime:  APITime(v.FinishTime),
+		Variants:    v.BuildVariants,
+		Tasks:       v.Tasks,
+		Activated:   v.Activated,
+	}
+	return nil
+}
+
+// ToService returns a service layer patch using the data from the APIPatch.
+func (ap *APIPatch) ToService() (interface{}, error) {
+	p := &patch.Patch{
+		Description:   string(ap.Description),
+		Project:       string(ap.ProjectId),
+		Githash:       string(ap.Githash),
+		PatchNumber:   ap.PatchNumber,
+		Author:        string(ap.Author),
+		Version:       string(ap.Version),
+		Status:        string(ap.Status),
+		CreateTime:    time.Time(ap.CreateTime),
+		StartTime:     time.Time(ap.StartTime),
+		FinishTime:    time.Time(ap.FinishTime),
+		BuildVariants: ap.Variants,
+		Tasks:         ap.Tasks,
+		Activated:     ap.Activated,
+	}
+	if patch.IsValidId(string(ap.Id)) {
+		p.Id = patch.NewId(string(ap.Id))
+	}
+	return interface{}(p), nil
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+)
+
+// APIProjectRef is the model to be returned by the API whenever project refs
+// are fetched, and to be sent to the API to create or replace a project ref.
+// Whether a project is tracked and its repotracker errors are managed by
+// evergreen, so they are ignored when converting to a service layer project.
+type APIProjectRef struct {
+	Identifier         APIString                             `json:"identifier"`
+	DisplayName        APIString                             `json:"display_name"`
+	Owner              APIString                             `json:"owner_name"`
+	Repo               APIString                             `json:"repo_name"`
+	Branch             APIString                             `json:"branch_name"`
+	RepoKind           APIString                             `json:"repo_kind"`
+	RepoPath           APIString                             `json:"repo_path"`
+	RemotePath         APIString                             `json:"remote_path"`
+	LocalConfig        APIString                             `json:"local_config"`
+	Enabled            bool                                  `json:"enabled"`
+	Private            bool                                  `json:"private"`
+	BatchTime          int                                   `json:"batch_time"`
+	DeactivatePrevious bool                                  `json:"deactivate_previous"`
+	ShareWeight        int                                   `json:"share_weight"`
+	Admins             []string                              `json:"admins"`
+	Roles              []serviceModel.ProjectRole            `json:"roles"`
+	Alerts             map[string][]serviceModel.AlertConfig `json:"alert_config"`
+	Tracked            bool                                  `json:"tracked"`
+	RepotrackerError   *serviceModel.RepositoryErrorDetails  `json:"repotracker_error"`
+}
+
+// BuildFromService converts from a service level project ref by loading the
+// data into the appropriate fields of the APIProjectRef.
+func (apr *APIProjectRef) BuildFromService(p interface{}) error {
+	v, ok := p.(*serviceModel.ProjectRef)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling project ref",
+		}
+	}
+	(*apr) = APIProjectRef{
+		Identifier:         APIString(v.Identifier),
+		DisplayName:        APIString(v.DisplayName),
+		Owner:              APIString(v.Owner),
+		Repo:               APIString(v.Repo),
+		Branch:             APIString(v.Branch),
+		RepoKind:           APIString(v.RepoKind),
+		RepoPath:           APIString(v.RepoPath),
+		RemotePath:         APIString(v.RemotePath),
+		LocalConfig:        APIString(v.LocalConfig),
+		Enabled:            v.Enabled,
+		Private:            v.Private,
+		BatchTime:          v.BatchTime,
+		DeactivatePrevious: v.DeactivatePrevious,
+		ShareWeight:        v.ShareWeight,
+		Admins:             v.Admins,
+		Roles:              v.Roles,
+		Alerts:             v.Alerts,
+		Tracked:            v.Tracked,
+		RepotrackerError:   v.RepotrackerError,
+	}
+	return nil
+}
+
+// ToService returns a service layer project ref using the data from the
+// APIProjectRef.
+func (apr *APIProjectRef) ToService() (interface{}, error) {
+	p := &serviceModel.ProjectRef{
+		Identifier:         string(apr.Identifier),
+		DisplayName:        string(apr.DisplayName),
+		Owner:              string(apr.Owner),
+		Repo:               string(apr.Repo),
+		Branch:             string(apr.Branch),
+		RepoKind:           string(apr.RepoKind),
+		RepoPath:           string(apr.RepoPath),
+		RemotePath:         string(apr.RemotePath),
+		LocalConfig:        string(apr.LocalConfig),
+		Enabled:            apr.Enabled,
+		Private:            apr.Private,
+		BatchTime:          apr.BatchTime,
+		DeactivatePrevious: apr.DeactivatePrevious,
+		ShareWeight:        apr.ShareWeight,
+		Admins:             apr.Admins,
+		Roles:              apr.Roles,
+		Alerts:             apr.Alerts,
+	}
+	return interface{}(p), nil
+}
+
+// APIProjectVars is the model to be returned by the API whenever a project's
+// variables are fetched, and to be sent to the API to replace them. The
+// values of private variables are never returned, and a private variable
+// sent without a value keeps its current value.
+type APIProjectVars struct {
+	Vars        map[string]string `json:"vars"`
+	PrivateVars map[string]bool   `json:"private_vars"`
+}
+
+// BuildFromService converts from service level project variables by loading
+// the data into the appropriate fields of the APIProjectVars.
+func (apv *APIProjectVars) BuildFromService(v interface{}) error {
+	vars, ok := v.(*serviceModel.ProjectVars)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling project variables",
+		}
+	}
+	(*apv) = APIProjectVars{
+		Vars:        vars.Vars,
+		PrivateVars: vars.PrivateVars,
+	}
+	return nil
+}
+
+// ToService returns service level project variables using the data from the
+// APIProjectVars. The id of the project is left to the caller.
+func (apv *APIProjectVars) ToService() (interface{}, error) {
+	vars := &serviceModel.ProjectVars{
+		Vars:        apv.Vars,
+		PrivateVars: apv.PrivateVars,
+	}
+	if vars.Vars == nil {
+		vars.Vars = map[string]string{}
+	}
+	if vars.PrivateVars == nil {
+		vars.PrivateVars = map[string]bool{}
+	}
+	return interface{}(vars), nil
+}
@@ -37,6 +37,7 @@ func TestTaskBuildFromService(t *testing.T) {
 					Revision:      APIString("testRevision"),
 					Priority:      100,
 					Execution:     2,
+					AutoRetries:   1,
 					Activated:     true,
 					ActivatedBy:   APIString("testActivator"),
 					BuildId:       APIString("testBuildId"),
@@ -66,6 +67,7 @@ func TestTaskBuildFromService(t *testing.T) {
 					Version:       "testVersion",
 					Revision:      "testRevision",
 					Execution:     2,
+					AutoRetries:   1,
 					Priority:      100,
 					Activated:     true,
 					ActivatedBy:   "testActivator",
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/task"
+)
+
+// APITest is the model to be returned by the API whenever the results of a
+// task's tests are fetched. The task and execution the test was run in are
+// not part of a service level test result, so they are left to the caller.
+type APITest struct {
+	TaskId    APIString `json:"task_id"`
+	Execution int       `json:"execution"`
+	Status    APIString `json:"status"`
+	TestFile  APIString `json:"test_file"`
+	Logs      testLogs  `json:"logs"`
+	ExitCode  int       `json:"exit_code"`
+	StartTime APITime   `json:"start_time"`
+	EndTime   APITime   `json:"end_time"`
+}
+
+type testLogs struct {
+	URL     APIString `json:"url"`
+	URLRaw  APIString `json:"url_raw"`
+	LineNum int       `json:"line_num"`
+	LogId   APIString `json:"log_id"`
+}
+
+// BuildFromService converts from service level structs to an APITest. It can
+// be called multiple times with different data types, a service layer test
+// result and the root URL of the UI, which is used to link to the test's log
+// if the test result doesn't have its own links.
+func (at *APITest) BuildFromService(t interface{}) error {
+	switch v := t.(type) {
+	case *task.TestResult:
+		at.Status = APIString(v.Status)
+		at.TestFile = APIString(v.TestFile)
+		at.Logs = testLogs{
+			URL:     APIString(v.URL),
+			URLRaw:  APIString(v.URLRaw),
+			LineNum: v.LineNum,
+			LogId:   APIString(v.LogId),
+		}
+		at.ExitCode = v.ExitCode
+		at.StartTime = NewTime(pythonTime(v.StartTime))
+		at.EndTime = NewTime(pythonTime(v.EndTime))
+	case string:
+		if at.Logs.LogId != "" {
+			if at.Logs.URL == "" {
+				at.Logs.URL = APIString(v + task.TestLogPath + string(at.Logs.LogId))
+			}
+			if at.Logs.URLRaw == "" {
+				at.Logs.URLRaw = APIString(v + task.TestLogPath + string(at.Logs.LogId) + "?raw=1")
+			}
+		}
+	default:
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling test",
+		}
+	}
+	return nil
+}
+
+// ToService returns a service layer test result using the data from the
+// APITest.
+func (at *APITest) ToService() (interface{}, error) {
+	return interface{}(&task.TestResult{
+		Status:    string(at.Status),
+		TestFile:  string(at.TestFile),
+		URL:       string(at.Logs.URL),
+		URLRaw:    string(at.Logs.URLRaw),
+		LogId:     string(at.Logs.LogId),
+		LineNum:   at.Logs.LineNum,
+		ExitCode:  at.ExitCode,
+		StartTime: pythonSeconds(time.Time(at.StartTime)),
+		EndTime:   pythonSeconds(time.Time(at.EndTime)),
+	}), nil
+}
+
+// pythonTime converts the seconds since the epoch that test results are
+// reported in to a time, leaving unset times as the zero time.
+func pythonTime(secs float64) time.Time {
+	if secs == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(secs*float64(time.Second)))
+}
+
+// pythonSeconds converts a time to seconds since the epoch, the inverse of
+// pythonTime.
+func pythonSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
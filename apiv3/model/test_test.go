@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTestBuildFromService(t *testing.T) {
+	Convey("With a test result with a log in the database", t, func() {
+		start := time.Date(2017, time.May, 1, 12, 0, 0, 500000000, time.UTC)
+		tr := task.TestResult{
+			Status:    evergreen.TestFailedStatus,
+			TestFile:  "test_file",
+			LogId:     "log_id",
+			LineNum:   12,
+			ExitCode:  1,
+			StartTime: float64(start.UnixNano()) / float64(time.Second),
+			EndTime:   float64(start.Add(time.Minute).UnixNano()) / float64(time.Second),
+		}
+		Convey("running BuildFromService(), should produce the equivalent model", func() {
+			apiTest := &APITest{}
+			So(apiTest.BuildFromService(&tr), ShouldBeNil)
+			So(apiTest.Status, ShouldEqual, APIString(evergreen.TestFailedStatus))
+			So(apiTest.TestFile, ShouldEqual, APIString("test_file"))
+			So(apiTest.ExitCode, ShouldEqual, 1)
+			So(time.Time(apiTest.StartTime).Equal(start), ShouldBeTrue)
+			So(time.Time(apiTest.EndTime).Equal(start.Add(time.Minute)), ShouldBeTrue)
+			So(apiTest.Logs.URL, ShouldEqual, APIString(""))
+
+			Convey("and running it with the root url should link to the log", func() {
+				So(apiTest.BuildFromService("https://evergreen.example.com"), ShouldBeNil)
+				So(apiTest.Logs.URL, ShouldEqual,
+					APIString("https://evergreen.example.com/test_log/log_id"))
+				So(apiTest.Logs.URLRaw, ShouldEqual,
+					APIString("https://evergreen.example.com/test_log/log_id?raw=1"))
+			})
+		})
+		Convey("a test result with its own links should keep them", func() {
+			tr.URL = "https://logs.example.com/test_file"
+			apiTest := &APITest{}
+			So(apiTest.BuildFromService(&tr), ShouldBeNil)
+			So(apiTest.BuildFromService("https://evergreen.example.com"), ShouldBeNil)
+			So(apiTest.Logs.URL, ShouldEqual, APIString("https://logs.example.com/test_file"))
+		})
+		Convey("a test result without times should have none", func() {
+			tr.StartTime = 0
+			tr.EndTime = 0
+			apiTest := &APITest{}
+			So(apiTest.BuildFromService(&tr), ShouldBeNil)
+			So(time.Time(apiTest.StartTime).IsZero(), ShouldBeTrue)
+			serviceTest, err := apiTest.ToService()
+			So(err, ShouldBeNil)
+			So(serviceTest.(*task.TestResult).StartTime, ShouldEqual, 0)
+		})
+	})
+}
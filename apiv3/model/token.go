@@ -0,0 +1,60 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/user"
+)
+
+// APIAccessToken is the model to be returned by the API whenever access
+// tokens are fetched, and to be sent to the API to create an access token.
+// The token that clients authenticate with is only returned when the access
+// token is created, since only its hash is stored.
+type APIAccessToken struct {
+	Name      APIString `json:"name"`
+	UserId    APIString `json:"user_id"`
+	Scope     APIString `json:"scope"`
+	Projects  []string  `json:"projects"`
+	CreatedAt APITime   `json:"created_at"`
+	ExpiresAt APITime   `json:"expires_at"`
+	Token     APIString `json:"token"`
+}
+
+// BuildFromService converts from service level structs to an
+// APIAccessToken. It can be called multiple times with different data types,
+// a service layer access token and the token that clients authenticate with
+// it, which is only known when the access token is created.
+func (at *APIAccessToken) BuildFromService(t interface{}) error {
+	switch v := t.(type) {
+	case *user.AccessToken:
+		at.Name = APIString(v.Name)
+		at.UserId = APIString(v.UserId)
+		at.Scope = APIString(v.Scope)
+		at.Projects = v.Projects
+		at.CreatedAt = NewTime(v.CreatedAt)
+		at.ExpiresAt = NewTime(v.ExpiresAt)
+	case string:
+		at.Token = APIString(v)
+	default:
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling access token",
+		}
+	}
+	return nil
+}
+
+// ToService returns a service layer access token using the data from the
+// APIAccessToken. The hash it is stored under is left to the caller.
+func (at *APIAccessToken) ToService() (interface{}, error) {
+	return interface{}(&user.AccessToken{
+		Name:      string(at.Name),
+		UserId:    string(at.UserId),
+		Scope:     string(at.Scope),
+		Projects:  at.Projects,
+		CreatedAt: time.Time(at.CreatedAt),
+		ExpiresAt: time.Time(at.ExpiresAt),
+	}), nil
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/version"
+)
+
+// APIVersion is the model to be returned by the API whenever versions are
+// fetched.
+type APIVersion struct {
+	Id          APIString `json:"version_id"`
+	ProjectId   APIString `json:"project_id"`
+	CreateTime  APITime   `json:"create_time"`
+	StartTime   APITime   `json:"start_time"`
+	FinishTime  APITime   `json:"finish_time"`
+	Revision    APIString `json:"revision"`
+	Author      APIString `json:"author"`
+	AuthorEmail APIString `json:"author_email"`
+	Message     APIString `json:"message"`
+	Status      APIString `json:"status"`
+	Order       int       `json:"order"`
+	Requester   APIString `json:"requester"`
+	Repo        APIString `json:"repo"`
+	Branch      APIString `json:"branch"`
+	Builds      []string  `json:"builds"`
+	Errors      []string  `json:"errors"`
+}
+
+// BuildFromService converts from a service level version by loading the
+// data into the appropriate fields of the APIVersion.
+func (av *APIVersion) BuildFromService(v interface{}) error {
+	sv, ok := v.(*version.Version)
+	if !ok {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Incorrect type when unmarshalling version",
+		}
+	}
+	(*av) = APIVersion{
+		Id:          APIString(sv.Id),
+		ProjectId:   APIString(sv.Identifier),
+		CreateTime:  APITime(sv.CreateTime),
+		StartTime:   APITime(sv.StartTime),
+		FinishTime:  APITime(sv.FinishTime),
+		Revision:    APIString(sv.Revision),
+		Author:      APIString(sv.Author),
+		AuthorEmail: APIString(sv.AuthorEmail),
+		Message:     APIString(sv.Message),
+		Status:      APIString(sv.Status),
+		Order:       sv.RevisionOrderNumber,
+		Requester:   APIString(sv.Requester),
+		Repo:        APIString(sv.Repo),
+		Branch:      APIString(sv.Branch),
+		Builds:      sv.BuildIds,
+		Errors:      sv.Errors,
+	}
+	return nil
+}
+
+// ToService returns a service layer version using the data from the
+// APIVersion.
+func (av *APIVersion) ToService() (interface{}, error) {
+	v := &version.Version{
+		Id:                  string(av.Id),
+		Identifier:          string(av.ProjectId),
+		CreateTime:          time.Time(av.CreateTime),
+		StartTime:           time.Time(av.StartTime),
+		FinishTime:          time.Time(av.FinishTime),
+		Revision:            string(av.Revision),
+		Author:              string(av.Author),
+		AuthorEmail:         string(av.AuthorEmail),
+		Message:             string(av.Message),
+		Status:              string(av.Status),
+		RevisionOrderNumber: av.Order,
+		Requester:           string(av.Requester),
+		Repo:                string(av.Repo),
+		Branch:              string(av.Branch),
+		BuildIds:            av.Builds,
+		Errors:              av.Errors,
+	}
+	return interface{}(v), nil
+}
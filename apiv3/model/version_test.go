@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/version"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type versionCompare struct {
+	av APIVersion
+	sv version.Version
+}
+
+func TestVersionBuildFromService(t *testing.T) {
+	Convey("With a list of models to compare", t, func() {
+		timeNow := time.Now()
+		cTime := timeNow.Add(10 * time.Minute)
+		sTime := timeNow.Add(11 * time.Minute)
+		fTime := timeNow.Add(12 * time.Minute)
+		modelPairs := []versionCompare{
+			{
+				av: APIVersion{
+					Id:          APIString("testId"),
+					ProjectId:   APIString("testProject"),
+					CreateTime:  APITime(cTime),
+					StartTime:   APITime(sTime),
+					FinishTime:  APITime(fTime),
+					Revision:    APIString("testRevision"),
+					Author:      APIString("testAuthor"),
+					AuthorEmail: APIString("author@example.com"),
+					Message:     APIString("testMessage"),
+					Status:      APIString("testStatus"),
+					Order:       12,
+					Requester:   APIString("testRequester"),
+					Repo:        APIString("testRepo"),
+					Branch:      APIString("testBranch"),
+					Builds:      []string{"testBuild1", "testBuild2"},
+					Errors:      []string{"testError"},
+				},
+				sv: version.Version{
+					Id:                  "testId",
+					Identifier:          "testProject",
+					CreateTime:          cTime,
+					StartTime:           sTime,
+					FinishTime:          fTime,
+					Revision:            "testRevision",
+					Author:              "testAuthor",
+					AuthorEmail:         "author@example.com",
+					Message:             "testMessage",
+					Status:              "testStatus",
+					RevisionOrderNumber: 12,
+					Requester:           "testRequester",
+					Repo:                "testRepo",
+					Branch:              "testBranch",
+					BuildIds:            []string{"testBuild1", "testBuild2"},
+					Errors:              []string{"testError"},
+				},
+			},
+			{
+				av: APIVersion{},
+				sv: version.Version{},
+			},
+		}
+		Convey("running BuildFromService(), should produce the equivalent model", func() {
+			for _, tc := range modelPairs {
+				apiVersion := &APIVersion{}
+				err := apiVersion.BuildFromService(&tc.sv)
+				So(err, ShouldBeNil)
+				So(apiVersion, ShouldResemble, &tc.av)
+			}
+		})
+		Convey("running ToService(), should produce the equivalent version", func() {
+			for _, tc := range modelPairs {
+				v, err := tc.av.ToService()
+				So(err, ShouldBeNil)
+				So(v, ShouldResemble, &tc.sv)
+			}
+		})
+		Convey("running BuildFromService() with the wrong type should error", func() {
+			apiVersion := &APIVersion{}
+			So(apiVersion.BuildFromService("testId"), ShouldNotBeNil)
+		})
+	})
+}
@@ -1,12 +1,15 @@
 package route
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apiv3"
 	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
 	"github.com/evergreen-ci/evergreen/auth"
-	"github.com/evergreen-ci/evergreen/util"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/user"
 )
 
 // Authenticator is an interface which defines how requests can authenticate
@@ -38,7 +41,7 @@ func (s *SuperUserAuthenticator) Authenticate(sc servicecontext.ServiceContext,
 	r *http.Request) error {
 	u := GetUser(r)
 
-	if auth.IsSuperUser(sc.GetSuperUsers(), u) {
+	if u != nil && auth.IsSuperUser(sc.GetSuperUsers(), u) {
 		return nil
 	}
 	return apiv3.APIError{
@@ -53,15 +56,15 @@ func (s *SuperUserAuthenticator) Authenticate(sc servicecontext.ServiceContext,
 type ProjectAdminAuthenticator struct{}
 
 // ProjectAdminAuthenticator checks that the user is either a super user or is
-// part of the project context's project admins.
+// a project_admin of the project context's project. If the project doesn't
+// exist, only super users are allowed, so that they may create it.
 func (p *ProjectAdminAuthenticator) Authenticate(sc servicecontext.ServiceContext,
 	r *http.Request) error {
 	projCtx := MustHaveProjectContext(r)
 	u := GetUser(r)
 
 	// If either a superuser or admin, request is allowed to proceed.
-	if auth.IsSuperUser(sc.GetSuperUsers(), u) ||
-		util.SliceContains(projCtx.ProjectRef.Admins, u.Username()) {
+	if u != nil && hasProjectRole(sc, u, projCtx.ProjectRef, serviceModel.ProjectRoleAdmin) {
 		return nil
 	}
 
@@ -71,6 +74,63 @@ func (p *ProjectAdminAuthenticator) Authenticate(sc servicecontext.ServiceContex
 	}
 }
 
+// ProjectRoleAuthenticator only allows users with at least the given role in
+// the project context's project, and superusers, to complete the request.
+type ProjectRoleAuthenticator struct {
+	Role string
+}
+
+// Authenticate checks that a user is set on the request and that they have
+// at least the authenticator's role in the project context's project.
+func (p *ProjectRoleAuthenticator) Authenticate(sc servicecontext.ServiceContext,
+	r *http.Request) error {
+	u := GetUser(r)
+	if u == nil {
+		return apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
+
+	projCtx := MustHaveProjectContext(r)
+	if !hasProjectRole(sc, u, projCtx.ProjectRef, p.Role) {
+		return apiv3.APIError{
+			StatusCode: http.StatusForbidden,
+			Message:    fmt.Sprintf("The %s role in the project is required", p.Role),
+		}
+	}
+	return nil
+}
+
+// projectRole returns the role that the user has in the project, given the
+// super users and the user groups in the settings. Super users are admins of
+// every project, and a nil user is one who isn't logged in.
+func projectRole(sc servicecontext.ServiceContext, u *user.DBUser,
+	projectRef *serviceModel.ProjectRef) string {
+	if u == nil {
+		return projectRef.UserRole("", nil)
+	}
+	if auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return serviceModel.ProjectRoleAdmin
+	}
+	groups := []string{}
+	if settings := sc.GetSettings(); settings != nil {
+		groups = settings.UserGroups.GroupsForUser(u.Id)
+	}
+	return projectRef.UserRole(u.Id, groups)
+}
+
+// hasProjectRole returns whether the user may do what the given role allows
+// in the project. If there is no project, only super users may, so that they
+// can create it.
+func hasProjectRole(sc servicecontext.ServiceContext, u *user.DBUser,
+	projectRef *serviceModel.ProjectRef, role string) bool {
+	if projectRef == nil {
+		return u != nil && auth.IsSuperUser(sc.GetSuperUsers(), u)
+	}
+	return serviceModel.ProjectRoleAllows(projectRole(sc, u, projectRef), role)
+}
+
 // RequireUserAuthenticator requires that a user be attached to a request.
 type RequireUserAuthenticator struct{}
 
@@ -89,3 +149,53 @@ func (rua *RequireUserAuthenticator) Authenticate(sc servicecontext.ServiceConte
 	}
 	return nil
 }
+
+// APIKeyUserAuthenticator requires that a user be attached to a request by
+// their API key, rather than by an access token, so that access tokens may
+// not be used to manage access tokens.
+type APIKeyUserAuthenticator struct{}
+
+// Authenticate checks that a user is set on the request and that the request
+// wasn't made with an access token.
+func (a *APIKeyUserAuthenticator) Authenticate(sc servicecontext.ServiceContext,
+	r *http.Request) error {
+	if GetUser(r) == nil || GetAccessToken(r) != nil {
+		return apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
+	return nil
+}
+
+// AccessTokenAuthenticator limits the requests made with an access token to
+// those the token's scope allows, then authenticates them with the wrapped
+// Authenticator, as it does requests made without an access token. Read
+// only tokens may only make GET requests, and project scoped tokens may only
+// make other requests against the projects they are scoped to.
+type AccessTokenAuthenticator struct {
+	MethodType string
+
+	Authenticator
+}
+
+// Authenticate checks that the access token the request was made with, if
+// any, allows the request, then authenticates the request with the wrapped
+// Authenticator.
+func (a *AccessTokenAuthenticator) Authenticate(sc servicecontext.ServiceContext,
+	r *http.Request) error {
+	if t := GetAccessToken(r); t != nil && a.MethodType != evergreen.MethodGet {
+		projectId := ""
+		if projCtx, err := GetProjectContext(r); err == nil && projCtx.ProjectRef != nil {
+			projectId = projCtx.ProjectRef.Identifier
+		}
+		if !t.CanWrite(projectId) {
+			return apiv3.APIError{
+				StatusCode: http.StatusForbidden,
+				Message: fmt.Sprintf("Access token '%s' with scope '%s' does not allow this request",
+					t.Name, t.Scope),
+			}
+		}
+	}
+	return a.Authenticator.Authenticate(sc, r)
+}
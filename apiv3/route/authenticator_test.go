@@ -77,10 +77,97 @@ func TestAdminAuthenticator(t *testing.T) {
 				}
 				So(err, ShouldResemble, errToResemble)
 			})
+			Convey("if there is no user, should error", func() {
+				projectRef.Admins = []string{"test_user"}
+				ctx := model.Context{
+					ProjectRef: &projectRef,
+				}
+				context.Set(req, RequestContext, &ctx)
+				So(author.Authenticate(serviceContext, req), ShouldNotBeNil)
+			})
+			Convey("if the project doesn't exist, only super users should succeed", func() {
+				serviceContext.SetSuperUsers([]string{"super_user"})
+				ctx := model.Context{}
+				context.Set(req, RequestContext, &ctx)
+
+				u := user.DBUser{
+					Id: "test_user",
+				}
+				context.Set(req, RequestUser, &u)
+				So(author.Authenticate(serviceContext, req), ShouldNotBeNil)
+
+				su := user.DBUser{
+					Id: "super_user",
+				}
+				context.Set(req, RequestUser, &su)
+				So(author.Authenticate(serviceContext, req), ShouldBeNil)
+			})
 		})
 	})
 
 }
+func TestProjectRoleAuthenticator(t *testing.T) {
+	Convey("When there is an http request, "+
+		"a project ref with roles, authenticator, and a service context", t, func() {
+		req, err := http.NewRequest(evergreen.MethodPost, "/", nil)
+		So(err, ShouldBeNil)
+		projectRef := model.ProjectRef{
+			Admins: []string{"admin_user"},
+			Roles: []model.ProjectRole{
+				{Role: model.ProjectRoleViewer, User: "test_user"},
+				{Role: model.ProjectRoleTaskController, Group: "build-team"},
+			},
+		}
+		ctx := model.Context{ProjectRef: &projectRef}
+		serviceContext := &servicecontext.MockServiceContext{}
+		serviceContext.SetSuperUsers([]string{"super_user"})
+		serviceContext.SetSettings(&evergreen.Settings{
+			UserGroups: evergreen.UserGroups{"build-team": {"group_user"}},
+		})
+		author := ProjectRoleAuthenticator{Role: model.ProjectRoleTaskController}
+		Convey("When authenticating", func() {
+			context.Set(req, RequestContext, &ctx)
+
+			Reset(func() {
+				context.Clear(req)
+			})
+
+			Convey("users with the role through a group should succeed", func() {
+				context.Set(req, RequestUser, &user.DBUser{Id: "group_user"})
+				So(author.Authenticate(serviceContext, req), ShouldBeNil)
+			})
+			Convey("admins and super users should succeed", func() {
+				context.Set(req, RequestUser, &user.DBUser{Id: "admin_user"})
+				So(author.Authenticate(serviceContext, req), ShouldBeNil)
+				context.Set(req, RequestUser, &user.DBUser{Id: "super_user"})
+				So(author.Authenticate(serviceContext, req), ShouldBeNil)
+			})
+			Convey("users with a less privileged role should be forbidden", func() {
+				context.Set(req, RequestUser, &user.DBUser{Id: "test_user"})
+				err := author.Authenticate(serviceContext, req)
+				So(err, ShouldNotBeNil)
+				So(err.(apiv3.APIError).StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+			Convey("users without a role should be forbidden", func() {
+				context.Set(req, RequestUser, &user.DBUser{Id: "other_user"})
+				err := author.Authenticate(serviceContext, req)
+				So(err, ShouldNotBeNil)
+				So(err.(apiv3.APIError).StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+			Convey("if there is no user, should error", func() {
+				err := author.Authenticate(serviceContext, req)
+				So(err, ShouldNotBeNil)
+				So(err.(apiv3.APIError).StatusCode, ShouldEqual, http.StatusNotFound)
+			})
+			Convey("projects without roles should allow any user", func() {
+				projectRef.Roles = nil
+				context.Set(req, RequestUser, &user.DBUser{Id: "other_user"})
+				So(author.Authenticate(serviceContext, req), ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestSuperUserAuthenticator(t *testing.T) {
 	Convey("When there is an http request, "+
 		"an authenticator, and a service context", t, func() {
@@ -121,6 +208,9 @@ func TestSuperUserAuthenticator(t *testing.T) {
 				So(err, ShouldResemble, errToResemble)
 
 			})
+			Convey("if there is no user, should error", func() {
+				So(author.Authenticate(serviceContext, req), ShouldNotBeNil)
+			})
 		})
 	})
 
@@ -0,0 +1,305 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/auth"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+)
+
+func getBuildRouteManager(route string, version int) *RouteManager {
+	bgh := &buildGetHandler{}
+	buildGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    bgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	bph := &buildPriorityHandler{}
+	buildPatch := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    bph.Handler(),
+		MethodType:        evergreen.MethodPatch,
+	}
+
+	buildRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{buildGet, buildPatch},
+		Version: version,
+	}
+	return &buildRoute
+}
+
+func getBuildAbortRouteManager(route string, version int) *RouteManager {
+	bah := &buildAbortHandler{}
+	buildAbort := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    bah.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	buildRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{buildAbort},
+		Version: version,
+	}
+	return &buildRoute
+}
+
+func getBuildRestartRouteManager(route string, version int) *RouteManager {
+	brh := &buildRestartHandler{}
+	buildRestart := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    brh.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	buildRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{buildRestart},
+		Version: version,
+	}
+	return &buildRoute
+}
+
+func getBuildsByProjectRouteManager(route string, version int) *RouteManager {
+	bph := &buildsByProjectHandler{}
+	buildsGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    bph.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	buildsRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{buildsGet},
+		Version: version,
+	}
+	return &buildsRoute
+}
+
+// buildIdFromContext returns the id of the build in the request's project
+// context, or a not found error if there is none.
+func buildIdFromContext(r *http.Request) (string, error) {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.Build == nil {
+		return "", apiv3.APIError{
+			Message:    "Build not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return projCtx.Build.Id, nil
+}
+
+// buildResponse fetches the build from the service layer and returns it as
+// the response of a request.
+func buildResponse(sc servicecontext.ServiceContext, buildId string) (ResponseData, error) {
+	b, err := sc.FindBuildById(buildId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	buildModel := &model.APIBuild{}
+	if err = buildModel.BuildFromService(b); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{buildModel},
+	}, nil
+}
+
+// buildGetHandler implements the route GET /builds/{build_id}.
+type buildGetHandler struct {
+	buildId string
+}
+
+func (bgh *buildGetHandler) Handler() RequestHandler {
+	return &buildGetHandler{}
+}
+
+// ParseAndValidate fetches the build id from the request context.
+func (bgh *buildGetHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	bgh.buildId, err = buildIdFromContext(r)
+	return err
+}
+
+// Execute returns the build.
+func (bgh *buildGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return buildResponse(sc, bgh.buildId)
+}
+
+// buildPriorityHandler implements the route PATCH /builds/{build_id}. It sets
+// the priority of all of the build's tasks.
+type buildPriorityHandler struct {
+	buildId  string
+	priority int64
+	user     auth.User
+}
+
+func (bph *buildPriorityHandler) Handler() RequestHandler {
+	return &buildPriorityHandler{}
+}
+
+// ParseAndValidate fetches the build id and user from the request context
+// and the priority from the request body.
+func (bph *buildPriorityHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if bph.priority, err = parsePriorityChange(r); err != nil {
+		return err
+	}
+	if bph.buildId, err = buildIdFromContext(r); err != nil {
+		return err
+	}
+	bph.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute sets the priority of the build's tasks and returns the build.
+func (bph *buildPriorityHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := checkPriorityAllowed(sc, bph.user, bph.priority); err != nil {
+		return ResponseData{}, err
+	}
+	if err := sc.SetBuildPriority(bph.buildId, bph.user.Username(), bph.priority); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return buildResponse(sc, bph.buildId)
+}
+
+// buildAbortHandler implements the route POST /builds/{build_id}/abort. It
+// aborts the build's in progress tasks and deactivates the build.
+type buildAbortHandler struct {
+	buildId  string
+	username string
+}
+
+func (bah *buildAbortHandler) Handler() RequestHandler {
+	return &buildAbortHandler{}
+}
+
+// ParseAndValidate fetches the build id and user from the request context.
+func (bah *buildAbortHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if bah.buildId, err = buildIdFromContext(r); err != nil {
+		return err
+	}
+	bah.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute aborts the build and returns it.
+func (bah *buildAbortHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.AbortBuild(bah.buildId, bah.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return buildResponse(sc, bah.buildId)
+}
+
+// buildRestartHandler implements the route POST /builds/{build_id}/restart.
+// It restarts the build's finished tasks.
+type buildRestartHandler struct {
+	buildId  string
+	username string
+}
+
+func (brh *buildRestartHandler) Handler() RequestHandler {
+	return &buildRestartHandler{}
+}
+
+// ParseAndValidate fetches the build id and user from the request context.
+func (brh *buildRestartHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if brh.buildId, err = buildIdFromContext(r); err != nil {
+		return err
+	}
+	brh.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute restarts the build and returns it.
+func (brh *buildRestartHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.RestartBuild(brh.buildId, brh.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return buildResponse(sc, brh.buildId)
+}
+
+// buildsByProjectHandler implements the route GET
+// /projects/{project_id}/builds. It pages through the project's non-patch
+// builds, most recent first.
+type buildsByProjectHandler struct {
+	*PaginationExecutor
+}
+
+func (bph *buildsByProjectHandler) Handler() RequestHandler {
+	return &buildsByProjectHandler{&PaginationExecutor{
+		KeyQueryParam:   "build_id",
+		LimitQueryParam: "limit",
+	}}
+}
+
+// ParseAndValidate fetches the project from the request context, and the key
+// and limit from the request.
+func (bph *buildsByProjectHandler) ParseAndValidate(r *http.Request) error {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.ProjectRef == nil {
+		return apiv3.APIError{
+			Message:    "Project not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	bph.Paginator = buildsByProjectPaginator(projCtx.ProjectRef.Identifier)
+	return bph.PaginationExecutor.ParseAndValidate(r)
+}
+
+// buildsByProjectPaginator returns a PaginatorFunc that pages through the
+// project's builds.
+func buildsByProjectPaginator(projectId string) PaginatorFunc {
+	return func(key string, limit int, sc servicecontext.ServiceContext) ([]model.Model, *PageResult, error) {
+		// Fetch this page of builds, plus the first build of the next one
+		builds, err := sc.FindBuildsByProject(projectId, key, limit+1, 1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+		prevBuilds, err := sc.FindBuildsByProject(projectId, key, limit, -1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+
+		pages := &PageResult{}
+		if len(builds) > limit {
+			pages.Next = &Page{
+				Relation: "next",
+				Key:      builds[limit].Id,
+				Limit:    limit,
+			}
+			builds = builds[:limit]
+		}
+		if len(prevBuilds) > 0 {
+			pages.Prev = &Page{
+				Relation: "prev",
+				Key:      prevBuilds[len(prevBuilds)-1].Id,
+				Limit:    len(prevBuilds),
+			}
+		}
+
+		models := make([]model.Model, len(builds))
+		for ix := range builds {
+			buildModel := &model.APIBuild{}
+			if err = buildModel.BuildFromService(&builds[ix]); err != nil {
+				return []model.Model{}, nil, err
+			}
+			models[ix] = buildModel
+		}
+		return models, pages, nil
+	}
+}
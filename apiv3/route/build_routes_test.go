@@ -0,0 +1,210 @@
+package route
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/context"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildsByProjectPaginator(t *testing.T) {
+	numBuilds := 10
+	Convey("When paginating with a ServiceContext", t, func() {
+		serviceContext := servicecontext.MockServiceContext{}
+		Convey("and there are builds from several projects", func() {
+			cachedBuilds := []build.Build{}
+			for i := 0; i < numBuilds; i++ {
+				cachedBuilds = append(cachedBuilds,
+					build.Build{Id: fmt.Sprintf("build%d", i), Project: "proj"},
+					build.Build{Id: fmt.Sprintf("other%d", i), Project: "other"})
+			}
+			serviceContext.MockBuildConnector.CachedBuilds = cachedBuilds
+			paginator := buildsByProjectPaginator("proj")
+
+			expectedBuilds := func(start, end int) []model.Model {
+				models := []model.Model{}
+				for i := start; i < end; i++ {
+					models = append(models, &model.APIBuild{
+						Id:        model.APIString(fmt.Sprintf("build%d", i)),
+						ProjectId: model.APIString("proj"),
+						Tasks:     []string{},
+					})
+				}
+				return models
+			}
+			Convey("then finding a key in the middle of the set should produce"+
+				" a next and previous page of the project's builds", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "build6",
+						Limit:    3,
+						Relation: "next",
+					},
+					Prev: &Page{
+						Key:      "build0",
+						Limit:    3,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "build3", 3,
+					&serviceContext, expectedPages, expectedBuilds(3, 6), nil)
+			})
+			Convey("then finding a key near the beginning of the set should produce"+
+				" a limited previous page", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "build4",
+						Limit:    3,
+						Relation: "next",
+					},
+					Prev: &Page{
+						Key:      "build0",
+						Limit:    1,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "build1", 3,
+					&serviceContext, expectedPages, expectedBuilds(1, 4), nil)
+			})
+			Convey("then starting without a key should produce only a next page", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "build3",
+						Limit:    3,
+						Relation: "next",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "", 3,
+					&serviceContext, expectedPages, expectedBuilds(0, 3), nil)
+			})
+			Convey("then finding a key in the last page should produce only a"+
+				" previous page", func() {
+				expectedPages := &PageResult{
+					Prev: &Page{
+						Key:      "build5",
+						Limit:    3,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "build8", 3,
+					&serviceContext, expectedPages, expectedBuilds(8, 10), nil)
+			})
+		})
+	})
+}
+
+func TestBuildPriorityPrepare(t *testing.T) {
+	Convey("With handler and a project context and user", t, func() {
+		bph := &buildPriorityHandler{}
+		projCtx := serviceModel.Context{
+			Build: &build.Build{Id: "testBuildId"},
+		}
+		u := user.DBUser{Id: "testUser"}
+
+		Convey("then should error on empty body", func() {
+			req, err := http.NewRequest(evergreen.MethodPatch, "builds/testBuildId", &bytes.Buffer{})
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = bph.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "No request body sent",
+				StatusCode: http.StatusBadRequest,
+			})
+		})
+		Convey("then should error when priority isn't set", func() {
+			req, err := http.NewRequest(evergreen.MethodPatch, "builds/testBuildId",
+				bytes.NewBufferString(`{}`))
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = bph.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Must set 'priority'",
+				StatusCode: http.StatusBadRequest,
+			})
+		})
+		Convey("then should error on empty build", func() {
+			projCtx.Build = nil
+			req, err := http.NewRequest(evergreen.MethodPatch, "builds/testBuildId",
+				bytes.NewBufferString(`{"priority": 10}`))
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = bph.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Build not found",
+				StatusCode: http.StatusNotFound,
+			})
+		})
+		Convey("then should set the build id, priority and user", func() {
+			req, err := http.NewRequest(evergreen.MethodPatch, "builds/testBuildId",
+				bytes.NewBufferString(`{"priority": 10}`))
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			So(bph.ParseAndValidate(req), ShouldBeNil)
+			So(bph.buildId, ShouldEqual, "testBuildId")
+			So(bph.priority, ShouldEqual, 10)
+			So(bph.user, ShouldResemble, &u)
+		})
+	})
+}
+
+func TestBuildPriorityExecute(t *testing.T) {
+	Convey("With a build returned by the ServiceContext", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		sc.MockBuildConnector.CachedBuilds = []build.Build{
+			{Id: "testBuildId", Project: "proj"},
+		}
+		bph := &buildPriorityHandler{
+			buildId: "testBuildId",
+			user:    &user.DBUser{Id: "testUser"},
+		}
+		Convey("then a user should be able to set a normal priority", func() {
+			bph.priority = evergreen.MaxTaskPriority
+			res, err := bph.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resBuild, ok := res.Result[0].(*model.APIBuild)
+			So(ok, ShouldBeTrue)
+			So(resBuild.Id, ShouldEqual, model.APIString("testBuildId"))
+			So(sc.MockBuildConnector.CachedPriorities["testBuildId"], ShouldEqual, evergreen.MaxTaskPriority)
+		})
+		Convey("then only a superuser should be able to set a high priority", func() {
+			sc.SetSuperUsers([]string{"superUser"})
+			bph.priority = evergreen.MaxTaskPriority + 1
+			_, err := bph.Execute(&sc)
+			apiErr, ok := err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusForbidden)
+			So(sc.MockBuildConnector.CachedPriorities, ShouldBeEmpty)
+
+			sc.SetSuperUsers([]string{"superUser", "testUser"})
+			_, err = bph.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(sc.MockBuildConnector.CachedPriorities["testBuildId"], ShouldEqual, evergreen.MaxTaskPriority+1)
+		})
+		Convey("then an error from the service function should be returned", func() {
+			sc.MockBuildConnector.StoredError = &apiv3.APIError{
+				Message:    "build not found",
+				StatusCode: http.StatusNotFound,
+			}
+			_, err := bph.Execute(&sc)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "build not found",
+				StatusCode: http.StatusNotFound,
+			})
+		})
+	})
+}
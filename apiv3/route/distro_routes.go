@@ -0,0 +1,256 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/gorilla/mux"
+)
+
+func getDistrosRouteManager(route string, version int) *RouteManager {
+	dgh := &distrosGetHandler{}
+	distrosGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &SuperUserAuthenticator{},
+		RequestHandler:    dgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	distrosRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{distrosGet},
+		Version: version,
+	}
+	return &distrosRoute
+}
+
+func getDistroRouteManager(route string, version int) *RouteManager {
+	dgh := &distroGetHandler{}
+	distroGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &SuperUserAuthenticator{},
+		RequestHandler:    dgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	dph := &distroPutHandler{}
+	distroPut := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &SuperUserAuthenticator{},
+		RequestHandler:    dph.Handler(),
+		MethodType:        evergreen.MethodPut,
+	}
+
+	ddh := &distroDeleteHandler{}
+	distroDelete := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &SuperUserAuthenticator{},
+		RequestHandler:    ddh.Handler(),
+		MethodType:        evergreen.MethodDelete,
+	}
+
+	distroRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{distroGet, distroPut, distroDelete},
+		Version: version,
+	}
+	return &distroRoute
+}
+
+// distroResponse fetches the distro from the service layer and returns it as
+// the response of a request.
+func distroResponse(sc servicecontext.ServiceContext, distroId string) (ResponseData, error) {
+	d, err := sc.FindDistroById(distroId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	distroModel := &model.APIDistro{}
+	if err = distroModel.BuildFromService(d); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{distroModel},
+	}, nil
+}
+
+// distroGetHandler implements the route GET /distros/{distro_id}.
+type distroGetHandler struct {
+	distroId string
+}
+
+func (dgh *distroGetHandler) Handler() RequestHandler {
+	return &distroGetHandler{}
+}
+
+// ParseAndValidate fetches the distro id from the request's route.
+func (dgh *distroGetHandler) ParseAndValidate(r *http.Request) error {
+	dgh.distroId = mux.Vars(r)["distro_id"]
+	return nil
+}
+
+// Execute returns the distro.
+func (dgh *distroGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return distroResponse(sc, dgh.distroId)
+}
+
+// distroPutHandler implements the route PUT /distros/{distro_id}. It creates
+// the distro, or replaces it if it already exists, after validating it.
+type distroPutHandler struct {
+	distro   *distro.Distro
+	username string
+}
+
+func (dph *distroPutHandler) Handler() RequestHandler {
+	return &distroPutHandler{}
+}
+
+// ParseAndValidate fetches the distro from the request body, with its id
+// taken from the request's route, and the user from the request context.
+func (dph *distroPutHandler) ParseAndValidate(r *http.Request) error {
+	distroId := mux.Vars(r)["distro_id"]
+
+	distroModel := &model.APIDistro{}
+	if err := decodeRequestBody(r, distroModel); err != nil {
+		return err
+	}
+	if distroModel.Id != "" && string(distroModel.Id) != distroId {
+		return apiv3.APIError{
+			Message: fmt.Sprintf("Distro id '%s' does not match the distro '%s' being changed",
+				distroModel.Id, distroId),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	distroModel.Id = model.APIString(distroId)
+
+	d, err := distroModel.ToService()
+	if err != nil {
+		return err
+	}
+	dph.distro = d.(*distro.Distro)
+	dph.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute creates or replaces the distro and returns it.
+func (dph *distroPutHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	newDistro := false
+	if _, err := sc.FindDistroById(dph.distro.Id); err != nil {
+		apiErr, ok := err.(*apiv3.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			return ResponseData{}, serviceError(err, "Database error")
+		}
+		newDistro = true
+	}
+
+	if err := sc.ValidateDistro(dph.distro, sc.GetSettings(), newDistro); err != nil {
+		return ResponseData{}, serviceError(err, "Validation error")
+	}
+	if newDistro {
+		if err := sc.CreateDistro(dph.distro, dph.username); err != nil {
+			return ResponseData{}, serviceError(err, "Database error")
+		}
+	} else {
+		if err := sc.UpdateDistro(dph.distro, dph.username); err != nil {
+			return ResponseData{}, serviceError(err, "Database error")
+		}
+	}
+	return distroResponse(sc, dph.distro.Id)
+}
+
+// distroDeleteHandler implements the route DELETE /distros/{distro_id}.
+type distroDeleteHandler struct {
+	distroId string
+	username string
+}
+
+func (ddh *distroDeleteHandler) Handler() RequestHandler {
+	return &distroDeleteHandler{}
+}
+
+// ParseAndValidate fetches the distro id from the request's route and the
+// user from the request context.
+func (ddh *distroDeleteHandler) ParseAndValidate(r *http.Request) error {
+	ddh.distroId = mux.Vars(r)["distro_id"]
+	ddh.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute removes the distro and returns it as it was.
+func (ddh *distroDeleteHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	d, err := sc.FindDistroById(ddh.distroId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	if err = sc.DeleteDistro(d, ddh.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	distroModel := &model.APIDistro{}
+	if err = distroModel.BuildFromService(d); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{distroModel},
+	}, nil
+}
+
+// distrosGetHandler implements the route GET /distros. It pages through the
+// distros in order of their ids.
+type distrosGetHandler struct {
+	*PaginationExecutor
+}
+
+func (dgh *distrosGetHandler) Handler() RequestHandler {
+	return &distrosGetHandler{&PaginationExecutor{
+		KeyQueryParam:   "distro_id",
+		LimitQueryParam: "limit",
+		Paginator:       distrosPaginator,
+	}}
+}
+
+// distrosPaginator is an instance of a PaginatorFunc that defines how to
+// paginate on the distros.
+func distrosPaginator(key string, limit int, sc servicecontext.ServiceContext) ([]model.Model, *PageResult, error) {
+	// Fetch this page of distros, plus the first distro of the next one
+	distros, err := sc.FindDistrosById(key, limit+1, 1)
+	if err != nil {
+		return []model.Model{}, nil, serviceError(err, "Database error")
+	}
+	prevDistros, err := sc.FindDistrosById(key, limit, -1)
+	if err != nil {
+		return []model.Model{}, nil, serviceError(err, "Database error")
+	}
+
+	pages := &PageResult{}
+	if len(distros) > limit {
+		pages.Next = &Page{
+			Relation: "next",
+			Key:      distros[limit].Id,
+			Limit:    limit,
+		}
+		distros = distros[:limit]
+	}
+	if len(prevDistros) > 0 {
+		pages.Prev = &Page{
+			Relation: "prev",
+			Key:      prevDistros[len(prevDistros)-1].Id,
+			Limit:    len(prevDistros),
+		}
+	}
+
+	models := make([]model.Model, len(distros))
+	for ix := range distros {
+		distroModel := &model.APIDistro{}
+		if err = distroModel.BuildFromService(&distros[ix]); err != nil {
+			return []model.Model{}, nil, err
+		}
+		models[ix] = distroModel
+	}
+	return models, pages, nil
+}
@@ -0,0 +1,153 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDistrosPaginator(t *testing.T) {
+	Convey("When paginating with a ServiceContext", t, func() {
+		serviceContext := servicecontext.MockServiceContext{}
+		for i := 0; i < 10; i++ {
+			serviceContext.MockDistroConnector.CachedDistros = append(
+				serviceContext.MockDistroConnector.CachedDistros,
+				distro.Distro{Id: fmt.Sprintf("distro%d", i)})
+		}
+		expectedDistros := func(start, end int) []model.Model {
+			models := []model.Model{}
+			for i := start; i < end; i++ {
+				models = append(models, &model.APIDistro{
+					Id: model.APIString(fmt.Sprintf("distro%d", i)),
+				})
+			}
+			return models
+		}
+		Convey("then finding a key in the middle of the set should produce"+
+			" a next and previous page", func() {
+			expectedPages := &PageResult{
+				Next: &Page{
+					Key:      "distro7",
+					Limit:    3,
+					Relation: "next",
+				},
+				Prev: &Page{
+					Key:      "distro1",
+					Limit:    3,
+					Relation: "prev",
+				},
+			}
+			checkPaginatorResultMatches(distrosPaginator, "distro4", 3,
+				&serviceContext, expectedPages, expectedDistros(4, 7), nil)
+		})
+		Convey("then starting without a key should produce only a next page", func() {
+			expectedPages := &PageResult{
+				Next: &Page{
+					Key:      "distro3",
+					Limit:    3,
+					Relation: "next",
+				},
+			}
+			checkPaginatorResultMatches(distrosPaginator, "", 3,
+				&serviceContext, expectedPages, expectedDistros(0, 3), nil)
+		})
+	})
+}
+
+func TestDistroRoutes(t *testing.T) {
+	Convey("With distro routes registered on a router", t, func() {
+		sc := &servicecontext.MockServiceContext{}
+		sc.SetPrefix("rest")
+		sc.SetSuperUsers([]string{"super_user"})
+		sc.MockUserConnector.CachedUsers = map[string]*user.DBUser{
+			"super_user": {Id: "super_user", APIKey: "super_key"},
+			"other_user": {Id: "other_user", APIKey: "other_key"},
+		}
+		sc.MockDistroConnector.CachedDistros = []distro.Distro{
+			{Id: "archlinux", Arch: "linux_amd64"},
+		}
+		r := mux.NewRouter()
+		getDistroRouteManager("/distros/{distro_id}", 2).Register(r, sc)
+
+		doRequest := func(method, distroId, userName, key string, body interface{}) *httptest.ResponseRecorder {
+			buf := &bytes.Buffer{}
+			if body != nil {
+				So(json.NewEncoder(buf).Encode(body), ShouldBeNil)
+			}
+			req, err := http.NewRequest(method, "/rest/v2/distros/"+distroId, buf)
+			So(err, ShouldBeNil)
+			req.Header.Add("Api-User", userName)
+			req.Header.Add("Api-Key", key)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			return rr
+		}
+
+		Convey("a super user should be able to fetch a distro", func() {
+			rr := doRequest(evergreen.MethodGet, "archlinux", "super_user", "super_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIDistro{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Id, ShouldEqual, model.APIString("archlinux"))
+			So(res.Arch, ShouldEqual, model.APIString("linux_amd64"))
+		})
+		Convey("other users should not be able to see distros", func() {
+			rr := doRequest(evergreen.MethodGet, "archlinux", "other_user", "other_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+			rr = doRequest(evergreen.MethodGet, "archlinux", "", "", nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+		})
+		Convey("fetching a distro that doesn't exist should 404", func() {
+			rr := doRequest(evergreen.MethodGet, "ubuntu", "super_user", "super_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+		})
+		Convey("putting a new distro should create it", func() {
+			rr := doRequest(evergreen.MethodPut, "ubuntu", "super_user", "super_key",
+				map[string]interface{}{"arch": "linux_amd64", "pool_size": 10})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(len(sc.MockDistroConnector.CachedDistros), ShouldEqual, 2)
+			d := sc.MockDistroConnector.CachedDistros[1]
+			So(d.Id, ShouldEqual, "ubuntu")
+			So(d.PoolSize, ShouldEqual, 10)
+		})
+		Convey("putting an existing distro should replace it", func() {
+			rr := doRequest(evergreen.MethodPut, "archlinux", "super_user", "super_key",
+				map[string]interface{}{"distro_id": "archlinux", "arch": "linux_386"})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockDistroConnector.CachedDistros, ShouldResemble,
+				[]distro.Distro{{Id: "archlinux", Arch: "linux_386"}})
+		})
+		Convey("putting a distro with a different id should error", func() {
+			rr := doRequest(evergreen.MethodPut, "archlinux", "super_user", "super_key",
+				map[string]interface{}{"distro_id": "ubuntu"})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("an invalid distro should not be saved", func() {
+			sc.MockDistroConnector.StoredError = &apiv3.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "invalid distro: distro 'user' cannot be blank",
+			}
+			rr := doRequest(evergreen.MethodPut, "ubuntu", "super_user", "super_key",
+				map[string]interface{}{"arch": "linux_amd64"})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			So(len(sc.MockDistroConnector.CachedDistros), ShouldEqual, 1)
+		})
+		Convey("deleting a distro should remove it", func() {
+			rr := doRequest(evergreen.MethodDelete, "archlinux", "super_user", "super_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockDistroConnector.CachedDistros, ShouldBeEmpty)
+		})
+	})
+}
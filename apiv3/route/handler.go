@@ -1,13 +1,20 @@
 package route
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen/apiv3"
 	"github.com/evergreen-ci/evergreen/apiv3/model"
 	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
 	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
 )
 
 // MethodHandler contains all of the methods necessary for completely processing
@@ -39,6 +46,15 @@ type ResponseData struct {
 	Metadata interface{}
 }
 
+// ListMetadata is the metadata of a response made up of all of the results of
+// a request, as a JSON list, rather than just the first of them.
+type ListMetadata struct{}
+
+// TextMetadata is the metadata of a response made up of the results of a
+// request as plain text, one line per result. Each of the results must
+// implement fmt.Stringer.
+type TextMetadata struct{}
+
 // RequestHandler is an interface that defines how to process an HTTP request
 // against an API resource.
 type RequestHandler interface {
@@ -71,7 +87,11 @@ func makeHandler(methodHandler MethodHandler, sc servicecontext.ServiceContext,
 			}
 		}
 
-		if err := methodHandler.Authenticate(sc, r); err != nil {
+		authenticator := &AccessTokenAuthenticator{
+			MethodType:    methodHandler.MethodType,
+			Authenticator: methodHandler.Authenticator,
+		}
+		if err := authenticator.Authenticate(sc, r); err != nil {
 			handleAPIError(err, w, r)
 			return
 		}
@@ -93,12 +113,22 @@ func makeHandler(methodHandler MethodHandler, sc servicecontext.ServiceContext,
 		// other specific cases for how to handle results.
 		switch m := result.Metadata.(type) {
 		case *PaginationMetadata:
-			err := m.MakeHeader(w, sc.GetPrefix(), sc.GetURL(), route, version)
+			// fill the route's variables in, so that the links point at the
+			// same resource
+			routePath := route
+			for name, value := range mux.Vars(r) {
+				routePath = strings.Replace(routePath, "{"+name+"}", value, -1)
+			}
+			err := m.MakeHeader(w, sc.GetPrefix(), sc.GetURL(), routePath, version)
 			if err != nil {
 				handleAPIError(err, w, r)
 				return
 			}
 			util.WriteJSON(&w, result.Result, http.StatusOK)
+		case *ListMetadata:
+			util.WriteJSON(&w, result.Result, http.StatusOK)
+		case *TextMetadata:
+			writeText(w, r, result.Result)
 		default:
 			if len(result.Result) < 1 {
 				http.Error(w, "{}", http.StatusInternalServerError)
@@ -130,3 +160,59 @@ func handleAPIError(e error, w http.ResponseWriter, r *http.Request) {
 
 	util.WriteJSON(&w, apiErr, apiErr.StatusCode)
 }
+
+// writeText writes out the results of a request as plain text, one line per
+// result.
+func writeText(w http.ResponseWriter, r *http.Request, results []model.Model) {
+	b := bytes.Buffer{}
+	for _, res := range results {
+		s, ok := res.(fmt.Stringer)
+		if !ok {
+			handleAPIError(errors.Errorf("cannot write %T as text", res), w, r)
+			return
+		}
+		b.WriteString(s.String())
+		b.WriteString("\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(b.Bytes())
+	grip.Warning(errors.Wrap(err, "error writing text response"))
+}
+
+// serviceError returns the APIError that a ServiceContext method returned as
+// a value, so that handleAPIError keeps its status code, and wraps any other
+// error with the given message.
+func serviceError(err error, message string) error {
+	if apiErr, ok := err.(*apiv3.APIError); ok {
+		return *apiErr
+	}
+	return errors.Wrap(err, message)
+}
+
+// decodeRequestBody reads the JSON body of the request into out, returning a
+// bad request error if there is no body or it has the wrong types.
+func decodeRequestBody(r *http.Request, out interface{}) error {
+	body := util.NewRequestReader(r)
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(out); err != nil {
+		if err == io.EOF {
+			return apiv3.APIError{
+				Message:    "No request body sent",
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		if e, ok := err.(*json.UnmarshalTypeError); ok {
+			return apiv3.APIError{
+				Message: fmt.Sprintf("Incorrect type given, expecting '%s' "+
+					"but receieved '%s'",
+					e.Type, e.Value),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		return errors.Wrap(err, "JSON unmarshal error")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+)
+
+const (
+	// allLogsType is the log type that selects the messages of all of the
+	// types of a task's log.
+	allLogsType = "ALL"
+
+	// maxLogMessages is the most log messages returned by one request, and
+	// the default limit. Clients read more by passing the time of the last
+	// message they received as 'since'.
+	maxLogMessages = 10000
+)
+
+func getTaskLogsRouteManager(route string, version int) *RouteManager {
+	lgh := &taskLogsGetHandler{}
+	logsGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    lgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	logsRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{logsGet},
+		Version: version,
+	}
+	return &logsRoute
+}
+
+// taskLogsGetHandler implements the route GET /tasks/{task_id}/logs. It
+// returns the log messages of an execution of the task, oldest first, as a
+// JSON list or, if the 'text' query param is true or plain text is accepted,
+// as plain text.
+type taskLogsGetHandler struct {
+	taskId    string
+	execution int
+	logTypes  []string
+	since     time.Time
+	until     time.Time
+	limit     int
+	text      bool
+}
+
+func (lgh *taskLogsGetHandler) Handler() RequestHandler {
+	return &taskLogsGetHandler{}
+}
+
+// ParseAndValidate fetches the task and its execution from the request
+// context and query params, along with the 'type' of log to return, which
+// defaults to all of them, the 'since' and 'until' times, in RFC 3339
+// format, to return messages within, and the 'limit' on the number of
+// messages, which can be at most maxLogMessages. Users who aren't logged in
+// may only read the task's own messages, not those of the agent or the
+// system.
+func (lgh *taskLogsGetHandler) ParseAndValidate(r *http.Request) error {
+	t, execution, err := taskExecutionFromRequest(r)
+	if err != nil {
+		return err
+	}
+	lgh.taskId = t.Id
+	lgh.execution = execution
+
+	vals := r.URL.Query()
+	logType := vals.Get("type")
+	if logType == "" {
+		logType = allLogsType
+	}
+	switch logType {
+	case allLogsType:
+		lgh.logTypes = []string{}
+	case serviceModel.TaskLogPrefix, serviceModel.AgentLogPrefix, serviceModel.SystemLogPrefix:
+		lgh.logTypes = []string{logType}
+	default:
+		return apiv3.APIError{
+			Message: fmt.Sprintf("Log type '%s' must be one of %s, %s, %s or %s", logType,
+				allLogsType, serviceModel.TaskLogPrefix, serviceModel.AgentLogPrefix,
+				serviceModel.SystemLogPrefix),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if GetUser(r) == nil {
+		if logType == allLogsType {
+			lgh.logTypes = []string{serviceModel.TaskLogPrefix}
+		} else if logType != serviceModel.TaskLogPrefix {
+			return apiv3.APIError{
+				Message:    "Must be logged in to read agent and system logs",
+				StatusCode: http.StatusUnauthorized,
+			}
+		}
+	}
+
+	if lgh.since, err = parseTimeQueryParam(r, "since"); err != nil {
+		return err
+	}
+	if lgh.until, err = parseTimeQueryParam(r, "until"); err != nil {
+		return err
+	}
+
+	lgh.limit = maxLogMessages
+	if limit := vals.Get("limit"); limit != "" {
+		lgh.limit, err = strconv.Atoi(limit)
+		if err != nil || lgh.limit <= 0 || lgh.limit > maxLogMessages {
+			return apiv3.APIError{
+				Message: fmt.Sprintf("Value '%s' provided for 'limit' must be an integer from 1 to %d",
+					limit, maxLogMessages),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	lgh.text = vals.Get("text") == "true" ||
+		strings.Contains(r.Header.Get("Accept"), "text/plain")
+	return nil
+}
+
+// Execute returns the log messages of the task's execution.
+func (lgh *taskLogsGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	msgs, err := sc.FindTaskLogs(lgh.taskId, lgh.execution, lgh.logTypes, lgh.since, lgh.until, lgh.limit)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	models := make([]model.Model, len(msgs))
+	for ix := range msgs {
+		msgModel := &model.APILogMessage{}
+		if err = msgModel.BuildFromService(&msgs[ix]); err != nil {
+			return ResponseData{}, err
+		}
+		models[ix] = msgModel
+	}
+
+	rd := ResponseData{
+		Result:   models,
+		Metadata: &ListMetadata{},
+	}
+	if lgh.text {
+		rd.Metadata = &TextMetadata{}
+	}
+	return rd, nil
+}
+
+// parseTimeQueryParam parses the RFC 3339 time in the given query param of
+// the request, returning the zero time if it isn't set.
+func parseTimeQueryParam(r *http.Request, param string) (time.Time, error) {
+	val := r.URL.Query().Get(param)
+	if val == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, apiv3.APIError{
+			Message:    fmt.Sprintf("Value '%s' provided for '%s' must be an RFC 3339 time", val, param),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTaskLogsRoute(t *testing.T) {
+	Convey("With the task logs route registered on a router", t, func() {
+		sc := &servicecontext.MockServiceContext{}
+		sc.SetPrefix("rest")
+		sc.MockUserConnector.CachedUsers = map[string]*user.DBUser{
+			"user": {Id: "user", APIKey: "key"},
+		}
+		start := time.Date(2017, time.May, 1, 12, 0, 0, 0, time.UTC)
+		sc.MockLogConnector.CachedLogs = []serviceModel.LogMessage{
+			{Type: serviceModel.TaskLogPrefix, Message: "task 1", Timestamp: start},
+			{Type: serviceModel.AgentLogPrefix, Message: "agent 1", Timestamp: start.Add(time.Minute)},
+			{Type: serviceModel.SystemLogPrefix, Message: "system 1", Timestamp: start.Add(2 * time.Minute)},
+			{Type: serviceModel.TaskLogPrefix, Message: "task 2", Timestamp: start.Add(3 * time.Minute)},
+		}
+		sc.MockContextConnector.CachedContext = serviceModel.Context{
+			Task: &task.Task{Id: "task1"},
+		}
+		r := mux.NewRouter()
+		getTaskLogsRouteManager("/tasks/{task_id}/logs", 2).Register(r, sc)
+
+		doRequest := func(query string, loggedIn bool) *httptest.ResponseRecorder {
+			req, err := http.NewRequest(evergreen.MethodGet, "/rest/v2/tasks/task1/logs"+query, nil)
+			So(err, ShouldBeNil)
+			if loggedIn {
+				req.Header.Add("Api-User", "user")
+				req.Header.Add("Api-Key", "key")
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			return rr
+		}
+		messages := func(rr *httptest.ResponseRecorder) []string {
+			res := []model.APILogMessage{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			msgs := []string{}
+			for _, m := range res {
+				msgs = append(msgs, string(m.Message))
+			}
+			return msgs
+		}
+
+		Convey("a user should be able to read all of the logs", func() {
+			rr := doRequest("", true)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(messages(rr), ShouldResemble, []string{"task 1", "agent 1", "system 1", "task 2"})
+		})
+		Convey("logs should be filterable by type", func() {
+			rr := doRequest("?type=E", true)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(messages(rr), ShouldResemble, []string{"agent 1"})
+			rr = doRequest("?type=X", true)
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("logs should be filterable by time", func() {
+			rr := doRequest("?since=2017-05-01T12:01:00Z&until=2017-05-01T12:03:00Z", true)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(messages(rr), ShouldResemble, []string{"agent 1", "system 1"})
+			rr = doRequest("?since=yesterday", true)
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("the number of logs should be limited", func() {
+			rr := doRequest("?limit=2", true)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(messages(rr), ShouldResemble, []string{"task 1", "agent 1"})
+			rr = doRequest("?limit=0", true)
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			rr = doRequest("?limit=10001", true)
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("logs should be returned as text if asked for", func() {
+			rr := doRequest("?type=T&text=true", true)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(rr.Header().Get("Content-Type"), ShouldStartWith, "text/plain")
+			So(rr.Body.String(), ShouldEqual,
+				"[2017/05/01 12:00:00.000] task 1\n[2017/05/01 12:03:00.000] task 2\n")
+		})
+		Convey("users who aren't logged in should only read task logs", func() {
+			rr := doRequest("", false)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(messages(rr), ShouldResemble, []string{"task 1", "task 2"})
+			rr = doRequest("?type=S", false)
+			So(rr.Code, ShouldEqual, http.StatusUnauthorized)
+		})
+	})
+}
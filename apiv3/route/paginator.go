@@ -38,8 +38,9 @@ type PaginationExecutor struct {
 	// retrieve results from the service layer.
 	Paginator PaginatorFunc
 
-	limit int
-	key   string
+	limit       int
+	key         string
+	queryParams url.Values
 }
 
 // PaginationMetadata is a struct that contains all of the information for
@@ -49,6 +50,10 @@ type PaginationMetadata struct {
 
 	KeyQueryParam   string
 	LimitQueryParam string
+
+	// QueryParams holds the rest of the query params of the request, such as
+	// filters on the results, which are kept in the links to other pages.
+	QueryParams url.Values
 }
 
 // Page contains the information about a single page of the resource.
@@ -81,6 +86,7 @@ func (pe *PaginationExecutor) Execute(sc servicecontext.ServiceContext) (Respons
 		Pages:           pages,
 		KeyQueryParam:   pe.KeyQueryParam,
 		LimitQueryParam: pe.LimitQueryParam,
+		QueryParams:     pe.queryParams,
 	}
 
 	rd := ResponseData{
@@ -94,6 +100,7 @@ func (pe *PaginationExecutor) Execute(sc servicecontext.ServiceContext) (Respons
 // and sets them on the PaginationExecutor.
 func (pe *PaginationExecutor) ParseAndValidate(r *http.Request) error {
 	vals := r.URL.Query()
+	pe.queryParams = vals
 	if k, ok := vals[pe.KeyQueryParam]; ok && len(k) > 0 {
 		pe.key = k[0]
 	}
@@ -203,6 +210,9 @@ func (pm *PaginationMetadata) MakeHeader(w http.ResponseWriter,
 		Host:   apiURL,
 		Path:   path.Clean(fmt.Sprintf("/%s/v%d/%s", prefix, version, route)),
 	}
+	if pm.QueryParams != nil {
+		baseURL.RawQuery = pm.QueryParams.Encode()
+	}
 
 	b := bytes.Buffer{}
 	if pm.Pages.Next != nil {
@@ -0,0 +1,359 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/auth"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+)
+
+func getPatchRouteManager(route string, version int) *RouteManager {
+	pgh := &patchGetHandler{}
+	patchGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    pgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	pph := &patchPriorityHandler{}
+	patchPatch := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRolePatchSubmitter},
+		RequestHandler:    pph.Handler(),
+		MethodType:        evergreen.MethodPatch,
+	}
+
+	patchRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{patchGet, patchPatch},
+		Version: version,
+	}
+	return &patchRoute
+}
+
+func getPatchAbortRouteManager(route string, version int) *RouteManager {
+	pah := &patchAbortHandler{}
+	patchAbort := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRolePatchSubmitter},
+		RequestHandler:    pah.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	patchRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{patchAbort},
+		Version: version,
+	}
+	return &patchRoute
+}
+
+func getPatchRestartRouteManager(route string, version int) *RouteManager {
+	prh := &patchRestartHandler{}
+	patchRestart := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRolePatchSubmitter},
+		RequestHandler:    prh.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	patchRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{patchRestart},
+		Version: version,
+	}
+	return &patchRoute
+}
+
+func getPatchesByProjectRouteManager(route string, version int) *RouteManager {
+	pph := &patchesByProjectHandler{}
+	patchesGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    pph.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	patchesRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{patchesGet},
+		Version: version,
+	}
+	return &patchesRoute
+}
+
+// patchFromContext returns the patch in the request's project context, or a
+// not found error if there is none.
+func patchFromContext(r *http.Request) (*patch.Patch, error) {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.Patch == nil {
+		return nil, apiv3.APIError{
+			Message:    "Patch not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return projCtx.Patch, nil
+}
+
+// finalizedPatchFromContext returns the patch in the request's project
+// context, or an error if there is none or it hasn't been finalized, since
+// only finalized patches have tasks.
+func finalizedPatchFromContext(r *http.Request) (*patch.Patch, error) {
+	p, err := patchFromContext(r)
+	if err != nil {
+		return nil, err
+	}
+	if p.Version == "" {
+		return nil, apiv3.APIError{
+			Message:    "Patch is not finalized",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return p, nil
+}
+
+// checkPatchEditAllowed returns an error if the user may not change the
+// patch, since only its author and superusers may, as in the UI.
+func checkPatchEditAllowed(sc servicecontext.ServiceContext, u auth.User, p *patch.Patch) error {
+	if u.Username() != p.Author && !auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return apiv3.APIError{
+			Message:    "Only the patch's author and superusers may change it",
+			StatusCode: http.StatusForbidden,
+		}
+	}
+	return nil
+}
+
+// patchResponse fetches the patch from the service layer and returns it as
+// the response of a request.
+func patchResponse(sc servicecontext.ServiceContext, patchId string) (ResponseData, error) {
+	p, err := sc.FindPatchById(patchId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	patchModel := &model.APIPatch{}
+	if err = patchModel.BuildFromService(p); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{patchModel},
+	}, nil
+}
+
+// patchGetHandler implements the route GET /patches/{patch_id}.
+type patchGetHandler struct {
+	patchId string
+}
+
+func (pgh *patchGetHandler) Handler() RequestHandler {
+	return &patchGetHandler{}
+}
+
+// ParseAndValidate fetches the patch id from the request context.
+func (pgh *patchGetHandler) ParseAndValidate(r *http.Request) error {
+	p, err := patchFromContext(r)
+	if err != nil {
+		return err
+	}
+	pgh.patchId = p.Id.Hex()
+	return nil
+}
+
+// Execute returns the patch.
+func (pgh *patchGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return patchResponse(sc, pgh.patchId)
+}
+
+// patchPriorityHandler implements the route PATCH /patches/{patch_id}. It
+// sets the priority of all of the patch's tasks.
+type patchPriorityHandler struct {
+	patch    *patch.Patch
+	priority int64
+	user     auth.User
+}
+
+func (pph *patchPriorityHandler) Handler() RequestHandler {
+	return &patchPriorityHandler{}
+}
+
+// ParseAndValidate fetches the patch and user from the request context and
+// the priority from the request body.
+func (pph *patchPriorityHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if pph.priority, err = parsePriorityChange(r); err != nil {
+		return err
+	}
+	if pph.patch, err = finalizedPatchFromContext(r); err != nil {
+		return err
+	}
+	pph.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute sets the priority of the patch's tasks and returns the patch.
+func (pph *patchPriorityHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := checkPatchEditAllowed(sc, pph.user, pph.patch); err != nil {
+		return ResponseData{}, err
+	}
+	if err := checkPriorityAllowed(sc, pph.user, pph.priority); err != nil {
+		return ResponseData{}, err
+	}
+	if err := sc.SetPatchPriority(pph.patch, pph.user.Username(), pph.priority); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return patchResponse(sc, pph.patch.Id.Hex())
+}
+
+// patchAbortHandler implements the route POST /patches/{patch_id}/abort. It
+// aborts the patch's in progress tasks and deactivates the patch, or deletes
+// the patch if it hasn't been finalized.
+type patchAbortHandler struct {
+	patch *patch.Patch
+	user  auth.User
+}
+
+func (pah *patchAbortHandler) Handler() RequestHandler {
+	return &patchAbortHandler{}
+}
+
+// ParseAndValidate fetches the patch and user from the request context.
+func (pah *patchAbortHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if pah.patch, err = patchFromContext(r); err != nil {
+		return err
+	}
+	pah.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute aborts the patch and returns it. A patch that wasn't finalized no
+// longer exists, so it is returned as it was.
+func (pah *patchAbortHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := checkPatchEditAllowed(sc, pah.user, pah.patch); err != nil {
+		return ResponseData{}, err
+	}
+	if err := sc.AbortPatch(pah.patch, pah.user.Username()); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	if pah.patch.Version != "" {
+		return patchResponse(sc, pah.patch.Id.Hex())
+	}
+
+	patchModel := &model.APIPatch{}
+	if err := patchModel.BuildFromService(pah.patch); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{patchModel},
+	}, nil
+}
+
+// patchRestartHandler implements the route POST /patches/{patch_id}/restart.
+// It restarts the patch's finished tasks.
+type patchRestartHandler struct {
+	patch *patch.Patch
+	user  auth.User
+}
+
+func (prh *patchRestartHandler) Handler() RequestHandler {
+	return &patchRestartHandler{}
+}
+
+// ParseAndValidate fetches the patch and user from the request context.
+func (prh *patchRestartHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if prh.patch, err = finalizedPatchFromContext(r); err != nil {
+		return err
+	}
+	prh.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute restarts the patch and returns it.
+func (prh *patchRestartHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := checkPatchEditAllowed(sc, prh.user, prh.patch); err != nil {
+		return ResponseData{}, err
+	}
+	if err := sc.RestartPatch(prh.patch, prh.user.Username()); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return patchResponse(sc, prh.patch.Id.Hex())
+}
+
+// patchesByProjectHandler implements the route GET
+// /projects/{project_id}/patches. It pages through the project's patches, most
+// recent first.
+type patchesByProjectHandler struct {
+	*PaginationExecutor
+}
+
+func (pph *patchesByProjectHandler) Handler() RequestHandler {
+	return &patchesByProjectHandler{&PaginationExecutor{
+		KeyQueryParam:   "patch_id",
+		LimitQueryParam: "limit",
+	}}
+}
+
+// ParseAndValidate fetches the project from the request context, and the key
+// and limit from the request.
+func (pph *patchesByProjectHandler) ParseAndValidate(r *http.Request) error {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.ProjectRef == nil {
+		return apiv3.APIError{
+			Message:    "Project not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	pph.Paginator = patchesByProjectPaginator(projCtx.ProjectRef.Identifier)
+	return pph.PaginationExecutor.ParseAndValidate(r)
+}
+
+// patchesByProjectPaginator returns a PaginatorFunc that pages through the
+// project's patches.
+func patchesByProjectPaginator(projectId string) PaginatorFunc {
+	return func(key string, limit int, sc servicecontext.ServiceContext) ([]model.Model, *PageResult, error) {
+		// Fetch this page of patches, plus the first patch of the next one
+		patches, err := sc.FindPatchesByProject(projectId, key, limit+1, 1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+		prevPatches, err := sc.FindPatchesByProject(projectId, key, limit, -1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+
+		pages := &PageResult{}
+		if len(patches) > limit {
+			pages.Next = &Page{
+				Relation: "next",
+				Key:      patches[limit].Id.Hex(),
+				Limit:    limit,
+			}
+			patches = patches[:limit]
+		}
+		if len(prevPatches) > 0 {
+			pages.Prev = &Page{
+				Relation: "prev",
+				Key:      prevPatches[len(prevPatches)-1].Id.Hex(),
+				Limit:    len(prevPatches),
+			}
+		}
+
+		models := make([]model.Model, len(patches))
+		for ix := range patches {
+			patchModel := &model.APIPatch{}
+			if err = patchModel.BuildFromService(&patches[ix]); err != nil {
+				return []model.Model{}, nil, err
+			}
+			models[ix] = patchModel
+		}
+		return models, pages, nil
+	}
+}
@@ -0,0 +1,185 @@
+package route
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/context"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestPatchRestartPrepare(t *testing.T) {
+	Convey("With handler and a project context and user", t, func() {
+		prh := &patchRestartHandler{}
+		projCtx := serviceModel.Context{
+			Patch: &patch.Patch{
+				Id:      bson.NewObjectId(),
+				Version: "testVersionId",
+			},
+		}
+		u := user.DBUser{Id: "testUser"}
+
+		Convey("then should error on empty patch", func() {
+			projCtx.Patch = nil
+			req, err := http.NewRequest(evergreen.MethodPost, "patches/testPatchId/restart", &bytes.Buffer{})
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = prh.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Patch not found",
+				StatusCode: http.StatusNotFound,
+			})
+		})
+		Convey("then should error on a patch that isn't finalized", func() {
+			projCtx.Patch.Version = ""
+			req, err := http.NewRequest(evergreen.MethodPost, "patches/testPatchId/restart", &bytes.Buffer{})
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = prh.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Patch is not finalized",
+				StatusCode: http.StatusBadRequest,
+			})
+		})
+		Convey("then should set the patch and user", func() {
+			req, err := http.NewRequest(evergreen.MethodPost, "patches/testPatchId/restart", &bytes.Buffer{})
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			So(prh.ParseAndValidate(req), ShouldBeNil)
+			So(prh.patch, ShouldEqual, projCtx.Patch)
+			So(prh.user, ShouldResemble, &u)
+		})
+	})
+}
+
+func TestPatchAbortExecute(t *testing.T) {
+	Convey("With a patch returned by the ServiceContext", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		p := patch.Patch{
+			Id:        bson.NewObjectId(),
+			Project:   "proj",
+			Version:   "testVersionId",
+			Activated: true,
+		}
+		sc.MockPatchConnector.CachedPatches = []patch.Patch{p}
+		pah := &patchAbortHandler{
+			patch: &p,
+			user:  &user.DBUser{Id: "testUser"},
+		}
+
+		Convey("then aborting a finalized patch should return the aborted patch", func() {
+			res, err := pah.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resPatch, ok := res.Result[0].(*model.APIPatch)
+			So(ok, ShouldBeTrue)
+			So(resPatch.Id, ShouldEqual, model.APIString(p.Id.Hex()))
+			So(resPatch.Activated, ShouldBeFalse)
+		})
+		Convey("then aborting a patch that isn't finalized should return it as it was", func() {
+			p.Version = ""
+			sc.MockPatchConnector.CachedPatches = nil
+			res, err := pah.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resPatch, ok := res.Result[0].(*model.APIPatch)
+			So(ok, ShouldBeTrue)
+			So(resPatch.Id, ShouldEqual, model.APIString(p.Id.Hex()))
+		})
+	})
+}
+
+func TestPatchRestartAndPriorityExecute(t *testing.T) {
+	Convey("With a patch that isn't finalized", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		p := patch.Patch{Id: bson.NewObjectId(), Project: "proj"}
+		sc.MockPatchConnector.CachedPatches = []patch.Patch{p}
+		u := &user.DBUser{Id: "testUser"}
+
+		Convey("then restarting it should be a bad request", func() {
+			prh := &patchRestartHandler{patch: &p, user: u}
+			_, err := prh.Execute(&sc)
+			apiErr, ok := err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("then setting its priority should be a bad request", func() {
+			pph := &patchPriorityHandler{patch: &p, user: u, priority: 10}
+			_, err := pph.Execute(&sc)
+			apiErr, ok := err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusBadRequest)
+			So(sc.MockPatchConnector.CachedPriorities, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestPatchEditPermissions(t *testing.T) {
+	Convey("With a patch and a list of superusers", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		sc.SetSuperUsers([]string{"superUser"})
+		p := patch.Patch{
+			Id:        bson.NewObjectId(),
+			Project:   "proj",
+			Author:    "author",
+			Version:   "testVersionId",
+			Activated: true,
+		}
+		sc.MockPatchConnector.CachedPatches = []patch.Patch{p}
+		other := &user.DBUser{Id: "other"}
+
+		Convey("then other users should not be able to change it", func() {
+			pph := &patchPriorityHandler{patch: &p, user: other, priority: 10}
+			_, err := pph.Execute(&sc)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Only the patch's author and superusers may change it",
+				StatusCode: http.StatusForbidden,
+			})
+			So(sc.MockPatchConnector.CachedPriorities, ShouldBeEmpty)
+
+			prh := &patchRestartHandler{patch: &p, user: other}
+			_, err = prh.Execute(&sc)
+			apiErr, ok := err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusForbidden)
+
+			pah := &patchAbortHandler{patch: &p, user: other}
+			_, err = pah.Execute(&sc)
+			apiErr, ok = err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusForbidden)
+			So(sc.MockPatchConnector.CachedPatches[0].Activated, ShouldBeTrue)
+		})
+		Convey("then other users should not be able to delete it before it's finalized", func() {
+			p.Version = ""
+			sc.MockPatchConnector.CachedPatches = []patch.Patch{p}
+			pah := &patchAbortHandler{patch: &p, user: other}
+			_, err := pah.Execute(&sc)
+			So(err, ShouldNotBeNil)
+			So(len(sc.MockPatchConnector.CachedPatches), ShouldEqual, 1)
+		})
+		Convey("then its author and superusers should be able to change it", func() {
+			for _, u := range []*user.DBUser{{Id: "author"}, {Id: "superUser"}} {
+				pph := &patchPriorityHandler{patch: &p, user: u, priority: 10}
+				_, err := pph.Execute(&sc)
+				So(err, ShouldBeNil)
+				So(sc.MockPatchConnector.CachedPriorities[p.Id.Hex()], ShouldEqual, 10)
+			}
+			pah := &patchAbortHandler{patch: &p, user: &user.DBUser{Id: "author"}}
+			_, err := pah.Execute(&sc)
+			So(err, ShouldBeNil)
+		})
+	})
+}
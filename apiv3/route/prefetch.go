@@ -2,6 +2,7 @@ package route
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/apiv3"
 	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
@@ -16,6 +17,7 @@ type (
 	// custom types used to attach specific values to request contexts, to prevent collisions.
 	requestUserKey    int
 	requestContextKey int
+	requestTokenKey   int
 )
 
 const (
@@ -23,6 +25,7 @@ const (
 	// These are private custom types to avoid key collisions.
 	RequestUser    requestUserKey    = 0
 	RequestContext requestContextKey = 0
+	RequestToken   requestTokenKey   = 0
 )
 
 // PrefetchFunc is a function signature that defines types of functions which may
@@ -32,7 +35,13 @@ type PrefetchFunc func(*http.Request, servicecontext.ServiceContext) error
 
 // PrefetchUser gets the user information from a request, and uses it to
 // get the associated user from the database and attaches it to the request context.
+// Requests made with an access token, rather than an API key, have the token
+// attached to the request context as well.
 func PrefetchUser(r *http.Request, sc servicecontext.ServiceContext) error {
+	if len(r.Header["Api-Token"]) > 0 {
+		return prefetchTokenUser(r, sc, r.Header["Api-Token"][0])
+	}
+
 	// Grab API auth details from header
 	var authDataAPIKey, authDataName string
 
@@ -61,6 +70,41 @@ func PrefetchUser(r *http.Request, sc servicecontext.ServiceContext) error {
 	return nil
 }
 
+// prefetchTokenUser attaches the access token that the request was made with,
+// and the token's user, to the request context, provided the token exists
+// and hasn't expired.
+func prefetchTokenUser(r *http.Request, sc servicecontext.ServiceContext, token string) error {
+	invalid := apiv3.APIError{
+		StatusCode: http.StatusUnauthorized,
+		Message:    "Invalid access token",
+	}
+
+	t, err := sc.FindAccessTokenByToken(token)
+	if err != nil {
+		if apiErr, ok := err.(*apiv3.APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+			return invalid
+		}
+		return err
+	}
+	if t.IsExpired(time.Now()) {
+		return apiv3.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "Access token has expired",
+		}
+	}
+
+	apiUser, err := sc.FindUserById(t.UserId)
+	if err != nil {
+		return err
+	}
+	if u, ok := apiUser.(*user.DBUser); !ok || u == nil {
+		return invalid
+	}
+	context.Set(r, RequestUser, apiUser)
+	context.Set(r, RequestToken, t)
+	return nil
+}
+
 // PrefetchProjectContext gets the information related to the project that the request contains
 // and fetches the associated project context and attaches that to the request context.
 func PrefetchProjectContext(r *http.Request, sc servicecontext.ServiceContext) error {
@@ -75,8 +119,9 @@ func PrefetchProjectContext(r *http.Request, sc servicecontext.ServiceContext) e
 		return err
 	}
 
-	if ctx.ProjectRef != nil && ctx.ProjectRef.Private && GetUser(r) == nil {
-		// Project is private and user is not authorized so return not found
+	if ctx.ProjectRef != nil &&
+		!hasProjectRole(sc, GetUser(r), ctx.ProjectRef, model.ProjectRoleViewer) {
+		// User can't view the project so return not found
 		return apiv3.APIError{
 			StatusCode: http.StatusNotFound,
 			Message:    "Project not found",
@@ -101,6 +146,15 @@ func GetUser(r *http.Request) *user.DBUser {
 	return nil
 }
 
+// GetAccessToken returns the access token that a given http request was made
+// with, or nil if it wasn't made with one.
+func GetAccessToken(r *http.Request) *user.AccessToken {
+	if rv := context.Get(r, RequestToken); rv != nil {
+		return rv.(*user.AccessToken)
+	}
+	return nil
+}
+
 // GetProjectContext returns the project context associated with a
 // given request.
 func GetProjectContext(r *http.Request) (*model.Context, error) {
@@ -134,6 +134,23 @@ func TestPrefetchProject(t *testing.T) {
 				}
 				So(err, ShouldResemble, errToResemble)
 			})
+			Convey("should error if project is private and the user has no role", func() {
+				ctx := model.Context{}
+				ctx.ProjectRef = &model.ProjectRef{
+					Private: true,
+					Roles:   []model.ProjectRole{{Role: model.ProjectRoleViewer, User: "other_user"}},
+				}
+				context.Set(req, RequestUser, &user.DBUser{Id: "test_user"})
+				serviceContext.MockContextConnector.CachedContext = ctx
+				err := PrefetchProjectContext(req, serviceContext)
+				So(context.Get(req, RequestContext), ShouldBeNil)
+
+				errToResemble := apiv3.APIError{
+					StatusCode: http.StatusNotFound,
+					Message:    "Project not found",
+				}
+				So(err, ShouldResemble, errToResemble)
+			})
 			Convey("should succeed if project ref exists and user is set", func() {
 				ctx := model.Context{}
 				ctx.ProjectRef = &model.ProjectRef{
@@ -0,0 +1,45 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/auth"
+)
+
+// priorityChange is the body of the requests that set the priority of the
+// tasks of a build, version or patch.
+type priorityChange struct {
+	Priority *int64 `json:"priority"`
+}
+
+// parsePriorityChange reads the priority to set from the body of the request.
+func parsePriorityChange(r *http.Request) (int64, error) {
+	change := priorityChange{}
+	if err := decodeRequestBody(r, &change); err != nil {
+		return 0, err
+	}
+	if change.Priority == nil {
+		return 0, apiv3.APIError{
+			Message:    "Must set 'priority'",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return *change.Priority, nil
+}
+
+// checkPriorityAllowed returns an error if the user may not set the priority,
+// since only superusers may set priorities above evergreen.MaxTaskPriority.
+func checkPriorityAllowed(sc servicecontext.ServiceContext, u auth.User, priority int64) error {
+	if priority > evergreen.MaxTaskPriority && !auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return apiv3.APIError{
+			Message: fmt.Sprintf("Insufficient privilege to set priority to %d, "+
+				"non-superusers can only set priority at or below %d", priority, evergreen.MaxTaskPriority),
+			StatusCode: http.StatusForbidden,
+		}
+	}
+	return nil
+}
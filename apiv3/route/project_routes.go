@@ -0,0 +1,387 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/auth"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+)
+
+func getProjectRouteManager(route string, version int) *RouteManager {
+	pgh := &projectGetHandler{}
+	projectGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectAdminAuthenticator{},
+		RequestHandler:    pgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	pph := &projectPutHandler{}
+	projectPut := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectAdminAuthenticator{},
+		RequestHandler:    pph.Handler(),
+		MethodType:        evergreen.MethodPut,
+	}
+
+	pdh := &projectDeleteHandler{}
+	projectDelete := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &SuperUserAuthenticator{},
+		RequestHandler:    pdh.Handler(),
+		MethodType:        evergreen.MethodDelete,
+	}
+
+	projectRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{projectGet, projectPut, projectDelete},
+		Version: version,
+	}
+	return &projectRoute
+}
+
+func getProjectVarsRouteManager(route string, version int) *RouteManager {
+	vgh := &projectVarsGetHandler{}
+	varsGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectAdminAuthenticator{},
+		RequestHandler:    vgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	vph := &projectVarsPutHandler{}
+	varsPut := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectAdminAuthenticator{},
+		RequestHandler:    vph.Handler(),
+		MethodType:        evergreen.MethodPut,
+	}
+
+	vdh := &projectVarsDeleteHandler{}
+	varsDelete := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectAdminAuthenticator{},
+		RequestHandler:    vdh.Handler(),
+		MethodType:        evergreen.MethodDelete,
+	}
+
+	varsRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{varsGet, varsPut, varsDelete},
+		Version: version,
+	}
+	return &varsRoute
+}
+
+// projectRefFromContext returns the project ref in the request's project
+// context, or a not found error if there is none.
+func projectRefFromContext(r *http.Request) (*serviceModel.ProjectRef, error) {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.ProjectRef == nil {
+		return nil, apiv3.APIError{
+			Message:    "Project not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return projCtx.ProjectRef, nil
+}
+
+// projectResponse fetches the project ref from the service layer and returns
+// it as the response of a request.
+func projectResponse(sc servicecontext.ServiceContext, projectId string) (ResponseData, error) {
+	p, err := sc.FindProjectRefById(projectId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	projectModel := &model.APIProjectRef{}
+	if err = projectModel.BuildFromService(p); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{projectModel},
+	}, nil
+}
+
+// projectVarsResponse fetches the project's variables from the service layer
+// and returns them as the response of a request.
+func projectVarsResponse(sc servicecontext.ServiceContext, projectId string) (ResponseData, error) {
+	vars, err := sc.FindProjectVarsById(projectId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	varsModel := &model.APIProjectVars{}
+	if err = varsModel.BuildFromService(vars); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{varsModel},
+	}, nil
+}
+
+// projectGetHandler implements the route GET /projects/{project_id}.
+type projectGetHandler struct {
+	projectId string
+}
+
+func (pgh *projectGetHandler) Handler() RequestHandler {
+	return &projectGetHandler{}
+}
+
+// ParseAndValidate fetches the project from the request context.
+func (pgh *projectGetHandler) ParseAndValidate(r *http.Request) error {
+	p, err := projectRefFromContext(r)
+	if err != nil {
+		return err
+	}
+	pgh.projectId = p.Identifier
+	return nil
+}
+
+// Execute returns the project ref.
+func (pgh *projectGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return projectResponse(sc, pgh.projectId)
+}
+
+// projectPutHandler implements the route PUT /projects/{project_id}. It
+// creates the project, or replaces its settings if it already exists.
+type projectPutHandler struct {
+	project  *serviceModel.ProjectRef
+	existing *serviceModel.ProjectRef
+	user     auth.User
+}
+
+func (pph *projectPutHandler) Handler() RequestHandler {
+	return &projectPutHandler{}
+}
+
+// ParseAndValidate fetches the project ref from the request body, with its
+// identifier taken from the request's route and its repo kind defaulting to
+// github, and the existing project and user from the request context.
+func (pph *projectPutHandler) ParseAndValidate(r *http.Request) error {
+	projectId := mux.Vars(r)["project_id"]
+
+	projectModel := &model.APIProjectRef{}
+	if err := decodeRequestBody(r, projectModel); err != nil {
+		return err
+	}
+	if projectModel.Identifier != "" && string(projectModel.Identifier) != projectId {
+		return apiv3.APIError{
+			Message: fmt.Sprintf("Project identifier '%s' does not match the project '%s' being changed",
+				projectModel.Identifier, projectId),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	projectModel.Identifier = model.APIString(projectId)
+	if projectModel.RepoKind == "" {
+		projectModel.RepoKind = model.APIString(serviceModel.GithubRepoType)
+	}
+	if !util.SliceContains(serviceModel.ValidRepoTypes, string(projectModel.RepoKind)) {
+		return apiv3.APIError{
+			Message: fmt.Sprintf("Repo kind '%s' must be one of %v",
+				projectModel.RepoKind, serviceModel.ValidRepoTypes),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	p, err := projectModel.ToService()
+	if err != nil {
+		return err
+	}
+	pph.project = p.(*serviceModel.ProjectRef)
+	if err = pph.project.ValidateRoles(); err != nil {
+		return apiv3.APIError{
+			Message:    fmt.Sprintf("Invalid roles: %v", err),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if err = pph.project.ValidateShareWeight(); err != nil {
+		return apiv3.APIError{
+			Message:    fmt.Sprintf("Invalid share weight: %v", err),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	pph.existing = MustHaveProjectContext(r).ProjectRef
+	pph.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute creates or replaces the project ref and returns it. Only superusers
+// may change the project's share weight, which is kept as it was if the
+// request leaves it unset.
+func (pph *projectPutHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	current := &serviceModel.ProjectRef{}
+	if pph.existing != nil {
+		current = pph.existing
+	}
+	if pph.project.ShareWeight != 0 && pph.project.GetShareWeight() != current.GetShareWeight() {
+		if !auth.IsSuperUser(sc.GetSuperUsers(), pph.user) {
+			return ResponseData{}, apiv3.APIError{
+				Message:    "Only superusers may change the share weight",
+				StatusCode: http.StatusForbidden,
+			}
+		}
+	} else {
+		pph.project.ShareWeight = current.ShareWeight
+	}
+
+	if pph.existing == nil {
+		pph.project.Tracked = true
+		if err := sc.CreateProjectRef(pph.project, pph.user.Username()); err != nil {
+			return ResponseData{}, serviceError(err, "Database error")
+		}
+	} else {
+		pph.project.Tracked = pph.existing.Tracked
+		pph.project.RepotrackerError = pph.existing.RepotrackerError
+		if err := sc.UpdateProjectRef(pph.project, pph.user.Username()); err != nil {
+			return ResponseData{}, serviceError(err, "Database error")
+		}
+	}
+	return projectResponse(sc, pph.project.Identifier)
+}
+
+// projectDeleteHandler implements the route DELETE /projects/{project_id}. It
+// removes the project ref and the project's variables.
+type projectDeleteHandler struct {
+	project  *serviceModel.ProjectRef
+	username string
+}
+
+func (pdh *projectDeleteHandler) Handler() RequestHandler {
+	return &projectDeleteHandler{}
+}
+
+// ParseAndValidate fetches the project and user from the request context.
+func (pdh *projectDeleteHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if pdh.project, err = projectRefFromContext(r); err != nil {
+		return err
+	}
+	pdh.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute removes the project and returns its project ref as it was.
+func (pdh *projectDeleteHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.DeleteProjectRef(pdh.project, pdh.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	projectModel := &model.APIProjectRef{}
+	if err := projectModel.BuildFromService(pdh.project); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{projectModel},
+	}, nil
+}
+
+// projectVarsGetHandler implements the route GET
+// /projects/{project_id}/vars. The values of private variables are redacted.
+type projectVarsGetHandler struct {
+	projectId string
+}
+
+func (vgh *projectVarsGetHandler) Handler() RequestHandler {
+	return &projectVarsGetHandler{}
+}
+
+// ParseAndValidate fetches the project from the request context.
+func (vgh *projectVarsGetHandler) ParseAndValidate(r *http.Request) error {
+	p, err := projectRefFromContext(r)
+	if err != nil {
+		return err
+	}
+	vgh.projectId = p.Identifier
+	return nil
+}
+
+// Execute returns the project's variables.
+func (vgh *projectVarsGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return projectVarsResponse(sc, vgh.projectId)
+}
+
+// projectVarsPutHandler implements the route PUT
+// /projects/{project_id}/vars. It replaces all of the project's variables.
+type projectVarsPutHandler struct {
+	vars     *serviceModel.ProjectVars
+	username string
+}
+
+func (vph *projectVarsPutHandler) Handler() RequestHandler {
+	return &projectVarsPutHandler{}
+}
+
+// ParseAndValidate fetches the project and user from the request context and
+// the variables from the request body.
+func (vph *projectVarsPutHandler) ParseAndValidate(r *http.Request) error {
+	varsModel := &model.APIProjectVars{}
+	if err := decodeRequestBody(r, varsModel); err != nil {
+		return err
+	}
+	p, err := projectRefFromContext(r)
+	if err != nil {
+		return err
+	}
+
+	vars, err := varsModel.ToService()
+	if err != nil {
+		return err
+	}
+	vph.vars = vars.(*serviceModel.ProjectVars)
+	vph.vars.Id = p.Identifier
+	vph.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute replaces the project's variables and returns them.
+func (vph *projectVarsPutHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.UpdateProjectVars(vph.vars, sc.GetSettings(), vph.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return projectVarsResponse(sc, vph.vars.Id)
+}
+
+// projectVarsDeleteHandler implements the route DELETE
+// /projects/{project_id}/vars. It removes all of the project's variables.
+type projectVarsDeleteHandler struct {
+	projectId string
+	username  string
+}
+
+func (vdh *projectVarsDeleteHandler) Handler() RequestHandler {
+	return &projectVarsDeleteHandler{}
+}
+
+// ParseAndValidate fetches the project and user from the request context.
+func (vdh *projectVarsDeleteHandler) ParseAndValidate(r *http.Request) error {
+	p, err := projectRefFromContext(r)
+	if err != nil {
+		return err
+	}
+	vdh.projectId = p.Identifier
+	vdh.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute removes the project's variables and returns the now empty set of
+// them.
+func (vdh *projectVarsDeleteHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	vars := &serviceModel.ProjectVars{
+		Id:          vdh.projectId,
+		Vars:        map[string]string{},
+		PrivateVars: map[string]bool{},
+	}
+	if err := sc.UpdateProjectVars(vars, sc.GetSettings(), vdh.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return projectVarsResponse(sc, vdh.projectId)
+}
@@ -0,0 +1,169 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProjectRoutes(t *testing.T) {
+	Convey("With project routes registered on a router", t, func() {
+		sc := &servicecontext.MockServiceContext{}
+		sc.SetPrefix("rest")
+		sc.SetSuperUsers([]string{"super_user"})
+		sc.MockUserConnector.CachedUsers = map[string]*user.DBUser{
+			"super_user": {Id: "super_user", APIKey: "super_key"},
+			"admin_user": {Id: "admin_user", APIKey: "admin_key"},
+			"other_user": {Id: "other_user", APIKey: "other_key"},
+		}
+		projectRef := serviceModel.ProjectRef{
+			Identifier:  "mci",
+			Owner:       "evergreen-ci",
+			Repo:        "evergreen",
+			RepoKind:    serviceModel.GithubRepoType,
+			Tracked:     true,
+			Admins:      []string{"admin_user"},
+			ShareWeight: 5,
+		}
+		sc.MockProjectConnector.CachedProjectRefs = []serviceModel.ProjectRef{projectRef}
+		sc.MockProjectConnector.CachedProjectVars = []serviceModel.ProjectVars{
+			{
+				Id:          "mci",
+				Vars:        map[string]string{"a": "1", "secret": "hunter2"},
+				PrivateVars: map[string]bool{"secret": true},
+			},
+		}
+		sc.MockContextConnector.CachedContext = serviceModel.Context{ProjectRef: &projectRef}
+		r := mux.NewRouter()
+		getProjectRouteManager("/projects/{project_id}", 2).Register(r, sc)
+		getProjectVarsRouteManager("/projects/{project_id}/vars", 2).Register(r, sc)
+
+		doRequest := func(method, path, userName, key string, body interface{}) *httptest.ResponseRecorder {
+			buf := &bytes.Buffer{}
+			if body != nil {
+				So(json.NewEncoder(buf).Encode(body), ShouldBeNil)
+			}
+			req, err := http.NewRequest(method, "/rest/v2"+path, buf)
+			So(err, ShouldBeNil)
+			req.Header.Add("Api-User", userName)
+			req.Header.Add("Api-Key", key)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			return rr
+		}
+
+		Convey("a project admin should be able to fetch the project", func() {
+			rr := doRequest(evergreen.MethodGet, "/projects/mci", "admin_user", "admin_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIProjectRef{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Identifier, ShouldEqual, model.APIString("mci"))
+			So(res.Admins, ShouldResemble, []string{"admin_user"})
+		})
+		Convey("other users should not be able to see the project", func() {
+			rr := doRequest(evergreen.MethodGet, "/projects/mci", "other_user", "other_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+		})
+		Convey("a project admin should be able to replace the project", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", "admin_user", "admin_key",
+				map[string]interface{}{"owner_name": "mongodb", "repo_name": "evergreen",
+					"admins": []string{"admin_user"}})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			p := sc.MockProjectConnector.CachedProjectRefs[0]
+			So(p.Owner, ShouldEqual, "mongodb")
+			So(p.RepoKind, ShouldEqual, serviceModel.GithubRepoType)
+			So(p.Tracked, ShouldBeTrue)
+		})
+		Convey("a project admin should keep the project's share weight", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", "admin_user", "admin_key",
+				map[string]interface{}{"owner_name": "mongodb", "share_weight": 5})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockProjectConnector.CachedProjectRefs[0].ShareWeight, ShouldEqual, 5)
+			rr = doRequest(evergreen.MethodPut, "/projects/mci", "admin_user", "admin_key",
+				map[string]interface{}{"owner_name": "mongodb"})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockProjectConnector.CachedProjectRefs[0].ShareWeight, ShouldEqual, 5)
+		})
+		Convey("only a super user should be able to change the project's share weight", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", "admin_user", "admin_key",
+				map[string]interface{}{"owner_name": "mongodb", "share_weight": 50})
+			So(rr.Code, ShouldEqual, http.StatusForbidden)
+			So(sc.MockProjectConnector.CachedProjectRefs[0].ShareWeight, ShouldEqual, 5)
+			rr = doRequest(evergreen.MethodPut, "/projects/mci", "super_user", "super_key",
+				map[string]interface{}{"owner_name": "mongodb", "share_weight": 50})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockProjectConnector.CachedProjectRefs[0].ShareWeight, ShouldEqual, 50)
+		})
+		Convey("a share weight out of range should not be saved", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", "super_user", "super_key",
+				map[string]interface{}{"share_weight": serviceModel.MaxShareWeight + 1})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			So(sc.MockProjectConnector.CachedProjectRefs[0].ShareWeight, ShouldEqual, 5)
+		})
+		Convey("a project with an unknown repo kind should not be saved", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", "admin_user", "admin_key",
+				map[string]interface{}{"repo_kind": "svn"})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("when the project doesn't exist", func() {
+			sc.MockContextConnector.CachedContext = serviceModel.Context{}
+
+			Convey("a super user should be able to create it", func() {
+				rr := doRequest(evergreen.MethodPut, "/projects/new", "super_user", "super_key",
+					map[string]interface{}{"owner_name": "evergreen-ci", "repo_name": "new"})
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(len(sc.MockProjectConnector.CachedProjectRefs), ShouldEqual, 2)
+				p := sc.MockProjectConnector.CachedProjectRefs[1]
+				So(p.Identifier, ShouldEqual, "new")
+				So(p.Tracked, ShouldBeTrue)
+			})
+			Convey("other users should not be able to create it", func() {
+				rr := doRequest(evergreen.MethodPut, "/projects/new", "admin_user", "admin_key",
+					map[string]interface{}{"owner_name": "evergreen-ci", "repo_name": "new"})
+				So(rr.Code, ShouldEqual, http.StatusNotFound)
+				So(len(sc.MockProjectConnector.CachedProjectRefs), ShouldEqual, 1)
+			})
+		})
+		Convey("only a super user should be able to delete the project", func() {
+			rr := doRequest(evergreen.MethodDelete, "/projects/mci", "admin_user", "admin_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+			rr = doRequest(evergreen.MethodDelete, "/projects/mci", "super_user", "super_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockProjectConnector.CachedProjectRefs, ShouldBeEmpty)
+			So(sc.MockProjectConnector.CachedProjectVars, ShouldBeEmpty)
+		})
+		Convey("fetching the project's variables should redact the private ones", func() {
+			rr := doRequest(evergreen.MethodGet, "/projects/mci/vars", "admin_user", "admin_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIProjectVars{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Vars, ShouldResemble, map[string]string{"a": "1", "secret": ""})
+			So(res.PrivateVars, ShouldResemble, map[string]bool{"secret": true})
+		})
+		Convey("putting the project's variables should replace them", func() {
+			rr := doRequest(evergreen.MethodPut, "/projects/mci/vars", "admin_user", "admin_key",
+				model.APIProjectVars{Vars: map[string]string{"b": "2"}})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIProjectVars{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Vars, ShouldResemble, map[string]string{"b": "2"})
+		})
+		Convey("deleting the project's variables should remove them", func() {
+			rr := doRequest(evergreen.MethodDelete, "/projects/mci/vars", "admin_user", "admin_key", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIProjectVars{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Vars, ShouldBeEmpty)
+		})
+	})
+}
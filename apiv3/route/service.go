@@ -12,12 +12,13 @@ import (
 // AttachHandler attaches the api's request handlers to the given mux router.
 // It builds a ServiceContext then attaches each of the main functions for
 // the api to the router.
-func AttachHandler(root *mux.Router, superUsers []string, URL, prefix string) http.Handler {
+func AttachHandler(root *mux.Router, settings *evergreen.Settings, URL, prefix string) http.Handler {
 	sc := &servicecontext.DBServiceContext{}
 
 	sc.SetURL(URL)
 	sc.SetPrefix(prefix)
-	sc.SetSuperUsers(superUsers)
+	sc.SetSuperUsers(settings.SuperUsers)
+	sc.SetSettings(settings)
 	return getHandler(root, sc)
 }
 
@@ -43,6 +44,26 @@ func getHandler(r *mux.Router, sc servicecontext.ServiceContext) http.Handler {
 	getHostRouteManager("/hosts", 2).Register(r, sc)
 	getTaskPatchRouteManager("/tasks/{task_id}", 2).Register(r, sc)
 	getTaskRestartRouteManager("/tasks/{task_id}/restart", 2).Register(r, sc)
+	getTestsRouteManager("/tasks/{task_id}/tests", 2).Register(r, sc)
+	getTaskLogsRouteManager("/tasks/{task_id}/logs", 2).Register(r, sc)
+	getBuildRouteManager("/builds/{build_id}", 2).Register(r, sc)
+	getBuildAbortRouteManager("/builds/{build_id}/abort", 2).Register(r, sc)
+	getBuildRestartRouteManager("/builds/{build_id}/restart", 2).Register(r, sc)
+	getBuildsByProjectRouteManager("/projects/{project_id}/builds", 2).Register(r, sc)
+	getVersionRouteManager("/versions/{version_id}", 2).Register(r, sc)
+	getVersionAbortRouteManager("/versions/{version_id}/abort", 2).Register(r, sc)
+	getVersionRestartRouteManager("/versions/{version_id}/restart", 2).Register(r, sc)
+	getVersionsByProjectRouteManager("/projects/{project_id}/versions", 2).Register(r, sc)
+	getPatchRouteManager("/patches/{patch_id}", 2).Register(r, sc)
+	getPatchAbortRouteManager("/patches/{patch_id}/abort", 2).Register(r, sc)
+	getPatchRestartRouteManager("/patches/{patch_id}/restart", 2).Register(r, sc)
+	getPatchesByProjectRouteManager("/projects/{project_id}/patches", 2).Register(r, sc)
+	getDistrosRouteManager("/distros", 2).Register(r, sc)
+	getDistroRouteManager("/distros/{distro_id}", 2).Register(r, sc)
+	getProjectRouteManager("/projects/{project_id}", 2).Register(r, sc)
+	getProjectVarsRouteManager("/projects/{project_id}/vars", 2).Register(r, sc)
+	getTokensRouteManager("/tokens", 2).Register(r, sc)
+	getTokenRouteManager("/tokens/{token_name}", 2).Register(r, sc)
 	placeHolderRoute.Register(r, sc)
 	return r
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apiv3"
@@ -21,7 +22,7 @@ func getTaskPatchRouteManager(route string, version int) *RouteManager {
 	tep := &TaskExecutionPatchHandler{}
 	TaskExecutionPatch := MethodHandler{
 		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
-		Authenticator:     &RequireUserAuthenticator{},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
 		RequestHandler:    tep.Handler(),
 		MethodType:        evergreen.MethodPatch,
 	}
@@ -34,6 +35,39 @@ func getTaskPatchRouteManager(route string, version int) *RouteManager {
 	return &taskRoute
 }
 
+// taskExecutionFromRequest returns the task in the request context and the
+// execution of it named by the request's 'execution' query param, which
+// defaults to the task's latest execution.
+func taskExecutionFromRequest(r *http.Request) (*task.Task, int, error) {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.Task == nil {
+		return nil, 0, apiv3.APIError{
+			Message:    "Task not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	t := projCtx.Task
+
+	val := r.URL.Query().Get("execution")
+	if val == "" {
+		return t, t.Execution, nil
+	}
+	execution, err := strconv.Atoi(val)
+	if err != nil {
+		return nil, 0, apiv3.APIError{
+			Message:    fmt.Sprintf("Value '%s' provided for 'execution' must be integer", val),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if execution < 0 || execution > t.Execution {
+		return nil, 0, apiv3.APIError{
+			Message:    fmt.Sprintf("Execution %d of task '%s' not found", execution, t.Id),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return t, execution, nil
+}
+
 // TaskRestartHandler implements the route POST /task/{task_id}/restart. It
 // fetches the needed task and project and calls the service function to
 // set the proper fields when reseting the task.
@@ -98,7 +132,7 @@ func getTaskRestartRouteManager(route string, version int) *RouteManager {
 	trh := &TaskRestartHandler{}
 	taskRestart := MethodHandler{
 		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
-		Authenticator:     &RequireUserAuthenticator{},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
 		RequestHandler:    trh.Handler(),
 		MethodType:        evergreen.MethodPost,
 	}
@@ -181,7 +215,7 @@ func (tep *TaskExecutionPatchHandler) Execute(sc servicecontext.ServiceContext)
 				StatusCode: http.StatusForbidden,
 			}
 		}
-		if err := sc.SetTaskPriority(tep.task, priority); err != nil {
+		if err := sc.SetTaskPriority(tep.task, tep.user.Username(), priority); err != nil {
 			return ResponseData{}, errors.Wrap(err, "Database error")
 		}
 	}
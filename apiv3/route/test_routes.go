@@ -0,0 +1,104 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+)
+
+func getTestsRouteManager(route string, version int) *RouteManager {
+	tgh := &testsGetHandler{}
+	testsGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    tgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	testsRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{testsGet},
+		Version: version,
+	}
+	return &testsRoute
+}
+
+// testsGetHandler implements the route GET /tasks/{task_id}/tests. It pages
+// through the results of the tests run in an execution of the task, sorted by
+// test file, optionally filtered by test file and status. Since test files
+// aren't unique, the page keys are a test file and the index of the result
+// among those with that test file.
+type testsGetHandler struct {
+	*PaginationExecutor
+}
+
+func (tgh *testsGetHandler) Handler() RequestHandler {
+	return &testsGetHandler{&PaginationExecutor{
+		KeyQueryParam:   "start_at",
+		LimitQueryParam: "limit",
+	}}
+}
+
+// ParseAndValidate fetches the task and its execution from the request
+// context and query params, the 'test_name' and 'status' filters, and the
+// key and limit from the request.
+func (tgh *testsGetHandler) ParseAndValidate(r *http.Request) error {
+	t, execution, err := taskExecutionFromRequest(r)
+	if err != nil {
+		return err
+	}
+	vals := r.URL.Query()
+	tgh.Paginator = testsPaginator(t.Id, execution, vals.Get("test_name"), vals.Get("status"))
+	return tgh.PaginationExecutor.ParseAndValidate(r)
+}
+
+// testsPaginator returns a PaginatorFunc that pages through the results of
+// the tests run in the task's execution that match the filters.
+func testsPaginator(taskId string, execution int, testName, status string) PaginatorFunc {
+	return func(key string, limit int, sc servicecontext.ServiceContext) ([]model.Model, *PageResult, error) {
+		// Fetch this page of tests, plus the first test of the next one
+		tests, err := sc.FindTestsByTaskId(taskId, execution, key, testName, status, limit+1, 1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+		prevTests, err := sc.FindTestsByTaskId(taskId, execution, key, testName, status, limit, -1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+
+		pages := &PageResult{}
+		if len(tests) > limit {
+			pages.Next = &Page{
+				Relation: "next",
+				Key:      tests[limit].Key,
+				Limit:    limit,
+			}
+			tests = tests[:limit]
+		}
+		if len(prevTests) > 0 {
+			pages.Prev = &Page{
+				Relation: "prev",
+				Key:      prevTests[len(prevTests)-1].Key,
+				Limit:    len(prevTests),
+			}
+		}
+
+		models := make([]model.Model, len(tests))
+		for ix := range tests {
+			testModel := &model.APITest{
+				TaskId:    model.APIString(taskId),
+				Execution: execution,
+			}
+			if err = testModel.BuildFromService(&tests[ix].TestResult); err != nil {
+				return []model.Model{}, nil, err
+			}
+			if err = testModel.BuildFromService(sc.GetURL()); err != nil {
+				return []model.Model{}, nil, err
+			}
+			models[ix] = testModel
+		}
+		return models, pages, nil
+	}
+}
@@ -0,0 +1,153 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTestsRoute(t *testing.T) {
+	Convey("With the tests route registered on a router", t, func() {
+		sc := &servicecontext.MockServiceContext{}
+		sc.SetPrefix("rest")
+		sc.SetURL("evergreen.example.com")
+		sc.MockTestConnector.CachedTests = []task.TestResult{
+			{TestFile: "test_d", Status: evergreen.TestSucceededStatus},
+			{TestFile: "test_a", Status: evergreen.TestFailedStatus, LogId: "log_a"},
+			{TestFile: "test_c", Status: evergreen.TestFailedStatus},
+			{TestFile: "test_b", Status: evergreen.TestSucceededStatus, URL: "http://logs/test_b"},
+			{TestFile: "test_e", Status: evergreen.TestSkippedStatus},
+		}
+		sc.MockContextConnector.CachedContext = serviceModel.Context{
+			Task: &task.Task{Id: "task1", Execution: 2},
+		}
+		r := mux.NewRouter()
+		getTestsRouteManager("/tasks/{task_id}/tests", 2).Register(r, sc)
+
+		doRequest := func(query string) (*httptest.ResponseRecorder, []model.APITest) {
+			req, err := http.NewRequest(evergreen.MethodGet, "/rest/v2/tasks/task1/tests"+query, nil)
+			So(err, ShouldBeNil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			res := []model.APITest{}
+			if rr.Code == http.StatusOK {
+				So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			}
+			return rr, res
+		}
+		testFiles := func(tests []model.APITest) []string {
+			files := []string{}
+			for _, t := range tests {
+				files = append(files, string(t.TestFile))
+			}
+			return files
+		}
+
+		Convey("all of the tests of the latest execution should be returned in order", func() {
+			rr, res := doRequest("")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(testFiles(res), ShouldResemble, []string{"test_a", "test_b", "test_c", "test_d", "test_e"})
+			So(res[0].TaskId, ShouldEqual, model.APIString("task1"))
+			So(res[0].Execution, ShouldEqual, 2)
+			So(res[0].Logs.URL, ShouldEqual, model.APIString("evergreen.example.com/test_log/log_a"))
+			So(res[1].Logs.URL, ShouldEqual, model.APIString("http://logs/test_b"))
+			So(rr.Header().Get("Link"), ShouldEqual, "")
+		})
+		Convey("tests should be filterable by status", func() {
+			rr, res := doRequest("?status=fail")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(testFiles(res), ShouldResemble, []string{"test_a", "test_c"})
+		})
+		Convey("tests should be filterable by test name", func() {
+			rr, res := doRequest("?test_name=test_d")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(testFiles(res), ShouldResemble, []string{"test_d"})
+		})
+		Convey("paging through tests should keep the filters in the links", func() {
+			rr, res := doRequest("?status=pass&limit=1&start_at=test_d")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(testFiles(res), ShouldResemble, []string{"test_d"})
+			pm, err := ParsePaginationHeader(rr.Header().Get("Link"), "start_at", "limit")
+			So(err, ShouldBeNil)
+			So(pm.Pages.Next, ShouldBeNil)
+			So(pm.Pages.Prev, ShouldResemble, &Page{Relation: "prev", Key: "test_b:0", Limit: 1})
+			So(rr.Header().Get("Link"), ShouldContainSubstring, "status=pass")
+		})
+		Convey("paging through tests that share a name should return each of them once", func() {
+			sc.MockTestConnector.CachedTests = []task.TestResult{
+				{TestFile: "test_b", Status: evergreen.TestSucceededStatus, LogId: "b0"},
+				{TestFile: "test_a", Status: evergreen.TestFailedStatus, LogId: "a0"},
+				{TestFile: "test_a", Status: evergreen.TestSucceededStatus, LogId: "a1"},
+				{TestFile: "test_a", Status: evergreen.TestFailedStatus, LogId: "a2"},
+				{TestFile: "test_b", Status: evergreen.TestFailedStatus, LogId: "b1"},
+			}
+			logIds := func(tests []model.APITest) []string {
+				ids := []string{}
+				for _, t := range tests {
+					ids = append(ids, string(t.Logs.URL))
+				}
+				return ids
+			}
+
+			seen := []string{}
+			query := "?limit=2"
+			for pages := 0; pages < 5; pages++ {
+				rr, res := doRequest(query)
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				seen = append(seen, logIds(res)...)
+				pm, err := ParsePaginationHeader(rr.Header().Get("Link"), "start_at", "limit")
+				So(err, ShouldBeNil)
+				if pm.Pages.Next == nil {
+					break
+				}
+				query = "?limit=2&start_at=" + url.QueryEscape(pm.Pages.Next.Key)
+			}
+			So(seen, ShouldResemble, []string{
+				"evergreen.example.com/test_log/a0",
+				"evergreen.example.com/test_log/a1",
+				"evergreen.example.com/test_log/a2",
+				"evergreen.example.com/test_log/b0",
+				"evergreen.example.com/test_log/b1",
+			})
+
+			Convey("and page back to the tests before the key", func() {
+				rr, res := doRequest("?limit=2&start_at=" + url.QueryEscape("test_a:2"))
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(logIds(res), ShouldResemble, []string{
+					"evergreen.example.com/test_log/a2",
+					"evergreen.example.com/test_log/b0",
+				})
+				pm, err := ParsePaginationHeader(rr.Header().Get("Link"), "start_at", "limit")
+				So(err, ShouldBeNil)
+				So(pm.Pages.Prev, ShouldResemble, &Page{Relation: "prev", Key: "test_a:0", Limit: 2})
+				So(pm.Pages.Next, ShouldResemble, &Page{Relation: "next", Key: "test_b:1", Limit: 2})
+			})
+		})
+		Convey("an earlier execution should be returned", func() {
+			rr, res := doRequest("?execution=0")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(res[0].Execution, ShouldEqual, 0)
+		})
+		Convey("an execution that hasn't happened should 404", func() {
+			rr, _ := doRequest("?execution=3")
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+			rr, _ = doRequest("?execution=latest")
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("a task that doesn't exist should 404", func() {
+			sc.MockContextConnector.CachedContext = serviceModel.Context{}
+			rr, _ := doRequest("")
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/mux"
+)
+
+func getTokensRouteManager(route string, version int) *RouteManager {
+	tgh := &tokensGetHandler{}
+	tokensGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &APIKeyUserAuthenticator{},
+		RequestHandler:    tgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	tph := &tokenPostHandler{}
+	tokenPost := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &APIKeyUserAuthenticator{},
+		RequestHandler:    tph.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	tokensRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{tokensGet, tokenPost},
+		Version: version,
+	}
+	return &tokensRoute
+}
+
+func getTokenRouteManager(route string, version int) *RouteManager {
+	tdh := &tokenDeleteHandler{}
+	tokenDelete := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+		Authenticator:     &APIKeyUserAuthenticator{},
+		RequestHandler:    tdh.Handler(),
+		MethodType:        evergreen.MethodDelete,
+	}
+
+	tokenRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{tokenDelete},
+		Version: version,
+	}
+	return &tokenRoute
+}
+
+// tokensGetHandler implements the route GET /tokens. It returns all of the
+// user's access tokens, sorted by name.
+type tokensGetHandler struct {
+	userId string
+}
+
+func (tgh *tokensGetHandler) Handler() RequestHandler {
+	return &tokensGetHandler{}
+}
+
+// ParseAndValidate fetches the user from the request context.
+func (tgh *tokensGetHandler) ParseAndValidate(r *http.Request) error {
+	tgh.userId = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute returns the user's access tokens.
+func (tgh *tokensGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	tokens, err := sc.FindAccessTokensByUser(tgh.userId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	models := make([]model.Model, len(tokens))
+	for ix := range tokens {
+		tokenModel := &model.APIAccessToken{}
+		if err = tokenModel.BuildFromService(&tokens[ix]); err != nil {
+			return ResponseData{}, err
+		}
+		models[ix] = tokenModel
+	}
+	return ResponseData{
+		Result:   models,
+		Metadata: &ListMetadata{},
+	}, nil
+}
+
+// tokenPostHandler implements the route POST /tokens. It creates an access
+// token for the user, with a read only scope unless another is given.
+type tokenPostHandler struct {
+	name      string
+	scope     string
+	projects  []string
+	expiresAt time.Time
+	userId    string
+}
+
+func (tph *tokenPostHandler) Handler() RequestHandler {
+	return &tokenPostHandler{}
+}
+
+// ParseAndValidate fetches the access token's name, scope, projects and
+// expiry from the request body, and the user from the request context.
+func (tph *tokenPostHandler) ParseAndValidate(r *http.Request) error {
+	tokenModel := &model.APIAccessToken{}
+	if err := decodeRequestBody(r, tokenModel); err != nil {
+		return err
+	}
+	tph.name = string(tokenModel.Name)
+	tph.scope = string(tokenModel.Scope)
+	if tph.scope == "" {
+		tph.scope = user.TokenScopeRead
+	}
+	tph.projects = tokenModel.Projects
+	tph.expiresAt = time.Time(tokenModel.ExpiresAt)
+	if !tph.expiresAt.IsZero() && !tph.expiresAt.After(time.Now()) {
+		return apiv3.APIError{
+			Message:    "Access token must expire in the future",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	tph.userId = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute creates the access token and returns it along with the token that
+// clients authenticate with it, which is not returned again.
+func (tph *tokenPostHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	t, token := user.NewAccessToken(tph.userId, tph.name, tph.scope, tph.projects, tph.expiresAt)
+	if err := sc.CreateAccessToken(t); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	tokenModel := &model.APIAccessToken{}
+	if err := tokenModel.BuildFromService(t); err != nil {
+		return ResponseData{}, err
+	}
+	if err := tokenModel.BuildFromService(token); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{tokenModel},
+	}, nil
+}
+
+// tokenDeleteHandler implements the route DELETE /tokens/{token_name}. It
+// revokes the user's access token.
+type tokenDeleteHandler struct {
+	name   string
+	userId string
+}
+
+func (tdh *tokenDeleteHandler) Handler() RequestHandler {
+	return &tokenDeleteHandler{}
+}
+
+// ParseAndValidate fetches the access token's name from the request's route
+// and the user from the request context.
+func (tdh *tokenDeleteHandler) ParseAndValidate(r *http.Request) error {
+	tdh.name = mux.Vars(r)["token_name"]
+	tdh.userId = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute revokes the access token and returns it as it was.
+func (tdh *tokenDeleteHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	t, err := sc.FindAccessTokenByName(tdh.userId, tdh.name)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	if err = sc.RevokeAccessToken(tdh.userId, tdh.name); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	tokenModel := &model.APIAccessToken{}
+	if err = tokenModel.BuildFromService(t); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{tokenModel},
+	}, nil
+}
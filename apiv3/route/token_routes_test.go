@@ -0,0 +1,153 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTokenRoutes(t *testing.T) {
+	Convey("With token, project and distro routes registered on a router", t, func() {
+		sc := &servicecontext.MockServiceContext{}
+		sc.SetPrefix("rest")
+		sc.SetSuperUsers([]string{"super_user"})
+		sc.MockUserConnector.CachedUsers = map[string]*user.DBUser{
+			"super_user": {Id: "super_user", APIKey: "super_key"},
+		}
+		projectRef := serviceModel.ProjectRef{
+			Identifier: "mci",
+			RepoKind:   serviceModel.GithubRepoType,
+			Admins:     []string{"super_user"},
+		}
+		sc.MockProjectConnector.CachedProjectRefs = []serviceModel.ProjectRef{projectRef}
+		sc.MockContextConnector.CachedContext = serviceModel.Context{ProjectRef: &projectRef}
+		sc.MockDistroConnector.CachedDistros = []distro.Distro{{Id: "archlinux"}}
+		r := mux.NewRouter()
+		getTokensRouteManager("/tokens", 2).Register(r, sc)
+		getTokenRouteManager("/tokens/{token_name}", 2).Register(r, sc)
+		getProjectRouteManager("/projects/{project_id}", 2).Register(r, sc)
+		getDistroRouteManager("/distros/{distro_id}", 2).Register(r, sc)
+
+		// doRequest makes a request with the given access token, or with the
+		// super user's API key if there is none.
+		doRequest := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+			buf := &bytes.Buffer{}
+			if body != nil {
+				So(json.NewEncoder(buf).Encode(body), ShouldBeNil)
+			}
+			req, err := http.NewRequest(method, "/rest/v2"+path, buf)
+			So(err, ShouldBeNil)
+			if token == "" {
+				req.Header.Add("Api-User", "super_user")
+				req.Header.Add("Api-Key", "super_key")
+			} else {
+				req.Header.Add("Api-Token", token)
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			return rr
+		}
+		createToken := func(body map[string]interface{}) string {
+			rr := doRequest(evergreen.MethodPost, "/tokens", "", body)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := model.APIAccessToken{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(res.Token, ShouldNotEqual, model.APIString(""))
+			return string(res.Token)
+		}
+
+		Convey("creating a token should default to a read only scope", func() {
+			createToken(map[string]interface{}{"name": "reader"})
+			So(len(sc.MockTokenConnector.CachedTokens), ShouldEqual, 1)
+			So(sc.MockTokenConnector.CachedTokens[0].UserId, ShouldEqual, "super_user")
+			So(sc.MockTokenConnector.CachedTokens[0].Scope, ShouldEqual, user.TokenScopeRead)
+		})
+		Convey("invalid tokens should not be created", func() {
+			createToken(map[string]interface{}{"name": "reader"})
+			rr := doRequest(evergreen.MethodPost, "/tokens", "", map[string]interface{}{"name": "reader"})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			rr = doRequest(evergreen.MethodPost, "/tokens", "",
+				map[string]interface{}{"name": "bot", "scope": user.TokenScopeProject})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			rr = doRequest(evergreen.MethodPost, "/tokens", "",
+				map[string]interface{}{"name": "old", "expires_at": "2001-01-01T00:00:00.000Z"})
+			So(rr.Code, ShouldEqual, http.StatusBadRequest)
+			So(len(sc.MockTokenConnector.CachedTokens), ShouldEqual, 1)
+		})
+		Convey("listing tokens should not return the tokens themselves", func() {
+			createToken(map[string]interface{}{"name": "reader"})
+			rr := doRequest(evergreen.MethodGet, "/tokens", "", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			res := []model.APIAccessToken{}
+			So(json.Unmarshal(rr.Body.Bytes(), &res), ShouldBeNil)
+			So(len(res), ShouldEqual, 1)
+			So(res[0].Name, ShouldEqual, model.APIString("reader"))
+			So(res[0].Token, ShouldEqual, model.APIString(""))
+		})
+		Convey("a revoked token should no longer work", func() {
+			token := createToken(map[string]interface{}{"name": "reader"})
+			rr := doRequest(evergreen.MethodDelete, "/tokens/reader", "", nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(sc.MockTokenConnector.CachedTokens, ShouldBeEmpty)
+			rr = doRequest(evergreen.MethodGet, "/projects/mci", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusUnauthorized)
+		})
+		Convey("tokens should not be able to manage tokens", func() {
+			token := createToken(map[string]interface{}{"name": "root", "scope": user.TokenScopeAdmin})
+			rr := doRequest(evergreen.MethodGet, "/tokens", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+			rr = doRequest(evergreen.MethodDelete, "/tokens/root", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusNotFound)
+		})
+		Convey("a read only token should only be able to read", func() {
+			token := createToken(map[string]interface{}{"name": "reader"})
+			rr := doRequest(evergreen.MethodGet, "/projects/mci", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			rr = doRequest(evergreen.MethodPut, "/projects/mci", token, map[string]interface{}{})
+			So(rr.Code, ShouldEqual, http.StatusForbidden)
+		})
+		Convey("a project scoped token should only be able to change its projects", func() {
+			token := createToken(map[string]interface{}{
+				"name":     "bot",
+				"scope":    user.TokenScopeProject,
+				"projects": []string{"mci"},
+			})
+			rr := doRequest(evergreen.MethodPut, "/projects/mci", token,
+				map[string]interface{}{"display_name": "Evergreen"})
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			rr = doRequest(evergreen.MethodGet, "/distros/archlinux", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			rr = doRequest(evergreen.MethodDelete, "/distros/archlinux", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusForbidden)
+		})
+		Convey("an admin token should be able to do what its user can", func() {
+			token := createToken(map[string]interface{}{"name": "root", "scope": user.TokenScopeAdmin})
+			rr := doRequest(evergreen.MethodDelete, "/distros/archlinux", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusOK)
+		})
+		Convey("an expired or unknown token should not work", func() {
+			token := createToken(map[string]interface{}{
+				"name":       "root",
+				"scope":      user.TokenScopeAdmin,
+				"expires_at": time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05.000Z"),
+			})
+			sc.MockTokenConnector.CachedTokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+			rr := doRequest(evergreen.MethodGet, "/distros/archlinux", token, nil)
+			So(rr.Code, ShouldEqual, http.StatusUnauthorized)
+			rr = doRequest(evergreen.MethodGet, "/distros/archlinux", "not_a_token", nil)
+			So(rr.Code, ShouldEqual, http.StatusUnauthorized)
+		})
+	})
+}
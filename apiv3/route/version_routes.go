@@ -0,0 +1,310 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	"github.com/evergreen-ci/evergreen/auth"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+)
+
+func getVersionRouteManager(route string, version int) *RouteManager {
+	vgh := &versionGetHandler{}
+	versionGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    vgh.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	vph := &versionPriorityHandler{}
+	versionPatch := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    vph.Handler(),
+		MethodType:        evergreen.MethodPatch,
+	}
+
+	versionRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{versionGet, versionPatch},
+		Version: version,
+	}
+	return &versionRoute
+}
+
+func getVersionAbortRouteManager(route string, version int) *RouteManager {
+	vah := &versionAbortHandler{}
+	versionAbort := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    vah.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	versionRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{versionAbort},
+		Version: version,
+	}
+	return &versionRoute
+}
+
+func getVersionRestartRouteManager(route string, version int) *RouteManager {
+	vrh := &versionRestartHandler{}
+	versionRestart := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &ProjectRoleAuthenticator{Role: serviceModel.ProjectRoleTaskController},
+		RequestHandler:    vrh.Handler(),
+		MethodType:        evergreen.MethodPost,
+	}
+
+	versionRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{versionRestart},
+		Version: version,
+	}
+	return &versionRoute
+}
+
+func getVersionsByProjectRouteManager(route string, version int) *RouteManager {
+	vph := &versionsByProjectHandler{}
+	versionsGet := MethodHandler{
+		PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+		Authenticator:     &NoAuthAuthenticator{},
+		RequestHandler:    vph.Handler(),
+		MethodType:        evergreen.MethodGet,
+	}
+
+	versionsRoute := RouteManager{
+		Route:   route,
+		Methods: []MethodHandler{versionsGet},
+		Version: version,
+	}
+	return &versionsRoute
+}
+
+// versionFromContext returns the version in the request's project context,
+// or a not found error if there is none.
+func versionFromContext(r *http.Request) (*version.Version, error) {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.Version == nil {
+		return nil, apiv3.APIError{
+			Message:    "Version not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return projCtx.Version, nil
+}
+
+// versionResponse fetches the version from the service layer and returns it
+// as the response of a request.
+func versionResponse(sc servicecontext.ServiceContext, versionId string) (ResponseData, error) {
+	v, err := sc.FindVersionById(versionId)
+	if err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+
+	versionModel := &model.APIVersion{}
+	if err = versionModel.BuildFromService(v); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{versionModel},
+	}, nil
+}
+
+// versionGetHandler implements the route GET /versions/{version_id}.
+type versionGetHandler struct {
+	versionId string
+}
+
+func (vgh *versionGetHandler) Handler() RequestHandler {
+	return &versionGetHandler{}
+}
+
+// ParseAndValidate fetches the version id from the request context.
+func (vgh *versionGetHandler) ParseAndValidate(r *http.Request) error {
+	v, err := versionFromContext(r)
+	if err != nil {
+		return err
+	}
+	vgh.versionId = v.Id
+	return nil
+}
+
+// Execute returns the version.
+func (vgh *versionGetHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	return versionResponse(sc, vgh.versionId)
+}
+
+// versionPriorityHandler implements the route PATCH /versions/{version_id}.
+// It sets the priority of all of the version's tasks.
+type versionPriorityHandler struct {
+	version  *version.Version
+	priority int64
+	user     auth.User
+}
+
+func (vph *versionPriorityHandler) Handler() RequestHandler {
+	return &versionPriorityHandler{}
+}
+
+// ParseAndValidate fetches the version and user from the request context
+// and the priority from the request body.
+func (vph *versionPriorityHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if vph.priority, err = parsePriorityChange(r); err != nil {
+		return err
+	}
+	if vph.version, err = versionFromContext(r); err != nil {
+		return err
+	}
+	vph.user = MustHaveUser(r)
+	return nil
+}
+
+// Execute sets the priority of the version's tasks and returns the version.
+func (vph *versionPriorityHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := checkPriorityAllowed(sc, vph.user, vph.priority); err != nil {
+		return ResponseData{}, err
+	}
+	if err := sc.SetVersionPriority(vph.version, vph.user.Username(), vph.priority); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return versionResponse(sc, vph.version.Id)
+}
+
+// versionAbortHandler implements the route POST
+// /versions/{version_id}/abort. It aborts the version's in progress tasks and
+// deactivates the version.
+type versionAbortHandler struct {
+	version  *version.Version
+	username string
+}
+
+func (vah *versionAbortHandler) Handler() RequestHandler {
+	return &versionAbortHandler{}
+}
+
+// ParseAndValidate fetches the version and user from the request context.
+func (vah *versionAbortHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if vah.version, err = versionFromContext(r); err != nil {
+		return err
+	}
+	vah.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute aborts the version and returns it.
+func (vah *versionAbortHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.AbortVersion(vah.version, vah.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return versionResponse(sc, vah.version.Id)
+}
+
+// versionRestartHandler implements the route POST
+// /versions/{version_id}/restart. It restarts the version's finished tasks.
+type versionRestartHandler struct {
+	version  *version.Version
+	username string
+}
+
+func (vrh *versionRestartHandler) Handler() RequestHandler {
+	return &versionRestartHandler{}
+}
+
+// ParseAndValidate fetches the version and user from the request context.
+func (vrh *versionRestartHandler) ParseAndValidate(r *http.Request) error {
+	var err error
+	if vrh.version, err = versionFromContext(r); err != nil {
+		return err
+	}
+	vrh.username = MustHaveUser(r).Username()
+	return nil
+}
+
+// Execute restarts the version and returns it.
+func (vrh *versionRestartHandler) Execute(sc servicecontext.ServiceContext) (ResponseData, error) {
+	if err := sc.RestartVersion(vrh.version, vrh.username); err != nil {
+		return ResponseData{}, serviceError(err, "Database error")
+	}
+	return versionResponse(sc, vrh.version.Id)
+}
+
+// versionsByProjectHandler implements the route GET
+// /projects/{project_id}/versions. It pages through the project's non-patch
+// versions, most recent first.
+type versionsByProjectHandler struct {
+	*PaginationExecutor
+}
+
+func (vph *versionsByProjectHandler) Handler() RequestHandler {
+	return &versionsByProjectHandler{&PaginationExecutor{
+		KeyQueryParam:   "version_id",
+		LimitQueryParam: "limit",
+	}}
+}
+
+// ParseAndValidate fetches the project from the request context, and the key
+// and limit from the request.
+func (vph *versionsByProjectHandler) ParseAndValidate(r *http.Request) error {
+	projCtx := MustHaveProjectContext(r)
+	if projCtx.ProjectRef == nil {
+		return apiv3.APIError{
+			Message:    "Project not found",
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	vph.Paginator = versionsByProjectPaginator(projCtx.ProjectRef.Identifier)
+	return vph.PaginationExecutor.ParseAndValidate(r)
+}
+
+// versionsByProjectPaginator returns a PaginatorFunc that pages through the
+// project's versions.
+func versionsByProjectPaginator(projectId string) PaginatorFunc {
+	return func(key string, limit int, sc servicecontext.ServiceContext) ([]model.Model, *PageResult, error) {
+		// Fetch this page of versions, plus the first version of the next one
+		versions, err := sc.FindVersionsByProject(projectId, key, limit+1, 1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+		prevVersions, err := sc.FindVersionsByProject(projectId, key, limit, -1)
+		if err != nil {
+			return []model.Model{}, nil, serviceError(err, "Database error")
+		}
+
+		pages := &PageResult{}
+		if len(versions) > limit {
+			pages.Next = &Page{
+				Relation: "next",
+				Key:      versions[limit].Id,
+				Limit:    limit,
+			}
+			versions = versions[:limit]
+		}
+		if len(prevVersions) > 0 {
+			pages.Prev = &Page{
+				Relation: "prev",
+				Key:      prevVersions[len(prevVersions)-1].Id,
+				Limit:    len(prevVersions),
+			}
+		}
+
+		models := make([]model.Model, len(versions))
+		for ix := range versions {
+			versionModel := &model.APIVersion{}
+			if err = versionModel.BuildFromService(&versions[ix]); err != nil {
+				return []model.Model{}, nil, err
+			}
+			models[ix] = versionModel
+		}
+		return models, pages, nil
+	}
+}
@@ -0,0 +1,260 @@
+package route
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/apiv3/model"
+	"github.com/evergreen-ci/evergreen/apiv3/servicecontext"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/gorilla/context"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVersionsByProjectPaginator(t *testing.T) {
+	numVersions := 10
+	Convey("When paginating with a ServiceContext", t, func() {
+		serviceContext := servicecontext.MockServiceContext{}
+		Convey("and there are versions from several projects", func() {
+			cachedVersions := []version.Version{}
+			for i := 0; i < numVersions; i++ {
+				cachedVersions = append(cachedVersions,
+					version.Version{Id: fmt.Sprintf("version%d", i), Identifier: "proj"},
+					version.Version{Id: fmt.Sprintf("other%d", i), Identifier: "other"})
+			}
+			serviceContext.MockVersionConnector.CachedVersions = cachedVersions
+			paginator := versionsByProjectPaginator("proj")
+
+			expectedVersions := func(start, end int) []model.Model {
+				models := []model.Model{}
+				for i := start; i < end; i++ {
+					models = append(models, &model.APIVersion{
+						Id:        model.APIString(fmt.Sprintf("version%d", i)),
+						ProjectId: model.APIString("proj"),
+					})
+				}
+				return models
+			}
+			Convey("then finding a key in the middle of the set should produce"+
+				" a next and previous page of the project's versions", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "version6",
+						Limit:    3,
+						Relation: "next",
+					},
+					Prev: &Page{
+						Key:      "version0",
+						Limit:    3,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "version3", 3,
+					&serviceContext, expectedPages, expectedVersions(3, 6), nil)
+			})
+			Convey("then finding a key near the beginning of the set should produce"+
+				" a limited previous page", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "version4",
+						Limit:    3,
+						Relation: "next",
+					},
+					Prev: &Page{
+						Key:      "version0",
+						Limit:    1,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "version1", 3,
+					&serviceContext, expectedPages, expectedVersions(1, 4), nil)
+			})
+			Convey("then starting without a key should produce only a next page", func() {
+				expectedPages := &PageResult{
+					Next: &Page{
+						Key:      "version3",
+						Limit:    3,
+						Relation: "next",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "", 3,
+					&serviceContext, expectedPages, expectedVersions(0, 3), nil)
+			})
+			Convey("then finding a key in the last page should produce only a"+
+				" previous page", func() {
+				expectedPages := &PageResult{
+					Prev: &Page{
+						Key:      "version5",
+						Limit:    3,
+						Relation: "prev",
+					},
+				}
+				checkPaginatorResultMatches(paginator, "version8", 3,
+					&serviceContext, expectedPages, expectedVersions(8, 10), nil)
+			})
+		})
+	})
+}
+
+func TestVersionGet(t *testing.T) {
+	Convey("With handler and a project context", t, func() {
+		vgh := &versionGetHandler{}
+		projCtx := serviceModel.Context{
+			Version: &version.Version{Id: "testVersionId"},
+		}
+
+		Convey("then should error on empty version", func() {
+			projCtx.Version = nil
+			req, err := http.NewRequest(evergreen.MethodGet, "versions/testVersionId", nil)
+			So(err, ShouldBeNil)
+			context.Set(req, RequestContext, &projCtx)
+			err = vgh.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Version not found",
+				StatusCode: http.StatusNotFound,
+			})
+		})
+		Convey("then should return the version", func() {
+			sc := servicecontext.MockServiceContext{}
+			sc.MockVersionConnector.CachedVersions = []version.Version{
+				{Id: "testVersionId", Identifier: "proj", Revision: "abcdef"},
+			}
+			req, err := http.NewRequest(evergreen.MethodGet, "versions/testVersionId", nil)
+			So(err, ShouldBeNil)
+			context.Set(req, RequestContext, &projCtx)
+			So(vgh.ParseAndValidate(req), ShouldBeNil)
+			res, err := vgh.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resVersion, ok := res.Result[0].(*model.APIVersion)
+			So(ok, ShouldBeTrue)
+			So(resVersion.Id, ShouldEqual, model.APIString("testVersionId"))
+			So(resVersion.Revision, ShouldEqual, model.APIString("abcdef"))
+		})
+	})
+}
+
+func TestVersionPriorityPrepare(t *testing.T) {
+	Convey("With handler and a project context and user", t, func() {
+		vph := &versionPriorityHandler{}
+		projCtx := serviceModel.Context{
+			Version: &version.Version{Id: "testVersionId"},
+		}
+		u := user.DBUser{Id: "testUser"}
+
+		Convey("then should error when priority isn't set", func() {
+			req, err := http.NewRequest(evergreen.MethodPatch, "versions/testVersionId",
+				bytes.NewBufferString(`{}`))
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			err = vph.ParseAndValidate(req)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "Must set 'priority'",
+				StatusCode: http.StatusBadRequest,
+			})
+		})
+		Convey("then should set the version, priority and user", func() {
+			req, err := http.NewRequest(evergreen.MethodPatch, "versions/testVersionId",
+				bytes.NewBufferString(`{"priority": 10}`))
+			So(err, ShouldBeNil)
+			context.Set(req, RequestUser, &u)
+			context.Set(req, RequestContext, &projCtx)
+			So(vph.ParseAndValidate(req), ShouldBeNil)
+			So(vph.version, ShouldEqual, projCtx.Version)
+			So(vph.priority, ShouldEqual, 10)
+			So(vph.user, ShouldResemble, &u)
+		})
+	})
+}
+
+func TestVersionPriorityExecute(t *testing.T) {
+	Convey("With a version returned by the ServiceContext", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		v := version.Version{Id: "testVersionId", Identifier: "proj"}
+		sc.MockVersionConnector.CachedVersions = []version.Version{v}
+		vph := &versionPriorityHandler{
+			version: &v,
+			user:    &user.DBUser{Id: "testUser"},
+		}
+		Convey("then a user should be able to set a normal priority", func() {
+			vph.priority = evergreen.MaxTaskPriority
+			res, err := vph.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resVersion, ok := res.Result[0].(*model.APIVersion)
+			So(ok, ShouldBeTrue)
+			So(resVersion.Id, ShouldEqual, model.APIString("testVersionId"))
+			So(sc.MockVersionConnector.CachedPriorities["testVersionId"], ShouldEqual, evergreen.MaxTaskPriority)
+		})
+		Convey("then only a superuser should be able to set a high priority", func() {
+			sc.SetSuperUsers([]string{"superUser"})
+			vph.priority = evergreen.MaxTaskPriority + 1
+			_, err := vph.Execute(&sc)
+			apiErr, ok := err.(apiv3.APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, http.StatusForbidden)
+			So(sc.MockVersionConnector.CachedPriorities, ShouldBeEmpty)
+
+			sc.SetSuperUsers([]string{"superUser", "testUser"})
+			_, err = vph.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(sc.MockVersionConnector.CachedPriorities["testVersionId"], ShouldEqual, evergreen.MaxTaskPriority+1)
+		})
+	})
+}
+
+func TestVersionAbortAndRestartExecute(t *testing.T) {
+	Convey("With a version returned by the ServiceContext", t, func() {
+		sc := servicecontext.MockServiceContext{}
+		v := version.Version{Id: "testVersionId", Identifier: "proj"}
+		sc.MockVersionConnector.CachedVersions = []version.Version{v}
+
+		Convey("then aborting it should return the version", func() {
+			vah := &versionAbortHandler{version: &v, username: "testUser"}
+			res, err := vah.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resVersion, ok := res.Result[0].(*model.APIVersion)
+			So(ok, ShouldBeTrue)
+			So(resVersion.Id, ShouldEqual, model.APIString("testVersionId"))
+			So(sc.MockVersionConnector.CachedAborted["testVersionId"], ShouldBeTrue)
+			So(sc.MockVersionConnector.CachedRestarted, ShouldBeEmpty)
+		})
+		Convey("then restarting it should return the version", func() {
+			vrh := &versionRestartHandler{version: &v, username: "testUser"}
+			res, err := vrh.Execute(&sc)
+			So(err, ShouldBeNil)
+			So(len(res.Result), ShouldEqual, 1)
+			resVersion, ok := res.Result[0].(*model.APIVersion)
+			So(ok, ShouldBeTrue)
+			So(resVersion.Id, ShouldEqual, model.APIString("testVersionId"))
+			So(sc.MockVersionConnector.CachedRestarted["testVersionId"], ShouldBeTrue)
+			So(sc.MockVersionConnector.CachedAborted, ShouldBeEmpty)
+		})
+		Convey("then an error from the service function should be returned", func() {
+			sc.MockVersionConnector.StoredError = &apiv3.APIError{
+				Message:    "version not found",
+				StatusCode: http.StatusNotFound,
+			}
+			vah := &versionAbortHandler{version: &v, username: "testUser"}
+			_, err := vah.Execute(&sc)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "version not found",
+				StatusCode: http.StatusNotFound,
+			})
+			vrh := &versionRestartHandler{version: &v, username: "testUser"}
+			_, err = vrh.Execute(&sc)
+			So(err, ShouldResemble, apiv3.APIError{
+				Message:    "version not found",
+				StatusCode: http.StatusNotFound,
+			})
+		})
+	})
+}
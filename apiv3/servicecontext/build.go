@@ -0,0 +1,192 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// DBBuildConnector is a struct that implements the Build related methods
+// from the ServiceContext through interactions with the backing database.
+type DBBuildConnector struct{}
+
+// FindBuildById uses the service layer's build type to query the backing
+// database for the build with the given buildId.
+func (bc *DBBuildConnector) FindBuildById(buildId string) (*build.Build, error) {
+	b, err := build.FindOne(build.ById(buildId))
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("build with id %s not found", buildId),
+		}
+	}
+	return b, nil
+}
+
+// FindBuildsByProject queries the backing database for up to limit of a
+// project's non-patch builds, most recent first, starting from the build with
+// the given id, or from the most recent build if it is empty. If sortDir is
+// negative, it instead returns the builds that come before the given one in
+// that order, nearest first.
+func (bc *DBBuildConnector) FindBuildsByProject(projectId, startBuildId string, limit, sortDir int) ([]build.Build, error) {
+	query := build.ByMostRecentForProject(projectId)
+	if startBuildId != "" {
+		start, err := build.FindOne(build.ById(startBuildId))
+		if err != nil {
+			return nil, err
+		}
+		if start == nil || start.Project != projectId ||
+			start.Requester != evergreen.RepotrackerVersionRequester {
+			return nil, &apiv3.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("build with id %s not found", startBuildId),
+			}
+		}
+		query = build.ByProjectFromBuild(start, sortDir)
+	} else if sortDir < 0 {
+		return []build.Build{}, nil
+	}
+	return build.Find(query.Limit(limit))
+}
+
+// AbortBuild aborts the build's in progress tasks and deactivates it using a
+// call to the service layer function.
+func (bc *DBBuildConnector) AbortBuild(buildId, user string) error {
+	if err := serviceModel.AbortBuild(buildId, user); err != nil {
+		return errors.Wrap(err, "Abort build error")
+	}
+	return errors.Wrap(serviceModel.RefreshTasksCache(buildId), "Error refreshing task cache")
+}
+
+// RestartBuild restarts the build's finished tasks using a call to the
+// service layer function.
+func (bc *DBBuildConnector) RestartBuild(buildId, user string) error {
+	tasks, err := task.Find(task.ByBuildId(buildId).WithFields(task.IdKey))
+	if err != nil {
+		return err
+	}
+	taskIds := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		taskIds = append(taskIds, t.Id)
+	}
+	return errors.Wrap(serviceModel.RestartBuild(buildId, taskIds, false, user),
+		"Restart build error")
+}
+
+// SetBuildPriority changes the priority value of the build's tasks using a
+// call to the service layer function, and records the change for each task.
+func (bc *DBBuildConnector) SetBuildPriority(buildId, user string, priority int64) error {
+	if err := serviceModel.SetBuildPriority(buildId, priority); err != nil {
+		return errors.Wrap(err, "Error setting build priority")
+	}
+	tasks, err := task.Find(task.ByBuildId(buildId).WithFields(task.IdKey, task.ProjectKey))
+	if err != nil {
+		return errors.Wrap(err, "Error finding build tasks")
+	}
+	for _, t := range tasks {
+		event.LogTaskPriorityChanged(t.Id, t.Project, user, priority)
+	}
+	return nil
+}
+
+// MockBuildConnector stores a cached set of builds that are queried against by
+// the implementations of the ServiceContext interface's Build related functions.
+type MockBuildConnector struct {
+	CachedBuilds []build.Build
+	// CachedPriorities holds the priority set on each build's tasks.
+	CachedPriorities map[string]int64
+	StoredError      error
+}
+
+// FindBuildById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached builds in the MockBuildConnector.
+func (bc *MockBuildConnector) FindBuildById(buildId string) (*build.Build, error) {
+	for _, b := range bc.CachedBuilds {
+		if b.Id == buildId {
+			return &b, bc.StoredError
+		}
+	}
+	return nil, bc.StoredError
+}
+
+// FindBuildsByProject provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. The cached
+// builds are expected to be sorted from most to least recent.
+func (bc *MockBuildConnector) FindBuildsByProject(projectId, startBuildId string, limit, sortDir int) ([]build.Build, error) {
+	builds := []build.Build{}
+	for _, b := range bc.CachedBuilds {
+		if b.Project == projectId {
+			builds = append(builds, b)
+		}
+	}
+	start := 0
+	if startBuildId != "" {
+		start = -1
+		for ix, b := range builds {
+			if b.Id == startBuildId {
+				start = ix
+			}
+		}
+		if start < 0 {
+			return nil, bc.StoredError
+		}
+	}
+
+	result := []build.Build{}
+	if sortDir < 0 {
+		for ix := start - 1; ix >= 0 && len(result) < limit; ix-- {
+			result = append(result, builds[ix])
+		}
+		return result, bc.StoredError
+	}
+	for ix := start; ix < len(builds) && len(result) < limit; ix++ {
+		result = append(result, builds[ix])
+	}
+	return result, bc.StoredError
+}
+
+// AbortBuild provides a mock implementation of the function for the
+// ServiceContext interface, deactivating the cached build.
+func (bc *MockBuildConnector) AbortBuild(buildId, user string) error {
+	for ix, b := range bc.CachedBuilds {
+		if b.Id == buildId {
+			bc.CachedBuilds[ix].Activated = false
+			bc.CachedBuilds[ix].ActivatedBy = user
+		}
+	}
+	return bc.StoredError
+}
+
+// RestartBuild provides a mock implementation of the function for the
+// ServiceContext interface, marking the cached build as started again.
+func (bc *MockBuildConnector) RestartBuild(buildId, user string) error {
+	for ix, b := range bc.CachedBuilds {
+		if b.Id == buildId {
+			bc.CachedBuilds[ix].Status = evergreen.BuildStarted
+			bc.CachedBuilds[ix].Activated = true
+			bc.CachedBuilds[ix].ActivatedBy = user
+		}
+	}
+	return bc.StoredError
+}
+
+// SetBuildPriority provides a mock implementation of the function for the
+// ServiceContext interface, recording the priority in CachedPriorities.
+func (bc *MockBuildConnector) SetBuildPriority(buildId, user string, priority int64) error {
+	if bc.CachedPriorities == nil {
+		bc.CachedPriorities = map[string]int64{}
+	}
+	bc.CachedPriorities[buildId] = priority
+	return bc.StoredError
+}
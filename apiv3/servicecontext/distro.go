@@ -0,0 +1,194 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/validator"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// DBDistroConnector is a struct that implements the Distro related methods
+// from the ServiceContext through interactions with the backing database.
+type DBDistroConnector struct{}
+
+// FindDistroById uses the service layer's distro type to query the backing
+// database for the distro with the given distroId.
+func (dc *DBDistroConnector) FindDistroById(distroId string) (*distro.Distro, error) {
+	d, err := distro.FindOne(distro.ById(distroId))
+	if err == mgo.ErrNotFound {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("distro with id %s not found", distroId),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// FindDistrosById queries the backing database for up to limit of the
+// distros, sorted by id, starting from the given id. If sortDir is negative,
+// it instead returns the distros that come before the given id, nearest
+// first.
+func (dc *DBDistroConnector) FindDistrosById(distroId string, limit, sortDir int) ([]distro.Distro, error) {
+	return distro.Find(distro.ByIdFrom(distroId, sortDir).Limit(limit))
+}
+
+// ValidateDistro checks the distro's configuration using the validator's
+// distro checks, and returns a bad request error listing any problems found.
+func (dc *DBDistroConnector) ValidateDistro(d *distro.Distro, settings *evergreen.Settings, newDistro bool) error {
+	vErrs, err := validator.CheckDistro(d, settings, newDistro)
+	if err != nil {
+		return errors.Wrap(err, "Error validating distro")
+	}
+	if len(vErrs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(vErrs))
+	for _, e := range vErrs {
+		messages = append(messages, e.Error())
+	}
+	return &apiv3.APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    fmt.Sprintf("invalid distro: %s", strings.Join(messages, "; ")),
+	}
+}
+
+// CreateDistro inserts the distro into the backing database and logs its
+// addition.
+func (dc *DBDistroConnector) CreateDistro(d *distro.Distro, user string) error {
+	if err := d.Insert(); err != nil {
+		return errors.Wrapf(err, "Error inserting distro '%s'", d.Id)
+	}
+	event.LogDistroAdded(d.Id, user, d)
+	return nil
+}
+
+// UpdateDistro replaces the distro in the backing database and logs its
+// modification.
+func (dc *DBDistroConnector) UpdateDistro(d *distro.Distro, user string) error {
+	if err := d.Update(); err != nil {
+		return errors.Wrapf(err, "Error updating distro '%s'", d.Id)
+	}
+	event.LogDistroModified(d.Id, user, d)
+	return nil
+}
+
+// DeleteDistro removes the distro from the backing database and logs its
+// removal.
+func (dc *DBDistroConnector) DeleteDistro(d *distro.Distro, user string) error {
+	if err := distro.Remove(d.Id); err != nil {
+		return errors.Wrapf(err, "Error removing distro '%s'", d.Id)
+	}
+	event.LogDistroRemoved(d.Id, user, d)
+	return nil
+}
+
+// MockDistroConnector stores a cached set of distros that are queried against
+// by the implementations of the ServiceContext interface's Distro related
+// functions.
+type MockDistroConnector struct {
+	// CachedDistros are expected to be sorted by id.
+	CachedDistros []distro.Distro
+	StoredError   error
+}
+
+// FindDistroById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached distros in the MockDistroConnector.
+func (dc *MockDistroConnector) FindDistroById(distroId string) (*distro.Distro, error) {
+	for _, d := range dc.CachedDistros {
+		if d.Id == distroId {
+			return &d, dc.StoredError
+		}
+	}
+	return nil, &apiv3.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("distro with id %s not found", distroId),
+	}
+}
+
+// FindDistrosById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database.
+func (dc *MockDistroConnector) FindDistrosById(distroId string, limit, sortDir int) ([]distro.Distro, error) {
+	result := []distro.Distro{}
+	if sortDir < 0 {
+		for ix := len(dc.CachedDistros) - 1; ix >= 0 && len(result) < limit; ix-- {
+			if dc.CachedDistros[ix].Id < distroId {
+				result = append(result, dc.CachedDistros[ix])
+			}
+		}
+		return result, dc.StoredError
+	}
+	for _, d := range dc.CachedDistros {
+		if d.Id >= distroId && len(result) < limit {
+			result = append(result, d)
+		}
+	}
+	return result, dc.StoredError
+}
+
+// ValidateDistro provides a mock implementation of the function for the
+// ServiceContext interface. It only checks that a new distro's id isn't
+// already in use.
+func (dc *MockDistroConnector) ValidateDistro(d *distro.Distro, settings *evergreen.Settings, newDistro bool) error {
+	if !newDistro {
+		return dc.StoredError
+	}
+	for _, cached := range dc.CachedDistros {
+		if cached.Id == d.Id {
+			return &apiv3.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid distro: distro '%s' already exists", d.Id),
+			}
+		}
+	}
+	return dc.StoredError
+}
+
+// CreateDistro provides a mock implementation of the function for the
+// ServiceContext interface, adding the distro to the cached distros.
+func (dc *MockDistroConnector) CreateDistro(d *distro.Distro, user string) error {
+	if dc.StoredError != nil {
+		return dc.StoredError
+	}
+	ix := 0
+	for ix < len(dc.CachedDistros) && dc.CachedDistros[ix].Id < d.Id {
+		ix++
+	}
+	dc.CachedDistros = append(dc.CachedDistros, distro.Distro{})
+	copy(dc.CachedDistros[ix+1:], dc.CachedDistros[ix:])
+	dc.CachedDistros[ix] = *d
+	return nil
+}
+
+// UpdateDistro provides a mock implementation of the function for the
+// ServiceContext interface, replacing the cached distro.
+func (dc *MockDistroConnector) UpdateDistro(d *distro.Distro, user string) error {
+	for ix, cached := range dc.CachedDistros {
+		if cached.Id == d.Id {
+			dc.CachedDistros[ix] = *d
+		}
+	}
+	return dc.StoredError
+}
+
+// DeleteDistro provides a mock implementation of the function for the
+// ServiceContext interface, removing the distro from the cached distros.
+func (dc *MockDistroConnector) DeleteDistro(d *distro.Distro, user string) error {
+	for ix, cached := range dc.CachedDistros {
+		if cached.Id == d.Id {
+			dc.CachedDistros = append(dc.CachedDistros[:ix], dc.CachedDistros[ix+1:]...)
+			break
+		}
+	}
+	return dc.StoredError
+}
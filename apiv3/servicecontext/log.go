@@ -0,0 +1,64 @@
+package servicecontext
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// DBLogConnector is a struct that implements the Log related methods
+// from the ServiceContext through interactions with the task log storage.
+type DBLogConnector struct{}
+
+// FindTaskLogs reads the log messages of the given execution of a task from
+// the task log storage, returning up to limit of those of the given types that
+// were logged within the time range, oldest first.
+func (lc *DBLogConnector) FindTaskLogs(taskId string, execution int, logTypes []string,
+	since, until time.Time, limit int) ([]model.LogMessage, error) {
+	return model.FindLogMessagesInRange(taskId, execution, logTypes, since, until, limit)
+}
+
+// logMessageInRange returns whether a log message was logged at or after
+// since and before until. Zero times leave the range unbounded.
+func logMessageInRange(msg model.LogMessage, since, until time.Time) bool {
+	if !since.IsZero() && msg.Timestamp.Before(since) {
+		return false
+	}
+	if !until.IsZero() && !msg.Timestamp.Before(until) {
+		return false
+	}
+	return true
+}
+
+// MockLogConnector stores a cached set of log messages that are queried
+// against by the implementations of the ServiceContext interface's Log
+// related functions.
+type MockLogConnector struct {
+	CachedLogs  []model.LogMessage
+	StoredError error
+}
+
+// FindTaskLogs provides a mock implementation of the functions for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached log messages in the MockLogConnector,
+// regardless of the task and execution.
+func (mlc *MockLogConnector) FindTaskLogs(taskId string, execution int, logTypes []string,
+	since, until time.Time, limit int) ([]model.LogMessage, error) {
+	if mlc.StoredError != nil {
+		return nil, mlc.StoredError
+	}
+	msgs := []model.LogMessage{}
+	for _, msg := range mlc.CachedLogs {
+		if len(logTypes) > 0 && !util.SliceContains(logTypes, msg.Type) {
+			continue
+		}
+		if limit > 0 && len(msgs) >= limit {
+			break
+		}
+		if logMessageInRange(msg, since, until) {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
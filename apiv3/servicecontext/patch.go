@@ -0,0 +1,206 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// DBPatchConnector is a struct that implements the Patch related methods
+// from the ServiceContext through interactions with the backing database.
+type DBPatchConnector struct{}
+
+// FindPatchById uses the service layer's patch type to query the backing
+// database for the patch with the given patchId.
+func (pc *DBPatchConnector) FindPatchById(patchId string) (*patch.Patch, error) {
+	notFound := &apiv3.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("patch with id %s not found", patchId),
+	}
+	if !patch.IsValidId(patchId) {
+		return nil, notFound
+	}
+	p, err := patch.FindOne(patch.ById(patch.NewId(patchId)).Project(patch.ExcludePatchDiff))
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, notFound
+	}
+	return p, nil
+}
+
+// FindPatchesByProject queries the backing database for up to limit of a
+// project's patches, most recent first, starting from the patch with the
+// given id, or from the most recent patch if it is empty. If sortDir is
+// negative, it instead returns the patches more recent than the given one,
+// nearest first.
+func (pc *DBPatchConnector) FindPatchesByProject(projectId, startPatchId string, limit, sortDir int) ([]patch.Patch, error) {
+	query := patch.ByProject(projectId).Sort([]string{"-" + patch.IdKey})
+	if startPatchId != "" {
+		if !patch.IsValidId(startPatchId) {
+			return nil, &apiv3.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("patch id '%s' is not an object id", startPatchId),
+			}
+		}
+		query = patch.ByProjectFromId(projectId, patch.NewId(startPatchId), sortDir)
+	} else if sortDir < 0 {
+		return []patch.Patch{}, nil
+	}
+	return patch.Find(query.Project(patch.ExcludePatchDiff).Limit(limit))
+}
+
+// AbortPatch aborts the patch's in progress tasks and deactivates it, or
+// removes it if it hasn't been finalized, using a call to the service layer
+// function.
+func (pc *DBPatchConnector) AbortPatch(p *patch.Patch, user string) error {
+	return errors.Wrap(serviceModel.CancelPatch(p, user), "Abort patch error")
+}
+
+// checkPatchFinalized returns an error if the patch hasn't been finalized,
+// since only finalized patches have a version with tasks.
+func checkPatchFinalized(p *patch.Patch) error {
+	if p.Version == "" {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("patch with id %s is not finalized", p.Id.Hex()),
+		}
+	}
+	return nil
+}
+
+// RestartPatch restarts the finished tasks of the patch's version using a
+// call to the service layer function. The patch must have been finalized.
+func (pc *DBPatchConnector) RestartPatch(p *patch.Patch, user string) error {
+	if err := checkPatchFinalized(p); err != nil {
+		return err
+	}
+	tasks, err := task.Find(task.ByVersion(p.Version).WithFields(task.IdKey))
+	if err != nil {
+		return err
+	}
+	taskIds := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		taskIds = append(taskIds, t.Id)
+	}
+	return errors.Wrap(serviceModel.RestartVersion(p.Version, taskIds, false, user),
+		"Restart patch error")
+}
+
+// SetPatchPriority changes the priority value of the tasks of the patch's
+// version using a call to the service layer function. The patch must have
+// been finalized.
+func (pc *DBPatchConnector) SetPatchPriority(p *patch.Patch, user string, priority int64) error {
+	if err := checkPatchFinalized(p); err != nil {
+		return err
+	}
+	if err := serviceModel.SetVersionPriority(p.Version, priority); err != nil {
+		return errors.Wrap(err, "Error setting patch priority")
+	}
+	event.LogVersionPriorityChanged(p.Version, p.Project, evergreen.PatchVersionRequester, user, priority)
+	return nil
+}
+
+// MockPatchConnector stores a cached set of patches that are queried against
+// by the implementations of the ServiceContext interface's Patch related
+// functions.
+type MockPatchConnector struct {
+	CachedPatches []patch.Patch
+	// CachedPriorities holds the priority set on each patch's tasks.
+	CachedPriorities map[string]int64
+	StoredError      error
+}
+
+// FindPatchById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached patches in the MockPatchConnector.
+func (pc *MockPatchConnector) FindPatchById(patchId string) (*patch.Patch, error) {
+	for _, p := range pc.CachedPatches {
+		if p.Id.Hex() == patchId {
+			return &p, pc.StoredError
+		}
+	}
+	return nil, pc.StoredError
+}
+
+// FindPatchesByProject provides a mock implementation of the function for
+// the ServiceContext interface without needing to use a database. The cached
+// patches are expected to be sorted from most to least recent.
+func (pc *MockPatchConnector) FindPatchesByProject(projectId, startPatchId string, limit, sortDir int) ([]patch.Patch, error) {
+	patches := []patch.Patch{}
+	for _, p := range pc.CachedPatches {
+		if p.Project == projectId {
+			patches = append(patches, p)
+		}
+	}
+	start := 0
+	if startPatchId != "" {
+		start = -1
+		for ix, p := range patches {
+			if p.Id.Hex() == startPatchId {
+				start = ix
+			}
+		}
+		if start < 0 {
+			return nil, pc.StoredError
+		}
+	}
+
+	result := []patch.Patch{}
+	if sortDir < 0 {
+		for ix := start - 1; ix >= 0 && len(result) < limit; ix-- {
+			result = append(result, patches[ix])
+		}
+		return result, pc.StoredError
+	}
+	for ix := start; ix < len(patches) && len(result) < limit; ix++ {
+		result = append(result, patches[ix])
+	}
+	return result, pc.StoredError
+}
+
+// AbortPatch provides a mock implementation of the function for the
+// ServiceContext interface, deactivating the cached patch.
+func (pc *MockPatchConnector) AbortPatch(p *patch.Patch, user string) error {
+	for ix, cached := range pc.CachedPatches {
+		if cached.Id == p.Id {
+			pc.CachedPatches[ix].Activated = false
+		}
+	}
+	return pc.StoredError
+}
+
+// RestartPatch provides a mock implementation of the function for the
+// ServiceContext interface, marking the cached patch as started again.
+func (pc *MockPatchConnector) RestartPatch(p *patch.Patch, user string) error {
+	if err := checkPatchFinalized(p); err != nil {
+		return err
+	}
+	for ix, cached := range pc.CachedPatches {
+		if cached.Id == p.Id {
+			pc.CachedPatches[ix].Status = evergreen.PatchStarted
+		}
+	}
+	return pc.StoredError
+}
+
+// SetPatchPriority provides a mock implementation of the function for the
+// ServiceContext interface, recording the priority in CachedPriorities.
+func (pc *MockPatchConnector) SetPatchPriority(p *patch.Patch, user string, priority int64) error {
+	if err := checkPatchFinalized(p); err != nil {
+		return err
+	}
+	if pc.CachedPriorities == nil {
+		pc.CachedPriorities = map[string]int64{}
+	}
+	pc.CachedPriorities[p.Id.Hex()] = priority
+	return pc.StoredError
+}
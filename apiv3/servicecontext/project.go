@@ -0,0 +1,230 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/pkg/errors"
+)
+
+// DBProjectConnector is a struct that implements the Project related methods
+// from the ServiceContext through interactions with the backing database.
+type DBProjectConnector struct{}
+
+// FindProjectRefById uses the service layer's project ref type to query the
+// backing database for the project ref with the given identifier.
+func (pc *DBProjectConnector) FindProjectRefById(projectId string) (*model.ProjectRef, error) {
+	p, err := model.FindOneProjectRef(projectId)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id %s not found", projectId),
+		}
+	}
+	return p, nil
+}
+
+// CreateProjectRef inserts the project ref into the backing database and
+// logs the project's addition.
+func (pc *DBProjectConnector) CreateProjectRef(p *model.ProjectRef, user string) error {
+	if err := p.Insert(); err != nil {
+		return errors.Wrapf(err, "Error inserting project '%s'", p.Identifier)
+	}
+	event.LogProjectAdded(p.Identifier, user)
+	return nil
+}
+
+// UpdateProjectRef replaces the project ref in the backing database and logs
+// the project's modification.
+func (pc *DBProjectConnector) UpdateProjectRef(p *model.ProjectRef, user string) error {
+	before, err := model.FindOneProjectRef(p.Identifier)
+	if err != nil {
+		return err
+	}
+	if err = p.Upsert(); err != nil {
+		return errors.Wrapf(err, "Error updating project '%s'", p.Identifier)
+	}
+	if before != nil && !reflect.DeepEqual(*before, *p) {
+		event.LogProjectModified(p.Identifier, user, before.WithRedactedSecrets(), p.WithRedactedSecrets())
+	}
+	return nil
+}
+
+// DeleteProjectRef removes the project ref and the project's variables from
+// the backing database and logs the project's removal.
+func (pc *DBProjectConnector) DeleteProjectRef(p *model.ProjectRef, user string) error {
+	if err := model.RemoveProjectRef(p.Identifier); err != nil {
+		return errors.Wrapf(err, "Error removing project '%s'", p.Identifier)
+	}
+	if err := model.RemoveProjectVars(p.Identifier); err != nil {
+		return errors.Wrapf(err, "Error removing variables of project '%s'", p.Identifier)
+	}
+	event.LogProjectRemoved(p.Identifier, user, p.WithRedactedSecrets())
+	return nil
+}
+
+// FindProjectVarsById queries the backing database for the variables of the
+// project with the given identifier, with the values of private variables
+// redacted. A project without variables has an empty set of them.
+func (pc *DBProjectConnector) FindProjectVarsById(projectId string) (*model.ProjectVars, error) {
+	vars, err := model.FindOneProjectVars(projectId)
+	if err != nil {
+		return nil, err
+	}
+	if vars == nil {
+		vars = &model.ProjectVars{Id: projectId}
+	}
+	if vars.Vars == nil {
+		vars.Vars = map[string]string{}
+	}
+	if vars.PrivateVars == nil {
+		vars.PrivateVars = map[string]bool{}
+	}
+	vars.RedactPrivateVars()
+	return vars, nil
+}
+
+// UpdateProjectVars encrypts the private variables with the settings' key,
+// keeping the stored value of any private variable given without one, then
+// replaces the project's variables in the backing database and logs which of
+// them changed.
+func (pc *DBProjectConnector) UpdateProjectVars(vars *model.ProjectVars, settings *evergreen.Settings, user string) error {
+	saved, err := model.FindOneProjectVars(vars.Id)
+	if err != nil {
+		return err
+	}
+	if err = vars.EncryptPrivateVars(settings.ProjectVarsKey, saved); err != nil {
+		return err
+	}
+	if saved == nil {
+		saved = &model.ProjectVars{}
+	}
+	// compare hashes of the private values, since their ciphertexts differ
+	// every time they're encrypted
+	before, err := saved.HashedVars(settings.ProjectVarsKey)
+	if err != nil {
+		return err
+	}
+	after, err := vars.HashedVars(settings.ProjectVarsKey)
+	if err != nil {
+		return err
+	}
+	if _, err = vars.Upsert(); err != nil {
+		return errors.Wrapf(err, "Error updating variables of project '%s'", vars.Id)
+	}
+	event.LogProjectVarsModified(vars.Id, user, before, after)
+	return nil
+}
+
+// MockProjectConnector stores a cached set of project refs and project
+// variables that are queried against by the implementations of the
+// ServiceContext interface's Project related functions.
+type MockProjectConnector struct {
+	CachedProjectRefs []model.ProjectRef
+	CachedProjectVars []model.ProjectVars
+	StoredError       error
+}
+
+// FindProjectRefById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached project refs in the MockProjectConnector.
+func (pc *MockProjectConnector) FindProjectRefById(projectId string) (*model.ProjectRef, error) {
+	for _, p := range pc.CachedProjectRefs {
+		if p.Identifier == projectId {
+			return &p, pc.StoredError
+		}
+	}
+	return nil, &apiv3.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("project with id %s not found", projectId),
+	}
+}
+
+// CreateProjectRef provides a mock implementation of the function for the
+// ServiceContext interface, adding the project ref to the cached project
+// refs.
+func (pc *MockProjectConnector) CreateProjectRef(p *model.ProjectRef, user string) error {
+	if pc.StoredError != nil {
+		return pc.StoredError
+	}
+	pc.CachedProjectRefs = append(pc.CachedProjectRefs, *p)
+	return nil
+}
+
+// UpdateProjectRef provides a mock implementation of the function for the
+// ServiceContext interface, replacing the cached project ref.
+func (pc *MockProjectConnector) UpdateProjectRef(p *model.ProjectRef, user string) error {
+	for ix, cached := range pc.CachedProjectRefs {
+		if cached.Identifier == p.Identifier {
+			pc.CachedProjectRefs[ix] = *p
+		}
+	}
+	return pc.StoredError
+}
+
+// DeleteProjectRef provides a mock implementation of the function for the
+// ServiceContext interface, removing the project ref and the project's
+// variables from the cache.
+func (pc *MockProjectConnector) DeleteProjectRef(p *model.ProjectRef, user string) error {
+	for ix, cached := range pc.CachedProjectRefs {
+		if cached.Identifier == p.Identifier {
+			pc.CachedProjectRefs = append(pc.CachedProjectRefs[:ix], pc.CachedProjectRefs[ix+1:]...)
+			break
+		}
+	}
+	for ix, cached := range pc.CachedProjectVars {
+		if cached.Id == p.Identifier {
+			pc.CachedProjectVars = append(pc.CachedProjectVars[:ix], pc.CachedProjectVars[ix+1:]...)
+			break
+		}
+	}
+	return pc.StoredError
+}
+
+// FindProjectVarsById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns a
+// redacted copy of the cached variables in the MockProjectConnector.
+func (pc *MockProjectConnector) FindProjectVarsById(projectId string) (*model.ProjectVars, error) {
+	vars := &model.ProjectVars{
+		Id:          projectId,
+		Vars:        map[string]string{},
+		PrivateVars: map[string]bool{},
+	}
+	for _, cached := range pc.CachedProjectVars {
+		if cached.Id == projectId {
+			for name, value := range cached.Vars {
+				vars.Vars[name] = value
+			}
+			for name, private := range cached.PrivateVars {
+				vars.PrivateVars[name] = private
+			}
+		}
+	}
+	vars.RedactPrivateVars()
+	return vars, pc.StoredError
+}
+
+// UpdateProjectVars provides a mock implementation of the function for the
+// ServiceContext interface, replacing the cached variables without
+// encrypting them.
+func (pc *MockProjectConnector) UpdateProjectVars(vars *model.ProjectVars, settings *evergreen.Settings, user string) error {
+	if pc.StoredError != nil {
+		return pc.StoredError
+	}
+	for ix, cached := range pc.CachedProjectVars {
+		if cached.Id == vars.Id {
+			pc.CachedProjectVars[ix] = *vars
+			return nil
+		}
+	}
+	pc.CachedProjectVars = append(pc.CachedProjectVars, *vars)
+	return nil
+}
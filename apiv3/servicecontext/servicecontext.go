@@ -1,10 +1,18 @@
 package servicecontext
 
 import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/model/version"
 )
 
 // ServiceContext is an interface that contains all of the methods which
@@ -25,10 +33,15 @@ type ServiceContext interface {
 	GetPrefix() string
 	SetPrefix(string)
 
+	// Get and Set Settings provide access to the evergreen settings, which
+	// are needed to validate distros and encrypt project variables.
+	GetSettings() *evergreen.Settings
+	SetSettings(*evergreen.Settings)
+
 	// FindTaskById is a method to find a specific task given its ID.
 	FindTaskById(string) (*task.Task, error)
 	FindTasksByIds([]string) ([]task.Task, error)
-	SetTaskPriority(*task.Task, int64) error
+	SetTaskPriority(*task.Task, string, int64) error
 	SetTaskActivated(string, string, bool) error
 	ResetTask(string, string, *model.Project) error
 
@@ -38,9 +51,93 @@ type ServiceContext interface {
 	// It returns a list of tasks which match.
 	FindTasksByBuildId(string, string, int) ([]task.Task, error)
 
+	// FindTestsByTaskId is a method to find the results of the tests run in
+	// an execution of a task, sorted by test file, with their page keys. It
+	// takes the task's ID and execution, the page key to start from, the test
+	// file and status to filter on, a limit, and the direction to paginate in.
+	FindTestsByTaskId(string, int, string, string, string, int, int) ([]KeyedTestResult, error)
+
+	// FindTaskLogs is a method to find the log messages of an execution of a
+	// task, oldest first. It takes the task's ID and execution, the types of
+	// log messages to return, the times to return messages since and until,
+	// and the most messages to return.
+	FindTaskLogs(string, int, []string, time.Time, time.Time, int) ([]model.LogMessage, error)
+
+	// FindBuildById is a method to find a specific build given its ID.
+	FindBuildById(string) (*build.Build, error)
+	// FindBuildsByProject is a method to find a project's non-patch builds,
+	// most recent first. It takes the project's ID, the ID of the build to
+	// start from, a limit, and the direction to paginate in.
+	FindBuildsByProject(string, string, int, int) ([]build.Build, error)
+	AbortBuild(string, string) error
+	RestartBuild(string, string) error
+	SetBuildPriority(string, string, int64) error
+
+	// FindVersionById is a method to find a specific version given its ID.
+	FindVersionById(string) (*version.Version, error)
+	// FindVersionsByProject is a method to find a project's non-patch
+	// versions, most recent first. It takes the project's ID, the ID of the
+	// version to start from, a limit, and the direction to paginate in.
+	FindVersionsByProject(string, string, int, int) ([]version.Version, error)
+	AbortVersion(*version.Version, string) error
+	RestartVersion(*version.Version, string) error
+	SetVersionPriority(*version.Version, string, int64) error
+
+	// FindPatchById is a method to find a specific patch given its ID.
+	FindPatchById(string) (*patch.Patch, error)
+	// FindPatchesByProject is a method to find a project's patches, most
+	// recent first. It takes the project's ID, the ID of the patch to start
+	// from, a limit, and the direction to paginate in.
+	FindPatchesByProject(string, string, int, int) ([]patch.Patch, error)
+	AbortPatch(*patch.Patch, string) error
+	RestartPatch(*patch.Patch, string) error
+	SetPatchPriority(*patch.Patch, string, int64) error
+
+	// FindDistroById is a method to find a specific distro given its ID.
+	FindDistroById(string) (*distro.Distro, error)
+	// FindDistrosById is a method to find a list of distros sorted by ID. It
+	// takes the ID of the distro to start from, a limit, and the direction to
+	// paginate in.
+	FindDistrosById(string, int, int) ([]distro.Distro, error)
+	// ValidateDistro checks a distro's configuration, using the given
+	// settings, and whether or not it is a new distro.
+	ValidateDistro(*distro.Distro, *evergreen.Settings, bool) error
+	CreateDistro(*distro.Distro, string) error
+	UpdateDistro(*distro.Distro, string) error
+	DeleteDistro(*distro.Distro, string) error
+
+	// FindProjectRefById is a method to find a specific project ref given
+	// its identifier.
+	FindProjectRefById(string) (*model.ProjectRef, error)
+	CreateProjectRef(*model.ProjectRef, string) error
+	UpdateProjectRef(*model.ProjectRef, string) error
+	DeleteProjectRef(*model.ProjectRef, string) error
+
+	// FindProjectVarsById is a method to find the variables of a project
+	// given its identifier. The values of private variables are redacted.
+	FindProjectVarsById(string) (*model.ProjectVars, error)
+	// UpdateProjectVars replaces the variables of a project. It takes the
+	// new variables, the settings holding the key to encrypt private
+	// variables with, and the user making the change.
+	UpdateProjectVars(*model.ProjectVars, *evergreen.Settings, string) error
+
 	// FindUserById is a method to find a specific user given its ID.
 	FindUserById(string) (auth.APIUser, error)
 
+	// FindAccessTokenByToken is a method to find the access token that
+	// clients authenticate with the given token.
+	FindAccessTokenByToken(string) (*user.AccessToken, error)
+	// FindAccessTokenByName is a method to find a user's access token given
+	// the user's ID and the token's name.
+	FindAccessTokenByName(string, string) (*user.AccessToken, error)
+	// FindAccessTokensByUser is a method to find all of a user's access
+	// tokens, sorted by name.
+	FindAccessTokensByUser(string) ([]user.AccessToken, error)
+	CreateAccessToken(*user.AccessToken) error
+	// RevokeAccessToken is a method to remove a user's access token given the
+	// user's ID and the token's name.
+	RevokeAccessToken(string, string) error
+
 	// FindHostsById is a method to find a sorted list of hosts given an ID to
 	// start from.
 	FindHostsById(string, int, int) ([]host.Host, error)
@@ -57,11 +154,20 @@ type DBServiceContext struct {
 	superUsers []string
 	URL        string
 	Prefix     string
+	settings   *evergreen.Settings
 
 	DBUserConnector
+	DBTokenConnector
 	DBTaskConnector
+	DBTestConnector
+	DBLogConnector
 	DBContextConnector
 	DBHostConnector
+	DBBuildConnector
+	DBVersionConnector
+	DBPatchConnector
+	DBDistroConnector
+	DBProjectConnector
 }
 
 func (ctx *DBServiceContext) GetSuperUsers() []string {
@@ -82,16 +188,31 @@ func (ctx *DBServiceContext) GetPrefix() string {
 func (ctx *DBServiceContext) SetPrefix(prefix string) {
 	ctx.Prefix = prefix
 }
+func (ctx *DBServiceContext) GetSettings() *evergreen.Settings {
+	return ctx.settings
+}
+func (ctx *DBServiceContext) SetSettings(settings *evergreen.Settings) {
+	ctx.settings = settings
+}
 
 type MockServiceContext struct {
 	superUsers []string
 	URL        string
 	Prefix     string
+	settings   *evergreen.Settings
 
 	MockUserConnector
+	MockTokenConnector
 	MockTaskConnector
+	MockTestConnector
+	MockLogConnector
 	MockContextConnector
 	MockHostConnector
+	MockBuildConnector
+	MockVersionConnector
+	MockPatchConnector
+	MockDistroConnector
+	MockProjectConnector
 }
 
 func (ctx *MockServiceContext) GetSuperUsers() []string {
@@ -112,3 +233,9 @@ func (ctx *MockServiceContext) GetPrefix() string {
 func (ctx *MockServiceContext) SetPrefix(prefix string) {
 	ctx.Prefix = prefix
 }
+func (ctx *MockServiceContext) GetSettings() *evergreen.Settings {
+	return ctx.settings
+}
+func (ctx *MockServiceContext) SetSettings(settings *evergreen.Settings) {
+	ctx.settings = settings
+}
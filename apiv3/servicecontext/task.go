@@ -32,6 +32,29 @@ func (tc *DBTaskConnector) FindTaskById(taskId string) (*task.Task, error) {
 	return t, nil
 }
 
+// findTaskExecution queries the backing database for the given execution of
+// the task. Executions before the task's latest one are archived in the old
+// tasks collection.
+func findTaskExecution(taskId string, execution int) (*task.Task, error) {
+	t, err := task.FindOne(task.ById(taskId))
+	if err != nil {
+		return nil, err
+	}
+	if t != nil && t.Execution != execution {
+		t, err = task.FindOneOld(task.ById(fmt.Sprintf("%v_%v", taskId, execution)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if t == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("execution %d of task with id %s not found", execution, taskId),
+		}
+	}
+	return t, nil
+}
+
 // FindTasksByBuildId uses the service layer's task type to query the backing database for a
 // list of task that matches buildId. It accepts the startTaskId and a limit
 // to allow for pagination of the queries. It returns results sorted by taskId.
@@ -77,8 +100,8 @@ func (tc *DBTaskConnector) FindTasksByIds(ids []string) ([]task.Task, error) {
 
 // SetTaskPriority changes the priority value of a task using a call to the
 // service layer function.
-func (tc *DBTaskConnector) SetTaskPriority(t *task.Task, priority int64) error {
-	err := t.SetPriority(priority)
+func (tc *DBTaskConnector) SetTaskPriority(t *task.Task, user string, priority int64) error {
+	err := t.SetPriority(priority, user)
 	return err
 }
 
@@ -128,7 +151,7 @@ func (mdf *MockTaskConnector) FindTasksByBuildId(buildId, startTaskId string, li
 
 // SetTaskPriority changes the priority value of a task using a call to the
 // service layer function.
-func (mdf *MockTaskConnector) SetTaskPriority(it *task.Task, priority int64) error {
+func (mdf *MockTaskConnector) SetTaskPriority(it *task.Task, user string, priority int64) error {
 	for ix, t := range mdf.CachedTasks {
 		if t.Id == it.Id {
 			mdf.CachedTasks[ix].Priority = priority
@@ -0,0 +1,142 @@
+package servicecontext
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+)
+
+// DBTestConnector is a struct that implements the Test related methods
+// from the ServiceContext through interactions with the backing database.
+type DBTestConnector struct{}
+
+// FindTestsByTaskId queries the backing database for the given execution of
+// the task, which may be archived, and returns the results of its tests that
+// match the test file and status filters, sorted by test file, along with
+// their page keys. It returns the results from the one with the startKey on,
+// or those before it if sortDir is negative, closest first.
+func (tc *DBTestConnector) FindTestsByTaskId(taskId string, execution int, startKey, testName,
+	status string, limit, sortDir int) ([]KeyedTestResult, error) {
+	t, err := findTaskExecution(taskId, execution)
+	if err != nil {
+		return nil, err
+	}
+	return filterTestResults(t.TestResults, startKey, testName, status, limit, sortDir), nil
+}
+
+// KeyedTestResult is a test result along with the key that identifies it
+// among the results of its task's execution, for paging through them. Test
+// files aren't unique, since tests may be rerun or share a name, so the key
+// is the test file and the number of results with the same test file that
+// come before it in the task.
+type KeyedTestResult struct {
+	task.TestResult
+	Key string
+}
+
+// testResultKey is the page key of a test result, parsed.
+type testResultKey struct {
+	testFile string
+	index    int
+}
+
+func (k testResultKey) String() string {
+	return fmt.Sprintf("%s:%d", k.testFile, k.index)
+}
+
+func (k testResultKey) less(other testResultKey) bool {
+	if k.testFile != other.testFile {
+		return k.testFile < other.testFile
+	}
+	return k.index < other.index
+}
+
+// parseTestResultKey parses a page key. A key that doesn't end in an index,
+// such as a plain test file, starts at the first result with that test file.
+func parseTestResultKey(key string) testResultKey {
+	sep := strings.LastIndex(key, ":")
+	if sep < 0 {
+		return testResultKey{testFile: key}
+	}
+	index, err := strconv.Atoi(key[sep+1:])
+	if err != nil || index < 0 {
+		return testResultKey{testFile: key}
+	}
+	return testResultKey{testFile: key[:sep], index: index}
+}
+
+// filterTestResults returns a page of test results, sorted by test file,
+// that match the test file and status filters. Empty filters match all of
+// the results.
+func filterTestResults(tests []task.TestResult, startKey, testName, status string,
+	limit, sortDir int) []KeyedTestResult {
+	start := parseTestResultKey(startKey)
+	seen := map[string]int{}
+	matching := []KeyedTestResult{}
+	keys := []testResultKey{}
+	for _, t := range tests {
+		key := testResultKey{testFile: t.TestFile, index: seen[t.TestFile]}
+		seen[t.TestFile]++
+		if testName != "" && t.TestFile != testName {
+			continue
+		}
+		if status != "" && t.Status != status {
+			continue
+		}
+		matching = append(matching, KeyedTestResult{TestResult: t, Key: key.String()})
+		keys = append(keys, key)
+	}
+	sort.Stable(testResultsByKey{matching, keys})
+
+	results := []KeyedTestResult{}
+	if sortDir < 0 {
+		for i := len(matching) - 1; i >= 0 && len(results) < limit; i-- {
+			if keys[i].less(start) {
+				results = append(results, matching[i])
+			}
+		}
+		return results
+	}
+	for i := 0; i < len(matching) && len(results) < limit; i++ {
+		if !keys[i].less(start) {
+			results = append(results, matching[i])
+		}
+	}
+	return results
+}
+
+// testResultsByKey sorts test results by their page keys.
+type testResultsByKey struct {
+	results []KeyedTestResult
+	keys    []testResultKey
+}
+
+func (t testResultsByKey) Len() int           { return len(t.results) }
+func (t testResultsByKey) Less(i, j int) bool { return t.keys[i].less(t.keys[j]) }
+func (t testResultsByKey) Swap(i, j int) {
+	t.results[i], t.results[j] = t.results[j], t.results[i]
+	t.keys[i], t.keys[j] = t.keys[j], t.keys[i]
+}
+
+// MockTestConnector stores a cached set of test results that are queried
+// against by the implementations of the ServiceContext interface's Test
+// related functions.
+type MockTestConnector struct {
+	CachedTests []task.TestResult
+	StoredError error
+}
+
+// FindTestsByTaskId provides a mock implementation of the functions for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached test results in the MockTestConnector,
+// regardless of the task and execution.
+func (mtc *MockTestConnector) FindTestsByTaskId(taskId string, execution int, startKey, testName,
+	status string, limit, sortDir int) ([]KeyedTestResult, error) {
+	if mtc.StoredError != nil {
+		return nil, mtc.StoredError
+	}
+	return filterTestResults(mtc.CachedTests, startKey, testName, status, limit, sortDir), nil
+}
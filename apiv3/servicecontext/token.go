@@ -0,0 +1,170 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/apiv3"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/pkg/errors"
+)
+
+// DBTokenConnector is a struct that implements the access token related
+// methods from the ServiceContext through interactions with the backing
+// database.
+type DBTokenConnector struct{}
+
+// FindAccessTokenByToken queries the backing database for the access token
+// that clients authenticate with the given token.
+func (tc *DBTokenConnector) FindAccessTokenByToken(token string) (*user.AccessToken, error) {
+	t, err := user.FindAccessTokenByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "access token not found",
+		}
+	}
+	return t, nil
+}
+
+// FindAccessTokenByName queries the backing database for the user's access
+// token with the given name.
+func (tc *DBTokenConnector) FindAccessTokenByName(userId, name string) (*user.AccessToken, error) {
+	t, err := user.FindOneAccessToken(userId, name)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("access token with name %s not found", name),
+		}
+	}
+	return t, nil
+}
+
+// FindAccessTokensByUser queries the backing database for all of the user's
+// access tokens, sorted by name.
+func (tc *DBTokenConnector) FindAccessTokensByUser(userId string) ([]user.AccessToken, error) {
+	return user.FindAccessTokensByUser(userId)
+}
+
+// CreateAccessToken checks that the access token is valid and that its user
+// has no other token with its name, then inserts it into the backing
+// database.
+func (tc *DBTokenConnector) CreateAccessToken(t *user.AccessToken) error {
+	if err := t.Validate(); err != nil {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	existing, err := user.FindOneAccessToken(t.UserId, t.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("access token with name %s already exists", t.Name),
+		}
+	}
+	return errors.Wrapf(t.Insert(), "Error inserting access token '%s'", t.Name)
+}
+
+// RevokeAccessToken removes the user's access token with the given name
+// from the backing database.
+func (tc *DBTokenConnector) RevokeAccessToken(userId, name string) error {
+	return errors.Wrapf(user.RemoveAccessToken(userId, name), "Error removing access token '%s'", name)
+}
+
+// MockTokenConnector stores a cached set of access tokens that are queried
+// against by the implementations of the ServiceContext interface's access
+// token related functions.
+type MockTokenConnector struct {
+	CachedTokens []user.AccessToken
+	StoredError  error
+}
+
+// FindAccessTokenByToken provides a mock implementation of the functions
+// for the ServiceContext interface without needing to use a database. It
+// returns results based on the cached access tokens in the
+// MockTokenConnector.
+func (mtc *MockTokenConnector) FindAccessTokenByToken(token string) (*user.AccessToken, error) {
+	id := user.HashAccessToken(token)
+	for ix := range mtc.CachedTokens {
+		if mtc.CachedTokens[ix].Id == id {
+			t := mtc.CachedTokens[ix]
+			return &t, mtc.StoredError
+		}
+	}
+	return nil, &apiv3.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    "access token not found",
+	}
+}
+
+// FindAccessTokenByName returns the cached access token of the user with the
+// given name.
+func (mtc *MockTokenConnector) FindAccessTokenByName(userId, name string) (*user.AccessToken, error) {
+	for ix := range mtc.CachedTokens {
+		if mtc.CachedTokens[ix].UserId == userId && mtc.CachedTokens[ix].Name == name {
+			t := mtc.CachedTokens[ix]
+			return &t, mtc.StoredError
+		}
+	}
+	return nil, &apiv3.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("access token with name %s not found", name),
+	}
+}
+
+// FindAccessTokensByUser returns the cached access tokens of the user.
+func (mtc *MockTokenConnector) FindAccessTokensByUser(userId string) ([]user.AccessToken, error) {
+	tokens := []user.AccessToken{}
+	for _, t := range mtc.CachedTokens {
+		if t.UserId == userId {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, mtc.StoredError
+}
+
+// CreateAccessToken checks that the access token is valid and that its user
+// has no other token with its name, then adds it to the cache.
+func (mtc *MockTokenConnector) CreateAccessToken(t *user.AccessToken) error {
+	if mtc.StoredError != nil {
+		return mtc.StoredError
+	}
+	if err := t.Validate(); err != nil {
+		return &apiv3.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	for _, existing := range mtc.CachedTokens {
+		if existing.UserId == t.UserId && existing.Name == t.Name {
+			return &apiv3.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("access token with name %s already exists", t.Name),
+			}
+		}
+	}
+	mtc.CachedTokens = append(mtc.CachedTokens, *t)
+	return nil
+}
+
+// RevokeAccessToken removes the user's access token with the given name from
+// the cache.
+func (mtc *MockTokenConnector) RevokeAccessToken(userId, name string) error {
+	for ix, t := range mtc.CachedTokens {
+		if t.UserId == userId && t.Name == name {
+			mtc.CachedTokens = append(mtc.CachedTokens[:ix], mtc.CachedTokens[ix+1:]...)
+			break
+		}
+	}
+	return mtc.StoredError
+}
@@ -0,0 +1,192 @@
+package servicecontext
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apiv3"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/pkg/errors"
+)
+
+// DBVersionConnector is a struct that implements the Version related methods
+// from the ServiceContext through interactions with the backing database.
+type DBVersionConnector struct{}
+
+// FindVersionById uses the service layer's version type to query the backing
+// database for the version with the given versionId.
+func (vc *DBVersionConnector) FindVersionById(versionId string) (*version.Version, error) {
+	v, err := version.FindOne(version.ById(versionId))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, &apiv3.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version with id %s not found", versionId),
+		}
+	}
+	return v, nil
+}
+
+// FindVersionsByProject queries the backing database for up to limit of a
+// project's non-patch versions, most recent first, starting from the version
+// with the given id, or from the most recent version if it is empty. If
+// sortDir is negative, it instead returns the versions more recent than the
+// given one, nearest first.
+func (vc *DBVersionConnector) FindVersionsByProject(projectId, startVersionId string, limit, sortDir int) ([]version.Version, error) {
+	query := version.ByMostRecentForRequester(projectId, evergreen.RepotrackerVersionRequester)
+	if startVersionId != "" {
+		start, err := version.FindOne(version.ById(startVersionId))
+		if err != nil {
+			return nil, err
+		}
+		if start == nil || start.Identifier != projectId ||
+			start.Requester != evergreen.RepotrackerVersionRequester {
+			return nil, &apiv3.APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("version with id %s not found", startVersionId),
+			}
+		}
+		query = version.ByProjectIdFromOrder(projectId, start.RevisionOrderNumber, sortDir)
+	} else if sortDir < 0 {
+		return []version.Version{}, nil
+	}
+	return version.Find(query.Limit(limit))
+}
+
+// AbortVersion aborts the version's in progress tasks and deactivates it
+// using calls to the service layer functions.
+func (vc *DBVersionConnector) AbortVersion(v *version.Version, user string) error {
+	if err := serviceModel.AbortVersion(v.Id); err != nil {
+		return errors.Wrap(err, "Abort version error")
+	}
+	if err := serviceModel.SetVersionActivation(v.Id, false, user); err != nil {
+		return errors.Wrap(err, "Error deactivating version")
+	}
+	event.LogVersionActivation(v.Id, v.Identifier, v.Requester, user, false)
+	return nil
+}
+
+// RestartVersion restarts the version's finished tasks using a call to the
+// service layer function.
+func (vc *DBVersionConnector) RestartVersion(v *version.Version, user string) error {
+	tasks, err := task.Find(task.ByVersion(v.Id).WithFields(task.IdKey))
+	if err != nil {
+		return err
+	}
+	taskIds := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		taskIds = append(taskIds, t.Id)
+	}
+	return errors.Wrap(serviceModel.RestartVersion(v.Id, taskIds, false, user),
+		"Restart version error")
+}
+
+// SetVersionPriority changes the priority value of the version's tasks using
+// a call to the service layer function.
+func (vc *DBVersionConnector) SetVersionPriority(v *version.Version, user string, priority int64) error {
+	if err := serviceModel.SetVersionPriority(v.Id, priority); err != nil {
+		return errors.Wrap(err, "Error setting version priority")
+	}
+	event.LogVersionPriorityChanged(v.Id, v.Identifier, v.Requester, user, priority)
+	return nil
+}
+
+// MockVersionConnector stores a cached set of versions that are queried
+// against by the implementations of the ServiceContext interface's Version
+// related functions.
+type MockVersionConnector struct {
+	CachedVersions []version.Version
+	// CachedPriorities holds the priority set on each version's tasks.
+	CachedPriorities map[string]int64
+	// CachedAborted and CachedRestarted hold the ids of the versions that
+	// were aborted and restarted.
+	CachedAborted   map[string]bool
+	CachedRestarted map[string]bool
+	StoredError     error
+}
+
+// FindVersionById provides a mock implementation of the function for the
+// ServiceContext interface without needing to use a database. It returns
+// results based on the cached versions in the MockVersionConnector.
+func (vc *MockVersionConnector) FindVersionById(versionId string) (*version.Version, error) {
+	for _, v := range vc.CachedVersions {
+		if v.Id == versionId {
+			return &v, vc.StoredError
+		}
+	}
+	return nil, vc.StoredError
+}
+
+// FindVersionsByProject provides a mock implementation of the function for
+// the ServiceContext interface without needing to use a database. The cached
+// versions are expected to be sorted from most to least recent.
+func (vc *MockVersionConnector) FindVersionsByProject(projectId, startVersionId string, limit, sortDir int) ([]version.Version, error) {
+	versions := []version.Version{}
+	for _, v := range vc.CachedVersions {
+		if v.Identifier == projectId {
+			versions = append(versions, v)
+		}
+	}
+	start := 0
+	if startVersionId != "" {
+		start = -1
+		for ix, v := range versions {
+			if v.Id == startVersionId {
+				start = ix
+			}
+		}
+		if start < 0 {
+			return nil, vc.StoredError
+		}
+	}
+
+	result := []version.Version{}
+	if sortDir < 0 {
+		for ix := start - 1; ix >= 0 && len(result) < limit; ix-- {
+			result = append(result, versions[ix])
+		}
+		return result, vc.StoredError
+	}
+	for ix := start; ix < len(versions) && len(result) < limit; ix++ {
+		result = append(result, versions[ix])
+	}
+	return result, vc.StoredError
+}
+
+// AbortVersion provides a mock implementation of the function for the
+// ServiceContext interface. Aborting a version only changes its tasks and
+// builds, so it is just recorded in CachedAborted.
+func (vc *MockVersionConnector) AbortVersion(v *version.Version, user string) error {
+	if vc.CachedAborted == nil {
+		vc.CachedAborted = map[string]bool{}
+	}
+	vc.CachedAborted[v.Id] = true
+	return vc.StoredError
+}
+
+// RestartVersion provides a mock implementation of the function for the
+// ServiceContext interface. Restarting a version only changes its tasks and
+// builds, so it is just recorded in CachedRestarted.
+func (vc *MockVersionConnector) RestartVersion(v *version.Version, user string) error {
+	if vc.CachedRestarted == nil {
+		vc.CachedRestarted = map[string]bool{}
+	}
+	vc.CachedRestarted[v.Id] = true
+	return vc.StoredError
+}
+
+// SetVersionPriority provides a mock implementation of the function for the
+// ServiceContext interface, recording the priority in CachedPriorities.
+func (vc *MockVersionConnector) SetVersionPriority(v *version.Version, user string, priority int64) error {
+	if vc.CachedPriorities == nil {
+		vc.CachedPriorities = map[string]int64{}
+	}
+	vc.CachedPriorities[v.Id] = priority
+	return vc.StoredError
+}
@@ -123,7 +123,7 @@ func TestCLIFetchSource(t *testing.T) {
 			"3c7bfeb82d492dc453e7431be664539c35b5db4b",
 			"all",
 			[]string{"all"},
-			false}
+			false, nil}
 
 		// Set up a test patch that contains module changes
 		ac, rc, _, err := getAPIClients(&Options{testSetup.settingsFilePath})
@@ -329,7 +329,7 @@ func TestCLIFunctions(t *testing.T) {
 					"3c7bfeb82d492dc453e7431be664539c35b5db4b",
 					"all",
 					[]string{"all"},
-					false}
+					false, nil}
 
 				newPatch, err := ac.PutPatch(patchSub)
 				So(err, ShouldBeNil)
@@ -385,6 +385,7 @@ func TestCLIFunctions(t *testing.T) {
 					"all",
 					[]string{},
 					false,
+					nil,
 				}
 				_, err := ac.PutPatch(patchSub)
 				So(err, ShouldBeNil)
@@ -397,7 +398,7 @@ func TestCLIFunctions(t *testing.T) {
 					"3c7bfeb82d492dc453e7431be664539c35b5db4b",
 					"osx-108",
 					[]string{"failing_test"},
-					false}
+					false, nil}
 
 				_, err := ac.PutPatch(patchSub)
 				So(err, ShouldBeNil)
@@ -502,7 +503,7 @@ func TestCLIFunctions(t *testing.T) {
 					"3c7bfeb82d492dc453e7431be664539c35b5db4b",
 					"all",
 					[]string{"failing_test"},
-					false}
+					false, nil}
 
 				_, err := ac.PutPatch(patchSub)
 				So(err, ShouldBeNil)
@@ -529,7 +530,7 @@ func TestCLIFunctions(t *testing.T) {
 					"3c7bfeb82d492dc453e7431be664539c35b5db4b",
 					"osx-108",
 					[]string{"all"},
-					false}
+					false, nil}
 
 				_, err := ac.PutPatch(patchSub)
 				So(err, ShouldBeNil)
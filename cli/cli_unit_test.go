@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/evergreen-ci/evergreen/model"
@@ -45,3 +46,29 @@ func TestGetHistoryCreateUrlQuery(t *testing.T) {
 		})
 	})
 }
+
+func TestReadTaskLogStream(t *testing.T) {
+
+	Convey("when reading a task log stream", t, func() {
+
+		Convey("each log message should be read until the end event", func() {
+			stream := ": keep-alive\n\n" +
+				"event: message\ndata: {\"t\":\"T\",\"m\":\"first\"}\n\n" +
+				"data: {\"t\":\"T\",\"m\":\"second\"}\n\n" +
+				"event: end\ndata: {\"status\":\"failed\"}\n\n" +
+				"event: message\ndata: {\"t\":\"T\",\"m\":\"after the end\"}\n\n"
+			messages := []string{}
+			status, err := readTaskLogStream(strings.NewReader(stream), func(msg model.LogMessage) {
+				messages = append(messages, msg.Message)
+			})
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, "failed")
+			So(messages, ShouldResemble, []string{"first", "second"})
+		})
+
+		Convey("a stream without an end event should be an error", func() {
+			_, err := readTaskLogStream(strings.NewReader("data: {\"m\":\"first\"}\n\n"), func(model.LogMessage) {})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
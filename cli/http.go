@@ -8,9 +8,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	restmodel "github.com/evergreen-ci/evergreen/apiv3/model"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/version"
@@ -74,6 +77,25 @@ func getAPIClients(o *Options) (*APIClient, *APIClient, *model.CLISettings, erro
 	return ac, rc, settings, nil
 }
 
+// getRESTv2Client loads user settings and returns an APIClient configured for
+// version 2 of the REST api.
+func getRESTv2Client(o *Options) (*APIClient, error) {
+	settings, err := LoadSettings(o)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl, err := url.Parse(settings.APIServerHost)
+	if err != nil {
+		return nil, errors.Errorf("Settings file contains an invalid url: %v", err)
+	}
+	return &APIClient{
+		APIRoot: apiUrl.Scheme + "://" + apiUrl.Host + "/rest/v2",
+		User:    settings.User,
+		APIKey:  settings.APIKey,
+	}, nil
+}
+
 // doReq performs a request of the given method type against path.
 // If body is not nil, also includes it as a request body as url-encoded data with the
 // appropriate header
@@ -357,6 +379,7 @@ func (ac *APIClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, erro
 		Variants    string   `json:"buildvariants"` //TODO make this an array
 		Tasks       []string `json:"tasks"`
 		Finalize    bool     `json:"finalize"`
+		Notify      *bool    `json:"notify,omitempty"`
 	}{
 		incomingPatch.description,
 		incomingPatch.projectId,
@@ -365,6 +388,7 @@ func (ac *APIClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, erro
 		incomingPatch.variants,
 		incomingPatch.tasks,
 		incomingPatch.finalize,
+		incomingPatch.notify,
 	}
 
 	rPipe, wPipe := io.Pipe()
@@ -510,6 +534,26 @@ func (ac *APIClient) GetTestHistory(project, queryParams string, isCSV bool) (io
 	return resp.Body, nil
 }
 
+// GetFlakyTests gets the flakiness of a project's tests, as JSON or CSV.
+func (ac *APIClient) GetFlakyTests(project, queryParams string, isCSV bool) (io.ReadCloser, error) {
+	if isCSV {
+		queryParams += "&csv=true"
+	}
+	resp, err := ac.get(fmt.Sprintf("projects/%v/flaky_tests?%v", project, queryParams), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("not found")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewAPIError(resp)
+	}
+
+	return resp.Body, nil
+}
+
 // GetPatchModules retrieves a list of modules available for a given patch.
 func (ac *APIClient) GetPatchModules(patchId, projectId string) ([]string, error) {
 	var out []string
@@ -536,3 +580,94 @@ func (ac *APIClient) GetPatchModules(patchId, projectId string) ([]string, error
 
 	return out, nil
 }
+
+// StreamTaskLog opens the server-sent event stream of a task's log. If
+// execution is nil, the latest execution's log is streamed. Unless follow is
+// true, the stream ends after the messages that were already posted.
+func (ac *APIClient) StreamTaskLog(taskId string, execution *int, logType string, follow bool) (io.ReadCloser, error) {
+	params := url.Values{}
+	params.Set("follow", strconv.FormatBool(follow))
+	if execution != nil {
+		params.Set("execution", strconv.Itoa(*execution))
+	}
+	if logType != "" {
+		params.Set("type", logType)
+	}
+	resp, err := ac.get(fmt.Sprintf("tasks/%v/log/stream?%v", taskId, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.Errorf("task %v not found", taskId)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewAPIError(resp)
+	}
+	return resp.Body, nil
+}
+
+// CreateAccessToken creates an access token for the user with the given
+// scope, projects and expiry, which is the zero time for a token that never
+// expires. The returned access token holds the token to authenticate with,
+// which the server does not return again.
+func (ac *APIClient) CreateAccessToken(name, scope string, projects []string, expiresAt time.Time) (*restmodel.APIAccessToken, error) {
+	data := restmodel.APIAccessToken{
+		Name:      restmodel.APIString(name),
+		Scope:     restmodel.APIString(scope),
+		Projects:  projects,
+		ExpiresAt: restmodel.NewTime(expiresAt),
+	}
+	rPipe, wPipe := io.Pipe()
+	encoder := json.NewEncoder(wPipe)
+	go func() {
+		grip.Warning(encoder.Encode(data))
+		grip.Warning(wPipe.Close())
+	}()
+	defer rPipe.Close()
+
+	resp, err := ac.post("tokens", rPipe)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewAPIError(resp)
+	}
+	token := &restmodel.APIAccessToken{}
+	if err := util.ReadJSONInto(resp.Body, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ListAccessTokens returns all of the user's access tokens, sorted by name.
+func (ac *APIClient) ListAccessTokens() ([]restmodel.APIAccessToken, error) {
+	resp, err := ac.get("tokens", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewAPIError(resp)
+	}
+	tokens := []restmodel.APIAccessToken{}
+	if err := util.ReadJSONInto(resp.Body, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeAccessToken revokes the user's access token with the given name.
+func (ac *APIClient) RevokeAccessToken(name string) error {
+	resp, err := ac.delete(fmt.Sprintf("tokens/%v", url.PathEscape(name)), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return errors.Errorf("access token %v not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return NewAPIError(resp)
+	}
+	return resp.Body.Close()
+}
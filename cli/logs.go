@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// LogsCommand prints a task's log, optionally following it as the agent
+// posts new messages.
+type LogsCommand struct {
+	GlobalOpts *Options `no-flag:"true"`
+	Follow     bool     `short:"f" long:"follow" description:"keep printing new messages until the task is finished"`
+	Execution  *int     `short:"e" long:"execution" description:"execution of the task to show the log of (defaults to the latest)"`
+	Type       string   `long:"type" description:"only show messages of this type: T (task), E (agent), S (system) or ALL"`
+	Positional struct {
+		TaskId string `positional-arg-name:"task_id" description:"id of the task to show the log of"`
+	} `positional-args:"1" required:"yes"`
+}
+
+func (lc *LogsCommand) Execute(_ []string) error {
+	_, rc, _, err := getAPIClients(lc.GlobalOpts)
+	if err != nil {
+		return err
+	}
+
+	stream, err := rc.StreamTaskLog(lc.Positional.TaskId, lc.Execution, lc.Type, lc.Follow)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	status, err := readTaskLogStream(stream, func(msg model.LogMessage) {
+		fmt.Printf("[%v] %v\n", msg.Timestamp.Local().Format("2006/01/02 15:04:05.000"), msg.Message)
+	})
+	if err != nil {
+		return err
+	}
+	if lc.Follow && status != "" {
+		fmt.Printf("Task finished with status '%v'\n", status)
+	}
+	return nil
+}
+
+// readTaskLogStream reads the server-sent events of a task log stream,
+// calling f with each log message, until the stream's end event. It returns
+// the task's status as of the end of the stream.
+func readTaskLogStream(stream io.Reader, f func(model.LogMessage)) (string, error) {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	event, data := "", []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// a blank line dispatches the event
+			payload := strings.Join(data, "\n")
+			switch event {
+			case "", "message":
+				if len(data) == 0 {
+					break
+				}
+				msg := model.LogMessage{}
+				if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+					return "", errors.Wrap(err, "error decoding log message")
+				}
+				f(msg)
+			case "end":
+				end := struct {
+					Status string `json:"status"`
+				}{}
+				if err := json.Unmarshal([]byte(payload), &end); err != nil {
+					return "", errors.Wrap(err, "error decoding end of log")
+				}
+				return end.Status, nil
+			}
+			event, data = "", []string{}
+		case strings.HasPrefix(line, ":"):
+			// comments keep the connection alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "error reading log stream")
+	}
+	return "", errors.New("log stream ended unexpectedly")
+}
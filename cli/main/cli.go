@@ -28,6 +28,10 @@ func main() {
 	parser.AddCommand("fetch", "fetch data associated with a task", "", &cli.FetchCommand{GlobalOpts: &opts})
 	parser.AddCommand("export", "export statistics as csv or json for given options", "", &cli.ExportCommand{GlobalOpts: &opts})
 	parser.AddCommand("test-history", "retrieve test history for a given project", "", &cli.TestHistoryCommand{GlobalOpts: &opts})
+	parser.AddCommand("logs", "show a task's log, optionally following it live", "", &cli.LogsCommand{GlobalOpts: &opts})
+	parser.AddCommand("create-token", "create an access token for REST clients", "", &cli.CreateTokenCommand{GlobalOpts: &opts})
+	parser.AddCommand("list-tokens", "list your access tokens", "", &cli.ListTokensCommand{GlobalOpts: &opts})
+	parser.AddCommand("revoke-token", "revoke an access token", "", &cli.RevokeTokenCommand{GlobalOpts: &opts})
 
 	_, err := parser.Parse()
 	if err != nil {
@@ -68,6 +68,7 @@ type patchSubmission struct {
 	variants    string
 	tasks       []string
 	finalize    bool
+	notify      *bool
 }
 
 // ListPatchesCommand is used to list a user's existing patches.
@@ -130,6 +131,7 @@ type PatchCommandParams struct {
 	Description string   `short:"d" long:"description" description:"description of patch (optional)"`
 	Finalize    bool     `short:"f" long:"finalize" description:"schedule tasks immediately"`
 	Large       bool     `long:"large" description:"enable submitting larger patches (>16MB)"`
+	Notify      string   `long:"notify" description:"'always' or 'never' notify you when the patch finishes, overriding your settings"`
 }
 
 // LastGreenCommand contains parameters for the finding a project's most recent passing version.
@@ -561,6 +563,10 @@ func validatePatchCommand(params *PatchCommandParams) (ac *APIClient, settings *
 		return
 	}
 
+	if _, err = params.notifyOverride(); err != nil {
+		return
+	}
+
 	ref, err = ac.GetProjectRef(params.Project)
 	if err != nil {
 		if apiErr, ok := err.(APIError); ok && apiErr.code == http.StatusNotFound {
@@ -616,6 +622,23 @@ func validatePatchCommand(params *PatchCommandParams) (ac *APIClient, settings *
 	return
 }
 
+// notifyOverride returns whether the --notify flag asks to always or never be
+// notified when the patch finishes, or nil if the user's settings apply.
+func (params *PatchCommandParams) notifyOverride() (*bool, error) {
+	var notify bool
+	switch params.Notify {
+	case "":
+		return nil, nil
+	case "always":
+		notify = true
+	case "never":
+		notify = false
+	default:
+		return nil, errors.Errorf("--notify must be 'always' or 'never', not '%v'", params.Notify)
+	}
+	return &notify, nil
+}
+
 // Creates a patch using diffData
 func createPatch(params PatchCommandParams, ac *APIClient, settings *model.CLISettings, diffData *localDiff) error {
 	if err := validatePatchSize(diffData, params.Large); err != nil {
@@ -636,10 +659,15 @@ func createPatch(params PatchCommandParams, ac *APIClient, settings *model.CLISe
 		}
 	}
 
+	notify, err := params.notifyOverride()
+	if err != nil {
+		return err
+	}
+
 	variantsStr := strings.Join(params.Variants, ",")
 	patchSub := patchSubmission{
 		params.Project, diffData.fullPatch, params.Description,
-		diffData.base, variantsStr, params.Tasks, params.Finalize,
+		diffData.base, variantsStr, params.Tasks, params.Finalize, notify,
 	}
 
 	newPatch, err := ac.PutPatch(patchSub)
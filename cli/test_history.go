@@ -2,11 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flaky"
 	"github.com/evergreen-ci/evergreen/service"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/pkg/errors"
@@ -16,6 +19,7 @@ var ()
 
 const (
 	prettyStringFormat = "%-25s %-15s %-40s%-40s %-40s %-40s \n"
+	flakyStringFormat  = "%-8s %-6s %-6s %-25s %-40s %-40s\n"
 	timeFormat         = "2006-01-02T15:04:05"
 	csvFormat          = "csv"
 	prettyFormat       = "pretty"
@@ -40,6 +44,7 @@ type TestHistoryCommand struct {
 	Earliest   bool   `long:"earliest" description:"sort test history from the earliest revisions to latest"`
 	Filepath   string `long:"filepath" description:"path to directory where file is to be saved, only used with json or csv format"`
 	Format     string `long:"format" description:"format to export test history, options are 'json', 'csv', 'pretty', default pretty to stdout"`
+	Flaky      bool   `long:"flaky" description:"list the project's flaky tests instead, narrowed down by --task, --test and --variant"`
 }
 
 // createUrlQuery returns a string url query parameter with relevant url parameters.
@@ -83,6 +88,13 @@ func (thc *TestHistoryCommand) Execute(_ []string) error {
 		return err
 	}
 
+	if thc.Format == "" {
+		thc.Format = prettyFormat
+	}
+	if thc.Flaky {
+		return thc.listFlakyTests(rc)
+	}
+
 	// convert the test and tasks statuses to the correct evergreen statuses
 	testStatuses := []string{}
 	for _, s := range thc.TestStatuses {
@@ -127,9 +139,6 @@ func (thc *TestHistoryCommand) Execute(_ []string) error {
 		return errors.Errorf("before revision must be a 40 character revision")
 	}
 
-	if thc.Format == "" {
-		thc.Format = prettyFormat
-	}
 	beforeDate := time.Time{}
 	if thc.BeforeDate != "" {
 		beforeDate, err = time.Parse(timeFormat, thc.BeforeDate)
@@ -194,3 +203,43 @@ func (thc *TestHistoryCommand) Execute(_ []string) error {
 	return WriteToFile(body, thc.Filepath)
 
 }
+
+// listFlakyTests gets the flakiness of the project's tests that match the
+// command's tasks, tests and variants from the api endpoint.
+func (thc *TestHistoryCommand) listFlakyTests(rc *APIClient) error {
+	if thc.Project == "" {
+		return errors.New("must specify a project")
+	}
+	query := url.Values{}
+	if len(thc.Tasks) > 0 {
+		query.Set("tasks", strings.Join(thc.Tasks, ","))
+	}
+	if len(thc.Tests) > 0 {
+		query.Set("tests", strings.Join(thc.Tests, ","))
+	}
+	if len(thc.Variants) > 0 {
+		query.Set("variants", strings.Join(thc.Variants, ","))
+	}
+
+	body, err := rc.GetFlakyTests(thc.Project, query.Encode(), thc.Format == csvFormat)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if thc.Format == prettyFormat {
+		results := []flaky.TestFlakiness{}
+		if err := util.ReadJSONInto(body, &results); err != nil {
+			return err
+		}
+
+		fmt.Printf(flakyStringFormat, "Score", "Flips", "Runs", "Variant", "Task Name", "Test File")
+		for _, f := range results {
+			fmt.Printf(flakyStringFormat, fmt.Sprintf("%.2f", f.Score), strconv.Itoa(f.Flips),
+				strconv.Itoa(f.Runs), f.BuildVariant, f.TaskName, f.TestFile)
+		}
+		return nil
+	}
+
+	return WriteToFile(body, thc.Filepath)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CreateTokenCommand creates an access token that REST clients, such as CI
+// bots, can make requests with as the user, limited to the token's scope.
+type CreateTokenCommand struct {
+	GlobalOpts *Options      `no-flag:"true"`
+	Scope      string        `short:"s" long:"scope" default:"read" description:"what the token allows: read, project (read, and change the given projects) or admin"`
+	Projects   []string      `short:"p" long:"project" description:"project a project scoped token may change (may be specified multiple times)"`
+	Expires    time.Duration `short:"e" long:"expires" description:"how long until the token expires, e.g. 720h (defaults to never)"`
+	Positional struct {
+		Name string `positional-arg-name:"name" description:"name of the token"`
+	} `positional-args:"1" required:"yes"`
+}
+
+// ListTokensCommand lists the user's access tokens.
+type ListTokensCommand struct {
+	GlobalOpts *Options `no-flag:"true"`
+}
+
+// RevokeTokenCommand revokes one of the user's access tokens.
+type RevokeTokenCommand struct {
+	GlobalOpts *Options `no-flag:"true"`
+	Positional struct {
+		Name string `positional-arg-name:"name" description:"name of the token to revoke"`
+	} `positional-args:"1" required:"yes"`
+}
+
+func (ctc *CreateTokenCommand) Execute(_ []string) error {
+	if ctc.Expires < 0 {
+		return errors.New("token must expire in the future")
+	}
+	client, err := getRESTv2Client(ctc.GlobalOpts)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Time{}
+	if ctc.Expires > 0 {
+		expiresAt = time.Now().Add(ctc.Expires)
+	}
+	token, err := client.CreateAccessToken(ctc.Positional.Name, ctc.Scope, ctc.Projects, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created access token '%v' with scope '%v'.\n", token.Name, token.Scope)
+	fmt.Println("Send it in the Api-Token header of requests. It will not be shown again:")
+	fmt.Println(token.Token)
+	return nil
+}
+
+func (ltc *ListTokensCommand) Execute(_ []string) error {
+	client, err := getRESTv2Client(ltc.GlobalOpts)
+	if err != nil {
+		return err
+	}
+	tokens, err := client.ListAccessTokens()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(len(tokens), "access tokens:")
+	w := new(tabwriter.Writer)
+	// Format in tab-separated columns with a tab stop of 8.
+	w.Init(os.Stdout, 0, 8, 0, '\t', 0)
+	for _, t := range tokens {
+		expires := "never expires"
+		if expiresAt := time.Time(t.ExpiresAt); !expiresAt.IsZero() {
+			expires = "expires " + expiresAt.Local().Format(time.RFC1123)
+		}
+		fmt.Fprintf(w, "\t%v\t%v\t%v\t%v\n", t.Name, t.Scope, strings.Join(t.Projects, ","), expires)
+	}
+	return errors.WithStack(w.Flush())
+}
+
+func (rtc *RevokeTokenCommand) Execute(_ []string) error {
+	client, err := getRESTv2Client(rtc.GlobalOpts)
+	if err != nil {
+		return err
+	}
+	if err = client.RevokeAccessToken(rtc.Positional.Name); err != nil {
+		return err
+	}
+	fmt.Printf("Revoked access token '%v'.\n", rtc.Positional.Name)
+	return nil
+}
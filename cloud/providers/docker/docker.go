@@ -3,7 +3,9 @@ package docker
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -24,6 +26,8 @@ const (
 	DockerStatusPaused
 	DockerStatusRestarting
 	DockerStatusKilled
+	DockerStatusCreated
+	DockerStatusExited
 	DockerStatusUnknown
 
 	ProviderName   = "docker"
@@ -44,6 +48,14 @@ type auth struct {
 	Ca   string `mapstructure:"ca" json:"ca" bson:"ca"`
 }
 
+// mount describes a directory on the Docker host that is mounted into
+// each container.
+type mount struct {
+	Source   string `mapstructure:"source" json:"source" bson:"source"`
+	Target   string `mapstructure:"target" json:"target" bson:"target"`
+	ReadOnly bool   `mapstructure:"read_only" json:"read_only" bson:"read_only"`
+}
+
 type Settings struct {
 	HostIp     string     `mapstructure:"host_ip" json:"host_ip" bson:"host_ip"`
 	BindIp     string     `mapstructure:"bind_ip" json:"bind_ip" bson:"bind_ip"`
@@ -51,6 +63,14 @@ type Settings struct {
 	ClientPort int        `mapstructure:"client_port" json:"client_port" bson:"client_port"`
 	PortRange  *portRange `mapstructure:"port_range" json:"port_range" bson:"port_range"`
 	Auth       *auth      `mapstructure:"auth" json:"auth" bson:"auth"`
+
+	// Resource limits and runtime settings applied to each container, so
+	// that many containers can share a single large Docker host.
+	CPUShares   int64             `mapstructure:"cpu_shares" json:"cpu_shares" bson:"cpu_shares"`
+	MemoryMB    int64             `mapstructure:"memory_mb" json:"memory_mb" bson:"memory_mb"`
+	Mounts      []mount           `mapstructure:"mounts" json:"mounts" bson:"mounts"`
+	NetworkMode string            `mapstructure:"network_mode" json:"network_mode" bson:"network_mode"`
+	Env         map[string]string `mapstructure:"env" json:"env" bson:"env"`
 }
 
 var (
@@ -62,6 +82,12 @@ var (
 	PortRange  = bsonutil.MustHaveTag(Settings{}, "PortRange")
 	Auth       = bsonutil.MustHaveTag(Settings{}, "Auth")
 
+	CPUShares   = bsonutil.MustHaveTag(Settings{}, "CPUShares")
+	MemoryMB    = bsonutil.MustHaveTag(Settings{}, "MemoryMB")
+	Mounts      = bsonutil.MustHaveTag(Settings{}, "Mounts")
+	NetworkMode = bsonutil.MustHaveTag(Settings{}, "NetworkMode")
+	Env         = bsonutil.MustHaveTag(Settings{}, "Env")
+
 	// bson fields for the portRange struct
 	MinPort = bsonutil.MustHaveTag(portRange{}, "MinPort")
 	MaxPort = bsonutil.MustHaveTag(portRange{}, "MaxPort")
@@ -71,6 +97,11 @@ var (
 	Key  = bsonutil.MustHaveTag(auth{}, "Key")
 	Ca   = bsonutil.MustHaveTag(auth{}, "Ca")
 
+	// bson fields for the mount struct
+	MountSource   = bsonutil.MustHaveTag(mount{}, "Source")
+	MountTarget   = bsonutil.MustHaveTag(mount{}, "Target")
+	MountReadOnly = bsonutil.MustHaveTag(mount{}, "ReadOnly")
+
 	// exposed port (set to 22/tcp, default ssh port)
 	SSHDPort docker.Port = "22/tcp"
 )
@@ -105,11 +136,12 @@ func generateClient(d *distro.Distro) (*docker.Client, *Settings, error) {
 	return client, settings, err
 }
 
-func populateHostConfig(hostConfig *docker.HostConfig, d *distro.Distro) error {
-	// Retrieve client for API call and settings
-	client, settings, err := generateClient(d)
-	if err != nil {
-		return errors.WithStack(err)
+func populateHostConfig(hostConfig *docker.HostConfig, client *docker.Client, settings *Settings) error {
+	populateResourceSettings(hostConfig, settings)
+
+	if settings.PortRange == nil {
+		hostConfig.PublishAllPorts = true
+		return nil
 	}
 	minPort := settings.PortRange.MinPort
 	maxPort := settings.PortRange.MaxPort
@@ -159,6 +191,33 @@ func populateHostConfig(hostConfig *docker.HostConfig, d *distro.Distro) error {
 	return nil
 }
 
+// populateResourceSettings applies the distro's resource limits, mounts and
+// network mode to the container's host config.
+func populateResourceSettings(hostConfig *docker.HostConfig, settings *Settings) {
+	hostConfig.CPUShares = settings.CPUShares
+	hostConfig.Memory = settings.MemoryMB * 1024 * 1024
+	hostConfig.NetworkMode = settings.NetworkMode
+	hostConfig.Binds = make([]string, 0, len(settings.Mounts))
+	for _, m := range settings.Mounts {
+		bind := fmt.Sprintf("%s:%s", m.Source, m.Target)
+		if m.ReadOnly {
+			bind += ":ro"
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+}
+
+// containerEnv returns the distro's container environment in the KEY=value
+// form Docker expects, sorted so that it is deterministic.
+func containerEnv(settings *Settings) []string {
+	env := make([]string, 0, len(settings.Env))
+	for k, v := range settings.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(env)
+	return env
+}
+
 func retrieveOpenPortBinding(containerPtr *docker.Container) (string, error) {
 	exposedPorts := containerPtr.Config.ExposedPorts
 	ports := containerPtr.NetworkSettings.Ports
@@ -208,6 +267,37 @@ func (settings *Settings) Validate() error {
 		return errors.New("Certificate authority must not be blank")
 	}
 
+	if settings.CPUShares < 0 {
+		return errors.New("CPU shares must not be negative")
+	}
+
+	// Docker refuses memory limits below 4MB
+	if settings.MemoryMB < 0 || settings.MemoryMB > 0 && settings.MemoryMB < 4 {
+		return errors.New("Memory limit must be at least 4MB")
+	}
+
+	for _, m := range settings.Mounts {
+		if m.Source == "" || m.Target == "" {
+			return errors.New("Mounts must have a source and a target")
+		}
+		if !filepath.IsAbs(m.Target) {
+			return errors.Errorf("Mount target '%s' must be an absolute path", m.Target)
+		}
+	}
+
+	// containers are reached over SSH through a published port, which is not
+	// possible without their own network namespace
+	if settings.NetworkMode == "host" || settings.NetworkMode == "none" {
+		return errors.Errorf("Network mode '%s' cannot be used for hosts reached over SSH",
+			settings.NetworkMode)
+	}
+
+	for k := range settings.Env {
+		if k == "" || strings.Contains(k, "=") {
+			return errors.Errorf("Invalid environment variable name '%s'", k)
+		}
+	}
+
 	return nil
 }
 
@@ -231,20 +321,22 @@ func (dockerMgr *DockerManager) SpawnInstance(d *distro.Distro, hostOpts cloud.H
 
 	// Create HostConfig structure
 	hostConfig := &docker.HostConfig{}
-	err = populateHostConfig(hostConfig, d)
+	err = populateHostConfig(hostConfig, dockerClient, settings)
 	if err != nil {
 		err = errors.Wrapf(err, "Unable to populate docker host config for host '%s'", settings.HostIp)
 		grip.Error(err)
 		return nil, err
 	}
 
-	// Build container
+	// Build container. The container's name is used as the host id, so that
+	// the container can be found again by later API calls.
 	containerName := "docker-" + bson.NewObjectId().Hex()
 	newContainer, err := dockerClient.CreateContainer(
 		docker.CreateContainerOptions{
 			Name: containerName,
 			Config: &docker.Config{
 				Cmd: []string{"/usr/sbin/sshd", "-D"},
+				Env: containerEnv(settings),
 				ExposedPorts: map[docker.Port]struct{}{
 					SSHDPort: {},
 				},
@@ -264,13 +356,7 @@ func (dockerMgr *DockerManager) SpawnInstance(d *distro.Distro, hostOpts cloud.H
 	if err != nil {
 		err = errors.Wrapf(err, "Docker start container API call failed for host '%s'", settings.HostIp)
 		// Clean up
-		err2 := dockerClient.RemoveContainer(
-			docker.RemoveContainerOptions{
-				ID:    newContainer.ID,
-				Force: true,
-			},
-		)
-		if err2 != nil {
+		if err2 := removeContainer(dockerClient, newContainer.ID); err2 != nil {
 			err = errors.Errorf("start container error: %+v;\nunable to cleanup: %+v", err, err2)
 		}
 		grip.Error(err)
@@ -288,20 +374,19 @@ func (dockerMgr *DockerManager) SpawnInstance(d *distro.Distro, hostOpts cloud.H
 	hostPort, err := retrieveOpenPortBinding(newContainer)
 	if err != nil {
 		grip.Errorf("Error with docker container '%v': %v", newContainer.ID, err)
+		grip.Error(removeContainer(dockerClient, newContainer.ID))
 		return nil, err
 	}
 
 	hostStr := fmt.Sprintf("%s:%s", settings.BindIp, hostPort)
 	// Add host info to db
-	instanceName := "container-" +
-		fmt.Sprintf("%d", rand.New(rand.NewSource(time.Now().UnixNano())).Int())
-
-	intentHost := cloud.NewIntent(*d, instanceName, ProviderName, hostOpts)
+	intentHost := cloud.NewIntent(*d, containerName, ProviderName, hostOpts)
 	intentHost.Host = hostStr
 
 	err = errors.Wrapf(intentHost.Insert(), "failed to insert new host '%s'", intentHost.Id)
 	if err != nil {
 		grip.Error(err)
+		grip.Error(removeContainer(dockerClient, newContainer.ID))
 		return nil, err
 	}
 
@@ -312,46 +397,85 @@ func (dockerMgr *DockerManager) SpawnInstance(d *distro.Distro, hostOpts cloud.H
 // getStatus is a helper function which returns the enum representation of the status
 // contained in a container's state
 func getStatus(s *docker.State) int {
-	if s.Running {
-		return DockerStatusRunning
-	} else if s.Paused {
+	// Docker reports paused and restarting containers as running too, so
+	// those states must be checked first
+	if s.Paused {
 		return DockerStatusPaused
 	} else if s.Restarting {
 		return DockerStatusRestarting
+	} else if s.Running {
+		return DockerStatusRunning
 	} else if s.OOMKilled {
 		return DockerStatusKilled
+	} else if s.StartedAt.IsZero() {
+		return DockerStatusCreated
+	} else if !s.FinishedAt.IsZero() {
+		return DockerStatusExited
 	}
 
 	return DockerStatusUnknown
 }
 
-// GetInstanceStatus returns a universal status code representing the state
-// of a container.
-func (dockerMgr *DockerManager) GetInstanceStatus(host *host.Host) (cloud.CloudStatus, error) {
-	dockerClient, _, err := generateClient(&host.Distro)
-	if err != nil {
-		return cloud.StatusUnknown, err
-	}
-
-	container, err := dockerClient.InspectContainer(host.Id)
+// containerStatus inspects a container and maps its state to a cloud status.
+// A container that no longer exists is reported as terminated.
+func containerStatus(client *docker.Client, id string) (cloud.CloudStatus, error) {
+	container, err := client.InspectContainer(id)
 	if err != nil {
-		return cloud.StatusUnknown, errors.Wrapf(err, "Failed to get container information for host '%v'", host.Id)
+		if _, ok := err.(*docker.NoSuchContainer); ok {
+			return cloud.StatusTerminated, nil
+		}
+		return cloud.StatusUnknown, errors.Wrapf(err, "Failed to get container information for host '%v'", id)
 	}
 
 	switch getStatus(&container.State) {
-	case DockerStatusRestarting:
+	case DockerStatusCreated, DockerStatusRestarting:
 		return cloud.StatusInitializing, nil
 	case DockerStatusRunning:
 		return cloud.StatusRunning, nil
 	case DockerStatusPaused:
 		return cloud.StatusStopped, nil
-	case DockerStatusKilled:
+	case DockerStatusKilled, DockerStatusExited:
 		return cloud.StatusTerminated, nil
 	default:
 		return cloud.StatusUnknown, nil
 	}
 }
 
+// removeContainer stops a container and removes it along with its volumes.
+// Containers that are already stopped or removed are not an error.
+func removeContainer(client *docker.Client, id string) error {
+	err := client.StopContainer(id, TimeoutSeconds)
+	switch err.(type) {
+	case nil, *docker.ContainerNotRunning:
+	case *docker.NoSuchContainer:
+		return nil
+	default:
+		grip.Warningf("failed to stop container '%s', forcing removal: %+v", id, err)
+	}
+
+	err = client.RemoveContainer(
+		docker.RemoveContainerOptions{
+			ID:            id,
+			RemoveVolumes: true,
+			Force:         true,
+		})
+	if _, ok := err.(*docker.NoSuchContainer); ok {
+		return nil
+	}
+	return errors.Wrapf(err, "Failed to remove container '%s'", id)
+}
+
+// GetInstanceStatus returns a universal status code representing the state
+// of a container.
+func (dockerMgr *DockerManager) GetInstanceStatus(host *host.Host) (cloud.CloudStatus, error) {
+	dockerClient, _, err := generateClient(&host.Distro)
+	if err != nil {
+		return cloud.StatusUnknown, err
+	}
+
+	return containerStatus(dockerClient, host.Id)
+}
+
 //GetDNSName gets the DNS hostname of a container by reading it directly from
 //the Docker API
 func (dockerMgr *DockerManager) GetDNSName(host *host.Host) (string, error) {
@@ -364,27 +488,14 @@ func (dockerMgr *DockerManager) CanSpawn() (bool, error) {
 	return true, nil
 }
 
-//TerminateInstance destroys a container.
+//TerminateInstance stops and removes a container, along with its volumes.
 func (dockerMgr *DockerManager) TerminateInstance(host *host.Host) error {
 	dockerClient, _, err := generateClient(&host.Distro)
 	if err != nil {
 		return err
 	}
 
-	if err != nil {
-		err = errors.Wrapf(dockerClient.StopContainer(host.Id, TimeoutSeconds),
-			"failed to stop container '%s'", host.Id)
-		grip.Error(err)
-		return err
-	}
-
-	err = dockerClient.RemoveContainer(
-		docker.RemoveContainerOptions{
-			ID: host.Id,
-		})
-
-	if err != nil {
-		err = errors.Wrapf(err, "Failed to remove container '%s'", host.Id)
+	if err = removeContainer(dockerClient, host.Id); err != nil {
 		grip.Error(err)
 		return err
 	}
@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/cloud"
+	docker "github.com/fsouza/go-dockerclient"
+	dockertest "github.com/fsouza/go-dockerclient/testing"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func validSettings() *Settings {
+	return &Settings{
+		HostIp:     "localhost",
+		BindIp:     "127.0.0.1",
+		ImageId:    "image",
+		ClientPort: 2376,
+		Auth:       &auth{Cert: "cert", Key: "key", Ca: "ca"},
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	Convey("With docker distro settings", t, func() {
+		settings := validSettings()
+
+		Convey("resource limits, mounts and env should be valid", func() {
+			settings.CPUShares = 512
+			settings.MemoryMB = 1024
+			settings.Mounts = []mount{{Source: "/data", Target: "/data", ReadOnly: true}}
+			settings.NetworkMode = "bridge"
+			settings.Env = map[string]string{"FOO": "bar"}
+			So(settings.Validate(), ShouldBeNil)
+		})
+
+		Convey("negative cpu shares or tiny memory limits should be invalid", func() {
+			settings.CPUShares = -1
+			So(settings.Validate(), ShouldNotBeNil)
+			settings.CPUShares = 0
+			settings.MemoryMB = 2
+			So(settings.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("mounts must have a source and an absolute target", func() {
+			settings.Mounts = []mount{{Source: "/data"}}
+			So(settings.Validate(), ShouldNotBeNil)
+			settings.Mounts = []mount{{Source: "/data", Target: "data"}}
+			So(settings.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("network modes without a published ssh port should be invalid", func() {
+			settings.NetworkMode = "host"
+			So(settings.Validate(), ShouldNotBeNil)
+			settings.NetworkMode = "none"
+			So(settings.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("env names must not be blank or contain '='", func() {
+			settings.Env = map[string]string{"A=B": "c"}
+			So(settings.Validate(), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGetStatus(t *testing.T) {
+	Convey("Container states should map to the right status", t, func() {
+		now := time.Now()
+		So(getStatus(&docker.State{Running: true, StartedAt: now}), ShouldEqual, DockerStatusRunning)
+		So(getStatus(&docker.State{Running: true, Paused: true, StartedAt: now}), ShouldEqual, DockerStatusPaused)
+		So(getStatus(&docker.State{Running: true, Restarting: true, StartedAt: now}), ShouldEqual, DockerStatusRestarting)
+		So(getStatus(&docker.State{OOMKilled: true, StartedAt: now, FinishedAt: now}), ShouldEqual, DockerStatusKilled)
+		So(getStatus(&docker.State{}), ShouldEqual, DockerStatusCreated)
+		So(getStatus(&docker.State{StartedAt: now, FinishedAt: now}), ShouldEqual, DockerStatusExited)
+	})
+}
+
+func TestContainerLifecycle(t *testing.T) {
+	Convey("With a fake Docker API server", t, func() {
+		server, err := dockertest.NewServer("127.0.0.1:0", nil, nil)
+		So(err, ShouldBeNil)
+		defer server.Stop()
+		client, err := docker.NewClient(server.URL())
+		So(err, ShouldBeNil)
+		So(client.PullImage(docker.PullImageOptions{Repository: "image"}, docker.AuthConfiguration{}), ShouldBeNil)
+
+		settings := validSettings()
+		settings.CPUShares = 256
+		settings.MemoryMB = 512
+		settings.NetworkMode = "bridge"
+		settings.Mounts = []mount{
+			{Source: "/srv/cache", Target: "/cache"},
+			{Source: "/srv/data", Target: "/data", ReadOnly: true},
+		}
+		settings.Env = map[string]string{"B": "2", "A": "1"}
+
+		Convey("the host config should include resource limits and mounts", func() {
+			hostConfig := &docker.HostConfig{}
+			So(populateHostConfig(hostConfig, client, settings), ShouldBeNil)
+			So(hostConfig.CPUShares, ShouldEqual, 256)
+			So(hostConfig.Memory, ShouldEqual, 512*1024*1024)
+			So(hostConfig.NetworkMode, ShouldEqual, "bridge")
+			So(hostConfig.Binds, ShouldResemble, []string{"/srv/cache:/cache", "/srv/data:/data:ro"})
+			So(hostConfig.PublishAllPorts, ShouldBeTrue)
+			So(containerEnv(settings), ShouldResemble, []string{"A=1", "B=2"})
+
+			Convey("and bind the first free port in the port range", func() {
+				settings.PortRange = &portRange{MinPort: 5000, MaxPort: 5010}
+				hostConfig = &docker.HostConfig{}
+				So(populateHostConfig(hostConfig, client, settings), ShouldBeNil)
+				So(hostConfig.PortBindings[SSHDPort], ShouldResemble,
+					[]docker.PortBinding{{HostIP: "127.0.0.1", HostPort: "5000"}})
+			})
+		})
+
+		Convey("container status should follow the container's lifecycle", func() {
+			_, err := client.CreateContainer(docker.CreateContainerOptions{
+				Name:   "docker-test",
+				Config: &docker.Config{Image: "image"},
+			})
+			So(err, ShouldBeNil)
+			So(client.StartContainer("docker-test", nil), ShouldBeNil)
+
+			status, err := containerStatus(client, "docker-test")
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, cloud.StatusRunning)
+
+			So(client.PauseContainer("docker-test"), ShouldBeNil)
+			status, err = containerStatus(client, "docker-test")
+			So(err, ShouldBeNil)
+			So(status, ShouldEqual, cloud.StatusStopped)
+			So(client.UnpauseContainer("docker-test"), ShouldBeNil)
+
+			Convey("and removed containers should be reported as terminated", func() {
+				So(removeContainer(client, "docker-test"), ShouldBeNil)
+				status, err = containerStatus(client, "docker-test")
+				So(err, ShouldBeNil)
+				So(status, ShouldEqual, cloud.StatusTerminated)
+
+				// removing a container twice is not an error
+				So(removeContainer(client, "docker-test"), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 
 	"github.com/pkg/errors"
 
@@ -148,6 +149,12 @@ type SMTPConfig struct {
 type SchedulerConfig struct {
 	LogFile     string
 	MergeToggle int
+
+	// FairShare interleaves each distro's queue across projects according
+	// to their share weights and the host time they have recently used,
+	// measured over the last FairShareWindowHours (default 24).
+	FairShare            bool
+	FairShareWindowHours int
 }
 
 // TaskRunnerConfig holds logging settings for the scheduler process.
@@ -184,6 +191,34 @@ type JiraConfig struct {
 // manually by their respective plugins
 type PluginConfig map[string]map[string]interface{}
 
+// FlakyTestsConfig holds settings for the process that detects flaky tests.
+type FlakyTestsConfig struct {
+	// IntervalMinutes is how often flakiness is recomputed (default 60), and
+	// WindowDays how many days of mainline test results it considers (default 14).
+	IntervalMinutes int
+	WindowDays      int
+}
+
+// LogStorageConfig holds settings for where task logs are stored.
+type LogStorageConfig struct {
+	// Backend is "mongo" (the default), "filesystem" or "s3".
+	Backend string `yaml:"backend"`
+	// Path is the directory the filesystem backend stores logs in.
+	Path string             `yaml:"path"`
+	S3   LogStorageS3Config `yaml:"s3"`
+}
+
+// LogStorageS3Config holds settings for storing task logs in an S3 bucket.
+// Endpoint is only needed for servers with an S3-compatible API.
+type LogStorageS3Config struct {
+	Bucket   string `yaml:"bucket"`
+	Prefix   string `yaml:"prefix"`
+	Region   string `yaml:"region"`
+	Endpoint string `yaml:"endpoint"`
+	Key      string `yaml:"key"`
+	Secret   string `yaml:"secret"`
+}
+
 type AlertsConfig struct {
 	LogFile string
 	SMTP    *SMTPConfig `yaml:"smtp"`
@@ -203,6 +238,26 @@ type DBSettings struct {
 	WriteConcernSettings WriteConcern `yaml:"write_concern"`
 }
 
+// UserGroups maps the names of groups of users, which can be given roles in
+// projects, to the ids of their members.
+type UserGroups map[string][]string
+
+// GroupsForUser returns the names of the groups that the user is a member
+// of, sorted by name.
+func (groups UserGroups) GroupsForUser(userId string) []string {
+	names := []string{}
+	for name, members := range groups {
+		for _, member := range members {
+			if member == userId {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Settings contains all configuration settings for running Evergreen.
 type Settings struct {
 	Database            DBSettings        `yaml:"database"`
@@ -212,10 +267,12 @@ type Settings struct {
 	AgentExecutablesDir string            `yaml:"agentexecutablesdir"`
 	ClientBinariesDir   string            `yaml:"client_binaries_dir"`
 	SuperUsers          []string          `yaml:"superusers"`
+	UserGroups          UserGroups        `yaml:"user_groups"`
 	Jira                JiraConfig        `yaml:"jira"`
 	Providers           CloudProviders    `yaml:"providers"`
 	Keys                map[string]string `yaml:"keys"`
 	Credentials         map[string]string `yaml:"credentials"`
+	ProjectVarsKey      string            `yaml:"project_vars_key"`
 	AuthConfig          AuthConfig        `yaml:"auth"`
 	RepoTracker         RepoTrackerConfig `yaml:"repotracker"`
 	Monitor             MonitorConfig     `yaml:"monitor"`
@@ -226,6 +283,8 @@ type Settings struct {
 	Notify              NotifyConfig      `yaml:"notify"`
 	Runner              RunnerConfig      `yaml:"runner"`
 	Scheduler           SchedulerConfig   `yaml:"scheduler"`
+	FlakyTests          FlakyTestsConfig  `yaml:"flakytests"`
+	LogStorage          LogStorageConfig  `yaml:"log_storage"`
 	TaskRunner          TaskRunnerConfig  `yaml:"taskrunner"`
 	Expansions          map[string]string `yaml:"expansions"`
 	Plugins             PluginConfig      `yaml:"plugins"`
@@ -375,4 +434,22 @@ var ConfigValidationRules = []ConfigValidator{
 		}
 		return nil
 	},
+
+	func(settings *Settings) error {
+		logStorage := settings.LogStorage
+		switch logStorage.Backend {
+		case "", "mongo":
+		case "filesystem":
+			if logStorage.Path == "" {
+				return errors.New("You must specify a path for filesystem log storage")
+			}
+		case "s3":
+			if logStorage.S3.Bucket == "" {
+				return errors.New("You must specify a bucket for s3 log storage")
+			}
+		default:
+			return errors.Errorf("Unknown log storage backend '%v'", logStorage.Backend)
+		}
+		return nil
+	},
 }
@@ -25,3 +25,20 @@ func TestBadInit(t *testing.T) {
 		So(err, ShouldNotBeNil)
 	})
 }
+
+func TestGroupsForUser(t *testing.T) {
+	Convey("With user groups", t, func() {
+		groups := UserGroups{
+			"dev-team":   {"alice", "bob"},
+			"build-team": {"bob"},
+		}
+		Convey("users should be in each group that lists them, sorted by name", func() {
+			So(groups.GroupsForUser("bob"), ShouldResemble, []string{"build-team", "dev-team"})
+			So(groups.GroupsForUser("alice"), ShouldResemble, []string{"dev-team"})
+		})
+		Convey("other users should be in no groups", func() {
+			So(groups.GroupsForUser("carol"), ShouldBeEmpty)
+			So(UserGroups(nil).GroupsForUser("alice"), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,90 @@
+// Package flakytests periodically scores how flaky the tests of each
+// project's mainline are, so that flaky failures can be told apart from
+// real ones.
+package flakytests
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flaky"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	RunnerName  = "flakytests"
+	Description = "score the flakiness of tests from their history"
+
+	defaultIntervalMinutes = 60
+	defaultWindowDays      = 14
+)
+
+// Runner recomputes test flakiness for every tracked project. Since the
+// computation is expensive, it only runs once per configured interval even
+// though the runner is invoked more often.
+type Runner struct {
+	lastComputed time.Time
+}
+
+func (r *Runner) Name() string {
+	return RunnerName
+}
+
+func (r *Runner) Description() string {
+	return Description
+}
+
+func (r *Runner) Run(config *evergreen.Settings) error {
+	startTime := time.Now()
+
+	interval := time.Duration(config.FlakyTests.IntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = defaultIntervalMinutes * time.Minute
+	}
+	if startTime.Sub(r.lastComputed) >= interval {
+		grip.Infoln("Starting flaky test detection at time", startTime)
+		if err := UpdateAllProjects(config); err != nil {
+			err = errors.Wrap(err, "error detecting flaky tests")
+			grip.Error(err)
+			return err
+		}
+		r.lastComputed = startTime
+	}
+
+	runtime := time.Since(startTime)
+	if err := model.SetProcessRuntimeCompleted(RunnerName, runtime); err != nil {
+		grip.Errorf("error updating process status: %+v", err)
+	}
+	grip.Infof("Flaky test detection took %s to run", runtime)
+	return nil
+}
+
+// UpdateAllProjects recomputes the test flakiness of every tracked project.
+func UpdateAllProjects(config *evergreen.Settings) error {
+	windowDays := config.FlakyTests.WindowDays
+	if windowDays <= 0 {
+		windowDays = defaultWindowDays
+	}
+	since := time.Now().AddDate(0, 0, -windowDays)
+
+	projects, err := model.FindAllTrackedProjectRefs()
+	if err != nil {
+		return errors.Wrap(err, "error finding tracked projects")
+	}
+
+	catcher := grip.NewCatcher()
+	for _, project := range projects {
+		if !project.Enabled {
+			continue
+		}
+		flips, err := flaky.UpdateProjectFlakiness(project.Identifier, since)
+		if err != nil {
+			catcher.Add(errors.Wrapf(err, "error updating test flakiness for project %v", project.Identifier))
+			continue
+		}
+		grip.Infof("Found %d tests that flipped in project %v", len(flips), project.Identifier)
+	}
+	return catcher.Resolve()
+}
@@ -74,6 +74,8 @@ const (
 	DefaultTaskActivator   = ""
 	StepbackTaskActivator  = "stepback"
 	APIServerTaskActivator = "apiserver"
+	RetryTaskActivator     = "retry"
+	BisectTaskActivator    = "bisect"
 
 	RestRoutePrefix = "rest"
 
@@ -19,6 +19,7 @@ var (
 	FirstTaskTypeFailureId = "first_tasktype_failure"
 	TaskFailTransitionId   = "task_transition_failure"
 	LastRevisionNotFound   = "last_revision_not_found"
+	BisectCulpritFoundId   = "bisect_culprit_found"
 )
 
 // Host triggers
@@ -30,6 +31,11 @@ var (
 	ProvisionFailed            = "provision_failed"
 )
 
+// Patch triggers
+var (
+	PatchFinishedId = "patch_finished"
+)
+
 type AlertRecord struct {
 	Id                  bson.ObjectId `bson:"_id"`
 	Type                string        `bson:"type"`
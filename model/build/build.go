@@ -23,6 +23,14 @@ type TaskCache struct {
 	StartTime     time.Time               `bson:"st" json:"start_time"`
 	TimeTaken     time.Duration           `bson:"tt" json:"time_taken"`
 	Activated     bool                    `bson:"a" json:"activated"`
+
+	// DisplayTask is the name of the display task that the task is shown
+	// as part of, if any.
+	DisplayTask string `bson:"dt,omitempty" json:"display_task,omitempty"`
+
+	// ExecutionTasks holds the ids of the tasks that make up a display task,
+	// and is only set in the build's display task caches.
+	ExecutionTasks []string `bson:"et,omitempty" json:"execution_tasks,omitempty"`
 }
 
 // Build represents a set of tasks on one variant of a Project
@@ -45,6 +53,7 @@ type Build struct {
 	ActivatedTime       time.Time     `bson:"activated_time" json:"activated_time,omitempty"`
 	RevisionOrderNumber int           `bson:"order,omitempty" json:"order,omitempty"`
 	Tasks               []TaskCache   `bson:"tasks" json:"tasks,omitempty"`
+	DisplayTasks        []TaskCache   `bson:"display_tasks,omitempty" json:"display_tasks,omitempty"`
 	TimeTaken           time.Duration `bson:"time_taken" json:"time_taken,omitempty"`
 	DisplayName         string        `bson:"display_name" json:"display_name,omitempty"`
 	PredictedMakespan   time.Duration `bson:"predicted_makespan" json:"predicted_makespan,omitempty"`
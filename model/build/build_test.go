@@ -1,10 +1,12 @@
 package build
 
 import (
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
@@ -533,3 +535,137 @@ func TestBuildUpdateStatus(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateDisplayTasksCache(t *testing.T) {
+	Convey("With a build whose tasks include execution tasks of display tasks", t, func() {
+		now := time.Now()
+		b := &Build{
+			Tasks: []TaskCache{
+				{Id: "compile", DisplayName: "compile", Status: evergreen.TaskSucceeded, Activated: true},
+				{Id: "t1", DisplayName: "test1", DisplayTask: "tests", Status: evergreen.TaskSucceeded,
+					Activated: true, StartTime: now, TimeTaken: time.Minute},
+				{Id: "lint", DisplayName: "lint", Status: evergreen.TaskUndispatched, Activated: true},
+				{Id: "t2", DisplayName: "test2", DisplayTask: "tests", Status: evergreen.TaskSucceeded,
+					Activated: true, StartTime: now.Add(-time.Minute), TimeTaken: 2 * time.Minute},
+			},
+		}
+
+		Convey("a display task should only succeed once all its tasks have", func() {
+			displayTasks := CreateDisplayTasksCache(b.Tasks)
+			So(len(displayTasks), ShouldEqual, 1)
+			So(displayTasks[0].DisplayName, ShouldEqual, "tests")
+			So(displayTasks[0].Status, ShouldEqual, evergreen.TaskSucceeded)
+			So(displayTasks[0].ExecutionTasks, ShouldResemble, []string{"t1", "t2"})
+			So(displayTasks[0].StartTime, ShouldResemble, now.Add(-time.Minute))
+			So(displayTasks[0].TimeTaken, ShouldEqual, 3*time.Minute)
+			So(displayTasks[0].Activated, ShouldBeTrue)
+
+			b.Tasks[3].Status = evergreen.TaskUndispatched
+			So(CreateDisplayTasksCache(b.Tasks)[0].Status, ShouldEqual, evergreen.TaskStarted)
+		})
+
+		Convey("a display task should be started while any of its tasks are running", func() {
+			b.Tasks[1].Status = evergreen.TaskFailed
+			b.Tasks[3].Status = evergreen.TaskDispatched
+			displayTasks := CreateDisplayTasksCache(b.Tasks)
+			So(displayTasks[0].Status, ShouldEqual, evergreen.TaskStarted)
+			So(displayTasks[0].Id, ShouldEqual, "t2")
+
+			Convey("and failed once they are finished and any of them failed", func() {
+				b.Tasks[3].Status = evergreen.TaskSucceeded
+				displayTasks = CreateDisplayTasksCache(b.Tasks)
+				So(displayTasks[0].Status, ShouldEqual, evergreen.TaskFailed)
+				So(displayTasks[0].Id, ShouldEqual, "t1")
+			})
+		})
+
+		Convey("inactive execution tasks should not count towards the status", func() {
+			b.Tasks[1].Status = evergreen.TaskUndispatched
+			b.Tasks[1].Activated = false
+			So(CreateDisplayTasksCache(b.Tasks)[0].Status, ShouldEqual, evergreen.TaskSucceeded)
+		})
+
+		Convey("displayed tasks should replace execution tasks with their display task", func() {
+			displayed := b.DisplayedTasks()
+			So(len(displayed), ShouldEqual, 3)
+			So(displayed[0].DisplayName, ShouldEqual, "compile")
+			So(displayed[1].DisplayName, ShouldEqual, "tests")
+			So(displayed[2].DisplayName, ShouldEqual, "lint")
+		})
+	})
+}
+
+func TestTaskCacheUpdatesRefreshDisplayTasks(t *testing.T) {
+	Convey("With a build that has a display task", t, func() {
+		testutil.HandleTestingErr(db.Clear(Collection), t, "Error clearing '%v' collection", Collection)
+		b := &Build{
+			Id: "displayBuild",
+			Tasks: []TaskCache{
+				{Id: "compile", DisplayName: "compile", Status: evergreen.TaskUndispatched, Activated: true},
+				{Id: "t1", DisplayName: "test1", DisplayTask: "tests", Status: evergreen.TaskUndispatched,
+					Activated: true},
+				{Id: "t2", DisplayName: "test2", DisplayTask: "tests", Status: evergreen.TaskUndispatched,
+					Activated: true},
+			},
+		}
+		b.DisplayTasks = CreateDisplayTasksCache(b.Tasks)
+		So(b.Insert(), ShouldBeNil)
+
+		displayTask := func() TaskCache {
+			dbBuild, err := FindOne(ById(b.Id))
+			So(err, ShouldBeNil)
+			So(len(dbBuild.DisplayTasks), ShouldEqual, 1)
+			return dbBuild.DisplayTasks[0]
+		}
+
+		Convey("the display task should follow its execution tasks' status changes", func() {
+			So(SetCachedTaskStarted(b.Id, "t1", time.Now()), ShouldBeNil)
+			So(displayTask().Status, ShouldEqual, evergreen.TaskStarted)
+
+			failed := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed}
+			So(SetCachedTaskFinished(b.Id, "t1", failed, time.Minute), ShouldBeNil)
+			succeeded := &apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded}
+			So(SetCachedTaskFinished(b.Id, "t2", succeeded, time.Minute), ShouldBeNil)
+			So(displayTask().Status, ShouldEqual, evergreen.TaskFailed)
+			So(displayTask().Id, ShouldEqual, "t1")
+
+			So(ResetCachedTask(b.Id, "t1"), ShouldBeNil)
+			So(displayTask().Status, ShouldEqual, evergreen.TaskStarted)
+		})
+
+		Convey("concurrently finished execution tasks should all be rolled up", func() {
+			wg := sync.WaitGroup{}
+			for _, id := range []string{"t1", "t2"} {
+				wg.Add(1)
+				go func(id string) {
+					defer wg.Done()
+					succeeded := &apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded}
+					testutil.HandleTestingErr(SetCachedTaskFinished(b.Id, id, succeeded, time.Minute), t,
+						"Error finishing task '%v'", id)
+				}(id)
+			}
+			wg.Wait()
+			So(displayTask().Status, ShouldEqual, evergreen.TaskSucceeded)
+		})
+
+		Convey("only the updated task's display task cache should change", func() {
+			other := TaskCache{DisplayName: "other", Status: evergreen.TaskFailed, ExecutionTasks: []string{"o1"}}
+			So(UpdateOne(bson.M{IdKey: b.Id}, bson.M{"$push": bson.M{DisplayTasksKey: other}}), ShouldBeNil)
+			So(SetCachedTaskStarted(b.Id, "t1", time.Now()), ShouldBeNil)
+			dbBuild, err := FindOne(ById(b.Id))
+			So(err, ShouldBeNil)
+			So(len(dbBuild.DisplayTasks), ShouldEqual, 2)
+			So(dbBuild.DisplayTasks[0].Status, ShouldEqual, evergreen.TaskStarted)
+			So(dbBuild.DisplayTasks[1].Status, ShouldEqual, evergreen.TaskFailed)
+		})
+
+		Convey("builds without display tasks should not get any", func() {
+			plain := &Build{Id: "plainBuild", Tasks: []TaskCache{{Id: "compile", DisplayName: "compile"}}}
+			So(plain.Insert(), ShouldBeNil)
+			So(SetCachedTaskStarted(plain.Id, "compile", time.Now()), ShouldBeNil)
+			dbBuild, err := FindOne(ById(plain.Id))
+			So(err, ShouldBeNil)
+			So(dbBuild.DisplayTasks, ShouldBeEmpty)
+		})
+	})
+}
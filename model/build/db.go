@@ -31,6 +31,7 @@ var (
 	ActivatedTimeKey       = bsonutil.MustHaveTag(Build{}, "ActivatedTime")
 	RevisionOrderNumberKey = bsonutil.MustHaveTag(Build{}, "RevisionOrderNumber")
 	TasksKey               = bsonutil.MustHaveTag(Build{}, "Tasks")
+	DisplayTasksKey        = bsonutil.MustHaveTag(Build{}, "DisplayTasks")
 	TimeTakenKey           = bsonutil.MustHaveTag(Build{}, "TimeTaken")
 	DisplayNameKey         = bsonutil.MustHaveTag(Build{}, "DisplayName")
 	RequesterKey           = bsonutil.MustHaveTag(Build{}, "Requester")
@@ -38,13 +39,15 @@ var (
 	ActualMakespanKey      = bsonutil.MustHaveTag(Build{}, "ActualMakespan")
 
 	// bson fields for the task caches
-	TaskCacheIdKey            = bsonutil.MustHaveTag(TaskCache{}, "Id")
-	TaskCacheDisplayNameKey   = bsonutil.MustHaveTag(TaskCache{}, "DisplayName")
-	TaskCacheStatusKey        = bsonutil.MustHaveTag(TaskCache{}, "Status")
-	TaskCacheStatusDetailsKey = bsonutil.MustHaveTag(TaskCache{}, "StatusDetails")
-	TaskCacheStartTimeKey     = bsonutil.MustHaveTag(TaskCache{}, "StartTime")
-	TaskCacheTimeTakenKey     = bsonutil.MustHaveTag(TaskCache{}, "TimeTaken")
-	TaskCacheActivatedKey     = bsonutil.MustHaveTag(TaskCache{}, "Activated")
+	TaskCacheIdKey             = bsonutil.MustHaveTag(TaskCache{}, "Id")
+	TaskCacheDisplayNameKey    = bsonutil.MustHaveTag(TaskCache{}, "DisplayName")
+	TaskCacheStatusKey         = bsonutil.MustHaveTag(TaskCache{}, "Status")
+	TaskCacheStatusDetailsKey  = bsonutil.MustHaveTag(TaskCache{}, "StatusDetails")
+	TaskCacheStartTimeKey      = bsonutil.MustHaveTag(TaskCache{}, "StartTime")
+	TaskCacheTimeTakenKey      = bsonutil.MustHaveTag(TaskCache{}, "TimeTaken")
+	TaskCacheActivatedKey      = bsonutil.MustHaveTag(TaskCache{}, "Activated")
+	TaskCacheDisplayTaskKey    = bsonutil.MustHaveTag(TaskCache{}, "DisplayTask")
+	TaskCacheExecutionTasksKey = bsonutil.MustHaveTag(TaskCache{}, "ExecutionTasks")
 )
 
 // Queries
@@ -192,6 +195,34 @@ func ByAfterRevision(project, buildVariant string, revision int) db.Q {
 	}).Sort([]string{RevisionOrderNumberKey})
 }
 
+// ByProjectFromBuild builds a query that returns the non-patch builds of the
+// given build's project from that build on, most recent first, with the
+// builds of a revision sorted by id. If dir is negative, it instead returns
+// the builds that come before the given build in that order, nearest first.
+func ByProjectFromBuild(b *Build, dir int) db.Q {
+	revisionOp, idOp, sort := "$lt", "$gte", []string{"-" + RevisionOrderNumberKey, IdKey}
+	if dir < 0 {
+		revisionOp, idOp, sort = "$gt", "$lt", []string{RevisionOrderNumberKey, "-" + IdKey}
+	}
+	return db.Query(bson.M{
+		ProjectKey:   b.Project,
+		RequesterKey: evergreen.RepotrackerVersionRequester,
+		"$or": []bson.M{
+			{RevisionOrderNumberKey: bson.M{revisionOp: b.RevisionOrderNumber}},
+			{RevisionOrderNumberKey: b.RevisionOrderNumber, IdKey: bson.M{idOp: b.Id}},
+		},
+	}).Sort(sort)
+}
+
+// ByMostRecentForProject builds a query that returns a project's non-patch
+// builds, most recent first, with the builds of a revision sorted by id.
+func ByMostRecentForProject(project string) db.Q {
+	return db.Query(bson.M{
+		ProjectKey:   project,
+		RequesterKey: evergreen.RepotrackerVersionRequester,
+	}).Sort([]string{"-" + RevisionOrderNumberKey, IdKey})
+}
+
 // ByRecentlyFinished builds a query that returns all builds for a given project
 // that are versions (not patches), that have finished.
 func ByRecentlyFinished(limit int) db.Q {
@@ -1,11 +1,13 @@
 package build
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/util"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -31,15 +33,230 @@ func SetTasksCache(buildId string, tasks []TaskCache) error {
 	)
 }
 
+// SetDisplayTasksCache updates one build with the given id
+// to contain the given display task caches.
+func SetDisplayTasksCache(buildId string, displayTasks []TaskCache) error {
+	return UpdateOne(
+		bson.M{IdKey: buildId},
+		bson.M{"$set": bson.M{DisplayTasksKey: displayTasks}},
+	)
+}
+
+// CreateDisplayTasksCache creates a cache for each display task that the given
+// cached tasks are a part of, in the order the display tasks first appear,
+// with a status rolled up from the display task's execution tasks.
+func CreateDisplayTasksCache(tasks []TaskCache) []TaskCache {
+	displayTasks := []TaskCache{}
+	executionTasks := map[string][]TaskCache{}
+	for _, t := range tasks {
+		if t.DisplayTask == "" {
+			continue
+		}
+		if _, ok := executionTasks[t.DisplayTask]; !ok {
+			displayTasks = append(displayTasks, TaskCache{DisplayName: t.DisplayTask})
+		}
+		executionTasks[t.DisplayTask] = append(executionTasks[t.DisplayTask], t)
+	}
+	for i := range displayTasks {
+		displayTasks[i].rollUp(executionTasks[displayTasks[i].DisplayName])
+	}
+	return displayTasks
+}
+
+// rollUp sets the state of a display task cache from its execution tasks. The
+// display task is started while any of its tasks are running, failed once
+// any of them has failed, and only succeeds once all of them have. It takes
+// its id and status details from the first execution task with its status.
+func (dt *TaskCache) rollUp(tasks []TaskCache) {
+	var running, failed, succeeded, unfinished int
+	dt.StartTime = util.ZeroTime
+	dt.ExecutionTasks = make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		dt.ExecutionTasks = append(dt.ExecutionTasks, t.Id)
+		dt.TimeTaken += t.TimeTaken
+		if t.StartTime.After(util.ZeroTime) &&
+			(!dt.StartTime.After(util.ZeroTime) || t.StartTime.Before(dt.StartTime)) {
+			dt.StartTime = t.StartTime
+		}
+		if !t.Activated {
+			continue
+		}
+		dt.Activated = true
+
+		switch t.Status {
+		case evergreen.TaskStarted, evergreen.TaskDispatched:
+			running++
+		case evergreen.TaskFailed:
+			failed++
+		case evergreen.TaskSucceeded:
+			succeeded++
+		default:
+			unfinished++
+		}
+	}
+
+	switch {
+	case running > 0:
+		dt.Status = evergreen.TaskStarted
+	case failed > 0:
+		dt.Status = evergreen.TaskFailed
+	case succeeded > 0 && unfinished > 0:
+		dt.Status = evergreen.TaskStarted
+	case succeeded > 0:
+		dt.Status = evergreen.TaskSucceeded
+	default:
+		dt.Status = evergreen.TaskUndispatched
+	}
+
+	if len(tasks) == 0 {
+		return
+	}
+	representative := tasks[0]
+	for _, t := range tasks {
+		if t.Activated && (t.Status == dt.Status ||
+			(dt.Status == evergreen.TaskStarted && t.Status == evergreen.TaskDispatched)) {
+			representative = t
+			break
+		}
+	}
+	dt.Id = representative.Id
+	dt.StatusDetails = representative.StatusDetails
+}
+
+// DisplayedTasks returns the build's cached tasks as they should be shown,
+// with the execution tasks of each display task replaced by a single,
+// rolled-up display task at the position of its first execution task.
+func (b *Build) DisplayedTasks() []TaskCache {
+	displayTasks := CreateDisplayTasksCache(b.Tasks)
+	if len(displayTasks) == 0 {
+		return b.Tasks
+	}
+	byName := make(map[string]TaskCache, len(displayTasks))
+	for _, dt := range displayTasks {
+		byName[dt.DisplayName] = dt
+	}
+
+	tasks := make([]TaskCache, 0, len(b.Tasks))
+	for _, t := range b.Tasks {
+		if t.DisplayTask == "" {
+			tasks = append(tasks, t)
+			continue
+		}
+		if dt, ok := byName[t.DisplayTask]; ok {
+			tasks = append(tasks, dt)
+			delete(byName, t.DisplayTask)
+		}
+	}
+	return tasks
+}
+
+// DisplayedTasksExpression returns an aggregation expression that evaluates
+// to a build's cached tasks as they should be shown, with the execution tasks
+// of display tasks replaced by the display tasks' caches. The display tasks
+// are placed after the other tasks. Note that $filter and $concatArrays
+// require MongoDB 3.2+.
+func DisplayedTasksExpression() bson.M {
+	return bson.M{
+		"$concatArrays": []interface{}{
+			bson.M{"$filter": bson.M{
+				"input": "$" + TasksKey,
+				"as":    "t",
+				"cond": bson.M{"$eq": []interface{}{
+					bson.M{"$ifNull": []interface{}{"$$t." + TaskCacheDisplayTaskKey, ""}}, ""}},
+			}},
+			bson.M{"$ifNull": []interface{}{"$" + DisplayTasksKey, []interface{}{}}},
+		},
+	}
+}
+
 // updateOneTaskCache is a helper for updating a single cached task for a build.
+// The cache of the task's display task, if it has one, is rolled up again
+// afterwards, so that it stays in step with its execution tasks.
 func updateOneTaskCache(buildId, taskId string, updateDoc bson.M) error {
-	return UpdateOne(
+	err := UpdateOne(
 		bson.M{
 			IdKey: buildId,
 			TasksKey + "." + TaskCacheIdKey: taskId,
 		},
 		updateDoc,
 	)
+	if err != nil {
+		return err
+	}
+	return refreshDisplayTaskCache(buildId, taskId)
+}
+
+// maxDisplayTaskRefreshes is how many times a display task cache is rolled up
+// before giving up on it catching up with concurrent updates.
+const maxDisplayTaskRefreshes = 5
+
+// refreshDisplayTaskCache rolls up the cache of the display task that the
+// given task is part of, if any, from the build's cached tasks. Only that
+// display task's cache is updated, in place. Other tasks of the display task
+// may be updated at the same time, so the cache is rolled up until it matches
+// the cached tasks it was rolled up from.
+func refreshDisplayTaskCache(buildId, taskId string) error {
+	for i := 0; i < maxDisplayTaskRefreshes; i++ {
+		b, err := FindOne(ById(buildId).WithFields(TasksKey, DisplayTasksKey))
+		if err != nil {
+			return err
+		}
+		if b == nil || len(b.DisplayTasks) == 0 {
+			return nil
+		}
+
+		displayTask := ""
+		for _, t := range b.Tasks {
+			if t.Id == taskId {
+				displayTask = t.DisplayTask
+				break
+			}
+		}
+		if displayTask == "" {
+			return nil
+		}
+		executionTasks := []TaskCache{}
+		for _, t := range b.Tasks {
+			if t.DisplayTask == displayTask {
+				executionTasks = append(executionTasks, t)
+			}
+		}
+		rolledUp := TaskCache{DisplayName: displayTask}
+		rolledUp.rollUp(executionTasks)
+
+		var cached *TaskCache
+		for ix := range b.DisplayTasks {
+			if b.DisplayTasks[ix].DisplayName == displayTask {
+				cached = &b.DisplayTasks[ix]
+				break
+			}
+		}
+		if cached != nil && reflect.DeepEqual(*cached, rolledUp) {
+			return nil
+		}
+
+		if cached == nil {
+			err = UpdateOne(
+				bson.M{
+					IdKey: buildId,
+					DisplayTasksKey + "." + TaskCacheDisplayNameKey: bson.M{"$ne": displayTask},
+				},
+				bson.M{"$push": bson.M{DisplayTasksKey: rolledUp}},
+			)
+		} else {
+			err = UpdateOne(
+				bson.M{
+					IdKey: buildId,
+					DisplayTasksKey + "." + TaskCacheDisplayNameKey: displayTask,
+				},
+				bson.M{"$set": bson.M{DisplayTasksKey + ".$": rolledUp}},
+			)
+		}
+		if err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetCachedTaskDispatched sets the given task to "dispatched"
@@ -25,6 +25,9 @@ var (
 	SpawnAllowedKey = bsonutil.MustHaveTag(Distro{}, "SpawnAllowed")
 	ExpansionsKey   = bsonutil.MustHaveTag(Distro{}, "Expansions")
 
+	PrioritizerSettingsKey   = bsonutil.MustHaveTag(Distro{}, "PrioritizerSettings")
+	HostAllocatorSettingsKey = bsonutil.MustHaveTag(Distro{}, "HostAllocatorSettings")
+
 	// bson fields for the UserData struct
 	UserDataFileKey     = bsonutil.MustHaveTag(UserData{}, "File")
 	UserDataValidateKey = bsonutil.MustHaveTag(UserData{}, "Validate")
@@ -77,3 +80,13 @@ func ByProvider(p string) db.Q {
 func BySpawnAllowed() db.Q {
 	return db.Query(bson.D{{SpawnAllowedKey, true}})
 }
+
+// ByIdFrom returns a query for the distros ordered by id, starting from the
+// given id. If dir is negative, it instead returns the distros that come
+// before the given id, nearest first.
+func ByIdFrom(id string, dir int) db.Q {
+	if dir < 0 {
+		return db.Query(bson.M{IdKey: bson.M{"$lt": id}}).Sort([]string{"-" + IdKey})
+	}
+	return db.Query(bson.M{IdKey: bson.M{"$gte": id}}).Sort([]string{IdKey})
+}
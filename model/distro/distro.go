@@ -25,6 +25,9 @@ type Distro struct {
 
 	SpawnAllowed bool        `bson:"spawn_allowed" json:"spawn_allowed,omitempty" mapstructure:"spawn_allowed,omitempty"`
 	Expansions   []Expansion `bson:"expansions,omitempty" json:"expansions,omitempty" mapstructure:"expansions,omitempty"`
+
+	PrioritizerSettings   PrioritizerSettings   `bson:"prioritizer_settings,omitempty" json:"prioritizer_settings,omitempty" mapstructure:"prioritizer_settings,omitempty"`
+	HostAllocatorSettings HostAllocatorSettings `bson:"host_allocator_settings,omitempty" json:"host_allocator_settings,omitempty" mapstructure:"host_allocator_settings,omitempty"`
 }
 
 type ValidateFormat string
@@ -34,6 +37,73 @@ type UserData struct {
 	Validate ValidateFormat `bson:"validate,omitempty" json:"validate,omitempty"`
 }
 
+// PrioritizerSettings configure how the scheduler orders the distro's task
+// queue. If no comparators are given the scheduler's default chain is used.
+type PrioritizerSettings struct {
+	Comparators []ComparatorSetting `bson:"comparators,omitempty" json:"comparators,omitempty" mapstructure:"comparators,omitempty"`
+}
+
+// ComparatorSetting names one of the scheduler's task comparators. If any
+// comparator in the chain has a non-zero weight, tasks are ordered by the
+// weighted sum of the comparators' decisions, with ties broken by the first
+// comparator in the chain to reach a decision.
+type ComparatorSetting struct {
+	Name   string `bson:"name" json:"name" mapstructure:"name"`
+	Weight int    `bson:"weight,omitempty" json:"weight,omitempty" mapstructure:"weight,omitempty"`
+}
+
+// HostAllocatorSettings configure how the scheduler decides how many hosts to
+// spawn for the distro. If no allocator is named the duration-based allocator
+// is used.
+type HostAllocatorSettings struct {
+	Name string `bson:"name,omitempty" json:"name,omitempty" mapstructure:"name,omitempty"`
+	// HourlyBudget is the most the cost-based allocator will let the distro's
+	// hosts cost per hour, in dollars.
+	HourlyBudget float64 `bson:"hourly_budget,omitempty" json:"hourly_budget,omitempty" mapstructure:"hourly_budget,omitempty"`
+	// HourlyHostCost is what one of the distro's hosts costs per hour, in
+	// dollars. The scheduler simulator charges its simulated hosts this
+	// rather than asking the provider.
+	HourlyHostCost float64 `bson:"hourly_host_cost,omitempty" json:"hourly_host_cost,omitempty" mapstructure:"hourly_host_cost,omitempty"`
+}
+
+// Names of the host allocators that may be configured on a distro
+const (
+	HostAllocatorDuration = "duration"
+	HostAllocatorDeficit  = "deficit"
+	HostAllocatorCost     = "cost"
+)
+
+// ValidHostAllocators lists all host allocator names the scheduler recognizes
+var ValidHostAllocators = []string{
+	HostAllocatorDuration,
+	HostAllocatorDeficit,
+	HostAllocatorCost,
+}
+
+// Names of the task comparators that may be configured on a distro
+const (
+	ComparatorByPriority            = "priority"
+	ComparatorByNumDeps             = "num_deps"
+	ComparatorByRevisionOrderNumber = "revision_order_number"
+	ComparatorByCreateTime          = "create_time"
+	ComparatorBySimilarFailing      = "similar_failing"
+	ComparatorByRecentlyFailing     = "recently_failing"
+	ComparatorByExpectedDuration    = "shortest_expected_duration"
+	ComparatorByWaitTime            = "oldest_waiting"
+)
+
+// ValidComparators lists all comparator names the scheduler recognizes
+var ValidComparators = []string{
+	ComparatorByPriority,
+	ComparatorByNumDeps,
+	ComparatorByRevisionOrderNumber,
+	ComparatorByCreateTime,
+	ComparatorBySimilarFailing,
+	ComparatorByRecentlyFailing,
+	ComparatorByExpectedDuration,
+	ComparatorByWaitTime,
+}
+
 type Expansion struct {
 	Key   string `bson:"key,omitempty" json:"key,omitempty"`
 	Value string `bson:"value,omitempty" json:"value,omitempty"`
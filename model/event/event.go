@@ -53,6 +53,10 @@ func (dw DataWrapper) MarshalJSON() ([]byte, error) {
 		return json.Marshal(event)
 	case *DistroEventData:
 		return json.Marshal(event)
+	case *ProjectEventData:
+		return json.Marshal(event)
+	case *VersionEventData:
+		return json.Marshal(event)
 	case *TaskSystemResourceData:
 		return json.Marshal(event)
 	case *TaskProcessResourceData:
@@ -68,7 +72,7 @@ func (dw DataWrapper) GetBSON() (interface{}, error) {
 
 func (dw *DataWrapper) SetBSON(raw bson.Raw) error {
 	impls := []interface{}{&TaskEventData{}, &HostEventData{}, &DistroEventData{}, &SchedulerEventData{},
-		&TaskSystemResourceData{}, &TaskProcessResourceData{}}
+		&TaskSystemResourceData{}, &TaskProcessResourceData{}, &ProjectEventData{}, &VersionEventData{}}
 
 	for _, impl := range impls {
 		err := raw.Unmarshal(impl)
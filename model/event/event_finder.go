@@ -65,6 +65,23 @@ func DistroEventsInOrder(id string) db.Q {
 	return DistroEventsForId(id).Sort([]string{TimestampKey})
 }
 
+// Project Audit Events
+
+// ProjectAuditEventsForId returns the events that make up a project's audit
+// trail: changes to the project's settings and variables, and users changing
+// the activation and priority of the project's versions and tasks.
+func ProjectAuditEventsForId(projectId string) db.Q {
+	return db.Query(bson.D{
+		{DataKey + "." + ProjectIdKey, projectId},
+		{DataKey + "." + ResourceTypeKey, bson.M{"$in": []string{
+			ResourceTypeProject, ResourceTypeVersion, ResourceTypeTask}}},
+	})
+}
+
+func MostRecentProjectAuditEvents(projectId string, n int) db.Q {
+	return ProjectAuditEventsForId(projectId).Sort([]string{"-" + TimestampKey}).Limit(n)
+}
+
 // Scheduler Events
 func SchedulerEventsForId(distroId string) db.Q {
 	return db.Query(bson.D{
@@ -0,0 +1,114 @@
+package event
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/mongodb/grip"
+)
+
+const (
+	// resource type
+	ResourceTypeProject = "PROJECT"
+
+	// event types
+	EventProjectAdded        = "PROJECT_ADDED"
+	EventProjectModified     = "PROJECT_MODIFIED"
+	EventProjectVarsModified = "PROJECT_VARS_MODIFIED"
+	EventProjectRemoved      = "PROJECT_REMOVED"
+)
+
+// ProjectIdKey is the key of the project id in the data of all events that
+// make up a project's audit trail.
+var ProjectIdKey = bsonutil.MustHaveTag(ProjectEventData{}, "ProjectId")
+
+// ProjectEventData implements EventData.
+type ProjectEventData struct {
+	// necessary for IsValid
+	ResourceType string      `bson:"r_type" json:"resource_type"`
+	ProjectId    string      `bson:"p_id" json:"project_id"`
+	UserId       string      `bson:"u_id,omitempty" json:"user_id,omitempty"`
+	Before       interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After        interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+func (p ProjectEventData) IsValid() bool {
+	return p.ResourceType == ResourceTypeProject
+}
+
+// ProjectVarsChange records which of a project's variables were changed,
+// without their values.
+type ProjectVarsChange struct {
+	Added    []string `bson:"added,omitempty" json:"added,omitempty"`
+	Modified []string `bson:"modified,omitempty" json:"modified,omitempty"`
+	Removed  []string `bson:"removed,omitempty" json:"removed,omitempty"`
+}
+
+func LogProjectEvent(projectId string, eventType string, eventData ProjectEventData) {
+	eventData.ResourceType = ResourceTypeProject
+	eventData.ProjectId = projectId
+	event := Event{
+		ResourceId: projectId,
+		Timestamp:  time.Now(),
+		EventType:  eventType,
+		Data:       DataWrapper{eventData},
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(event); err != nil {
+		grip.Errorf("Error logging project event: %+v", err)
+	}
+}
+
+func LogProjectAdded(projectId, userId string) {
+	LogProjectEvent(projectId, EventProjectAdded, ProjectEventData{UserId: userId})
+}
+
+// LogProjectRemoved records the removal of a project, along with its settings
+// at the time.
+func LogProjectRemoved(projectId, userId string, before interface{}) {
+	LogProjectEvent(projectId, EventProjectRemoved,
+		ProjectEventData{UserId: userId, Before: before})
+}
+
+// LogProjectModified records a change to a project's settings, including its
+// admins and alert settings.
+func LogProjectModified(projectId, userId string, before, after interface{}) {
+	LogProjectEvent(projectId, EventProjectModified,
+		ProjectEventData{UserId: userId, Before: before, After: after})
+}
+
+// LogProjectVarsModified records which of a project's variables a user
+// changed. The variables' values are never logged. Private variables must be
+// given as comparable values, such as keyed hashes, rather than ciphertexts.
+func LogProjectVarsModified(projectId, userId string, before, after map[string]string) {
+	change := DiffProjectVars(before, after)
+	if len(change.Added) == 0 && len(change.Modified) == 0 && len(change.Removed) == 0 {
+		return
+	}
+	LogProjectEvent(projectId, EventProjectVarsModified,
+		ProjectEventData{UserId: userId, After: change})
+}
+
+// DiffProjectVars returns the names of the variables added, modified and
+// removed between two sets of project variables, in sorted order.
+func DiffProjectVars(before, after map[string]string) ProjectVarsChange {
+	change := ProjectVarsChange{}
+	for name, value := range after {
+		oldValue, ok := before[name]
+		if !ok {
+			change.Added = append(change.Added, name)
+		} else if oldValue != value {
+			change.Modified = append(change.Modified, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			change.Removed = append(change.Removed, name)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Modified)
+	sort.Strings(change.Removed)
+	return change
+}
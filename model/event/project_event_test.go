@@ -0,0 +1,80 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoggingProjectEvents(t *testing.T) {
+	Convey("When logging project audit events, ", t, func() {
+
+		So(db.Clear(AllLogCollection), ShouldBeNil)
+
+		Convey("events for the project's settings, versions and tasks should make up its audit trail", func() {
+			projectId := "project_id"
+			userId := "user_id"
+
+			LogProjectModified(projectId, userId, "before", "after")
+			time.Sleep(1 * time.Millisecond)
+			LogProjectVarsModified(projectId, userId,
+				map[string]string{"a": "1", "b": "2"}, map[string]string{"b": "3", "c": "4"})
+			time.Sleep(1 * time.Millisecond)
+			LogVersionPriorityChanged("version_id", projectId, "", userId, 10)
+			time.Sleep(1 * time.Millisecond)
+			LogTaskPriorityChanged("task_id", projectId, userId, 20)
+			time.Sleep(1 * time.Millisecond)
+			LogDistroModified("distro_id", userId, nil)
+			LogProjectModified("other_project", userId, nil, nil)
+
+			events, err := Find(AllLogCollection, MostRecentProjectAuditEvents(projectId, 10))
+			So(err, ShouldBeNil)
+			So(len(events), ShouldEqual, 4)
+
+			So(events[0].EventType, ShouldEqual, TaskPriorityChanged)
+			taskData, ok := events[0].Data.Data.(*TaskEventData)
+			So(ok, ShouldBeTrue)
+			So(taskData.Priority, ShouldEqual, 20)
+
+			So(events[1].EventType, ShouldEqual, EventVersionPriorityChanged)
+			versionData, ok := events[1].Data.Data.(*VersionEventData)
+			So(ok, ShouldBeTrue)
+			So(versionData.UserId, ShouldEqual, userId)
+			So(versionData.Priority, ShouldEqual, 10)
+
+			So(events[2].EventType, ShouldEqual, EventProjectVarsModified)
+			projectData, ok := events[2].Data.Data.(*ProjectEventData)
+			So(ok, ShouldBeTrue)
+			So(projectData.Before, ShouldBeNil)
+			So(projectData.After, ShouldNotBeNil)
+
+			So(events[3].EventType, ShouldEqual, EventProjectModified)
+			projectData, ok = events[3].Data.Data.(*ProjectEventData)
+			So(ok, ShouldBeTrue)
+			So(projectData.UserId, ShouldEqual, userId)
+			So(projectData.Before.(string), ShouldEqual, "before")
+			So(projectData.After.(string), ShouldEqual, "after")
+		})
+	})
+}
+
+func TestDiffProjectVars(t *testing.T) {
+	Convey("When diffing project variables", t, func() {
+		Convey("added, modified and removed variables should be named without their values", func() {
+			change := DiffProjectVars(
+				map[string]string{"same": "1", "changed": "2", "gone": "3"},
+				map[string]string{"same": "1", "changed": "4", "new": "5", "new2": "6"})
+			So(change.Added, ShouldResemble, []string{"new", "new2"})
+			So(change.Modified, ShouldResemble, []string{"changed"})
+			So(change.Removed, ShouldResemble, []string{"gone"})
+		})
+		Convey("unchanged variables should produce no change", func() {
+			change := DiffProjectVars(map[string]string{"a": "1"}, map[string]string{"a": "1"})
+			So(change.Added, ShouldBeEmpty)
+			So(change.Modified, ShouldBeEmpty)
+			So(change.Removed, ShouldBeEmpty)
+		})
+	})
+}
@@ -15,9 +15,37 @@ const (
 )
 
 type TaskQueueInfo struct {
-	TaskQueueLength  int           `bson:"tq_l" json:"task_queue_length"`
-	NumHostsRunning  int           `bson:"n_h" json:"num_hosts_running"`
-	ExpectedDuration time.Duration `bson:"ex_d" json:"expected_duration,"`
+	TaskQueueLength  int                `bson:"tq_l" json:"task_queue_length"`
+	NumHostsRunning  int                `bson:"n_h" json:"num_hosts_running"`
+	ExpectedDuration time.Duration      `bson:"ex_d" json:"expected_duration,"`
+	ProjectShares    []ProjectShareInfo `bson:"p_sh,omitempty" json:"project_shares,omitempty"`
+	HostCost         *HostCostInfo      `bson:"h_c,omitempty" json:"host_cost,omitempty"`
+}
+
+// HostCostInfo records, for a distro using the cost-based host allocator, the
+// distro's hourly budget and what its hosts cost per hour before and after
+// spawning the hosts the allocator allowed.
+type HostCostInfo struct {
+	HourlyBudget        float64 `bson:"b" json:"hourly_budget"`
+	CurrentHourlyCost   float64 `bson:"c" json:"current_hourly_cost"`
+	ProjectedHourlyCost float64 `bson:"p" json:"projected_hourly_cost"`
+	NewHostsWanted      int     `bson:"n_w" json:"new_hosts_wanted"`
+	NewHostsAllowed     int     `bson:"n_a" json:"new_hosts_allowed"`
+	// Waiting is set when no hosts were spawned because the queued work fits
+	// in time already paid for on the distro's existing hosts.
+	Waiting bool `bson:"w,omitempty" json:"waiting,omitempty"`
+}
+
+// ProjectShareInfo records, for a project with tasks in a distro's queue, the
+// fraction of host time it is entitled to under fair-share scheduling versus
+// the fraction it recently used.
+type ProjectShareInfo struct {
+	Project      string        `bson:"p" json:"project"`
+	ShareWeight  int           `bson:"w" json:"share_weight"`
+	Share        float64       `bson:"s" json:"share"`
+	HostTimeUsed time.Duration `bson:"h_t" json:"host_time_used"`
+	Usage        float64       `bson:"u" json:"usage"`
+	NumTasks     int           `bson:"n_t" json:"num_tasks"`
 }
 
 // implements EventData
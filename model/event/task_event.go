@@ -21,6 +21,8 @@ const (
 	TaskDeactivated  = "TASK_DEACTIVATED"
 	TaskAbortRequest = "TASK_ABORT_REQUEST"
 	TaskScheduled    = "TASK_SCHEDULED"
+
+	TaskPriorityChanged = "TASK_PRIORITY_CHANGED"
 )
 
 // implements Data
@@ -29,7 +31,9 @@ type TaskEventData struct {
 	ResourceType string    `bson:"r_type" json:"resource_type"`
 	HostId       string    `bson:"h_id,omitempty" json:"host_id,omitempty"`
 	UserId       string    `bson:"u_id,omitempty" json:"user_id,omitempty"`
+	ProjectId    string    `bson:"p_id,omitempty" json:"project_id,omitempty"`
 	Status       string    `bson:"s,omitempty" json:"status,omitempty"`
+	Priority     int64     `bson:"pri,omitempty" json:"priority,omitempty"`
 	Timestamp    time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 }
 
@@ -77,12 +81,17 @@ func LogTaskRestarted(taskId string, userId string) {
 	LogTaskEvent(taskId, TaskRestarted, TaskEventData{UserId: userId})
 }
 
-func LogTaskActivated(taskId string, userId string) {
-	LogTaskEvent(taskId, TaskActivated, TaskEventData{UserId: userId})
+func LogTaskActivated(taskId, projectId, userId string) {
+	LogTaskEvent(taskId, TaskActivated, TaskEventData{ProjectId: projectId, UserId: userId})
+}
+
+func LogTaskDeactivated(taskId, projectId, userId string) {
+	LogTaskEvent(taskId, TaskDeactivated, TaskEventData{ProjectId: projectId, UserId: userId})
 }
 
-func LogTaskDeactivated(taskId string, userId string) {
-	LogTaskEvent(taskId, TaskDeactivated, TaskEventData{UserId: userId})
+func LogTaskPriorityChanged(taskId, projectId, userId string, priority int64) {
+	LogTaskEvent(taskId, TaskPriorityChanged,
+		TaskEventData{ProjectId: projectId, UserId: userId, Priority: priority})
 }
 
 func LogTaskAbortRequest(taskId string, userId string) {
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+)
+
+const (
+	// resource type
+	ResourceTypeVersion = "VERSION"
+
+	// event types
+	EventVersionActivated       = "VERSION_ACTIVATED"
+	EventVersionDeactivated     = "VERSION_DEACTIVATED"
+	EventVersionPriorityChanged = "VERSION_PRIORITY_CHANGED"
+)
+
+// VersionEventData implements EventData.
+type VersionEventData struct {
+	// necessary for IsValid
+	ResourceType string `bson:"r_type" json:"resource_type"`
+	ProjectId    string `bson:"p_id" json:"project_id"`
+	Requester    string `bson:"req,omitempty" json:"requester,omitempty"`
+	UserId       string `bson:"u_id,omitempty" json:"user_id,omitempty"`
+	Priority     int64  `bson:"pri,omitempty" json:"priority"`
+}
+
+func (v VersionEventData) IsValid() bool {
+	return v.ResourceType == ResourceTypeVersion
+}
+
+func LogVersionEvent(versionId string, eventType string, eventData VersionEventData) {
+	eventData.ResourceType = ResourceTypeVersion
+	event := Event{
+		ResourceId: versionId,
+		Timestamp:  time.Now(),
+		EventType:  eventType,
+		Data:       DataWrapper{eventData},
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(event); err != nil {
+		grip.Errorf("Error logging version event: %+v", err)
+	}
+}
+
+// LogVersionActivation records a user activating or deactivating a version
+// or patch.
+func LogVersionActivation(versionId, projectId, requester, userId string, active bool) {
+	eventType := EventVersionDeactivated
+	if active {
+		eventType = EventVersionActivated
+	}
+	LogVersionEvent(versionId, eventType,
+		VersionEventData{ProjectId: projectId, Requester: requester, UserId: userId})
+}
+
+// LogVersionPriorityChanged records a user setting the priority of a
+// version's or patch's tasks.
+func LogVersionPriorityChanged(versionId, projectId, requester, userId string, priority int64) {
+	LogVersionEvent(versionId, EventVersionPriorityChanged,
+		VersionEventData{ProjectId: projectId, Requester: requester, UserId: userId, Priority: priority})
+}
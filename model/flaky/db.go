@@ -0,0 +1,99 @@
+package flaky
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	Collection = "test_flakiness"
+)
+
+var (
+	IdKey                = bsonutil.MustHaveTag(TestFlakiness{}, "Id")
+	ProjectKey           = bsonutil.MustHaveTag(TestFlakiness{}, "Project")
+	BuildVariantKey      = bsonutil.MustHaveTag(TestFlakiness{}, "BuildVariant")
+	TaskNameKey          = bsonutil.MustHaveTag(TestFlakiness{}, "TaskName")
+	TestFileKey          = bsonutil.MustHaveTag(TestFlakiness{}, "TestFile")
+	RunsKey              = bsonutil.MustHaveTag(TestFlakiness{}, "Runs")
+	FlipsKey             = bsonutil.MustHaveTag(TestFlakiness{}, "Flips")
+	SameRevisionFlipsKey = bsonutil.MustHaveTag(TestFlakiness{}, "SameRevisionFlips")
+	ScoreKey             = bsonutil.MustHaveTag(TestFlakiness{}, "Score")
+	FlakyKey             = bsonutil.MustHaveTag(TestFlakiness{}, "Flaky")
+	LastFlipRevisionKey  = bsonutil.MustHaveTag(TestFlakiness{}, "LastFlipRevision")
+	ComputedAtKey        = bsonutil.MustHaveTag(TestFlakiness{}, "ComputedAt")
+)
+
+// ByProject returns a query for the flakiness of a project's tests, most
+// flaky first. If onlyFlaky is set, tests that flipped but aren't
+// considered flaky are left out.
+func ByProject(project string, onlyFlaky bool) db.Q {
+	filter := bson.M{ProjectKey: project}
+	if onlyFlaky {
+		filter[FlakyKey] = true
+	}
+	return db.Query(filter).Sort([]string{"-" + ScoreKey, TestFileKey})
+}
+
+// ByTask returns a query for the flakiness of the tests of a task in a
+// project's build variant.
+func ByTask(project, buildVariant, taskName string) db.Q {
+	return db.Query(bson.M{
+		ProjectKey:      project,
+		BuildVariantKey: buildVariant,
+		TaskNameKey:     taskName,
+	})
+}
+
+// Find gets the test flakiness for the given query.
+func Find(query db.Q) ([]TestFlakiness, error) {
+	flakiness := []TestFlakiness{}
+	err := db.FindAllQ(Collection, query, &flakiness)
+	return flakiness, err
+}
+
+// ReplaceForProject replaces all of a project's stored test flakiness. Each
+// test's flakiness is updated in place before the flakiness of tests that no
+// longer flip is removed, so that readers never find the project's flakiness
+// missing. The flakiness is stamped with the time it was stored at.
+func ReplaceForProject(project string, flakiness []TestFlakiness) error {
+	// the database stores times in milliseconds, so truncate the stamp to be
+	// able to find the flakiness stored before it
+	computedAt := time.Now().Truncate(time.Millisecond)
+	for i := range flakiness {
+		flakiness[i].ComputedAt = computedAt
+		f := flakiness[i]
+		_, err := db.Upsert(Collection,
+			bson.M{
+				ProjectKey:      project,
+				BuildVariantKey: f.BuildVariant,
+				TaskNameKey:     f.TaskName,
+				TestFileKey:     f.TestFile,
+			},
+			bson.M{
+				"$set": bson.M{
+					RunsKey:              f.Runs,
+					FlipsKey:             f.Flips,
+					SameRevisionFlipsKey: f.SameRevisionFlips,
+					ScoreKey:             f.Score,
+					FlakyKey:             f.Flaky,
+					LastFlipRevisionKey:  f.LastFlipRevision,
+					ComputedAtKey:        computedAt,
+				},
+				"$setOnInsert": bson.M{IdKey: f.Id},
+			})
+		if err != nil {
+			return errors.Wrapf(err, "error updating flakiness of test %v", f.TestFile)
+		}
+	}
+
+	err := db.RemoveAll(Collection, bson.M{
+		ProjectKey:    project,
+		ComputedAtKey: bson.M{"$lt": computedAt},
+	})
+	return errors.Wrapf(err, "error removing stale test flakiness for project %v", project)
+}
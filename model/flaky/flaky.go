@@ -0,0 +1,178 @@
+package flaky
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// FlakyScoreThreshold is the score at or above which a test that has
+	// flipped at least MinFlakyFlips times is considered flaky.
+	FlakyScoreThreshold = 0.2
+	// MinFlakyFlips is the number of flips a test needs before its score
+	// is trusted, so that a single regression and its fix isn't flaky.
+	MinFlakyFlips = 3
+)
+
+// TestFlakiness is how often a test's status flips between passing and
+// failing in a task of a project's mainline.
+type TestFlakiness struct {
+	Id           bson.ObjectId `bson:"_id" json:"-"`
+	Project      string        `bson:"project" json:"project" csv:"project"`
+	BuildVariant string        `bson:"build_variant" json:"build_variant" csv:"build_variant"`
+	TaskName     string        `bson:"task_name" json:"task_name" csv:"task_name"`
+	TestFile     string        `bson:"test_file" json:"test_file" csv:"test_file"`
+
+	// Runs is the number of results of the test that were considered.
+	Runs int `bson:"runs" json:"runs" csv:"runs"`
+	// Flips is the number of times the test's status differed from its
+	// previous result, either in a restart of the same revision or in the
+	// next revision it ran on.
+	Flips int `bson:"flips" json:"flips" csv:"flips"`
+	// SameRevisionFlips is the number of flips between executions of the
+	// same revision, which can't be explained by code changes.
+	SameRevisionFlips int `bson:"same_revision_flips" json:"same_revision_flips" csv:"same_revision_flips"`
+	// Score is the fraction of consecutive results that flipped.
+	Score float64 `bson:"score" json:"score" csv:"score"`
+	// Flaky is whether the test is considered flaky.
+	Flaky bool `bson:"flaky" json:"flaky" csv:"flaky"`
+
+	LastFlipRevision string    `bson:"last_flip_revision" json:"last_flip_revision" csv:"last_flip_revision"`
+	ComputedAt       time.Time `bson:"computed_at" json:"computed_at"`
+}
+
+// TestRun is a single result of a test in a task execution.
+type TestRun struct {
+	BuildVariant string `bson:"bv"`
+	TaskName     string `bson:"tn"`
+	TestFile     string `bson:"tf"`
+	Revision     string `bson:"r"`
+	Order        int    `bson:"o"`
+	Execution    int    `bson:"ex"`
+	Status       string `bson:"st"`
+}
+
+// ComputeFlakiness scores the results of a single test in a single task.
+// Results are ordered by revision and execution, and only passes and
+// failures are considered.
+func ComputeFlakiness(runs []TestRun) TestFlakiness {
+	considered := []TestRun{}
+	for _, run := range runs {
+		if run.Status == evergreen.TestSucceededStatus || run.Status == evergreen.TestFailedStatus {
+			considered = append(considered, run)
+		}
+	}
+	sort.Sort(runsByOrder(considered))
+
+	flakiness := TestFlakiness{Runs: len(considered)}
+	for i := 1; i < len(considered); i++ {
+		prev, cur := considered[i-1], considered[i]
+		if prev.Status == cur.Status {
+			continue
+		}
+		flakiness.Flips++
+		if prev.Revision == cur.Revision {
+			flakiness.SameRevisionFlips++
+		}
+		flakiness.LastFlipRevision = cur.Revision
+	}
+	if len(considered) > 1 {
+		flakiness.Score = float64(flakiness.Flips) / float64(len(considered)-1)
+	}
+	flakiness.Flaky = flakiness.SameRevisionFlips > 0 ||
+		(flakiness.Flips >= MinFlakyFlips && flakiness.Score >= FlakyScoreThreshold)
+	return flakiness
+}
+
+type runsByOrder []TestRun
+
+func (r runsByOrder) Len() int      { return len(r) }
+func (r runsByOrder) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r runsByOrder) Less(i, j int) bool {
+	if r[i].Order != r[j].Order {
+		return r[i].Order < r[j].Order
+	}
+	return r[i].Execution < r[j].Execution
+}
+
+// FindMainlineTestRuns returns the passing and failing test results of the
+// project's mainline tasks that finished after the given time, including
+// those of earlier executions of restarted tasks.
+func FindMainlineTestRuns(project string, since time.Time) ([]TestRun, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			task.ProjectKey:    project,
+			task.RequesterKey:  evergreen.RepotrackerVersionRequester,
+			task.FinishTimeKey: bson.M{"$gte": since},
+			task.StatusKey:     bson.M{"$in": []string{evergreen.TaskSucceeded, evergreen.TaskFailed}},
+		}},
+		{"$unwind": "$" + task.TestResultsKey},
+		{"$match": bson.M{
+			task.TestResultsKey + "." + task.TestResultStatusKey: bson.M{
+				"$in": []string{evergreen.TestSucceededStatus, evergreen.TestFailedStatus}},
+		}},
+		{"$project": bson.M{
+			"bv": "$" + task.BuildVariantKey,
+			"tn": "$" + task.DisplayNameKey,
+			"tf": "$" + task.TestResultsKey + "." + task.TestResultTestFileKey,
+			"r":  "$" + task.RevisionKey,
+			"o":  "$" + task.RevisionOrderNumberKey,
+			"ex": "$" + task.ExecutionKey,
+			"st": "$" + task.TestResultsKey + "." + task.TestResultStatusKey,
+		}},
+	}
+
+	runs := []TestRun{}
+	if err := db.Aggregate(task.Collection, pipeline, &runs); err != nil {
+		return nil, errors.Wrap(err, "error aggregating test results of tasks")
+	}
+	oldRuns := []TestRun{}
+	if err := db.Aggregate(task.OldCollection, pipeline, &oldRuns); err != nil {
+		return nil, errors.Wrap(err, "error aggregating test results of old tasks")
+	}
+	return append(runs, oldRuns...), nil
+}
+
+// UpdateProjectFlakiness recomputes the flakiness of the tests of the
+// project's mainline tasks that finished after the given time, and replaces
+// the project's stored flakiness with that of the tests that flipped.
+func UpdateProjectFlakiness(project string, since time.Time) ([]TestFlakiness, error) {
+	runs, err := FindMainlineTestRuns(project, since)
+	if err != nil {
+		return nil, err
+	}
+
+	type testKey struct{ variant, task, test string }
+	runsByTest := map[testKey][]TestRun{}
+	for _, run := range runs {
+		key := testKey{run.BuildVariant, run.TaskName, run.TestFile}
+		runsByTest[key] = append(runsByTest[key], run)
+	}
+
+	now := time.Now()
+	flips := []TestFlakiness{}
+	for key, testRuns := range runsByTest {
+		flakiness := ComputeFlakiness(testRuns)
+		if flakiness.Flips == 0 {
+			continue
+		}
+		flakiness.Id = bson.NewObjectId()
+		flakiness.Project = project
+		flakiness.BuildVariant = key.variant
+		flakiness.TaskName = key.task
+		flakiness.TestFile = key.test
+		flakiness.ComputedAt = now
+		flips = append(flips, flakiness)
+	}
+
+	if err = ReplaceForProject(project, flips); err != nil {
+		return nil, err
+	}
+	return flips, nil
+}
@@ -0,0 +1,120 @@
+package flaky
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func init() {
+	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(testutil.TestConfig()))
+}
+
+func run(revision string, order, execution int, status string) TestRun {
+	return TestRun{
+		BuildVariant: "linux",
+		TaskName:     "compile",
+		TestFile:     "test.js",
+		Revision:     revision,
+		Order:        order,
+		Execution:    execution,
+		Status:       status,
+	}
+}
+
+func TestComputeFlakiness(t *testing.T) {
+	pass, fail := evergreen.TestSucceededStatus, evergreen.TestFailedStatus
+
+	Convey("When computing the flakiness of a test", t, func() {
+
+		Convey("a test that always passes should not flip", func() {
+			f := ComputeFlakiness([]TestRun{run("a", 1, 0, pass), run("b", 2, 0, pass), run("c", 3, 0, pass)})
+			So(f.Runs, ShouldEqual, 3)
+			So(f.Flips, ShouldEqual, 0)
+			So(f.Score, ShouldEqual, 0)
+			So(f.Flaky, ShouldBeFalse)
+		})
+
+		Convey("a regression and its fix should not be flaky", func() {
+			f := ComputeFlakiness([]TestRun{
+				run("a", 1, 0, pass), run("b", 2, 0, fail), run("c", 3, 0, fail), run("d", 4, 0, pass),
+			})
+			So(f.Flips, ShouldEqual, 2)
+			So(f.LastFlipRevision, ShouldEqual, "d")
+			So(f.Flaky, ShouldBeFalse)
+		})
+
+		Convey("a test that passes when its task is restarted should be flaky", func() {
+			f := ComputeFlakiness([]TestRun{run("a", 1, 1, pass), run("a", 1, 0, fail), run("b", 2, 0, pass)})
+			So(f.Flips, ShouldEqual, 1)
+			So(f.SameRevisionFlips, ShouldEqual, 1)
+			So(f.LastFlipRevision, ShouldEqual, "a")
+			So(f.Flaky, ShouldBeTrue)
+		})
+
+		Convey("a test that flips often across revisions should be flaky", func() {
+			f := ComputeFlakiness([]TestRun{
+				run("e", 5, 0, fail), run("d", 4, 0, pass), run("c", 3, 0, fail),
+				run("b", 2, 0, pass), run("a", 1, 0, pass),
+			})
+			So(f.Runs, ShouldEqual, 5)
+			So(f.Flips, ShouldEqual, 3)
+			So(f.SameRevisionFlips, ShouldEqual, 0)
+			So(f.Score, ShouldEqual, 0.75)
+			So(f.LastFlipRevision, ShouldEqual, "e")
+			So(f.Flaky, ShouldBeTrue)
+		})
+
+		Convey("results that aren't passes or failures should be ignored", func() {
+			f := ComputeFlakiness([]TestRun{
+				run("a", 1, 0, pass), run("b", 2, 0, evergreen.TestSkippedStatus), run("c", 3, 0, pass),
+			})
+			So(f.Runs, ShouldEqual, 2)
+			So(f.Flips, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestReplaceForProject(t *testing.T) {
+	Convey("With stored flakiness for two projects", t, func() {
+		So(db.Clear(Collection), ShouldBeNil)
+		stored := []TestFlakiness{
+			{Id: bson.NewObjectId(), Project: "p", BuildVariant: "linux", TaskName: "compile", TestFile: "a.js", Flips: 1},
+			{Id: bson.NewObjectId(), Project: "p", BuildVariant: "linux", TaskName: "compile", TestFile: "b.js", Flips: 1},
+			{Id: bson.NewObjectId(), Project: "other", BuildVariant: "linux", TaskName: "compile", TestFile: "a.js", Flips: 1},
+		}
+		for _, f := range stored {
+			So(db.Insert(Collection, f), ShouldBeNil)
+		}
+
+		Convey("replacing a project's flakiness should update its tests in place and remove stale ones", func() {
+			So(ReplaceForProject("p", []TestFlakiness{
+				{Id: bson.NewObjectId(), Project: "p", BuildVariant: "linux", TaskName: "compile", TestFile: "a.js", Flips: 3},
+				{Id: bson.NewObjectId(), Project: "p", BuildVariant: "linux", TaskName: "compile", TestFile: "c.js", Flips: 2},
+			}), ShouldBeNil)
+
+			flakiness, err := Find(ByProject("p", false))
+			So(err, ShouldBeNil)
+			So(len(flakiness), ShouldEqual, 2)
+			So(flakiness[0].TestFile, ShouldEqual, "a.js")
+			So(flakiness[0].Id, ShouldEqual, stored[0].Id)
+			So(flakiness[0].Flips, ShouldEqual, 3)
+			So(flakiness[1].TestFile, ShouldEqual, "c.js")
+
+			other, err := Find(ByProject("other", false))
+			So(err, ShouldBeNil)
+			So(len(other), ShouldEqual, 1)
+		})
+
+		Convey("replacing a project's flakiness with none should remove it", func() {
+			So(ReplaceForProject("p", nil), ShouldBeNil)
+			flakiness, err := Find(ByProject("p", false))
+			So(err, ShouldBeNil)
+			So(len(flakiness), ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,387 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	generatedTasksKey         = "tasks"
+	generatedBuildVariantsKey = "buildvariants"
+	generatedFunctionsKey     = "functions"
+	generatedNameKey          = "name"
+	generatedBVTasksKey       = "tasks"
+	generatedDisplayTasksKey  = "display_tasks"
+)
+
+// MergeGeneratedConfig merges fragments of project configuration uploaded by
+// the generate.tasks command into the given project config, returning the new
+// config. Fragments may only add tasks, functions, build variants and task
+// entries on existing build variants; redefining anything that already exists
+// with a different definition is an error, while identical definitions are
+// ignored so that a restarted generator task can upload the same fragment again.
+func MergeGeneratedConfig(config string, fragments ...[]byte) (string, error) {
+	project := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(config), &project); err != nil {
+		return "", errors.Wrap(err, "error parsing project config")
+	}
+
+	for i, data := range fragments {
+		fragment := yaml.MapSlice{}
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return "", errors.Wrapf(err, "error parsing generated config %d", i)
+		}
+		var err error
+		project, err = mergeGeneratedFragment(project, fragment)
+		if err != nil {
+			return "", errors.Wrapf(err, "error merging generated config %d", i)
+		}
+	}
+
+	out, err := yaml.Marshal(project)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshalling merged project config")
+	}
+	return string(out), nil
+}
+
+// mergeGeneratedFragment adds the tasks, functions and build variants of a
+// single generated fragment to the project.
+func mergeGeneratedFragment(project, fragment yaml.MapSlice) (yaml.MapSlice, error) {
+	for _, item := range fragment {
+		key := fmt.Sprint(item.Key)
+		existing, _ := mapSliceGet(project, key)
+
+		var merged interface{}
+		var err error
+		switch key {
+		case generatedTasksKey:
+			merged, err = mergeNamedList(existing, item.Value, mergeIdentical)
+		case generatedBuildVariantsKey:
+			merged, err = mergeNamedList(existing, item.Value, mergeBuildVariant)
+		case generatedFunctionsKey:
+			merged, err = mergeFunctions(existing, item.Value)
+		default:
+			err = errors.Errorf("generated config cannot set '%v'", key)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error merging %v", key)
+		}
+		project = mapSliceSet(project, key, merged)
+	}
+	return project, nil
+}
+
+// mergeNamedList appends the entries of the generated list to the existing
+// list, using merge to combine entries that share a name.
+func mergeNamedList(existing, generated interface{},
+	merge func(old, new interface{}) (interface{}, error)) ([]interface{}, error) {
+	list, err := toList(existing)
+	if err != nil {
+		return nil, err
+	}
+	additions, err := toList(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range additions {
+		name := entryName(entry)
+		if name == "" {
+			return nil, errors.New("generated entries must have a name")
+		}
+		idx := -1
+		for i := range list {
+			if entryName(list[i]) == name {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			list = append(list, entry)
+			continue
+		}
+		if list[idx], err = merge(list[idx], entry); err != nil {
+			return nil, errors.Wrapf(err, "error merging '%v'", name)
+		}
+	}
+	return list, nil
+}
+
+// mergeIdentical allows an entry to be generated again only if it is
+// unchanged.
+func mergeIdentical(old, new interface{}) (interface{}, error) {
+	if !reflect.DeepEqual(old, new) {
+		return nil, errors.New("already defined with a different definition")
+	}
+	return old, nil
+}
+
+// mergeBuildVariant adds the task and display task entries of a generated
+// build variant to an existing one. Any other fields must be unchanged.
+func mergeBuildVariant(old, new interface{}) (interface{}, error) {
+	oldBV, ok := old.(yaml.MapSlice)
+	if !ok {
+		return nil, errors.New("build variant is not a map")
+	}
+	newBV, ok := new.(yaml.MapSlice)
+	if !ok {
+		return nil, errors.New("build variant is not a map")
+	}
+
+	merged := append(yaml.MapSlice{}, oldBV...)
+	for _, item := range newBV {
+		key := fmt.Sprint(item.Key)
+		existing, found := mapSliceGet(merged, key)
+		switch key {
+		case generatedBVTasksKey, generatedDisplayTasksKey:
+			list, err := mergeNamedList(existing, item.Value, mergeIdentical)
+			if err != nil {
+				return nil, err
+			}
+			merged = mapSliceSet(merged, key, list)
+		default:
+			if !found || !reflect.DeepEqual(existing, item.Value) {
+				return nil, errors.Errorf("cannot change '%v' of an existing build variant", key)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// mergeFunctions adds generated functions to the existing function map.
+func mergeFunctions(existing, generated interface{}) (yaml.MapSlice, error) {
+	functions, ok := existing.(yaml.MapSlice)
+	if existing != nil && !ok {
+		return nil, errors.New("functions are not a map")
+	}
+	additions, ok := generated.(yaml.MapSlice)
+	if generated != nil && !ok {
+		return nil, errors.New("generated functions are not a map")
+	}
+
+	for _, item := range additions {
+		name := fmt.Sprint(item.Key)
+		if old, found := mapSliceGet(functions, name); found {
+			if _, err := mergeIdentical(old, item.Value); err != nil {
+				return nil, errors.Wrapf(err, "error merging '%v'", name)
+			}
+			continue
+		}
+		functions = append(functions, item)
+	}
+	return functions, nil
+}
+
+func toList(value interface{}) ([]interface{}, error) {
+	if value == nil {
+		return []interface{}{}, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected a list, found %T", value)
+	}
+	return append([]interface{}{}, list...), nil
+}
+
+// entryName returns the name of a list entry, which is either the entry
+// itself or its name field.
+func entryName(entry interface{}) string {
+	switch e := entry.(type) {
+	case string:
+		return e
+	case yaml.MapSlice:
+		if name, found := mapSliceGet(e, generatedNameKey); found {
+			return fmt.Sprint(name)
+		}
+	}
+	return ""
+}
+
+func mapSliceGet(ms yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range ms {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+func mapSliceSet(ms yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i := range ms {
+		if fmt.Sprint(ms[i].Key) == key {
+			ms[i].Value = value
+			return ms
+		}
+	}
+	return append(ms, yaml.MapItem{Key: key, Value: value})
+}
+
+// NewGeneratedPairs returns the variant/task pairs that exist in the project
+// after generating tasks but did not exist before.
+func NewGeneratedPairs(old, generated *Project) TVPairSet {
+	existing := map[TVPair]bool{}
+	for _, bv := range old.BuildVariants {
+		for _, t := range bv.Tasks {
+			existing[TVPair{bv.Name, t.Name}] = true
+		}
+	}
+
+	pairs := TVPairSet{}
+	for _, bv := range generated.BuildVariants {
+		if bv.Disabled {
+			continue
+		}
+		for _, t := range bv.Tasks {
+			pair := TVPair{bv.Name, t.Name}
+			if !existing[pair] {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	return pairs
+}
+
+// AddGeneratedTasks creates and activates the given variant/task pairs in the
+// version, adding tasks to builds that already exist and creating builds for
+// variants the version does not have yet. Builds and versions that had
+// finished are marked as started again. Pairs that already have a task are
+// skipped, so it can be called again after failing partway through.
+func AddGeneratedTasks(v *version.Version, project *Project, pairs TVPairSet) error {
+	existingTasks, err := task.Find(task.ByVersion(v.Id).WithFields(
+		task.IdKey, task.DisplayNameKey, task.BuildVariantKey))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	existingPairs := map[TVPair]bool{}
+	for _, t := range existingTasks {
+		existingPairs[TVPair{t.BuildVariant, t.DisplayName}] = true
+	}
+	missingPairs := TVPairSet{}
+	for _, pair := range pairs {
+		if !existingPairs[pair] {
+			missingPairs = append(missingPairs, pair)
+		}
+	}
+	pairs = missingPairs
+
+	// generated tasks may depend on any task already in the version
+	var tt TaskIdTable
+	if v.Requester == evergreen.PatchVersionRequester {
+		tt = NewPatchTaskIdTable(project, v, pairs)
+	} else {
+		tt = NewTaskIdTable(project, v)
+	}
+	for _, t := range existingTasks {
+		tt.AddId(t.BuildVariant, t.DisplayName, t.Id)
+	}
+
+	// a build created by an earlier call may not have been added to the
+	// version yet, so look builds up by version rather than by the version's
+	// build ids
+	builds, err := build.Find(build.ByVersion(v.Id))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	versionBuilds := map[string]bool{}
+	for _, id := range v.BuildIds {
+		versionBuilds[id] = true
+	}
+	newBuildIds := []string{}
+	newBuildStatuses := []version.BuildStatus{}
+	variantsProcessed := map[string]bool{}
+	for _, b := range builds {
+		variantsProcessed[b.BuildVariant] = true
+		if !versionBuilds[b.Id] {
+			newBuildIds = append(newBuildIds, b.Id)
+			newBuildStatuses = append(newBuildStatuses, version.BuildStatus{
+				BuildVariant: b.BuildVariant,
+				BuildId:      b.Id,
+				Activated:    true,
+			})
+		}
+		taskNames := pairs.TaskNames(b.BuildVariant)
+		if len(taskNames) == 0 {
+			continue
+		}
+		buildVariant := project.FindBuildVariant(b.BuildVariant)
+		if buildVariant == nil {
+			return errors.Errorf("could not find build variant %v in project", b.BuildVariant)
+		}
+		if !b.Activated {
+			b.Activated = true
+			if err = build.UpdateActivation(b.Id, true, evergreen.DefaultTaskActivator); err != nil {
+				return errors.Wrapf(err, "error activating build %s", b.Id)
+			}
+		}
+		tasks, err := createTasksForBuild(project, buildVariant, &b, v, tt, taskNames)
+		if err != nil {
+			return errors.Wrapf(err, "error creating tasks for build %s", b.Id)
+		}
+		for _, t := range tasks {
+			grip.Infof("Creating generated task %s in build %s", t.Id, b.Id)
+			if err = t.Insert(); err != nil {
+				return errors.Wrapf(err, "error inserting task %s", t.Id)
+			}
+		}
+		if err = RefreshTasksCache(b.Id); err != nil {
+			return errors.Wrapf(err, "error updating task cache for %s", b.Id)
+		}
+		if b.IsFinished() {
+			if err = b.UpdateStatus(evergreen.BuildStarted); err != nil {
+				return errors.Wrapf(err, "error updating status of build %s", b.Id)
+			}
+		}
+	}
+
+	if v.Status == evergreen.VersionSucceeded || v.Status == evergreen.VersionFailed {
+		err = version.UpdateOne(
+			bson.M{version.IdKey: v.Id},
+			bson.M{"$set": bson.M{version.StatusKey: evergreen.VersionStarted}},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error updating status of version %s", v.Id)
+		}
+		v.Status = evergreen.VersionStarted
+	}
+
+	for _, pair := range pairs {
+		if variantsProcessed[pair.Variant] {
+			continue
+		}
+		variantsProcessed[pair.Variant] = true
+		grip.Infof("Creating generated build for version %s, buildVariant %s", v.Id, pair.Variant)
+		buildId, err := CreateBuildFromVersion(project, v, tt, pair.Variant, true, pairs.TaskNames(pair.Variant))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		newBuildIds = append(newBuildIds, buildId)
+		newBuildStatuses = append(newBuildStatuses, version.BuildStatus{
+			BuildVariant: pair.Variant,
+			BuildId:      buildId,
+			Activated:    true,
+		})
+	}
+	if len(newBuildIds) == 0 {
+		return nil
+	}
+
+	return errors.WithStack(version.UpdateOne(
+		bson.M{version.IdKey: v.Id},
+		bson.M{
+			"$push": bson.M{
+				version.BuildIdsKey:      bson.M{"$each": newBuildIds},
+				version.BuildVariantsKey: bson.M{"$each": newBuildStatuses},
+			},
+		},
+	))
+}
@@ -0,0 +1,228 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const generatorConfig = `
+functions:
+  run:
+    command: shell.exec
+tasks:
+- name: generator
+  commands:
+  - command: generate.tasks
+    params:
+      files: [generated.json]
+buildvariants:
+- name: bv1
+  display_name: Variant One
+  run_on: [d1]
+  tasks:
+  - name: generator
+`
+
+func TestMergeGeneratedConfig(t *testing.T) {
+	Convey("With a project config containing a generator task", t, func() {
+		generated := []byte(`{
+			"functions": {"run_suite": {"command": "shell.exec"}},
+			"tasks": [
+				{"name": "suite1", "commands": [{"func": "run_suite"}], "depends_on": [{"name": "generator"}]},
+				{"name": "suite2", "commands": [{"func": "run_suite"}]}
+			],
+			"buildvariants": [
+				{"name": "bv1", "tasks": ["suite1", {"name": "suite2"}],
+				 "display_tasks": [{"name": "suites", "execution_tasks": ["suite1", "suite2"]}]},
+				{"name": "bv2", "display_name": "Variant Two", "run_on": ["d1"], "tasks": ["suite1"]}
+			]
+		}`)
+
+		Convey("generated tasks, functions and variants should be merged in", func() {
+			config, err := MergeGeneratedConfig(generatorConfig, generated)
+			So(err, ShouldBeNil)
+			p := &Project{}
+			So(LoadProjectInto([]byte(config), "project", p), ShouldBeNil)
+			So(len(p.Tasks), ShouldEqual, 3)
+			So(p.Functions["run"], ShouldNotBeNil)
+			So(p.Functions["run_suite"], ShouldNotBeNil)
+			So(len(p.BuildVariants), ShouldEqual, 2)
+			bv1 := p.FindBuildVariant("bv1")
+			So(bv1.DisplayName, ShouldEqual, "Variant One")
+			So(len(bv1.Tasks), ShouldEqual, 3)
+			So(bv1.FindDisplayTaskForTask("suite2"), ShouldEqual, "suites")
+			So(p.FindBuildVariant("bv2").Tasks[0].Name, ShouldEqual, "suite1")
+
+			Convey("and only the new variant/task pairs should be reported", func() {
+				old := &Project{}
+				So(LoadProjectInto([]byte(generatorConfig), "project", old), ShouldBeNil)
+				So(NewGeneratedPairs(old, p), ShouldResemble, TVPairSet{
+					{"bv1", "suite1"}, {"bv1", "suite2"}, {"bv2", "suite1"},
+				})
+			})
+		})
+
+		Convey("generating the same config again should be a no-op", func() {
+			once, err := MergeGeneratedConfig(generatorConfig, generated)
+			So(err, ShouldBeNil)
+			twice, err := MergeGeneratedConfig(once, generated)
+			So(err, ShouldBeNil)
+			So(twice, ShouldEqual, once)
+		})
+
+		Convey("redefining an existing task should be an error", func() {
+			_, err := MergeGeneratedConfig(generatorConfig,
+				[]byte(`{"tasks": [{"name": "generator", "commands": []}]}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "generator")
+		})
+
+		Convey("changing an existing variant's settings should be an error", func() {
+			_, err := MergeGeneratedConfig(generatorConfig,
+				[]byte(`{"buildvariants": [{"name": "bv1", "run_on": ["d2"]}]}`))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "run_on")
+		})
+
+		Convey("setting other project fields should be an error", func() {
+			_, err := MergeGeneratedConfig(generatorConfig, []byte(`{"pre": []}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("invalid JSON should be an error", func() {
+			_, err := MergeGeneratedConfig(generatorConfig, []byte(`{"tasks": [`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestAddGeneratedTasks(t *testing.T) {
+	Convey("With a version whose generator task has uploaded new tasks", t, func() {
+		testutil.HandleTestingErr(db.ClearCollections(build.Collection, task.Collection, version.Collection), t,
+			"error clearing collections")
+
+		v := &version.Version{
+			Id:         "v1",
+			Revision:   "abcdef",
+			Requester:  evergreen.RepotrackerVersionRequester,
+			CreateTime: time.Now(),
+			BuildIds:   []string{"b1"},
+			Status:     evergreen.VersionSucceeded,
+		}
+		So(v.Insert(), ShouldBeNil)
+		b := &build.Build{Id: "b1", BuildVariant: "bv1", Version: v.Id, Status: evergreen.BuildSucceeded}
+		So(b.Insert(), ShouldBeNil)
+		generator := &task.Task{Id: "generator_id", DisplayName: "generator", BuildId: "b1",
+			BuildVariant: "bv1", Version: v.Id}
+		So(generator.Insert(), ShouldBeNil)
+
+		config, err := MergeGeneratedConfig(generatorConfig, []byte(`{
+			"tasks": [{"name": "suite1", "depends_on": [{"name": "generator"}]}],
+			"buildvariants": [
+				{"name": "bv1", "tasks": ["suite1"]},
+				{"name": "bv2", "run_on": ["d1"], "tasks": ["suite1"]}
+			]
+		}`))
+		So(err, ShouldBeNil)
+		old, project := &Project{}, &Project{}
+		So(LoadProjectInto([]byte(generatorConfig), "project", old), ShouldBeNil)
+		So(LoadProjectInto([]byte(config), "project", project), ShouldBeNil)
+
+		So(AddGeneratedTasks(v, project, NewGeneratedPairs(old, project)), ShouldBeNil)
+
+		Convey("tasks should be added to existing builds and depend on existing tasks", func() {
+			tasks, err := task.Find(task.ByBuildId("b1"))
+			So(err, ShouldBeNil)
+			So(len(tasks), ShouldEqual, 2)
+			var suite task.Task
+			for _, t := range tasks {
+				if t.DisplayName == "suite1" {
+					suite = t
+				}
+			}
+			So(suite.DisplayName, ShouldEqual, "suite1")
+			So(suite.Activated, ShouldBeTrue)
+			So(suite.DependsOn, ShouldResemble, []task.Dependency{{TaskId: "generator_id", Status: "success"}})
+
+			dbBuild, err := build.FindOne(build.ById("b1"))
+			So(err, ShouldBeNil)
+			So(len(dbBuild.Tasks), ShouldEqual, 2)
+		})
+
+		Convey("tasks in mainline versions should get mainline task ids", func() {
+			tasks, err := task.Find(task.ByBuildId("b1"))
+			So(err, ShouldBeNil)
+			ids := []string{}
+			for _, t := range tasks {
+				ids = append(ids, t.Id)
+			}
+			So(ids, ShouldContain, NewTaskIdTable(project, v).GetId("bv1", "suite1"))
+		})
+
+		Convey("existing builds should be activated and restarted", func() {
+			dbBuild, err := build.FindOne(build.ById("b1"))
+			So(err, ShouldBeNil)
+			So(dbBuild.Activated, ShouldBeTrue)
+			So(dbBuild.Status, ShouldEqual, evergreen.BuildStarted)
+		})
+
+		Convey("a finished version should be started again", func() {
+			dbVersion, err := version.FindOne(version.ById(v.Id))
+			So(err, ShouldBeNil)
+			So(dbVersion.Status, ShouldEqual, evergreen.VersionStarted)
+		})
+
+		Convey("builds should be created for new variants", func() {
+			dbVersion, err := version.FindOne(version.ById(v.Id))
+			So(err, ShouldBeNil)
+			So(len(dbVersion.BuildIds), ShouldEqual, 2)
+			So(dbVersion.BuildVariants[0].BuildVariant, ShouldEqual, "bv2")
+			So(dbVersion.BuildVariants[0].Activated, ShouldBeTrue)
+
+			newBuild, err := build.FindOne(build.ById(dbVersion.BuildIds[1]))
+			So(err, ShouldBeNil)
+			So(newBuild.Activated, ShouldBeTrue)
+			So(len(newBuild.Tasks), ShouldEqual, 1)
+			So(newBuild.Tasks[0].DisplayName, ShouldEqual, "suite1")
+		})
+
+		Convey("generating again should not create any task or build twice", func() {
+			dbVersion, err := version.FindOne(version.ById(v.Id))
+			So(err, ShouldBeNil)
+			newBuildId := dbVersion.BuildIds[1]
+			// as if the earlier call failed before adding the new build
+			So(version.UpdateOne(
+				bson.M{version.IdKey: v.Id},
+				bson.M{"$pull": bson.M{
+					version.BuildIdsKey:      newBuildId,
+					version.BuildVariantsKey: bson.M{version.BuildStatusVariantKey: "bv2"},
+				}},
+			), ShouldBeNil)
+			dbVersion, err = version.FindOne(version.ById(v.Id))
+			So(err, ShouldBeNil)
+			So(dbVersion.BuildIds, ShouldResemble, []string{"b1"})
+
+			So(AddGeneratedTasks(dbVersion, project, NewGeneratedPairs(old, project)), ShouldBeNil)
+
+			numTasks, err := task.Count(task.ByVersion(v.Id))
+			So(err, ShouldBeNil)
+			So(numTasks, ShouldEqual, 3)
+			builds, err := build.Find(build.ByVersion(v.Id))
+			So(err, ShouldBeNil)
+			So(len(builds), ShouldEqual, 2)
+			dbVersion, err = version.FindOne(version.ById(v.Id))
+			So(err, ShouldBeNil)
+			So(dbVersion.BuildIds, ShouldResemble, []string{"b1", newBuildId})
+			So(dbVersion.BuildVariants[0].BuildId, ShouldEqual, newBuildId)
+		})
+	})
+}
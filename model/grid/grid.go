@@ -96,9 +96,10 @@ func FetchCells(current version.Version, depth int) (Grid, error) {
 		{"$sort": bson.M{
 			build.RevisionOrderNumberKey: -1,
 		}},
-		// Stage 3: Project only the relevant fields.
+		// Stage 3: Project only the relevant fields, showing display tasks
+		// in place of their execution tasks.
 		{"$project": bson.M{
-			build.TasksKey:    1,
+			build.TasksKey:    build.DisplayedTasksExpression(),
 			build.RevisionKey: 1,
 			"v":               "$" + build.BuildVariantKey,
 		}},
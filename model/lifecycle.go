@@ -36,6 +36,7 @@ func cacheFromTask(t task.Task) build.TaskCache {
 		StartTime:     t.StartTime,
 		TimeTaken:     t.TimeTaken,
 		Activated:     t.Activated,
+		DisplayTask:   t.DisplayTask,
 	}
 }
 
@@ -333,12 +334,16 @@ func CreateTasksCache(tasks []task.Task) []build.TaskCache {
 // state of the tasks it represents.
 func RefreshTasksCache(buildId string) error {
 	tasks, err := task.Find(task.ByBuildId(buildId).WithFields(task.IdKey, task.DisplayNameKey, task.StatusKey,
-		task.DetailsKey, task.StartTimeKey, task.TimeTakenKey, task.ActivatedKey, task.DependsOnKey))
+		task.DetailsKey, task.StartTimeKey, task.TimeTakenKey, task.ActivatedKey, task.DependsOnKey,
+		task.DisplayTaskKey))
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	cache := CreateTasksCache(tasks)
-	return errors.WithStack(build.SetTasksCache(buildId, cache))
+	if err = build.SetTasksCache(buildId, cache); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(build.SetDisplayTasksCache(buildId, build.CreateDisplayTasksCache(cache)))
 }
 
 //AddTasksToBuild creates the tasks for the given build of a project
@@ -439,6 +444,7 @@ func CreateBuildFromVersion(project *Project, v *version.Version, tt TaskIdTable
 		tasks = append(tasks, *taskP)
 	}
 	b.Tasks = CreateTasksCache(tasks)
+	b.DisplayTasks = build.CreateDisplayTasksCache(b.Tasks)
 
 	// insert the build
 	if err := b.Insert(); err != nil {
@@ -644,6 +650,11 @@ func TryMarkPatchBuildFinished(b *build.Build, finishTime time.Time) error {
 // createOneTask is a helper to create a single task.
 func createOneTask(id string, buildVarTask BuildVariantTask, project *Project,
 	buildVariant *BuildVariant, b *build.Build, v *version.Version) *task.Task {
+	var taskGroup string
+	tg, taskGroupOrder := project.FindTaskGroupForTask(buildVarTask.Name)
+	if tg != nil {
+		taskGroup = tg.Name
+	}
 	return &task.Task{
 		Id:                  id,
 		Secret:              util.RandomString(),
@@ -665,6 +676,9 @@ func createOneTask(id string, buildVarTask BuildVariantTask, project *Project,
 		Revision:            v.Revision,
 		Project:             project.Identifier,
 		Priority:            buildVarTask.Priority,
+		DisplayTask:         buildVariant.FindDisplayTaskForTask(buildVarTask.Name),
+		TaskGroup:           taskGroup,
+		TaskGroupOrder:      taskGroupOrder,
 	}
 }
 
@@ -1,6 +1,9 @@
 package patch
 
 import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/bsonutil"
 	"gopkg.in/mgo.v2"
@@ -71,6 +74,22 @@ func ByProject(project string) db.Q {
 	return db.Query(bson.D{{ProjectKey, project}})
 }
 
+// ByProjectFromId produces a query that returns a project's patches from
+// the given patch on, most recent first. If dir is negative, it instead
+// returns the patches more recent than the given patch, least recent first.
+func ByProjectFromId(project string, id bson.ObjectId, dir int) db.Q {
+	if dir < 0 {
+		return db.Query(bson.M{
+			ProjectKey: project,
+			IdKey:      bson.M{"$gt": id},
+		}).Sort([]string{IdKey})
+	}
+	return db.Query(bson.M{
+		ProjectKey: project,
+		IdKey:      bson.M{"$lte": id},
+	}).Sort([]string{"-" + IdKey})
+}
+
 // ByUser produces a query that returns patches by the given user.
 func ByUser(user string) db.Q {
 	return db.Query(bson.D{{AuthorKey, user}})
@@ -91,6 +110,16 @@ func ByVersion(version string) db.Q {
 	return db.Query(bson.D{{VersionKey, version}})
 }
 
+// ByFinishedAfter produces a query that returns the patches in a project
+// that finished after the given time.
+func ByFinishedAfter(finishTime time.Time, project string) db.Q {
+	return db.Query(bson.M{
+		ProjectKey:    project,
+		StatusKey:     bson.M{"$in": []string{evergreen.PatchSucceeded, evergreen.PatchFailed}},
+		FinishTimeKey: bson.M{"$gt": finishTime},
+	})
+}
+
 // ByVersion produces a query that returns the patch for a given version.
 func ByVersions(versions []string) db.Q {
 	return db.Query(bson.M{VersionKey: bson.M{"$in": versions}})
@@ -6,6 +6,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -38,6 +42,8 @@ type Patch struct {
 	Patches       []ModulePatch  `bson:"patches"`
 	Activated     bool           `bson:"activated"`
 	PatchedConfig string         `bson:"patched_config"`
+	// Notify overrides the author's patch notification setting when set.
+	Notify *bool `bson:"notify,omitempty"`
 }
 
 // this stores request details for a patch
@@ -201,7 +207,18 @@ func TryMarkStarted(versionId string, startTime time.Time) error {
 	return err
 }
 
-// TryMarkFinished attempts to mark a patch of a given version as finished.
+// ShouldNotifyAuthor returns whether the patch's author, who has the given
+// settings, should be told that it finished. The patch's own setting, if any,
+// overrides the author's.
+func (p *Patch) ShouldNotifyAuthor(settings user.PatchNotificationSettings) bool {
+	if p.Notify != nil {
+		return *p.Notify
+	}
+	return !settings.Disabled
+}
+
+// TryMarkFinished attempts to mark a patch of a given version as finished,
+// and queues a notification to the patch's author.
 func TryMarkFinished(versionId string, finishTime time.Time, status string) error {
 	filter := bson.M{VersionKey: versionId}
 	update := bson.M{
@@ -210,7 +227,25 @@ func TryMarkFinished(versionId string, finishTime time.Time, status string) erro
 			StatusKey:     status,
 		},
 	}
-	return UpdateOne(filter, update)
+	if err := UpdateOne(filter, update); err != nil {
+		return err
+	}
+	p, err := FindOne(ByVersion(versionId).WithFields(IdKey, ProjectKey))
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return errors.Errorf("patch for version %v not found", versionId)
+	}
+
+	return alert.EnqueueAlertRequest(&alert.AlertRequest{
+		Id:        bson.NewObjectId(),
+		Trigger:   alertrecord.PatchFinishedId,
+		PatchId:   p.Id.Hex(),
+		VersionId: versionId,
+		ProjectId: p.Project,
+		CreatedAt: finishTime,
+	})
 }
 
 // Insert inserts the patch into the db, returning any errors that occur
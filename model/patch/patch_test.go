@@ -3,6 +3,7 @@ package patch
 import (
 	"testing"
 
+	"github.com/evergreen-ci/evergreen/model/user"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -44,3 +45,29 @@ func TestConfigChanged(t *testing.T) {
 		})
 	})
 }
+
+func TestShouldNotifyAuthor(t *testing.T) {
+	Convey("With a patch and its author's settings", t, func() {
+		p := &Patch{Author: "me"}
+		settings := user.PatchNotificationSettings{}
+
+		Convey("the author is notified by default", func() {
+			So(p.ShouldNotifyAuthor(settings), ShouldBeTrue)
+		})
+		Convey("an author who opted out is not notified", func() {
+			settings.Disabled = true
+			So(p.ShouldNotifyAuthor(settings), ShouldBeFalse)
+
+			Convey("unless the patch asks to notify them", func() {
+				notify := true
+				p.Notify = &notify
+				So(p.ShouldNotifyAuthor(settings), ShouldBeTrue)
+			})
+		})
+		Convey("a patch can ask not to notify its author", func() {
+			notify := false
+			p.Notify = &notify
+			So(p.ShouldNotifyAuthor(settings), ShouldBeFalse)
+		})
+	})
+}
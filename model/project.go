@@ -19,6 +19,7 @@ import (
 const (
 	TestCommandType   = "test"
 	SystemCommandType = "system"
+	SetupCommandType  = "setup"
 )
 
 const (
@@ -30,6 +31,7 @@ const (
 type Project struct {
 	Enabled         bool                       `yaml:"enabled,omitempty" bson:"enabled"`
 	Stepback        bool                       `yaml:"stepback,omitempty" bson:"stepback"`
+	Bisect          bool                       `yaml:"bisect,omitempty" bson:"bisect"`
 	BatchTime       int                        `yaml:"batchtime,omitempty" bson:"batch_time"`
 	Owner           string                     `yaml:"owner,omitempty" bson:"owner_name"`
 	Repo            string                     `yaml:"repo,omitempty" bson:"repo_name"`
@@ -48,6 +50,7 @@ type Project struct {
 	BuildVariants   []BuildVariant             `yaml:"buildvariants,omitempty" bson:"build_variants"`
 	Functions       map[string]*YAMLCommandSet `yaml:"functions,omitempty" bson:"functions"`
 	Tasks           []ProjectTask              `yaml:"tasks,omitempty" bson:"tasks"`
+	TaskGroups      []TaskGroup                `yaml:"task_groups,omitempty" bson:"task_groups"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 
 	// Flag that indicates a project as requiring user authentication
@@ -72,6 +75,12 @@ type BuildVariantTask struct {
 
 	// the distros that the task can be run on
 	Distros []string `yaml:"distros,omitempty" bson:"distros"`
+
+	// the number of times the task is automatically retried after failing
+	// in one of the RetryOn ways, and the command types whose failures
+	// trigger a retry. A nil MaxRetries uses the project task's setting.
+	MaxRetries *int     `yaml:"max_retries,omitempty" bson:"max_retries,omitempty"`
+	RetryOn    []string `yaml:"retry_on,omitempty" bson:"retry_on,omitempty"`
 }
 
 // Populate updates the base fields of the BuildVariantTask with
@@ -97,6 +106,12 @@ func (bvt *BuildVariantTask) Populate(pt ProjectTask) {
 	if bvt.Stepback == nil {
 		bvt.Stepback = pt.Stepback
 	}
+	if bvt.MaxRetries == nil {
+		bvt.MaxRetries = pt.MaxRetries
+	}
+	if len(bvt.RetryOn) == 0 {
+		bvt.RetryOn = pt.RetryOn
+	}
 }
 
 // UnmarshalYAML allows tasks to be referenced as single selector strings.
@@ -147,6 +162,63 @@ type BuildVariant struct {
 
 	// all of the tasks to be run on the build variant, compile through tests.
 	Tasks []BuildVariantTask `yaml:"tasks,omitempty" bson:"tasks"`
+
+	// display tasks group several of the variant's tasks into one for
+	// ui purposes
+	DisplayTasks []DisplayTask `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
+}
+
+// DisplayTask is shown in place of the execution tasks it groups together,
+// with a status rolled up from theirs.
+type DisplayTask struct {
+	Name           string   `yaml:"name,omitempty" bson:"name"`
+	ExecutionTasks []string `yaml:"execution_tasks,omitempty" bson:"execution_tasks"`
+}
+
+// FindDisplayTaskForTask returns the name of the display task that the named
+// task is shown as part of on the build variant, or an empty string if none.
+func (bv *BuildVariant) FindDisplayTaskForTask(taskName string) string {
+	for _, dt := range bv.DisplayTasks {
+		for _, et := range dt.ExecutionTasks {
+			if et == taskName {
+				return dt.Name
+			}
+		}
+	}
+	return ""
+}
+
+// TaskGroup is a set of tasks that are dispatched in order to the same host
+// and share a single working directory, so that expensive setup only has to
+// be done once for the whole group.
+type TaskGroup struct {
+	Name  string   `yaml:"name,omitempty" bson:"name"`
+	Tasks []string `yaml:"tasks,omitempty" bson:"tasks"`
+
+	// SetupGroup is run before the first task of the group on a host and
+	// TeardownGroup after the last one, in addition to the pre and post
+	// commands that are run for every task.
+	SetupGroup    *YAMLCommandSet `yaml:"setup_group,omitempty" bson:"setup_group"`
+	TeardownGroup *YAMLCommandSet `yaml:"teardown_group,omitempty" bson:"teardown_group"`
+}
+
+// NextTaskOnVariant returns the name of the first task after the given one in
+// the group that is run on the build variant, or an empty string if the given
+// task is the last member of the group on that variant.
+func (tg *TaskGroup) NextTaskOnVariant(taskName string, bv *BuildVariant) string {
+	found := false
+	for _, name := range tg.Tasks {
+		if !found {
+			found = name == taskName
+			continue
+		}
+		for _, bvt := range bv.Tasks {
+			if bvt.Name == name {
+				return name
+			}
+		}
+	}
+	return ""
 }
 
 type Module struct {
@@ -281,6 +353,13 @@ type ProjectTask struct {
 	//   3. false = overriding the project setting with false
 	Patchable *bool `yaml:"patchable,omitempty" bson:"patchable,omitempty"`
 	Stepback  *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// MaxRetries is the number of times the task is automatically retried
+	// after failing in one of the RetryOn ways, defaulting to none. RetryOn
+	// lists the command types whose failures trigger a retry, defaulting to
+	// system failures.
+	MaxRetries *int     `yaml:"max_retries,omitempty" bson:"max_retries,omitempty"`
+	RetryOn    []string `yaml:"retry_on,omitempty" bson:"retry_on,omitempty"`
 }
 
 type TaskConfig struct {
@@ -646,6 +725,29 @@ func (p *Project) FindProjectTask(name string) *ProjectTask {
 	return nil
 }
 
+func (p *Project) FindTaskGroup(name string) *TaskGroup {
+	for _, tg := range p.TaskGroups {
+		if tg.Name == name {
+			return &tg
+		}
+	}
+	return nil
+}
+
+// FindTaskGroupForTask returns the task group that the named task belongs to
+// along with the task's 1-based position within the group, or nil and 0 if
+// the task is not part of a group.
+func (p *Project) FindTaskGroupForTask(name string) (*TaskGroup, int) {
+	for _, tg := range p.TaskGroups {
+		for i, t := range tg.Tasks {
+			if t == name {
+				return &tg, i + 1
+			}
+		}
+	}
+	return nil, 0
+}
+
 func (p *Project) GetModuleByName(name string) (*Module, error) {
 	for _, v := range p.Modules {
 		if v.Name == name {
@@ -39,6 +39,7 @@ import (
 type parserProject struct {
 	Enabled         bool                       `yaml:"enabled"`
 	Stepback        bool                       `yaml:"stepback"`
+	Bisect          bool                       `yaml:"bisect"`
 	BatchTime       int                        `yaml:"batchtime"`
 	Owner           string                     `yaml:"owner"`
 	Repo            string                     `yaml:"repo"`
@@ -57,6 +58,7 @@ type parserProject struct {
 	BuildVariants   []parserBV                 `yaml:"buildvariants"`
 	Functions       map[string]*YAMLCommandSet `yaml:"functions"`
 	Tasks           []parserTask               `yaml:"tasks"`
+	TaskGroups      []TaskGroup                `yaml:"task_groups"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs"`
 
 	// Matrix code
@@ -75,6 +77,8 @@ type parserTask struct {
 	Tags            parserStringSlice   `yaml:"tags"`
 	Patchable       *bool               `yaml:"patchable"`
 	Stepback        *bool               `yaml:"stepback"`
+	MaxRetries      *int                `yaml:"max_retries"`
+	RetryOn         parserStringSlice   `yaml:"retry_on"`
 }
 
 // helper methods for task tag evaluations
@@ -212,17 +216,18 @@ func (ts *taskSelector) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // parserBV is a helper type storing intermediary variant definitions.
 type parserBV struct {
-	Name        string             `yaml:"name"`
-	DisplayName string             `yaml:"display_name"`
-	Expansions  command.Expansions `yaml:"expansions"`
-	Tags        parserStringSlice  `yaml:"tags"`
-	Modules     parserStringSlice  `yaml:"modules"`
-	Disabled    bool               `yaml:"disabled"`
-	Push        bool               `yaml:"push"`
-	BatchTime   *int               `yaml:"batchtime"`
-	Stepback    *bool              `yaml:"stepback"`
-	RunOn       parserStringSlice  `yaml:"run_on"`
-	Tasks       parserBVTasks      `yaml:"tasks"`
+	Name         string             `yaml:"name"`
+	DisplayName  string             `yaml:"display_name"`
+	Expansions   command.Expansions `yaml:"expansions"`
+	Tags         parserStringSlice  `yaml:"tags"`
+	Modules      parserStringSlice  `yaml:"modules"`
+	Disabled     bool               `yaml:"disabled"`
+	Push         bool               `yaml:"push"`
+	BatchTime    *int               `yaml:"batchtime"`
+	Stepback     *bool              `yaml:"stepback"`
+	RunOn        parserStringSlice  `yaml:"run_on"`
+	Tasks        parserBVTasks      `yaml:"tasks"`
+	DisplayTasks []DisplayTask      `yaml:"display_tasks"`
 
 	// internal matrix stuff
 	matrixId  string
@@ -275,6 +280,8 @@ type parserBVTask struct {
 	Stepback        *bool              `yaml:"stepback"`
 	Distros         parserStringSlice  `yaml:"distros"`
 	RunOn           parserStringSlice  `yaml:"run_on"` // Alias for "Distros" TODO: deprecate Distros
+	MaxRetries      *int               `yaml:"max_retries"`
+	RetryOn         parserStringSlice  `yaml:"retry_on"`
 }
 
 // UnmarshalYAML allows the YAML parser to read both a single selector string or
@@ -404,6 +411,7 @@ func translateProject(pp *parserProject) (*Project, []error) {
 	proj := &Project{
 		Enabled:         pp.Enabled,
 		Stepback:        pp.Stepback,
+		Bisect:          pp.Bisect,
 		BatchTime:       pp.BatchTime,
 		Owner:           pp.Owner,
 		Repo:            pp.Repo,
@@ -420,6 +428,7 @@ func translateProject(pp *parserProject) (*Project, []error) {
 		CallbackTimeout: pp.CallbackTimeout,
 		Modules:         pp.Modules,
 		Functions:       pp.Functions,
+		TaskGroups:      pp.TaskGroups,
 		ExecTimeoutSecs: pp.ExecTimeoutSecs,
 	}
 	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
@@ -465,6 +474,8 @@ func evaluateTasks(tse *taskSelectorEvaluator, vse *variantSelectorEvaluator,
 			Tags:            pt.Tags,
 			Patchable:       pt.Patchable,
 			Stepback:        pt.Stepback,
+			MaxRetries:      pt.MaxRetries,
+			RetryOn:         pt.RetryOn,
 		}
 		t.DependsOn, errs = evaluateDependsOn(tse, vse, pt.DependsOn)
 		evalErrs = append(evalErrs, errs...)
@@ -483,16 +494,17 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, vse *variantSelectorEvalu
 	var evalErrs, errs []error
 	for _, pbv := range pbvs {
 		bv := BuildVariant{
-			DisplayName: pbv.DisplayName,
-			Name:        pbv.Name,
-			Expansions:  pbv.Expansions,
-			Modules:     pbv.Modules,
-			Disabled:    pbv.Disabled,
-			Push:        pbv.Push,
-			BatchTime:   pbv.BatchTime,
-			Stepback:    pbv.Stepback,
-			RunOn:       pbv.RunOn,
-			Tags:        pbv.Tags,
+			DisplayName:  pbv.DisplayName,
+			Name:         pbv.Name,
+			Expansions:   pbv.Expansions,
+			Modules:      pbv.Modules,
+			Disabled:     pbv.Disabled,
+			Push:         pbv.Push,
+			BatchTime:    pbv.BatchTime,
+			Stepback:     pbv.Stepback,
+			RunOn:        pbv.RunOn,
+			Tags:         pbv.Tags,
+			DisplayTasks: pbv.DisplayTasks,
 		}
 		bv.Tasks, errs = evaluateBVTasks(tse, vse, pbv.Tasks)
 		// evaluate any rules passed in during matrix construction
@@ -572,6 +584,8 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, vse *variantSelectorEvaluator,
 				ExecTimeoutSecs: pt.ExecTimeoutSecs,
 				Stepback:        pt.Stepback,
 				Distros:         pt.Distros,
+				MaxRetries:      pt.MaxRetries,
+				RetryOn:         pt.RetryOn,
 			}
 			t.DependsOn, errs = evaluateDependsOn(tse, vse, pt.DependsOn)
 			evalErrs = append(evalErrs, errs...)
@@ -539,3 +539,145 @@ func TestParserTaskSelectorEvaluation(t *testing.T) {
 		})
 	})
 }
+
+func TestParseTaskRetries(t *testing.T) {
+	Convey("With a project that configures task retries", t, func() {
+		yml := `
+tasks:
+- name: compile
+  max_retries: 2
+  retry_on: [system, setup]
+- name: test
+  max_retries: 1
+buildvariants:
+- name: v1
+  run_on: "d1"
+  tasks:
+  - name: compile
+  - name: test
+    max_retries: 3
+    retry_on: setup
+- name: v2
+  run_on: "d1"
+  tasks:
+  - name: compile
+    max_retries: 0
+`
+		p := &Project{}
+		So(LoadProjectInto([]byte(yml), "id", p), ShouldBeNil)
+
+		Convey("project tasks should have their retry settings", func() {
+			So(*p.Tasks[0].MaxRetries, ShouldEqual, 2)
+			So(p.Tasks[0].RetryOn, ShouldResemble, []string{SystemCommandType, SetupCommandType})
+			So(*p.Tasks[1].MaxRetries, ShouldEqual, 1)
+			So(len(p.Tasks[1].RetryOn), ShouldEqual, 0)
+		})
+
+		Convey("build variant tasks should inherit or override the settings", func() {
+			bvt := p.FindTaskForVariant("compile", "v1")
+			So(*bvt.MaxRetries, ShouldEqual, 2)
+			So(bvt.RetryOn, ShouldResemble, []string{SystemCommandType, SetupCommandType})
+			bvt = p.FindTaskForVariant("test", "v1")
+			So(*bvt.MaxRetries, ShouldEqual, 3)
+			So(bvt.RetryOn, ShouldResemble, []string{SetupCommandType})
+		})
+
+		Convey("build variant tasks should be able to turn off retries", func() {
+			bvt := p.FindTaskForVariant("compile", "v2")
+			So(*bvt.MaxRetries, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestParseTaskGroups(t *testing.T) {
+	Convey("With a project that defines a task group", t, func() {
+		yml := `
+tasks:
+- name: compile
+- name: test
+- name: lint
+task_groups:
+- name: build_and_test
+  tasks: [compile, test]
+  setup_group:
+  - command: git.get_project
+  teardown_group:
+    command: shell.exec
+buildvariants:
+- name: v1
+  run_on: "d1"
+  tasks:
+  - name: compile
+  - name: lint
+- name: v2
+  run_on: "d1"
+  tasks:
+  - name: compile
+  - name: test
+`
+		p := &Project{}
+		So(LoadProjectInto([]byte(yml), "id", p), ShouldBeNil)
+
+		Convey("the group should be parsed with its commands", func() {
+			So(len(p.TaskGroups), ShouldEqual, 1)
+			tg := p.FindTaskGroup("build_and_test")
+			So(tg, ShouldNotBeNil)
+			So(tg.Tasks, ShouldResemble, []string{"compile", "test"})
+			So(len(tg.SetupGroup.List()), ShouldEqual, 1)
+			So(tg.SetupGroup.List()[0].Command, ShouldEqual, "git.get_project")
+			So(len(tg.TeardownGroup.List()), ShouldEqual, 1)
+			So(p.FindTaskGroup("nonexistent"), ShouldBeNil)
+		})
+
+		Convey("tasks should be found in their group along with their position", func() {
+			tg, order := p.FindTaskGroupForTask("test")
+			So(tg.Name, ShouldEqual, "build_and_test")
+			So(order, ShouldEqual, 2)
+			tg, order = p.FindTaskGroupForTask("lint")
+			So(tg, ShouldBeNil)
+			So(order, ShouldEqual, 0)
+		})
+
+		Convey("the next group member should only be found on variants that run it", func() {
+			tg := p.FindTaskGroup("build_and_test")
+			So(tg.NextTaskOnVariant("compile", p.FindBuildVariant("v2")), ShouldEqual, "test")
+			So(tg.NextTaskOnVariant("compile", p.FindBuildVariant("v1")), ShouldEqual, "")
+			So(tg.NextTaskOnVariant("test", p.FindBuildVariant("v2")), ShouldEqual, "")
+		})
+	})
+}
+
+func TestParseDisplayTasks(t *testing.T) {
+	Convey("With a project whose build variant defines a display task", t, func() {
+		yml := `
+tasks:
+- name: compile
+- name: test1
+- name: test2
+buildvariants:
+- name: v1
+  run_on: "d1"
+  tasks:
+  - name: compile
+  - name: test1
+  - name: test2
+  display_tasks:
+  - name: tests
+    execution_tasks: [test1, test2]
+`
+		p := &Project{}
+		So(LoadProjectInto([]byte(yml), "id", p), ShouldBeNil)
+		bv := p.FindBuildVariant("v1")
+
+		Convey("the display task should be parsed with its execution tasks", func() {
+			So(bv.DisplayTasks, ShouldResemble, []DisplayTask{
+				{Name: "tests", ExecutionTasks: []string{"test1", "test2"}},
+			})
+		})
+
+		Convey("execution tasks should be found in their display task", func() {
+			So(bv.FindDisplayTaskForTask("test2"), ShouldEqual, "tests")
+			So(bv.FindDisplayTaskForTask("compile"), ShouldEqual, "")
+		})
+	})
+}
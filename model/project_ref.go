@@ -5,6 +5,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -28,9 +29,22 @@ type ProjectRef struct {
 	//Tracked determines whether or not the project is discoverable in the UI
 	Tracked bool `bson:"tracked" json:"tracked"`
 
+	// RepoPath is the location on disk of the local or mirrored git
+	// repository to poll when RepoKind is "git".
+	RepoPath string `bson:"repo_path" json:"repo_path" yaml:"repo_path"`
+
+	// ShareWeight is the project's relative share of host time when the
+	// scheduler runs in fair-share mode. 0 means unset and is treated as 1.
+	// Only superusers may change it.
+	ShareWeight int `bson:"share_weight" json:"share_weight" yaml:"share_weight"`
+
 	// Admins contain a list of users who are able to access the projects page.
 	Admins []string `bson:"admins" json:"admins"`
 
+	// Roles give users and groups roles in the project. Projects without any
+	// roles let every logged in user control their tasks.
+	Roles []ProjectRole `bson:"roles,omitempty" json:"roles"`
+
 	// The "Alerts" field is a map of trigger (e.g. 'task-failed') to
 	// the set of alert deliveries to be processed for that trigger.
 	Alerts map[string][]AlertConfig `bson:"alert_settings" json:"alert_config,omitempty"`
@@ -56,6 +70,9 @@ type AlertConfig struct {
 	Settings bson.M `bson:"settings" json:"settings"`
 }
 
+// alertSecretSettings are the alert settings that hold secrets.
+var alertSecretSettings = []string{"secret"}
+
 type EmailAlertData struct {
 	Recipients []string `bson:"recipients"`
 }
@@ -66,6 +83,7 @@ var (
 	ProjectRefRepoKey               = bsonutil.MustHaveTag(ProjectRef{}, "Repo")
 	ProjectRefBranchKey             = bsonutil.MustHaveTag(ProjectRef{}, "Branch")
 	ProjectRefRepoKindKey           = bsonutil.MustHaveTag(ProjectRef{}, "RepoKind")
+	ProjectRefRepoPathKey           = bsonutil.MustHaveTag(ProjectRef{}, "RepoPath")
 	ProjectRefEnabledKey            = bsonutil.MustHaveTag(ProjectRef{}, "Enabled")
 	ProjectRefPrivateKey            = bsonutil.MustHaveTag(ProjectRef{}, "Private")
 	ProjectRefBatchTimeKey          = bsonutil.MustHaveTag(ProjectRef{}, "BatchTime")
@@ -78,10 +96,15 @@ var (
 	ProjectRefAlertsKey             = bsonutil.MustHaveTag(ProjectRef{}, "Alerts")
 	ProjectRefRepotrackerError      = bsonutil.MustHaveTag(ProjectRef{}, "RepotrackerError")
 	ProjectRefAdminsKey             = bsonutil.MustHaveTag(ProjectRef{}, "Admins")
+	ProjectRefRolesKey              = bsonutil.MustHaveTag(ProjectRef{}, "Roles")
+	ProjectRefShareWeightKey        = bsonutil.MustHaveTag(ProjectRef{}, "ShareWeight")
 )
 
 const (
 	ProjectRefCollection = "project_ref"
+
+	// MaxShareWeight is the largest share weight a project may be given.
+	MaxShareWeight = 100
 )
 
 func (projectRef *ProjectRef) Insert() error {
@@ -165,6 +188,7 @@ func (projectRef *ProjectRef) Upsert() error {
 		bson.M{
 			"$set": bson.M{
 				ProjectRefRepoKindKey:           projectRef.RepoKind,
+				ProjectRefRepoPathKey:           projectRef.RepoPath,
 				ProjectRefEnabledKey:            projectRef.Enabled,
 				ProjectRefPrivateKey:            projectRef.Private,
 				ProjectRefBatchTimeKey:          projectRef.BatchTime,
@@ -180,17 +204,74 @@ func (projectRef *ProjectRef) Upsert() error {
 				ProjectRefAlertsKey:             projectRef.Alerts,
 				ProjectRefRepotrackerError:      projectRef.RepotrackerError,
 				ProjectRefAdminsKey:             projectRef.Admins,
+				ProjectRefRolesKey:              projectRef.Roles,
+				ProjectRefShareWeightKey:        projectRef.ShareWeight,
 			},
 		},
 	)
 	return err
 }
 
+// RemoveProjectRef removes the project ref with the given identifier from
+// the db.
+func RemoveProjectRef(identifier string) error {
+	return db.Remove(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey: identifier,
+		},
+	)
+}
+
+// WithRedactedSecrets returns a copy of the project ref with the secrets in
+// its alert settings, such as webhook signing secrets, blanked out so that
+// it can be logged.
+func (projectRef *ProjectRef) WithRedactedSecrets() ProjectRef {
+	redacted := *projectRef
+	if projectRef.Alerts == nil {
+		return redacted
+	}
+	redacted.Alerts = map[string][]AlertConfig{}
+	for triggerId, alerts := range projectRef.Alerts {
+		redactedAlerts := make([]AlertConfig, 0, len(alerts))
+		for _, alert := range alerts {
+			settings := bson.M{}
+			for name, value := range alert.Settings {
+				if util.SliceContains(alertSecretSettings, name) {
+					value = ""
+				}
+				settings[name] = value
+			}
+			redactedAlerts = append(redactedAlerts, AlertConfig{Provider: alert.Provider, Settings: settings})
+		}
+		redacted.Alerts[triggerId] = redactedAlerts
+	}
+	return redacted
+}
+
 // ProjectRef returns a string representation of a ProjectRef
 func (projectRef *ProjectRef) String() string {
 	return projectRef.Identifier
 }
 
+// GetShareWeight returns the fair-share scheduling weight of the ProjectRef
+func (p *ProjectRef) GetShareWeight() int {
+	if p.ShareWeight <= 0 {
+		return 1
+	}
+	return p.ShareWeight
+}
+
+// ValidateShareWeight checks that the project's share weight is either unset
+// or between 1 and MaxShareWeight.
+func (p *ProjectRef) ValidateShareWeight() error {
+	if p.ShareWeight < 0 || p.ShareWeight > MaxShareWeight {
+		return errors.Errorf("share weight %v must be between 1 and %v",
+			p.ShareWeight, MaxShareWeight)
+	}
+	return nil
+}
+
 // GetBatchTime returns the Batch Time of the ProjectRef
 func (p *ProjectRef) GetBatchTime(variant *BuildVariant) int {
 	if variant.BatchTime != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func TestFindOneProjectRef(t *testing.T) {
@@ -37,3 +38,56 @@ func TestFindOneProjectRef(t *testing.T) {
 		})
 	})
 }
+
+func TestProjectRefWithRedactedSecrets(t *testing.T) {
+	Convey("With a project ref with webhook and email alerts", t, func() {
+		projectRef := &ProjectRef{
+			Identifier: "project",
+			Alerts: map[string][]AlertConfig{
+				"task_failed": {
+					{Provider: "webhook", Settings: bson.M{"url": "https://example.com", "secret": "shh"}},
+					{Provider: "email", Settings: bson.M{"recipient": "me@example.com"}},
+				},
+			},
+		}
+
+		redacted := projectRef.WithRedactedSecrets()
+
+		Convey("the copy's secrets should be blanked out", func() {
+			So(redacted.Alerts["task_failed"], ShouldResemble, []AlertConfig{
+				{Provider: "webhook", Settings: bson.M{"url": "https://example.com", "secret": ""}},
+				{Provider: "email", Settings: bson.M{"recipient": "me@example.com"}},
+			})
+		})
+
+		Convey("the original's secrets should be kept", func() {
+			So(projectRef.Alerts["task_failed"][0].Settings["secret"], ShouldEqual, "shh")
+		})
+	})
+}
+
+func TestValidateShareWeight(t *testing.T) {
+	Convey("With a project ref", t, func() {
+		projectRef := &ProjectRef{Identifier: "project"}
+
+		Convey("an unset share weight should be valid and count as 1", func() {
+			So(projectRef.ValidateShareWeight(), ShouldBeNil)
+			So(projectRef.GetShareWeight(), ShouldEqual, 1)
+		})
+
+		Convey("share weights up to the maximum should be valid", func() {
+			for _, w := range []int{1, 10, MaxShareWeight} {
+				projectRef.ShareWeight = w
+				So(projectRef.ValidateShareWeight(), ShouldBeNil)
+				So(projectRef.GetShareWeight(), ShouldEqual, w)
+			}
+		})
+
+		Convey("negative or oversized share weights should be invalid", func() {
+			for _, w := range []int{-1, MaxShareWeight + 1, 1000000} {
+				projectRef.ShareWeight = w
+				So(projectRef.ValidateShareWeight(), ShouldNotBeNil)
+			}
+		})
+	})
+}
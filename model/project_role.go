@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// Roles that users and groups can be given in a project. Each role allows
+// everything that the roles before it do.
+const (
+	// ProjectRoleViewer may see the project, even if it is private.
+	ProjectRoleViewer = "viewer"
+	// ProjectRolePatchSubmitter may also submit, finalize and cancel patches.
+	ProjectRolePatchSubmitter = "patch_submitter"
+	// ProjectRoleTaskController may also schedule, restart, abort and
+	// prioritize the project's tasks, builds and versions.
+	ProjectRoleTaskController = "task_controller"
+	// ProjectRoleAdmin may also change the project's settings.
+	ProjectRoleAdmin = "project_admin"
+)
+
+// ValidProjectRoles are the roles a project can give, from least to most
+// privileged.
+var ValidProjectRoles = []string{ProjectRoleViewer, ProjectRolePatchSubmitter,
+	ProjectRoleTaskController, ProjectRoleAdmin}
+
+// ProjectRole gives a role in a project to either a user, by id, or a group
+// of users, by name.
+type ProjectRole struct {
+	Role  string `bson:"role" json:"role" yaml:"role"`
+	User  string `bson:"user,omitempty" json:"user,omitempty" yaml:"user,omitempty"`
+	Group string `bson:"group,omitempty" json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// projectRoleRank returns the position of the role in ValidProjectRoles, or
+// -1 if it isn't a valid role.
+func projectRoleRank(role string) int {
+	for ix, r := range ValidProjectRoles {
+		if r == role {
+			return ix
+		}
+	}
+	return -1
+}
+
+// ProjectRoleAllows returns whether a user with the given role may do what
+// the required role allows.
+func ProjectRoleAllows(role, required string) bool {
+	rank := projectRoleRank(role)
+	return rank >= 0 && rank >= projectRoleRank(required)
+}
+
+// ValidateRoles checks that each of the project's roles is valid and is
+// given to either a user or a group.
+func (projectRef *ProjectRef) ValidateRoles() error {
+	errs := []string{}
+	for _, r := range projectRef.Roles {
+		if projectRoleRank(r.Role) < 0 {
+			errs = append(errs, fmt.Sprintf("invalid role '%v', must be one of %v",
+				r.Role, ValidProjectRoles))
+		}
+		if (r.User == "") == (r.Group == "") {
+			errs = append(errs, fmt.Sprintf("role '%v' must be given to either a user or a group",
+				r.Role))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// UserRole returns the most privileged role that the user with the given id
+// and groups has in the project, or the empty string if they have none. An
+// empty user id is a user who isn't logged in. Project admins are always
+// project_admin. Projects without any roles keep evergreen's defaults, where
+// every logged in user is a task_controller, and the public may view public
+// projects.
+func (projectRef *ProjectRef) UserRole(userId string, groups []string) string {
+	if userId != "" && util.SliceContains(projectRef.Admins, userId) {
+		return ProjectRoleAdmin
+	}
+
+	role := ""
+	for _, r := range projectRef.Roles {
+		if userId != "" && (r.User == userId || (r.Group != "" && util.SliceContains(groups, r.Group))) &&
+			projectRoleRank(r.Role) > projectRoleRank(role) {
+			role = r.Role
+		}
+	}
+	if role != "" {
+		return role
+	}
+
+	if userId != "" && len(projectRef.Roles) == 0 {
+		return ProjectRoleTaskController
+	}
+	if !projectRef.Private {
+		return ProjectRoleViewer
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProjectRoleAllows(t *testing.T) {
+	Convey("Each role should allow what the roles before it do", t, func() {
+		So(ProjectRoleAllows(ProjectRoleAdmin, ProjectRoleViewer), ShouldBeTrue)
+		So(ProjectRoleAllows(ProjectRoleTaskController, ProjectRolePatchSubmitter), ShouldBeTrue)
+		So(ProjectRoleAllows(ProjectRolePatchSubmitter, ProjectRolePatchSubmitter), ShouldBeTrue)
+		So(ProjectRoleAllows(ProjectRolePatchSubmitter, ProjectRoleTaskController), ShouldBeFalse)
+		So(ProjectRoleAllows(ProjectRoleViewer, ProjectRoleAdmin), ShouldBeFalse)
+	})
+	Convey("No role should allow nothing", t, func() {
+		So(ProjectRoleAllows("", ProjectRoleViewer), ShouldBeFalse)
+		So(ProjectRoleAllows("owner", ProjectRoleViewer), ShouldBeFalse)
+	})
+}
+
+func TestProjectRefUserRole(t *testing.T) {
+	Convey("With a project ref without any roles", t, func() {
+		projectRef := &ProjectRef{Identifier: "mci", Admins: []string{"admin"}}
+
+		Convey("admins should be project admins", func() {
+			So(projectRef.UserRole("admin", nil), ShouldEqual, ProjectRoleAdmin)
+		})
+		Convey("logged in users should control tasks", func() {
+			So(projectRef.UserRole("user", nil), ShouldEqual, ProjectRoleTaskController)
+			projectRef.Private = true
+			So(projectRef.UserRole("user", nil), ShouldEqual, ProjectRoleTaskController)
+		})
+		Convey("the public should only view public projects", func() {
+			So(projectRef.UserRole("", nil), ShouldEqual, ProjectRoleViewer)
+			projectRef.Private = true
+			So(projectRef.UserRole("", nil), ShouldEqual, "")
+		})
+	})
+
+	Convey("With a project ref with roles", t, func() {
+		projectRef := &ProjectRef{
+			Identifier: "mci",
+			Admins:     []string{"admin"},
+			Roles: []ProjectRole{
+				{Role: ProjectRoleViewer, User: "user"},
+				{Role: ProjectRoleTaskController, Group: "build-team"},
+				{Role: ProjectRolePatchSubmitter, Group: "dev-team"},
+			},
+		}
+
+		Convey("users should have the roles given to them", func() {
+			So(projectRef.UserRole("user", nil), ShouldEqual, ProjectRoleViewer)
+			So(projectRef.UserRole("admin", nil), ShouldEqual, ProjectRoleAdmin)
+		})
+		Convey("users should have the most privileged role of their groups", func() {
+			So(projectRef.UserRole("user", []string{"dev-team"}), ShouldEqual, ProjectRolePatchSubmitter)
+			So(projectRef.UserRole("other", []string{"dev-team", "build-team"}),
+				ShouldEqual, ProjectRoleTaskController)
+		})
+		Convey("other users should only view public projects", func() {
+			So(projectRef.UserRole("other", []string{"qa-team"}), ShouldEqual, ProjectRoleViewer)
+			So(projectRef.UserRole("", nil), ShouldEqual, ProjectRoleViewer)
+			projectRef.Private = true
+			So(projectRef.UserRole("other", []string{"qa-team"}), ShouldEqual, "")
+			So(projectRef.UserRole("", nil), ShouldEqual, "")
+			So(projectRef.UserRole("user", nil), ShouldEqual, ProjectRoleViewer)
+		})
+	})
+}
+
+func TestProjectRefValidateRoles(t *testing.T) {
+	Convey("With a project ref", t, func() {
+		projectRef := &ProjectRef{Identifier: "mci"}
+
+		Convey("roles given to a user or a group should be valid", func() {
+			projectRef.Roles = []ProjectRole{
+				{Role: ProjectRoleViewer, User: "user"},
+				{Role: ProjectRoleAdmin, Group: "dev-team"},
+			}
+			So(projectRef.ValidateRoles(), ShouldBeNil)
+		})
+		Convey("unknown roles should be invalid", func() {
+			projectRef.Roles = []ProjectRole{{Role: "owner", User: "user"}}
+			So(projectRef.ValidateRoles(), ShouldNotBeNil)
+		})
+		Convey("roles should be given to exactly one user or group", func() {
+			projectRef.Roles = []ProjectRole{{Role: ProjectRoleViewer}}
+			So(projectRef.ValidateRoles(), ShouldNotBeNil)
+			projectRef.Roles = []ProjectRole{{Role: ProjectRoleViewer, User: "user", Group: "dev-team"}}
+			So(projectRef.ValidateRoles(), ShouldNotBeNil)
+		})
+	})
+}
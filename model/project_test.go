@@ -271,3 +271,7 @@ func TestIgnoresAllFiles(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func intPtr(i int) *int {
+	return &i
+}
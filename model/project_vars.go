@@ -3,6 +3,8 @@ package model
 import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -10,6 +12,7 @@ import (
 var (
 	ProjectVarIdKey   = bsonutil.MustHaveTag(ProjectVars{}, "Id")
 	ProjectVarsMapKey = bsonutil.MustHaveTag(ProjectVars{}, "Vars")
+	PrivateVarsMapKey = bsonutil.MustHaveTag(ProjectVars{}, "PrivateVars")
 )
 
 const (
@@ -27,6 +30,10 @@ type ProjectVars struct {
 
 	//The actual mapping of variables for this project
 	Vars map[string]string `bson:"vars" json:"vars"`
+
+	//The names of the variables whose values are secret. Their values are
+	//stored encrypted, are never shown in the UI, and are masked in task logs.
+	PrivateVars map[string]bool `bson:"private_vars" json:"private_vars"`
 }
 
 func FindOneProjectVars(projectId string) (*ProjectVars, error) {
@@ -58,7 +65,97 @@ func (projectVars *ProjectVars) Upsert() (*mgo.ChangeInfo, error) {
 		bson.M{
 			"$set": bson.M{
 				ProjectVarsMapKey: projectVars.Vars,
+				PrivateVarsMapKey: projectVars.PrivateVars,
 			},
 		},
 	)
 }
+
+// RemoveProjectVars removes the variables of the project with the given id,
+// if it has any.
+func RemoveProjectVars(projectId string) error {
+	err := db.Remove(
+		ProjectVarsCollection,
+		bson.M{
+			ProjectVarIdKey: projectId,
+		},
+	)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// EncryptPrivateVars encrypts the values of the private variables with the
+// given key so that they can be stored. Since the UI never receives the
+// values of private variables, a private variable submitted without a value
+// keeps its value from saved, the currently stored variables.
+func (projectVars *ProjectVars) EncryptPrivateVars(key string, saved *ProjectVars) error {
+	for name, private := range projectVars.PrivateVars {
+		value, ok := projectVars.Vars[name]
+		if !private || !ok {
+			delete(projectVars.PrivateVars, name)
+			continue
+		}
+		if value == "" && saved != nil && saved.PrivateVars[name] {
+			projectVars.Vars[name] = saved.Vars[name]
+			continue
+		}
+		if key == "" {
+			return errors.New("no key is configured for encrypting private project variables")
+		}
+		encrypted, err := util.EncryptString(key, value)
+		if err != nil {
+			return errors.Wrapf(err, "error encrypting project variable %v", name)
+		}
+		projectVars.Vars[name] = encrypted
+	}
+	return nil
+}
+
+// DecryptPrivateVars decrypts the values of the private variables in place
+// with the key they were encrypted with.
+func (projectVars *ProjectVars) DecryptPrivateVars(key string) error {
+	for name, private := range projectVars.PrivateVars {
+		value, ok := projectVars.Vars[name]
+		if !private || !ok {
+			continue
+		}
+		decrypted, err := util.DecryptString(key, value)
+		if err != nil {
+			return errors.Wrapf(err, "error decrypting project variable %v", name)
+		}
+		projectVars.Vars[name] = decrypted
+	}
+	return nil
+}
+
+// HashedVars returns a copy of the variables in which the values of the
+// private variables, encrypted with the given key, are replaced with keyed
+// hashes of their decrypted values. Since encrypting the same value twice
+// gives different ciphertexts, comparing the copies rather than the stored
+// variables is how to tell which private variables actually changed.
+func (projectVars *ProjectVars) HashedVars(key string) (map[string]string, error) {
+	hashed := make(map[string]string, len(projectVars.Vars))
+	for name, value := range projectVars.Vars {
+		if projectVars.PrivateVars[name] {
+			decrypted, err := util.DecryptString(key, value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decrypting project variable %v", name)
+			}
+			value = util.HashString(key, decrypted)
+		}
+		hashed[name] = value
+	}
+	return hashed, nil
+}
+
+// RedactPrivateVars blanks out the values of the private variables so that
+// the variables can be shown to users.
+func (projectVars *ProjectVars) RedactPrivateVars() {
+	for name, private := range projectVars.PrivateVars {
+		if _, ok := projectVars.Vars[name]; private && ok {
+			projectVars.Vars[name] = ""
+		}
+	}
+}
@@ -32,3 +32,74 @@ func TestFindOneProjectVar(t *testing.T) {
 		})
 	})
 }
+
+func TestPrivateProjectVars(t *testing.T) {
+	Convey("With project vars that include a private variable", t, func() {
+		key := "project vars key"
+		projectVars := ProjectVars{
+			Id:          "mongodb",
+			Vars:        map[string]string{"a": "b", "secret": "hunter2"},
+			PrivateVars: map[string]bool{"secret": true},
+		}
+
+		Convey("encrypting should only change the private values", func() {
+			So(projectVars.EncryptPrivateVars(key, nil), ShouldBeNil)
+			So(projectVars.Vars["a"], ShouldEqual, "b")
+			So(projectVars.Vars["secret"], ShouldNotEqual, "hunter2")
+
+			Convey("and decrypting should restore them", func() {
+				So(projectVars.DecryptPrivateVars(key), ShouldBeNil)
+				So(projectVars.Vars["secret"], ShouldEqual, "hunter2")
+			})
+
+			Convey("and a private variable saved without a value should keep its stored value", func() {
+				updated := ProjectVars{
+					Id:          "mongodb",
+					Vars:        map[string]string{"a": "c", "secret": ""},
+					PrivateVars: map[string]bool{"secret": true},
+				}
+				So(updated.EncryptPrivateVars(key, &projectVars), ShouldBeNil)
+				So(updated.Vars["secret"], ShouldEqual, projectVars.Vars["secret"])
+			})
+		})
+
+		Convey("hashing should give equal values for a re-encrypted private variable", func() {
+			So(projectVars.EncryptPrivateVars(key, nil), ShouldBeNil)
+			before, err := projectVars.HashedVars(key)
+			So(err, ShouldBeNil)
+			So(before["a"], ShouldEqual, "b")
+			So(before["secret"], ShouldNotEqual, "hunter2")
+
+			same := ProjectVars{
+				Id:          "mongodb",
+				Vars:        map[string]string{"a": "b", "secret": "hunter2"},
+				PrivateVars: map[string]bool{"secret": true},
+			}
+			So(same.EncryptPrivateVars(key, nil), ShouldBeNil)
+			So(same.Vars["secret"], ShouldNotEqual, projectVars.Vars["secret"])
+			after, err := same.HashedVars(key)
+			So(err, ShouldBeNil)
+			So(after, ShouldResemble, before)
+
+			changed := ProjectVars{
+				Id:          "mongodb",
+				Vars:        map[string]string{"a": "b", "secret": "hunter3"},
+				PrivateVars: map[string]bool{"secret": true},
+			}
+			So(changed.EncryptPrivateVars(key, nil), ShouldBeNil)
+			after, err = changed.HashedVars(key)
+			So(err, ShouldBeNil)
+			So(after["secret"], ShouldNotEqual, before["secret"])
+		})
+
+		Convey("encrypting without a key should fail", func() {
+			So(projectVars.EncryptPrivateVars("", nil), ShouldNotBeNil)
+		})
+
+		Convey("redacting should blank out the private values", func() {
+			projectVars.RedactPrivateVars()
+			So(projectVars.Vars["a"], ShouldEqual, "b")
+			So(projectVars.Vars["secret"], ShouldEqual, "")
+		})
+	})
+}
@@ -32,11 +32,12 @@ const (
 
 const (
 	GithubRepoType = "github"
+	GitRepoType    = "git"
 )
 
-// valid repositories - currently only github supported
+// valid repositories - github, or a local/mirrored git repository
 var (
-	ValidRepoTypes = []string{GithubRepoType}
+	ValidRepoTypes = []string{GithubRepoType, GitRepoType}
 )
 
 type Revision struct {
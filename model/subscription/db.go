@@ -0,0 +1,83 @@
+package subscription
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	Collection = "subscriptions"
+)
+
+var (
+	IdKey         = bsonutil.MustHaveTag(Subscription{}, "Id")
+	TriggerKey    = bsonutil.MustHaveTag(Subscription{}, "Trigger")
+	SelectorKey   = bsonutil.MustHaveTag(Subscription{}, "Selector")
+	SubscriberKey = bsonutil.MustHaveTag(Subscription{}, "Subscriber")
+	OwnerKey      = bsonutil.MustHaveTag(Subscription{}, "Owner")
+
+	SelectorProjectKey = bsonutil.MustHaveTag(Selector{}, "Project")
+	SubscriberTypeKey  = bsonutil.MustHaveTag(Subscriber{}, "Type")
+	SubscriberNameKey  = bsonutil.MustHaveTag(Subscriber{}, "Name")
+)
+
+// ById returns a query for the subscription with the given id.
+func ById(id bson.ObjectId) db.Q {
+	return db.Query(bson.M{IdKey: id})
+}
+
+// ByOwner returns a query for the subscriptions created by a user.
+func ByOwner(userId string) db.Q {
+	return db.Query(bson.M{OwnerKey: userId})
+}
+
+// ByTriggers returns a query for the subscriptions to any of the triggers.
+func ByTriggers(triggers ...string) db.Q {
+	return db.Query(bson.M{TriggerKey: bson.M{"$in": triggers}})
+}
+
+// ByPersonAndTrigger returns a query for a user's own subscriptions to a
+// trigger.
+func ByPersonAndTrigger(userId, trigger string) db.Q {
+	return db.Query(bson.M{
+		TriggerKey: trigger,
+		fmt.Sprintf("%s.%s", SubscriberKey, SubscriberTypeKey): PersonSubscriber,
+		fmt.Sprintf("%s.%s", SubscriberKey, SubscriberNameKey): userId,
+	})
+}
+
+// ByTriggerSelectorAndSubscriber returns a query for the subscriptions that
+// notify the subscriber of the same events.
+func ByTriggerSelectorAndSubscriber(trigger string, selector Selector, subscriber Subscriber) db.Q {
+	return db.Query(bson.M{
+		TriggerKey:    trigger,
+		SelectorKey:   selector,
+		SubscriberKey: subscriber,
+	})
+}
+
+// FindOne gets one subscription for the given query.
+func FindOne(query db.Q) (*Subscription, error) {
+	s := &Subscription{}
+	err := db.FindOneQ(Collection, query, s)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return s, err
+}
+
+// Find gets all subscriptions for the given query.
+func Find(query db.Q) ([]Subscription, error) {
+	subs := []Subscription{}
+	err := db.FindAllQ(Collection, query, &subs)
+	return subs, err
+}
+
+// Remove deletes the subscription with the given id.
+func Remove(id bson.ObjectId) error {
+	return db.Remove(Collection, bson.M{IdKey: id})
+}
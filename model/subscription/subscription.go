@@ -0,0 +1,143 @@
+package subscription
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Triggers a subscription can be notified of. The version triggers match the
+// names of the notifier's handlers.
+const (
+	TaskFailure           = "task_failure"
+	TaskSuccessToFailure  = "task_success_to_failure"
+	BuildFailure          = "build_failure"
+	BuildSuccessToFailure = "build_success_to_failure"
+	BuildCompletion       = "build_completion"
+	PatchFinished         = "patch_finished"
+	SpawnHostExpiring     = "spawnhost_expiring"
+)
+
+// Kinds of subscribers.
+const (
+	PersonSubscriber = "person"
+	TeamSubscriber   = "team"
+)
+
+var (
+	// ValidTriggers are all the triggers that can be subscribed to.
+	ValidTriggers = []string{TaskFailure, TaskSuccessToFailure, BuildFailure,
+		BuildSuccessToFailure, BuildCompletion, PatchFinished, SpawnHostExpiring}
+
+	// taskTriggers are the triggers that can be narrowed down to a task.
+	taskTriggers = []string{TaskFailure, TaskSuccessToFailure}
+)
+
+// Subscription is a user's or team's request to be notified of an event.
+type Subscription struct {
+	Id         bson.ObjectId `bson:"_id" json:"id"`
+	Trigger    string        `bson:"trigger" json:"trigger"`
+	Selector   Selector      `bson:"selector" json:"selector"`
+	Subscriber Subscriber    `bson:"subscriber" json:"subscriber"`
+
+	// Owner is the id of the user who created the subscription and who is
+	// allowed to remove it.
+	Owner string `bson:"owner" json:"owner"`
+}
+
+// Selector narrows down the events a subscription is notified of. Empty
+// fields match anything.
+type Selector struct {
+	Project string `bson:"project,omitempty" json:"project,omitempty"`
+	Variant string `bson:"variant,omitempty" json:"variant,omitempty"`
+	Task    string `bson:"task,omitempty" json:"task,omitempty"`
+}
+
+// Subscriber is who gets notified: either a person, by user id, or a team,
+// by name and email address.
+type Subscriber struct {
+	Type    string `bson:"type" json:"type"`
+	Name    string `bson:"name" json:"name"`
+	Address string `bson:"address,omitempty" json:"address,omitempty"`
+}
+
+// Matches returns whether the selector matches an event in the given
+// project, variant and task. An empty variant or task in the event matches
+// only an empty selector field.
+func (s Selector) Matches(project, variant, task string) bool {
+	return (s.Project == "" || s.Project == project) &&
+		(s.Variant == "" || s.Variant == variant) &&
+		(s.Task == "" || s.Task == task)
+}
+
+// String returns the subscriber in a form that can be used as an email
+// recipient for teams, or the user id for people.
+func (s Subscriber) String() string {
+	if s.Type == TeamSubscriber {
+		return (&mail.Address{Name: s.Name, Address: s.Address}).String()
+	}
+	return s.Name
+}
+
+// Validate checks that the subscription can be delivered.
+func (s *Subscription) Validate() error {
+	errs := []string{}
+	if !util.SliceContains(ValidTriggers, s.Trigger) {
+		errs = append(errs, fmt.Sprintf("invalid trigger '%v', must be one of %v",
+			s.Trigger, ValidTriggers))
+	}
+	switch s.Trigger {
+	case SpawnHostExpiring:
+		if s.Selector != (Selector{}) {
+			errs = append(errs, "spawn host subscriptions cannot have a selector")
+		}
+		if s.Subscriber.Type != PersonSubscriber {
+			errs = append(errs, "only people can subscribe to their spawn hosts")
+		}
+	default:
+		if s.Selector.Project == "" {
+			errs = append(errs, "a project must be selected")
+		}
+		if s.Selector.Task != "" && !util.SliceContains(taskTriggers, s.Trigger) {
+			errs = append(errs, fmt.Sprintf("'%v' subscriptions cannot select a task",
+				s.Trigger))
+		}
+	}
+
+	switch s.Subscriber.Type {
+	case PersonSubscriber:
+		if s.Subscriber.Name == "" {
+			errs = append(errs, "subscribed users must have an id")
+		}
+	case TeamSubscriber:
+		if s.Subscriber.Name == "" {
+			errs = append(errs, "subscribed teams must have a name")
+		}
+		if _, err := mail.ParseAddress(s.Subscriber.Address); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid team address '%v': %v",
+				s.Subscriber.Address, err))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("invalid subscriber type '%v'", s.Subscriber.Type))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Insert validates the subscription and adds it to the database, assigning
+// it a new id.
+func (s *Subscription) Insert() error {
+	if err := s.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	s.Id = bson.NewObjectId()
+	return db.Insert(Collection, s)
+}
@@ -0,0 +1,62 @@
+package subscription
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidate(t *testing.T) {
+	Convey("With a subscription to task failures", t, func() {
+		sub := &Subscription{
+			Trigger:    TaskFailure,
+			Selector:   Selector{Project: "proj", Variant: "bv", Task: "compile"},
+			Subscriber: Subscriber{Type: PersonSubscriber, Name: "me"},
+		}
+		So(sub.Validate(), ShouldBeNil)
+
+		Convey("an unknown trigger should be invalid", func() {
+			sub.Trigger = "task_started"
+			So(sub.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("a project should be required", func() {
+			sub.Selector.Project = ""
+			So(sub.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("only task triggers should select tasks", func() {
+			sub.Trigger = BuildFailure
+			So(sub.Validate(), ShouldNotBeNil)
+			sub.Selector.Task = ""
+			So(sub.Validate(), ShouldBeNil)
+		})
+
+		Convey("teams should have a valid address", func() {
+			sub.Subscriber = Subscriber{Type: TeamSubscriber, Name: "Team"}
+			So(sub.Validate(), ShouldNotBeNil)
+			sub.Subscriber.Address = "team@example.com"
+			So(sub.Validate(), ShouldBeNil)
+			So(sub.Subscriber.String(), ShouldEqual, `"Team" <team@example.com>`)
+		})
+
+		Convey("spawn host subscriptions should be for people without a selector", func() {
+			sub.Trigger = SpawnHostExpiring
+			So(sub.Validate(), ShouldNotBeNil)
+			sub.Selector = Selector{}
+			So(sub.Validate(), ShouldBeNil)
+			sub.Subscriber = Subscriber{Type: TeamSubscriber, Name: "Team", Address: "team@example.com"}
+			So(sub.Validate(), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSelectorMatches(t *testing.T) {
+	Convey("Selectors should match on their non-empty fields", t, func() {
+		So(Selector{Project: "proj"}.Matches("proj", "bv", "compile"), ShouldBeTrue)
+		So(Selector{Project: "proj", Variant: "bv"}.Matches("proj", "bv", "compile"), ShouldBeTrue)
+		So(Selector{Project: "proj", Variant: "bv"}.Matches("proj", "bv2", "compile"), ShouldBeFalse)
+		So(Selector{Project: "proj", Task: "test"}.Matches("proj", "bv", "compile"), ShouldBeFalse)
+		So(Selector{Project: "proj"}.Matches("other", "bv", "compile"), ShouldBeFalse)
+	})
+}
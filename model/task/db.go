@@ -39,9 +39,14 @@ var (
 	DependsOnKey           = bsonutil.MustHaveTag(Task{}, "DependsOn")
 	NumDepsKey             = bsonutil.MustHaveTag(Task{}, "NumDependents")
 	DisplayNameKey         = bsonutil.MustHaveTag(Task{}, "DisplayName")
+	DisplayTaskKey         = bsonutil.MustHaveTag(Task{}, "DisplayTask")
+	TaskGroupKey           = bsonutil.MustHaveTag(Task{}, "TaskGroup")
+	TaskGroupOrderKey      = bsonutil.MustHaveTag(Task{}, "TaskGroupOrder")
 	HostIdKey              = bsonutil.MustHaveTag(Task{}, "HostId")
 	ExecutionKey           = bsonutil.MustHaveTag(Task{}, "Execution")
 	RestartsKey            = bsonutil.MustHaveTag(Task{}, "Restarts")
+	AutoRetriesKey         = bsonutil.MustHaveTag(Task{}, "AutoRetries")
+	BisectCulpritKey       = bsonutil.MustHaveTag(Task{}, "BisectCulprit")
 	OldTaskIdKey           = bsonutil.MustHaveTag(Task{}, "OldTaskId")
 	ArchivedKey            = bsonutil.MustHaveTag(Task{}, "Archived")
 	RevisionOrderNumberKey = bsonutil.MustHaveTag(Task{}, "RevisionOrderNumber")
@@ -114,6 +119,15 @@ func ByBuildId(buildId string) db.Q {
 	})
 }
 
+// ByTaskGroup creates a query to return the members of a task group
+// within a build
+func ByTaskGroup(buildId, taskGroup string) db.Q {
+	return db.Query(bson.M{
+		BuildIdKey:   buildId,
+		TaskGroupKey: taskGroup,
+	})
+}
+
 // ByAborted creates a query to return tasks with an aborted state
 func ByAborted(aborted bool) db.Q {
 	return db.Query(bson.M{
@@ -240,6 +254,22 @@ func ByBeforeRevisionWithStatuses(revisionOrder int, statuses []string, buildVar
 	}).Sort([]string{"-" + RevisionOrderNumberKey})
 }
 
+// ByAfterRevisionWithStatuses returns a query for the tasks with the given
+// statuses after a revision, earliest first.
+func ByAfterRevisionWithStatuses(revisionOrder int, statuses []string, buildVariant, displayName, project string) db.Q {
+	return db.Query(bson.M{
+		BuildVariantKey: buildVariant,
+		DisplayNameKey:  displayName,
+		RevisionOrderNumberKey: bson.M{
+			"$gt": revisionOrder,
+		},
+		StatusKey: bson.M{
+			"$in": statuses,
+		},
+		ProjectKey: project,
+	}).Sort([]string{RevisionOrderNumberKey})
+}
+
 func ByActivatedBeforeRevisionWithStatuses(revisionOrder int, statuses []string, buildVariant, displayName, project string) db.Q {
 	return db.Query(bson.M{
 		BuildVariantKey: buildVariant,
@@ -288,6 +318,20 @@ func ByTimeRun(startTime, endTime time.Time) db.Q {
 			}})
 }
 
+// ByFinishedScheduledBetween returns all tasks that were scheduled between
+// two given times and have since succeeded or failed, optionally restricted
+// to a single distro.
+func ByFinishedScheduledBetween(startTime, endTime time.Time, distroId string) db.Q {
+	query := bson.M{
+		ScheduledTimeKey: bson.M{"$gte": startTime, "$lt": endTime},
+		StatusKey:        bson.M{"$in": []string{evergreen.TaskSucceeded, evergreen.TaskFailed}},
+	}
+	if distroId != "" {
+		query[DistroIdKey] = distroId
+	}
+	return db.Query(query)
+}
+
 func ByStatuses(statuses []string, buildVariant, displayName, project, requester string) db.Q {
 	return db.Query(bson.M{
 		BuildVariantKey: buildVariant,
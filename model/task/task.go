@@ -59,6 +59,15 @@ type Task struct {
 	// Tags that describe the task
 	Tags []string `bson:"tags,omitempty" json:"tags,omitempty"`
 
+	// the display task the task is shown as part of, if any
+	DisplayTask string `bson:"display_task,omitempty" json:"display_task,omitempty"`
+
+	// the task group the task belongs to, if any, and its 1-based position
+	// within that group. members of a group in the same build are run in
+	// order on a single host.
+	TaskGroup      string `bson:"task_group,omitempty" json:"task_group,omitempty"`
+	TaskGroupOrder int    `bson:"task_group_order,omitempty" json:"task_group_order,omitempty"`
+
 	// The host the task was run on
 	HostId string `bson:"host_id" json:"host_id"`
 
@@ -69,6 +78,14 @@ type Task struct {
 	Archived            bool   `bson:"archived,omitempty" json:"archived,omitempty"`
 	RevisionOrderNumber int    `bson:"order,omitempty" json:"order,omitempty"`
 
+	// the number of times this task has been automatically retried after
+	// failing, as allowed by its max_retries setting
+	AutoRetries int `bson:"auto_retries,omitempty" json:"auto_retries,omitempty"`
+
+	// the revision that bisecting the task's failures found to have
+	// caused them, if this task failed in that revision or after it
+	BisectCulprit string `bson:"bisect_culprit,omitempty" json:"bisect_culprit,omitempty"`
+
 	// task requester - this is used to help tell the
 	// reason this task was created. e.g. it could be
 	// because the repotracker requested it (via tracking the
@@ -275,6 +292,30 @@ func (t *Task) PreviousCompletedTask(project string,
 		t.DisplayName, project))
 }
 
+// NextCompletedTasks finds the tasks for the same build variant and display
+// name combination as the specified task that completed with the given
+// statuses after it, earliest first.
+func (t *Task) NextCompletedTasks(project string, statuses []string) ([]Task, error) {
+	if len(statuses) == 0 {
+		statuses = CompletedStatuses
+	}
+	return Find(ByAfterRevisionWithStatuses(t.RevisionOrderNumber, statuses, t.BuildVariant,
+		t.DisplayName, project))
+}
+
+// SetBisectCulprit records the revision that bisection found to have caused
+// the failures of the given tasks.
+func SetBisectCulprit(taskIds []string, revision string) error {
+	_, err := UpdateAll(
+		bson.M{
+			IdKey: bson.M{"$in": taskIds},
+		},
+		bson.M{
+			"$set": bson.M{BisectCulpritKey: revision},
+		})
+	return err
+}
+
 // SetExpectedDuration updates the expected duration field for the task
 func (t *Task) SetExpectedDuration(duration time.Duration) error {
 	return UpdateOne(
@@ -473,6 +514,19 @@ func (t *Task) MarkEnd(finishTime time.Time, detail *apimodels.TaskEndDetail) er
 
 }
 
+// SetAutoRetries records the number of times the task has been
+// automatically retried.
+func (t *Task) SetAutoRetries(retries int) error {
+	t.AutoRetries = retries
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$set": bson.M{AutoRetriesKey: retries},
+		})
+}
+
 // Reset sets the task state to be activated, with a new secret,
 // undispatched status and zero time on Start, Scheduled, Dispatch and FinishTime
 func (t *Task) Reset() error {
@@ -483,6 +537,7 @@ func (t *Task) Reset() error {
 	t.ScheduledTime = util.ZeroTime
 	t.FinishTime = util.ZeroTime
 	t.TestResults = []TestResult{}
+	t.AutoRetries = 0
 	reset := bson.M{
 		"$set": bson.M{
 			ActivatedKey:     true,
@@ -495,7 +550,8 @@ func (t *Task) Reset() error {
 			TestResultsKey:   []TestResult{},
 		},
 		"$unset": bson.M{
-			DetailsKey: "",
+			DetailsKey:     "",
+			AutoRetriesKey: "",
 		},
 	}
 
@@ -522,7 +578,8 @@ func ResetTasks(taskIds []string) error {
 			TestResultsKey:   []TestResult{},
 		},
 		"$unset": bson.M{
-			DetailsKey: "",
+			DetailsKey:     "",
+			AutoRetriesKey: "",
 		},
 	}
 
@@ -551,8 +608,9 @@ func (t *Task) UpdateHeartbeat() error {
 	)
 }
 
-// SetPriority sets the priority of the tasks and the tasks that they depend on
-func (t *Task) SetPriority(priority int64) error {
+// SetPriority sets the priority of the tasks and the tasks that they depend on,
+// and logs that the caller changed it.
+func (t *Task) SetPriority(priority int64, caller string) error {
 	t.Priority = priority
 	modifier := bson.M{PriorityKey: priority}
 
@@ -574,7 +632,12 @@ func (t *Task) SetPriority(priority int64) error {
 		}},
 		bson.M{"$set": modifier},
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	event.LogTaskPriorityChanged(t.Id, t.Project, caller, priority)
+	return nil
 }
 
 // getRecursiveDependencies creates a slice containing t.Id and the Ids of all recursive dependencies.
@@ -812,6 +875,49 @@ func AverageTaskTimeDifference(field1 string, field2 string,
 	return avgTimes, nil
 }
 
+// TimeTakenByProject computes the total time taken - grouped by project - by
+// tasks that have completed within a given threshold as determined by the window
+func TimeTakenByProject(window time.Duration) (map[string]time.Duration, error) {
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				StatusKey: bson.M{
+					"$in": []string{evergreen.TaskSucceeded, evergreen.TaskFailed},
+				},
+				FinishTimeKey: bson.M{
+					"$gte": time.Now().Add(-window),
+				},
+				StartTimeKey: bson.M{
+					"$gt": util.ZeroTime,
+				},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": fmt.Sprintf("$%v", ProjectKey),
+				"total": bson.M{
+					"$sum": fmt.Sprintf("$%v", TimeTakenKey),
+				},
+			},
+		},
+	}
+
+	var results []struct {
+		Project   string `bson:"_id"`
+		TimeTaken int64  `bson:"total"`
+	}
+
+	if err := db.Aggregate(Collection, pipeline, &results); err != nil {
+		return nil, errors.Wrap(err, "error aggregating task time taken by project")
+	}
+
+	timeTaken := make(map[string]time.Duration)
+	for _, result := range results {
+		timeTaken[result.Project] = time.Duration(result.TimeTaken)
+	}
+	return timeTaken, nil
+}
+
 // ExpectedTaskDuration takes a given project and buildvariant and computes
 // the average duration - grouped by task display name - for tasks that have
 // completed within a given threshold as determined by the window
@@ -6,6 +6,8 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
@@ -13,6 +15,7 @@ import (
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func SetActiveState(taskId string, caller string, active bool) error {
@@ -59,9 +62,9 @@ func SetActiveState(taskId string, caller string, active bool) error {
 	}
 
 	if active {
-		event.LogTaskActivated(taskId, caller)
+		event.LogTaskActivated(taskId, t.Project, caller)
 	} else {
-		event.LogTaskDeactivated(taskId, caller)
+		event.LogTaskDeactivated(taskId, t.Project, caller)
 	}
 	return errors.WithStack(build.SetCachedTaskActivated(t.BuildId, taskId, active))
 }
@@ -90,7 +93,8 @@ func ActivatePreviousTask(taskId, caller string) error {
 	return errors.WithStack(SetActiveState(prevTask.Id, caller, true))
 }
 
-// reset task finds a task, attempts to archive it, and resets the task and resets the TaskCache in the build as well.
+// reset task finds a task, attempts to archive it, and resets the task, including
+// its count of automatic retries, and resets the TaskCache in the build as well.
 func resetTask(taskId string) error {
 	t, err := task.FindOne(task.ById(taskId))
 	if err != nil {
@@ -201,7 +205,7 @@ func DeactivatePreviousTasks(taskId, caller string) error {
 		if err != nil {
 			return err
 		}
-		event.LogTaskDeactivated(t.Id, caller)
+		event.LogTaskDeactivated(t.Id, t.Project, caller)
 		// update the cached version of the task, in its build document to be deactivated
 		if err = build.SetCachedTaskActivated(t.BuildId, t.Id, false); err != nil {
 			return err
@@ -240,6 +244,50 @@ func getStepback(taskId string, project *Project) (bool, error) {
 	return project.Stepback, nil
 }
 
+// getRetryPolicy returns the maximum number of automatic retries for a task
+// and the command types whose failures trigger them, preferring the build
+// variant's settings over the project task's.
+func getRetryPolicy(t *task.Task, project *Project) (int, []string) {
+	projectTask := project.FindProjectTask(t.DisplayName)
+	if projectTask == nil {
+		return 0, nil
+	}
+	maxRetries, retryOn := projectTask.MaxRetries, projectTask.RetryOn
+	if bvt := project.FindTaskForVariant(t.DisplayName, t.BuildVariant); bvt != nil {
+		maxRetries, retryOn = bvt.MaxRetries, bvt.RetryOn
+	}
+	if len(retryOn) == 0 {
+		retryOn = []string{SystemCommandType}
+	}
+	if maxRetries == nil {
+		return 0, retryOn
+	}
+	return *maxRetries, retryOn
+}
+
+// shouldRetry returns true if the task failed in one of the ways its retry
+// policy covers and has automatic retries and executions remaining. Tasks
+// whose heartbeat timed out are treated as having failed on a system command.
+func shouldRetry(t *task.Task, detail *apimodels.TaskEndDetail, project *Project) bool {
+	if project == nil || detail.Status != evergreen.TaskFailed ||
+		t.Execution >= evergreen.MaxTaskExecution {
+		return false
+	}
+	maxRetries, retryOn := getRetryPolicy(t, project)
+	if t.AutoRetries >= maxRetries {
+		return false
+	}
+
+	failureType := detail.Type
+	if detail.Description == task.AgentHeartbeat {
+		failureType = SystemCommandType
+	}
+	if failureType == "" {
+		failureType = DefaultCommandType
+	}
+	return util.SliceContains(retryOn, failureType)
+}
+
 // doStepBack performs a stepback on the task if there is a previous task and if not it returns nothing.
 func doStepback(t *task.Task) error {
 	//See if there is a prior success for this particular task.
@@ -257,6 +305,114 @@ func doStepback(t *task.Task) error {
 	return errors.WithStack(ActivatePreviousTask(t.Id, evergreen.StepbackTaskActivator))
 }
 
+// doBisect bisects the revisions between the failed task and the previous
+// success of the task, activating the task in the middle of the revisions
+// that haven't run yet. Once none are left, the earliest failure's revision
+// is the culprit, which is recorded on the failures and alerted on. Bisection
+// replaces stepback, so it only runs for tasks that step back.
+func doBisect(t *task.Task) error {
+	prevTask, err := t.PreviousCompletedTask(t.Project, []string{evergreen.TaskSucceeded})
+	if err != nil {
+		return errors.Wrap(err, "Error locating previous successful task")
+	}
+	if prevTask == nil {
+		return nil
+	}
+
+	// intermediate tasks are ordered from most recent to least recent
+	intermediateTasks, err := t.FindIntermediateTasks(prevTask)
+	if err != nil {
+		return errors.Wrap(err, "Error locating intermediate tasks")
+	}
+	firstFailure := t
+	candidates := intermediateTasks
+	for i := len(intermediateTasks) - 1; i >= 0; i-- {
+		if intermediateTasks[i].Status == evergreen.TaskFailed {
+			firstFailure = &intermediateTasks[i]
+			candidates = intermediateTasks[i+1:]
+			break
+		}
+	}
+
+	// the culprit of the earliest failure was already found and alerted on,
+	// so only record it on this failure
+	if firstFailure.BisectCulprit != "" {
+		if t.BisectCulprit != "" {
+			return nil
+		}
+		return errors.Wrap(task.SetBisectCulprit([]string{t.Id}, firstFailure.BisectCulprit),
+			"Error recording bisect culprit")
+	}
+
+	untested := []task.Task{}
+	for _, candidate := range candidates {
+		if candidate.Activated && !task.IsFinished(candidate) {
+			// the bisection continues once this task finishes
+			return nil
+		}
+		if !candidate.Activated && candidate.Priority >= 0 {
+			untested = append(untested, candidate)
+		}
+	}
+
+	if len(untested) > 0 {
+		return errors.WithStack(SetActiveState(untested[len(untested)/2].Id, evergreen.BisectTaskActivator, true))
+	}
+
+	// a failure right after a success that no bisection led to is already
+	// reported by the task failure alerts
+	if firstFailure.ActivatedBy != evergreen.BisectTaskActivator &&
+		prevTask.ActivatedBy != evergreen.BisectTaskActivator {
+		return nil
+	}
+	return errors.WithStack(recordBisectCulprit(firstFailure))
+}
+
+// continueBisect continues the bisection a successful task was activated
+// for, which searches the revisions before the next failure of the task.
+func continueBisect(t *task.Task) error {
+	nextTasks, err := t.NextCompletedTasks(t.Project, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error locating next completed task")
+	}
+	if len(nextTasks) == 0 || nextTasks[0].Status != evergreen.TaskFailed {
+		return nil
+	}
+	return doBisect(&nextTasks[0])
+}
+
+// recordBisectCulprit records the revision of the task as the culprit of
+// its failure and of the failures that followed it, and queues an alert.
+func recordBisectCulprit(culprit *task.Task) error {
+	nextTasks, err := culprit.NextCompletedTasks(culprit.Project, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error locating next completed tasks")
+	}
+	failedIds := []string{culprit.Id}
+	for _, t := range nextTasks {
+		if t.Status != evergreen.TaskFailed {
+			break
+		}
+		failedIds = append(failedIds, t.Id)
+	}
+	if err = task.SetBisectCulprit(failedIds, culprit.Revision); err != nil {
+		return errors.Wrap(err, "Error recording bisect culprit")
+	}
+	grip.Infof("Bisecting task %s on %s found culprit revision %s",
+		culprit.DisplayName, culprit.BuildVariant, culprit.Revision)
+
+	return errors.WithStack(alert.EnqueueAlertRequest(&alert.AlertRequest{
+		Id:        bson.NewObjectId(),
+		Trigger:   alertrecord.BisectCulpritFoundId,
+		TaskId:    culprit.Id,
+		Execution: culprit.Execution,
+		BuildId:   culprit.BuildId,
+		VersionId: culprit.Version,
+		ProjectId: culprit.Project,
+		CreatedAt: time.Now(),
+	}))
+}
+
 // MarkEnd updates the task as being finished, performs a stepback if necessary, and updates the build status
 func MarkEnd(taskId, caller string, finishTime time.Time, detail *apimodels.TaskEndDetail,
 	p *Project, deactivatePrevious bool) error {
@@ -289,6 +445,18 @@ func MarkEnd(taskId, caller string, finishTime time.Time, detail *apimodels.Task
 	}
 	event.LogTaskFinished(t.Id, t.HostId, detail.Status)
 
+	// automatically re-execute the task if its retry policy covers the
+	// failure, before the failure is reflected in its build and version
+	if shouldRetry(t, detail, p) {
+		retries := t.AutoRetries + 1
+		grip.Infof("Automatically retrying task %s after '%s' failure (retry %d)",
+			t.Id, detail.Type, retries)
+		if err = TryResetTask(t.Id, "", evergreen.RetryTaskActivator, p, nil); err != nil {
+			return errors.Wrap(err, "error retrying task")
+		}
+		return errors.Wrap(t.SetAutoRetries(retries), "error recording task retry")
+	}
+
 	// update the cached version of the task, in its build document
 	err = build.SetCachedTaskFinished(t.BuildId, t.Id, detail, t.TimeTaken)
 	if err != nil {
@@ -305,19 +473,33 @@ func MarkEnd(taskId, caller string, finishTime time.Time, detail *apimodels.Task
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		if shouldStepBack {
+		if shouldStepBack && p.Bisect {
+			if err = doBisect(t); err != nil {
+				return errors.Wrap(err, "Error during bisect")
+			}
+		} else if shouldStepBack {
 			if err = doStepback(t); err != nil {
 				return errors.Wrap(err, "Error during step back")
 			}
 		} else {
 			grip.Debugln("Not stepping backwards on task failure:", t.Id)
 		}
-	} else if deactivatePrevious {
-		// if the task was successful, ignore running previous
-		// activated tasks for this buildvariant
+	} else {
+		// a successful task activated by bisect narrows down the search
+		// for the failure after it
+		if t.ActivatedBy == evergreen.BisectTaskActivator {
+			if err = continueBisect(t); err != nil {
+				return errors.Wrap(err, "Error during bisect")
+			}
+		}
+
+		if deactivatePrevious {
+			// if the task was successful, ignore running previous
+			// activated tasks for this buildvariant
 
-		if err = DeactivatePreviousTasks(t.Id, caller); err != nil {
-			return errors.Wrap(err, "Error deactivating previous task")
+			if err = DeactivatePreviousTasks(t.Id, caller); err != nil {
+				return errors.Wrap(err, "Error deactivating previous task")
+			}
 		}
 	}
 
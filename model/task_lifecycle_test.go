@@ -8,12 +8,15 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/alert"
+	"github.com/evergreen-ci/evergreen/model/alertrecord"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
 )
 
 var (
@@ -947,3 +950,252 @@ func TestGetstepback(t *testing.T) {
 
 	})
 }
+
+func TestShouldRetry(t *testing.T) {
+	Convey("With a project whose tasks have retry policies", t, func() {
+		project := &Project{
+			BuildVariants: []BuildVariant{
+				{
+					Name: "bv",
+					Tasks: []BuildVariantTask{
+						{Name: "default"},
+						{Name: "setup"},
+						{Name: "override", MaxRetries: intPtr(2), RetryOn: []string{SetupCommandType}},
+						{Name: "none"},
+						{Name: "disabled", MaxRetries: intPtr(0)},
+					},
+				},
+			},
+			Tasks: []ProjectTask{
+				{Name: "default", MaxRetries: intPtr(1)},
+				{Name: "setup", MaxRetries: intPtr(1), RetryOn: []string{SystemCommandType, SetupCommandType}},
+				{Name: "override", MaxRetries: intPtr(1)},
+				{Name: "none"},
+				{Name: "disabled", MaxRetries: intPtr(1)},
+			},
+		}
+		systemFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType}
+		setupFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SetupCommandType}
+		testFailure := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed}
+
+		Convey("tasks should retry system failures by default", func() {
+			testTask := &task.Task{DisplayName: "default", BuildVariant: "bv"}
+			So(shouldRetry(testTask, systemFailure, project), ShouldBeTrue)
+			So(shouldRetry(testTask, setupFailure, project), ShouldBeFalse)
+			So(shouldRetry(testTask, testFailure, project), ShouldBeFalse)
+			So(shouldRetry(testTask, &apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded}, project),
+				ShouldBeFalse)
+		})
+
+		Convey("heartbeat timeouts should count as system failures", func() {
+			testTask := &task.Task{DisplayName: "default", BuildVariant: "bv"}
+			heartbeatFailure := &apimodels.TaskEndDetail{
+				Status:      evergreen.TaskFailed,
+				Description: task.AgentHeartbeat,
+				TimedOut:    true,
+			}
+			So(shouldRetry(testTask, heartbeatFailure, project), ShouldBeTrue)
+		})
+
+		Convey("tasks should only retry the configured failure types", func() {
+			testTask := &task.Task{DisplayName: "setup", BuildVariant: "bv"}
+			So(shouldRetry(testTask, systemFailure, project), ShouldBeTrue)
+			So(shouldRetry(testTask, setupFailure, project), ShouldBeTrue)
+			So(shouldRetry(testTask, testFailure, project), ShouldBeFalse)
+		})
+
+		Convey("build variant settings should override the project task's", func() {
+			testTask := &task.Task{DisplayName: "override", BuildVariant: "bv", AutoRetries: 1}
+			So(shouldRetry(testTask, setupFailure, project), ShouldBeTrue)
+			So(shouldRetry(testTask, systemFailure, project), ShouldBeFalse)
+		})
+
+		Convey("build variants should be able to turn off the project task's retries", func() {
+			testTask := &task.Task{DisplayName: "disabled", BuildVariant: "bv"}
+			So(shouldRetry(testTask, systemFailure, project), ShouldBeFalse)
+		})
+
+		Convey("tasks should not retry once their retries or executions are used up", func() {
+			So(shouldRetry(&task.Task{DisplayName: "none", BuildVariant: "bv"},
+				systemFailure, project), ShouldBeFalse)
+			So(shouldRetry(&task.Task{DisplayName: "default", BuildVariant: "bv", AutoRetries: 1},
+				systemFailure, project), ShouldBeFalse)
+			So(shouldRetry(&task.Task{DisplayName: "default", BuildVariant: "bv",
+				Execution: evergreen.MaxTaskExecution}, systemFailure, project), ShouldBeFalse)
+		})
+	})
+}
+
+func TestMarkEndRetriesTask(t *testing.T) {
+	Convey("With a task configured to retry system failures", t, func() {
+		testutil.HandleTestingErr(db.ClearCollections(task.Collection, task.OldCollection,
+			build.Collection, version.Collection), t, "Error clearing task and build collections")
+		b := &build.Build{
+			Id:      "buildtest",
+			Status:  evergreen.BuildStarted,
+			Version: "abc",
+		}
+		v := &version.Version{
+			Id:     b.Version,
+			Status: evergreen.VersionStarted,
+		}
+		testTask := &task.Task{
+			Id:           "testone",
+			DisplayName:  "compile",
+			BuildVariant: "bv",
+			Activated:    true,
+			BuildId:      b.Id,
+			Project:      "sample",
+			Status:       evergreen.TaskStarted,
+		}
+		p := &Project{
+			Identifier:    "sample",
+			BuildVariants: []BuildVariant{{Name: "bv", Tasks: []BuildVariantTask{{Name: "compile"}}}},
+			Tasks:         []ProjectTask{{Name: "compile", MaxRetries: intPtr(1)}},
+		}
+		b.Tasks = []build.TaskCache{{Id: testTask.Id, Status: evergreen.TaskStarted}}
+		So(b.Insert(), ShouldBeNil)
+		So(testTask.Insert(), ShouldBeNil)
+		So(v.Insert(), ShouldBeNil)
+
+		Convey("a system failure should reset the task and record the retry", func() {
+			detail := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType}
+			So(MarkEnd(testTask.Id, "", time.Now(), detail, p, false), ShouldBeNil)
+
+			dbTask, err := task.FindOne(task.ById(testTask.Id))
+			So(err, ShouldBeNil)
+			So(dbTask.Status, ShouldEqual, evergreen.TaskUndispatched)
+			So(dbTask.Execution, ShouldEqual, 1)
+			So(dbTask.AutoRetries, ShouldEqual, 1)
+
+			oldTask, err := task.FindOneOld(task.ById(fmt.Sprintf("%v_%v", testTask.Id, 0)))
+			So(err, ShouldBeNil)
+			So(oldTask, ShouldNotBeNil)
+			So(oldTask.Status, ShouldEqual, evergreen.TaskFailed)
+
+			dbBuild, err := build.FindOne(build.ById(b.Id))
+			So(err, ShouldBeNil)
+			So(dbBuild.Status, ShouldNotEqual, evergreen.BuildFailed)
+			So(dbBuild.Tasks[0].Status, ShouldEqual, evergreen.TaskUndispatched)
+
+			Convey("and a second system failure should not be retried", func() {
+				So(dbTask.MarkStart(time.Now()), ShouldBeNil)
+				So(MarkEnd(testTask.Id, "", time.Now(), detail, p, false), ShouldBeNil)
+				dbTask, err = task.FindOne(task.ById(testTask.Id))
+				So(err, ShouldBeNil)
+				So(dbTask.Status, ShouldEqual, evergreen.TaskFailed)
+				So(dbTask.Execution, ShouldEqual, 1)
+			})
+
+			Convey("and restarting the task should reset its retries", func() {
+				So(dbTask.MarkStart(time.Now()), ShouldBeNil)
+				So(MarkEnd(testTask.Id, "", time.Now(), detail, p, false), ShouldBeNil)
+				So(TryResetTask(testTask.Id, "user", evergreen.UIPackage, p, nil), ShouldBeNil)
+				dbTask, err = task.FindOne(task.ById(testTask.Id))
+				So(err, ShouldBeNil)
+				So(dbTask.Execution, ShouldEqual, 2)
+				So(dbTask.AutoRetries, ShouldEqual, 0)
+			})
+		})
+
+		Convey("a test failure should not be retried", func() {
+			detail := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: TestCommandType}
+			So(MarkEnd(testTask.Id, "", time.Now(), detail, p, false), ShouldBeNil)
+			dbTask, err := task.FindOne(task.ById(testTask.Id))
+			So(err, ShouldBeNil)
+			So(dbTask.Status, ShouldEqual, evergreen.TaskFailed)
+			So(dbTask.AutoRetries, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestBisect(t *testing.T) {
+	Convey("With a failure five inactive revisions after a success", t, func() {
+		testutil.HandleTestingErr(db.ClearCollections(task.Collection, build.Collection, alert.Collection), t,
+			"Error clearing task, build and alert collections")
+
+		for order := 1; order <= 7; order++ {
+			b := &build.Build{Id: fmt.Sprintf("build%v", order)}
+			testTask := &task.Task{
+				Id:                  fmt.Sprintf("task%v", order),
+				DisplayName:         "compile",
+				BuildVariant:        "bv",
+				Project:             "sample",
+				Requester:           evergreen.RepotrackerVersionRequester,
+				Revision:            fmt.Sprintf("rev%v", order),
+				RevisionOrderNumber: order,
+				Status:              evergreen.TaskUndispatched,
+				BuildId:             b.Id,
+			}
+			switch order {
+			case 1:
+				testTask.Status = evergreen.TaskSucceeded
+				testTask.Activated = true
+			case 7:
+				testTask.Status = evergreen.TaskFailed
+				testTask.Activated = true
+			}
+			b.Tasks = []build.TaskCache{{Id: testTask.Id}}
+			So(b.Insert(), ShouldBeNil)
+			So(testTask.Insert(), ShouldBeNil)
+		}
+
+		findTask := func(id string) *task.Task {
+			dbTask, err := task.FindOne(task.ById(id))
+			So(err, ShouldBeNil)
+			So(dbTask, ShouldNotBeNil)
+			return dbTask
+		}
+		finishTask := func(id, status string) *task.Task {
+			So(task.UpdateOne(bson.M{task.IdKey: id}, bson.M{"$set": bson.M{task.StatusKey: status}}), ShouldBeNil)
+			return findTask(id)
+		}
+
+		Convey("bisecting should activate the task in the middle", func() {
+			So(doBisect(findTask("task7")), ShouldBeNil)
+			middle := findTask("task4")
+			So(middle.Activated, ShouldBeTrue)
+			So(middle.ActivatedBy, ShouldEqual, evergreen.BisectTaskActivator)
+			So(findTask("task3").Activated, ShouldBeFalse)
+			So(findTask("task5").Activated, ShouldBeFalse)
+
+			Convey("and should wait for it to finish", func() {
+				So(doBisect(findTask("task7")), ShouldBeNil)
+				So(findTask("task2").Activated, ShouldBeFalse)
+				So(findTask("task3").Activated, ShouldBeFalse)
+				So(findTask("task5").Activated, ShouldBeFalse)
+				So(findTask("task6").Activated, ShouldBeFalse)
+			})
+
+			Convey("and should find the culprit as the bisected tasks finish", func() {
+				So(doBisect(finishTask("task4", evergreen.TaskFailed)), ShouldBeNil)
+				So(findTask("task2").Activated, ShouldBeTrue)
+
+				So(continueBisect(finishTask("task2", evergreen.TaskSucceeded)), ShouldBeNil)
+				So(findTask("task3").Activated, ShouldBeTrue)
+
+				So(doBisect(finishTask("task3", evergreen.TaskFailed)), ShouldBeNil)
+				So(findTask("task3").BisectCulprit, ShouldEqual, "rev3")
+				So(findTask("task4").BisectCulprit, ShouldEqual, "rev3")
+				So(findTask("task7").BisectCulprit, ShouldEqual, "rev3")
+				So(findTask("task2").BisectCulprit, ShouldEqual, "")
+
+				queued, err := alert.DequeueAlertRequest()
+				So(err, ShouldBeNil)
+				So(queued, ShouldNotBeNil)
+				So(queued.Trigger, ShouldEqual, alertrecord.BisectCulpritFoundId)
+				So(queued.TaskId, ShouldEqual, "task3")
+
+				Convey("and should not alert on it again for later failures", func() {
+					So(doBisect(findTask("task7")), ShouldBeNil)
+					So(doBisect(finishTask("task6", evergreen.TaskFailed)), ShouldBeNil)
+					So(findTask("task6").BisectCulprit, ShouldEqual, "rev3")
+
+					queued, err := alert.DequeueAlertRequest()
+					So(err, ShouldBeNil)
+					So(queued, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
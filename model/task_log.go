@@ -6,6 +6,8 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/bsonutil"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -173,54 +175,47 @@ func FindTaskLogsBeforeTime(taskId string, execution int, ts time.Time, limit in
 	return result, err
 }
 
-func GetRawTaskLogChannel(taskId string, execution int, severities []string,
-	msgTypes []string) (chan LogMessage, error) {
+// FindTaskLogsSinceTime returns the task log documents of a task execution
+// from the given time on, oldest first.
+func FindTaskLogsSinceTime(taskId string, execution int, ts time.Time) ([]TaskLog, error) {
 	session, db, err := getSessionAndDB()
 	if err != nil {
 		return nil, err
 	}
+	defer session.Close()
+
+	query := bson.M{
+		TaskLogTaskIdKey:    taskId,
+		TaskLogExecutionKey: execution,
+		TaskLogTimestampKey: bson.M{
+			"$gte": ts,
+		},
+	}
 
-	logObj := TaskLog{}
+	result := []TaskLog{}
+	err = db.C(TaskLogCollection).Find(query).Sort(TaskLogTimestampKey).All(&result)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return result, err
+}
+
+// GetRawTaskLogChannel streams the messages of a task execution's log from
+// the task log storage, filtered by severity and type.
+func GetRawTaskLogChannel(taskId string, execution int, severities []string,
+	msgTypes []string) (chan LogMessage, error) {
+	storage := GetTaskLogStorage()
 
 	// 100 is an arbitrary magic number. Unbuffered channel would be bad for
 	// performance, so just picked a buffer size out of thin air.
 	channel := make(chan LogMessage, 100)
 
-	// TODO(EVG-227)
-	var query bson.M
-	if execution == 0 {
-		query = bson.M{"$and": []bson.M{
-			{TaskLogTaskIdKey: taskId},
-			{"$or": []bson.M{
-				{TaskLogExecutionKey: 0},
-				{TaskLogExecutionKey: nil},
-			}}}}
-	} else {
-		query = bson.M{
-			TaskLogTaskIdKey:    taskId,
-			TaskLogExecutionKey: execution,
-		}
-	}
-	iter := db.C(TaskLogCollection).Find(query).Sort(TaskLogTimestampKey).Iter()
-
-	oldMsgTypes := []string{}
-	for _, msgType := range msgTypes {
-		switch msgType {
-		case SystemLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "system")
-		case AgentLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "agent")
-		case TaskLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "task")
-		}
-	}
+	oldMsgTypes := oldLogMessageTypes(msgTypes)
 
 	go func() {
-		defer session.Close()
 		defer close(channel)
-		defer iter.Close()
 
-		for iter.Next(&logObj) {
+		err := storage.Each(taskId, execution, time.Time{}, func(logObj *TaskLog) bool {
 			for _, logMsg := range logObj.Messages {
 				if len(severities) > 0 &&
 					!util.SliceContains(severities, logMsg.Severity) {
@@ -234,12 +229,85 @@ func GetRawTaskLogChannel(taskId string, execution int, severities []string,
 				}
 				channel <- logMsg
 			}
-		}
+			return true
+		})
+		grip.Error(errors.Wrapf(err, "error reading logs of task %v", taskId))
 	}()
 
 	return channel, nil
 }
 
+// FindLogMessagesInRange returns up to limit messages of a task execution's
+// log of the given types that were logged at or after since and before until,
+// oldest first. Zero times leave the range unbounded, as does a limit that
+// isn't positive. Only the log chunks that can hold messages in the range are
+// read.
+func FindLogMessagesInRange(taskId string, execution int, msgTypes []string,
+	since, until time.Time, limit int) ([]LogMessage, error) {
+	storage := GetTaskLogStorage()
+	oldMsgTypes := oldLogMessageTypes(msgTypes)
+
+	// a chunk holds the messages logged from its timestamp on, so the chunk
+	// before the range may hold messages in it
+	from := since
+	if !since.IsZero() {
+		before, err := storage.FindBeforeTime(taskId, execution, since, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(before) > 0 {
+			from = before[0].Timestamp
+		}
+	}
+
+	logMsgs := []LogMessage{}
+	err := storage.Each(taskId, execution, from, func(logObj *TaskLog) bool {
+		if !until.IsZero() && !logObj.Timestamp.Before(until) {
+			return false
+		}
+		for _, logMsg := range logObj.Messages {
+			if !since.IsZero() && logMsg.Timestamp.Before(since) {
+				continue
+			}
+			if !until.IsZero() && !logMsg.Timestamp.Before(until) {
+				continue
+			}
+			if len(msgTypes) > 0 {
+				if !(util.SliceContains(msgTypes, logMsg.Type) ||
+					util.SliceContains(oldMsgTypes, logMsg.Type)) {
+					continue
+				}
+			}
+			logMsgs = append(logMsgs, logMsg)
+			if limit > 0 && len(logMsgs) >= limit {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading logs of task %v", taskId)
+	}
+	return logMsgs, nil
+}
+
+// oldLogMessageTypes returns the names that older agents logged the given
+// message types under.
+func oldLogMessageTypes(msgTypes []string) []string {
+	oldMsgTypes := []string{}
+	for _, msgType := range msgTypes {
+		switch msgType {
+		case SystemLogPrefix:
+			oldMsgTypes = append(oldMsgTypes, "system")
+		case AgentLogPrefix:
+			oldMsgTypes = append(oldMsgTypes, "agent")
+		case TaskLogPrefix:
+			oldMsgTypes = append(oldMsgTypes, "task")
+		}
+	}
+	return oldMsgTypes
+}
+
 /******************************************************
 Functions that operate on individual log messages
 ******************************************************/
@@ -272,24 +340,15 @@ func FindMostRecentLogMessages(taskId string, execution int, numMsgs int,
 	severities []string, msgTypes []string) ([]LogMessage, error) {
 	logMsgs := []LogMessage{}
 	numMsgsNeeded := numMsgs
-	lastTimeStamp := time.Date(2020, 0, 0, 0, 0, 0, 0, time.UTC)
+	// start well after now, since the agent's clock may be ahead of ours
+	lastTimeStamp := time.Now().AddDate(1, 0, 0)
 
-	oldMsgTypes := []string{}
-	for _, msgType := range msgTypes {
-		switch msgType {
-		case SystemLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "system")
-		case AgentLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "agent")
-		case TaskLogPrefix:
-			oldMsgTypes = append(oldMsgTypes, "task")
-		}
-	}
+	oldMsgTypes := oldLogMessageTypes(msgTypes)
 
 	// keep grabbing task logs from farther back until there are enough messages
 	for numMsgsNeeded != 0 {
 		numTaskLogsToFetch := numMsgsNeeded / MessagesPerLog
-		taskLogs, err := FindTaskLogsBeforeTime(taskId, execution, lastTimeStamp,
+		taskLogs, err := GetTaskLogStorage().FindBeforeTime(taskId, execution, lastTimeStamp,
 			numTaskLogsToFetch)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,311 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/goamz/goamz/aws"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// task log storage backends
+const (
+	MongoLogStorage      = "mongo"
+	FilesystemLogStorage = "filesystem"
+	S3LogStorage         = "s3"
+)
+
+// TaskLogStorage stores the chunks of task logs, as sent by the agent.
+type TaskLogStorage interface {
+	// Insert stores a new chunk of a task execution's log.
+	Insert(taskLog *TaskLog) error
+	// FindBeforeTime returns up to limit chunks of a task execution's log
+	// from before the given time, most recent first. A limit of 0 returns
+	// all of them.
+	FindBeforeTime(taskId string, execution int, ts time.Time, limit int) ([]TaskLog, error)
+	// FindSinceTime returns the chunks of a task execution's log from the
+	// given time on, oldest first.
+	FindSinceTime(taskId string, execution int, ts time.Time) ([]TaskLog, error)
+	// Each calls f with each chunk of a task execution's log from the given
+	// time on, oldest first, until f returns false. A zero time starts from
+	// the first chunk.
+	Each(taskId string, execution int, since time.Time, f func(*TaskLog) bool) error
+}
+
+var taskLogStorage TaskLogStorage = MongoTaskLogStorage{}
+
+// SetTaskLogStorage sets where task logs are stored and read from.
+func SetTaskLogStorage(storage TaskLogStorage) {
+	taskLogStorage = storage
+}
+
+// GetTaskLogStorage returns where task logs are stored and read from, which
+// is MongoDB unless set otherwise.
+func GetTaskLogStorage() TaskLogStorage {
+	return taskLogStorage
+}
+
+// NewTaskLogStorage returns the task log storage the settings configure.
+func NewTaskLogStorage(conf evergreen.LogStorageConfig) (TaskLogStorage, error) {
+	switch conf.Backend {
+	case "", MongoLogStorage:
+		return MongoTaskLogStorage{}, nil
+	case FilesystemLogStorage:
+		bucket, err := thirdparty.NewLocalBlobBucket(conf.Path)
+		if err != nil {
+			return nil, err
+		}
+		return &BlobTaskLogStorage{Bucket: bucket}, nil
+	case S3LogStorage:
+		auth := &aws.Auth{AccessKey: conf.S3.Key, SecretKey: conf.S3.Secret}
+		bucket, err := thirdparty.NewS3BlobBucket(auth, conf.S3.Region, conf.S3.Endpoint, conf.S3.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		return &BlobTaskLogStorage{Bucket: bucket, Prefix: conf.S3.Prefix}, nil
+	default:
+		return nil, errors.Errorf("unknown log storage backend '%v'", conf.Backend)
+	}
+}
+
+// MongoTaskLogStorage stores task log chunks as documents in MongoDB.
+type MongoTaskLogStorage struct{}
+
+func (MongoTaskLogStorage) Insert(taskLog *TaskLog) error {
+	return taskLog.Insert()
+}
+
+func (MongoTaskLogStorage) FindBeforeTime(taskId string, execution int, ts time.Time, limit int) ([]TaskLog, error) {
+	return FindTaskLogsBeforeTime(taskId, execution, ts, limit)
+}
+
+func (MongoTaskLogStorage) FindSinceTime(taskId string, execution int, ts time.Time) ([]TaskLog, error) {
+	return FindTaskLogsSinceTime(taskId, execution, ts)
+}
+
+func (MongoTaskLogStorage) Each(taskId string, execution int, since time.Time, f func(*TaskLog) bool) error {
+	session, db, err := getSessionAndDB()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	// TODO(EVG-227)
+	var query bson.M
+	if execution == 0 {
+		query = bson.M{"$and": []bson.M{
+			{TaskLogTaskIdKey: taskId},
+			{"$or": []bson.M{
+				{TaskLogExecutionKey: 0},
+				{TaskLogExecutionKey: nil},
+			}}}}
+	} else {
+		query = bson.M{
+			TaskLogTaskIdKey:    taskId,
+			TaskLogExecutionKey: execution,
+		}
+	}
+	if !since.IsZero() {
+		query = bson.M{"$and": []bson.M{query, {TaskLogTimestampKey: bson.M{"$gte": since}}}}
+	}
+	iter := db.C(TaskLogCollection).Find(query).Sort(TaskLogTimestampKey).Iter()
+
+	logObj := TaskLog{}
+	for iter.Next(&logObj) {
+		if !f(&logObj) {
+			break
+		}
+		logObj = TaskLog{}
+	}
+	return errors.WithStack(iter.Close())
+}
+
+// BlobTaskLogStorage stores each task log chunk as a gzipped JSON blob,
+// keyed by the task, the execution and the chunk's timestamp, so that the
+// chunks of a task execution list in order.
+type BlobTaskLogStorage struct {
+	Bucket thirdparty.BlobBucket
+	// Prefix is prepended to the key of every chunk.
+	Prefix string
+}
+
+// the timestamp format of chunk keys, which sorts lexically
+const taskLogChunkTimeFormat = "20060102T150405.000000000Z"
+
+func (s *BlobTaskLogStorage) executionPrefix(taskId string, execution int) string {
+	return fmt.Sprintf("%v%v/%v/", s.Prefix, taskId, execution)
+}
+
+// chunkTime parses the timestamp of the chunk from its key.
+func chunkTime(key string) (time.Time, error) {
+	name := path.Base(key)
+	if idx := strings.Index(name, "_"); idx >= 0 {
+		name = name[:idx]
+	}
+	ts, err := time.Parse(taskLogChunkTimeFormat, name)
+	return ts, errors.Wrapf(err, "invalid task log chunk key %v", key)
+}
+
+func (s *BlobTaskLogStorage) Insert(taskLog *TaskLog) error {
+	if taskLog.Id == "" {
+		taskLog.Id = bson.NewObjectId()
+	}
+	if taskLog.Timestamp.IsZero() {
+		taskLog.Timestamp = time.Now()
+	}
+
+	data := &bytes.Buffer{}
+	gz := gzip.NewWriter(data)
+	if err := json.NewEncoder(gz).Encode(taskLog); err != nil {
+		return errors.Wrap(err, "error encoding task log")
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(err, "error compressing task log")
+	}
+
+	key := fmt.Sprintf("%v%v_%v.json.gz", s.executionPrefix(taskLog.TaskId, taskLog.Execution),
+		taskLog.Timestamp.UTC().Format(taskLogChunkTimeFormat), taskLog.Id.Hex())
+	return s.Bucket.Put(key, data.Bytes())
+}
+
+func (s *BlobTaskLogStorage) load(key string) (*TaskLog, error) {
+	reader, err := s.Bucket.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decompressing task log chunk %v", key)
+	}
+	defer gz.Close()
+
+	taskLog := &TaskLog{}
+	if err = json.NewDecoder(gz).Decode(taskLog); err != nil {
+		return nil, errors.Wrapf(err, "error decoding task log chunk %v", key)
+	}
+	return taskLog, nil
+}
+
+func (s *BlobTaskLogStorage) FindBeforeTime(taskId string, execution int, ts time.Time, limit int) ([]TaskLog, error) {
+	keys, err := s.Bucket.List(s.executionPrefix(taskId, execution))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []TaskLog{}
+	for i := len(keys) - 1; i >= 0; i-- {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		chunkTs, err := chunkTime(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		if !chunkTs.Before(ts) {
+			continue
+		}
+		taskLog, err := s.load(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *taskLog)
+	}
+	return result, nil
+}
+
+func (s *BlobTaskLogStorage) FindSinceTime(taskId string, execution int, ts time.Time) ([]TaskLog, error) {
+	keys, err := s.Bucket.List(s.executionPrefix(taskId, execution))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []TaskLog{}
+	for _, key := range keys {
+		chunkTs, err := chunkTime(key)
+		if err != nil {
+			return nil, err
+		}
+		if chunkTs.Before(ts) {
+			continue
+		}
+		taskLog, err := s.load(key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *taskLog)
+	}
+	return result, nil
+}
+
+func (s *BlobTaskLogStorage) Each(taskId string, execution int, since time.Time, f func(*TaskLog) bool) error {
+	keys, err := s.Bucket.List(s.executionPrefix(taskId, execution))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		chunkTs, err := chunkTime(key)
+		if err != nil {
+			return err
+		}
+		if chunkTs.Before(since) {
+			continue
+		}
+		taskLog, err := s.load(key)
+		if err != nil {
+			return err
+		}
+		if !f(taskLog) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// MigrateTaskLogs moves up to limit task log chunks from before the given
+// time out of MongoDB and into the given storage, oldest first, returning
+// how many were moved. A limit of 0 moves all of them. Each chunk is
+// removed from MongoDB once it is stored, so an interrupted migration can
+// be run again.
+func MigrateTaskLogs(to TaskLogStorage, before time.Time, limit int) (int, error) {
+	if _, ok := to.(MongoTaskLogStorage); ok {
+		return 0, errors.New("can't migrate task logs from mongo to mongo")
+	}
+
+	session, db, err := getSessionAndDB()
+	if err != nil {
+		return 0, err
+	}
+	defer session.Close()
+
+	iter := db.C(TaskLogCollection).Find(bson.M{
+		TaskLogTimestampKey: bson.M{"$lt": before},
+	}).Sort(TaskLogTimestampKey).Limit(limit).Iter()
+
+	migrated := 0
+	taskLog := TaskLog{}
+	for iter.Next(&taskLog) {
+		if err = to.Insert(&taskLog); err != nil {
+			grip.Warning(iter.Close())
+			return migrated, errors.Wrapf(err, "error storing log chunk %v of task %v",
+				taskLog.Id.Hex(), taskLog.TaskId)
+		}
+		if err = db.C(TaskLogCollection).RemoveId(taskLog.Id); err != nil {
+			grip.Warning(iter.Close())
+			return migrated, errors.Wrapf(err, "error removing log chunk %v of task %v",
+				taskLog.Id.Hex(), taskLog.TaskId)
+		}
+		migrated++
+		taskLog = TaskLog{}
+	}
+	return migrated, errors.WithStack(iter.Close())
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBlobTaskLogStorage(t *testing.T) {
+	Convey("With task logs stored in a local blob bucket", t, func() {
+		dir, err := ioutil.TempDir("", "task_logs")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		bucket, err := thirdparty.NewLocalBlobBucket(dir)
+		So(err, ShouldBeNil)
+		storage := &BlobTaskLogStorage{Bucket: bucket, Prefix: "logs/"}
+
+		start := time.Now().Add(-time.Hour)
+		for i := 0; i < 5; i++ {
+			ts := start.Add(time.Duration(i) * time.Minute)
+			taskLog := &TaskLog{
+				TaskId:       "task_id",
+				Timestamp:    ts,
+				MessageCount: 2,
+				Messages: []LogMessage{
+					{Type: TaskLogPrefix, Severity: LogInfoPrefix, Message: "task", Timestamp: ts},
+					{Type: SystemLogPrefix, Severity: LogErrorPrefix, Message: "system", Timestamp: ts},
+				},
+			}
+			So(storage.Insert(taskLog), ShouldBeNil)
+		}
+		So(storage.Insert(&TaskLog{TaskId: "task_id", Execution: 1, Timestamp: start}), ShouldBeNil)
+
+		Convey("chunks before a time should be found most recent first", func() {
+			taskLogs, err := storage.FindBeforeTime("task_id", 0, start.Add(3*time.Minute), 2)
+			So(err, ShouldBeNil)
+			So(len(taskLogs), ShouldEqual, 2)
+			So(taskLogs[0].Timestamp.Equal(start.Add(2*time.Minute)), ShouldBeTrue)
+			So(taskLogs[1].Timestamp.Equal(start.Add(time.Minute)), ShouldBeTrue)
+			So(len(taskLogs[0].Messages), ShouldEqual, 2)
+
+			taskLogs, err = storage.FindBeforeTime("task_id", 0, time.Now(), 0)
+			So(err, ShouldBeNil)
+			So(len(taskLogs), ShouldEqual, 5)
+		})
+
+		Convey("chunks should be iterated oldest first", func() {
+			timestamps := []time.Time{}
+			So(storage.Each("task_id", 0, time.Time{}, func(taskLog *TaskLog) bool {
+				timestamps = append(timestamps, taskLog.Timestamp)
+				return true
+			}), ShouldBeNil)
+			So(len(timestamps), ShouldEqual, 5)
+			for i, ts := range timestamps {
+				So(ts.Equal(start.Add(time.Duration(i)*time.Minute)), ShouldBeTrue)
+			}
+		})
+
+		Convey("the raw log channel should read from the storage", func() {
+			SetTaskLogStorage(storage)
+			defer SetTaskLogStorage(MongoTaskLogStorage{})
+
+			channel, err := GetRawTaskLogChannel("task_id", 0, []string{}, []string{TaskLogPrefix})
+			So(err, ShouldBeNil)
+			count := 0
+			for msg := range channel {
+				So(msg.Message, ShouldEqual, "task")
+				count++
+			}
+			So(count, ShouldEqual, 5)
+		})
+
+		Convey("messages in a time range should read from the storage", func() {
+			SetTaskLogStorage(storage)
+			defer SetTaskLogStorage(MongoTaskLogStorage{})
+
+			msgs, err := FindLogMessagesInRange("task_id", 0, []string{TaskLogPrefix},
+				start.Add(time.Minute), start.Add(3*time.Minute), 0)
+			So(err, ShouldBeNil)
+			So(len(msgs), ShouldEqual, 2)
+			So(msgs[0].Timestamp.Equal(start.Add(time.Minute)), ShouldBeTrue)
+			So(msgs[1].Timestamp.Equal(start.Add(2*time.Minute)), ShouldBeTrue)
+
+			msgs, err = FindLogMessagesInRange("task_id", 0, []string{},
+				start.Add(90*time.Second), time.Time{}, 0)
+			So(err, ShouldBeNil)
+			So(len(msgs), ShouldEqual, 6)
+			So(msgs[0].Timestamp.Equal(start.Add(2*time.Minute)), ShouldBeTrue)
+
+			msgs, err = FindLogMessagesInRange("task_id", 0, []string{},
+				start.Add(90*time.Second), time.Time{}, 4)
+			So(err, ShouldBeNil)
+			So(len(msgs), ShouldEqual, 4)
+			So(msgs[0].Timestamp.Equal(start.Add(2*time.Minute)), ShouldBeTrue)
+		})
+
+		Convey("the most recent messages should read from the storage", func() {
+			SetTaskLogStorage(storage)
+			defer SetTaskLogStorage(MongoTaskLogStorage{})
+
+			msgs, err := FindMostRecentLogMessages("task_id", 0, 3, []string{}, []string{SystemLogPrefix})
+			So(err, ShouldBeNil)
+			So(len(msgs), ShouldEqual, 3)
+			So(msgs[0].Message, ShouldEqual, "system")
+			So(msgs[0].Timestamp.Equal(start.Add(4*time.Minute)), ShouldBeTrue)
+		})
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -40,6 +43,9 @@ type TaskQueueItem struct {
 	Project             string        `bson:"project" json:"project"`
 	ExpectedDuration    time.Duration `bson:"exp_dur" json:"exp_dur"`
 	Priority            int64         `bson:"priority" json:"priority"`
+	BuildId             string        `bson:"build_id,omitempty" json:"build_id,omitempty"`
+	Group               string        `bson:"group,omitempty" json:"group,omitempty"`
+	GroupOrder          int           `bson:"group_order,omitempty" json:"group_order,omitempty"`
 }
 
 var (
@@ -66,6 +72,12 @@ var (
 		"ExpectedDuration")
 	TaskQueuePriorityKey = bsonutil.MustHaveTag(TaskQueueItem{},
 		"Priority")
+	TaskQueueItemBuildIdKey = bsonutil.MustHaveTag(TaskQueueItem{},
+		"BuildId")
+	TaskQueueItemGroupKey = bsonutil.MustHaveTag(TaskQueueItem{},
+		"Group")
+	TaskQueueItemGroupOrderKey = bsonutil.MustHaveTag(TaskQueueItem{},
+		"GroupOrder")
 )
 
 func (self *TaskQueue) Length() int {
@@ -80,6 +92,91 @@ func (self *TaskQueue) NextTask() TaskQueueItem {
 	return self.Queue[0]
 }
 
+// NextTaskForHost returns the queue item that the given host should run
+// next, or nil if there is nothing in the queue it can run. Members of a task
+// group are handed out in group order, and only to the host that is already
+// running that group in their build, if any; a host that just finished a
+// member of a group is given the next member of that group first.
+func (self *TaskQueue) NextTaskForHost(h *host.Host) (*TaskQueueItem, error) {
+	if h.LastTaskCompleted != "" {
+		lastTask, err := task.FindOne(task.ById(h.LastTaskCompleted).WithFields(
+			task.BuildIdKey, task.TaskGroupKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error finding last task %s run by host %s",
+				h.LastTaskCompleted, h.Id)
+		}
+		if lastTask != nil && lastTask.TaskGroup != "" {
+			if item := self.nextGroupMember(lastTask.BuildId, lastTask.TaskGroup); item != nil {
+				return item, nil
+			}
+		}
+	}
+
+	checked := map[string]bool{}
+	for _, item := range self.Queue {
+		if item.Group == "" {
+			return &item, nil
+		}
+		groupKey := item.BuildId + "/" + item.Group
+		if checked[groupKey] {
+			continue
+		}
+		checked[groupKey] = true
+
+		claimed, err := taskGroupClaimedByOtherHost(item.BuildId, item.Group, h.Id)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !claimed {
+			return self.nextGroupMember(item.BuildId, item.Group), nil
+		}
+	}
+	return nil, nil
+}
+
+// nextGroupMember returns the queued member of the task group with the lowest
+// position in the group, or nil if no member of the group is queued.
+func (self *TaskQueue) nextGroupMember(buildId, group string) *TaskQueueItem {
+	var next *TaskQueueItem
+	for i, item := range self.Queue {
+		if item.BuildId != buildId || item.Group != group {
+			continue
+		}
+		if next == nil || item.GroupOrder < next.GroupOrder {
+			next = &self.Queue[i]
+		}
+	}
+	return next
+}
+
+// taskGroupClaimedByOtherHost returns whether a host other than the given one
+// is running a member of the task group, or has just finished one and has not
+// yet asked for its next task.
+func taskGroupClaimedByOtherHost(buildId, group, hostId string) (bool, error) {
+	members, err := task.Find(task.ByTaskGroup(buildId, group).WithFields(
+		task.StatusKey, task.HostIdKey))
+	if err != nil {
+		return false, errors.Wrapf(err, "error finding members of task group %s", group)
+	}
+	for _, member := range members {
+		if member.HostId == "" || member.HostId == hostId {
+			continue
+		}
+		if task.IsAbortable(member) {
+			return true, nil
+		}
+		memberHost, err := host.FindOne(host.ById(member.HostId))
+		if err != nil {
+			return false, errors.Wrapf(err, "error finding host %s", member.HostId)
+		}
+		if memberHost != nil && memberHost.Status == evergreen.HostRunning &&
+			memberHost.RunningTask == "" && memberHost.LastTaskCompleted == member.Id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (self *TaskQueue) Save() error {
 	return UpdateTaskQueue(self.Distro, self.Queue)
 }
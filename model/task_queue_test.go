@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/mongodb/grip"
 	. "github.com/smartystreets/goconvey/convey"
@@ -76,3 +79,71 @@ func TestDequeueTask(t *testing.T) {
 
 	})
 }
+
+func TestNextTaskForHost(t *testing.T) {
+
+	Convey("With a task queue containing members of a task group", t, func() {
+		So(db.ClearCollections(task.Collection, host.Collection), ShouldBeNil)
+
+		taskQueue := &TaskQueue{
+			Distro: "d1",
+			Queue: []TaskQueueItem{
+				{Id: "test", BuildId: "b1", Group: "g1", GroupOrder: 2},
+				{Id: "other", BuildId: "b1"},
+				{Id: "compile", BuildId: "b1", Group: "g1", GroupOrder: 1},
+			},
+		}
+		h1 := &host.Host{Id: "h1", Status: evergreen.HostRunning}
+		h2 := &host.Host{Id: "h2", Status: evergreen.HostRunning}
+
+		Convey("an unclaimed group should be started from its first queued member", func() {
+			item, err := taskQueue.NextTaskForHost(h1)
+			So(err, ShouldBeNil)
+			So(item.Id, ShouldEqual, "compile")
+		})
+
+		Convey("a group running on another host should be skipped", func() {
+			running := &task.Task{Id: "setup", BuildId: "b1", TaskGroup: "g1",
+				HostId: "h2", Status: evergreen.TaskStarted}
+			So(running.Insert(), ShouldBeNil)
+
+			item, err := taskQueue.NextTaskForHost(h1)
+			So(err, ShouldBeNil)
+			So(item.Id, ShouldEqual, "other")
+
+			taskQueue.Queue = taskQueue.Queue[:1]
+			item, err = taskQueue.NextTaskForHost(h1)
+			So(err, ShouldBeNil)
+			So(item, ShouldBeNil)
+		})
+
+		Convey("a group should stay claimed by a host that just finished a member", func() {
+			finished := &task.Task{Id: "setup", BuildId: "b1", TaskGroup: "g1",
+				HostId: "h2", Status: evergreen.TaskSucceeded}
+			So(finished.Insert(), ShouldBeNil)
+			h2.LastTaskCompleted = "setup"
+			So(h2.Insert(), ShouldBeNil)
+
+			item, err := taskQueue.NextTaskForHost(h1)
+			So(err, ShouldBeNil)
+			So(item.Id, ShouldEqual, "other")
+
+			Convey("and that host should be given the next member first", func() {
+				item, err = taskQueue.NextTaskForHost(h2)
+				So(err, ShouldBeNil)
+				So(item.Id, ShouldEqual, "compile")
+			})
+
+			Convey("unless it has moved on to another task", func() {
+				h2.LastTaskCompleted = "elsewhere"
+				h2.RunningTask = "elsewhere"
+				So(db.Clear(host.Collection), ShouldBeNil)
+				So(h2.Insert(), ShouldBeNil)
+
+				item, err = taskQueue.NextTaskForHost(h1)
+				So(err, ShouldBeNil)
+				So(item.Id, ShouldEqual, "compile")
+			})
+		})
+	})
+}
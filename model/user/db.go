@@ -38,6 +38,11 @@ func ById(userId string) db.Q {
 	return db.Query(bson.M{IdKey: userId})
 }
 
+// ByEmail returns a query for the users with the given email address.
+func ByEmail(email string) db.Q {
+	return db.Query(bson.M{EmailAddressKey: email})
+}
+
 func ByIds(userIds ...string) db.Q {
 	return db.Query(bson.M{
 		IdKey: bson.M{
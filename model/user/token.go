@@ -0,0 +1,163 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/db/bsonutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	AccessTokenCollection = "access_tokens"
+
+	// TokenScopeRead tokens may only make requests that read data.
+	TokenScopeRead = "read"
+	// TokenScopeProject tokens may also make requests that change the
+	// projects the token is scoped to.
+	TokenScopeProject = "project"
+	// TokenScopeAdmin tokens may make any request that their user may.
+	TokenScopeAdmin = "admin"
+)
+
+// ValidTokenScopes are the scopes an access token may have.
+var ValidTokenScopes = []string{TokenScopeRead, TokenScopeProject, TokenScopeAdmin}
+
+// AccessToken is a named, revocable token that a user creates for REST
+// clients, such as CI bots, to make requests as them without their API key,
+// limited to the token's scope. Only a hash of the token is stored, so the
+// token itself is only known when it is created.
+type AccessToken struct {
+	Id        string    `bson:"_id" json:"-"`
+	Name      string    `bson:"name" json:"name"`
+	UserId    string    `bson:"user_id" json:"user_id"`
+	Scope     string    `bson:"scope" json:"scope"`
+	Projects  []string  `bson:"projects,omitempty" json:"projects,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	// ExpiresAt is the zero time for tokens that never expire.
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+var (
+	AccessTokenIdKey        = bsonutil.MustHaveTag(AccessToken{}, "Id")
+	AccessTokenNameKey      = bsonutil.MustHaveTag(AccessToken{}, "Name")
+	AccessTokenUserIdKey    = bsonutil.MustHaveTag(AccessToken{}, "UserId")
+	AccessTokenScopeKey     = bsonutil.MustHaveTag(AccessToken{}, "Scope")
+	AccessTokenProjectsKey  = bsonutil.MustHaveTag(AccessToken{}, "Projects")
+	AccessTokenCreatedAtKey = bsonutil.MustHaveTag(AccessToken{}, "CreatedAt")
+	AccessTokenExpiresAtKey = bsonutil.MustHaveTag(AccessToken{}, "ExpiresAt")
+)
+
+// NewAccessToken creates an access token for the user, returning it along
+// with the token that clients send to authenticate with it.
+func NewAccessToken(userId, name, scope string, projects []string, expiresAt time.Time) (*AccessToken, string) {
+	token := util.RandomString()
+	return &AccessToken{
+		Id:        HashAccessToken(token),
+		Name:      name,
+		UserId:    userId,
+		Scope:     scope,
+		Projects:  projects,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}, token
+}
+
+// HashAccessToken returns the hash of a token that its access token is
+// stored under.
+func HashAccessToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+// Validate checks that the access token has a name and a valid scope, and
+// that only project scoped tokens, and all of them, have projects.
+func (t *AccessToken) Validate() error {
+	switch {
+	case t.Name == "":
+		return errors.New("access token requires a name")
+	case !util.SliceContains(ValidTokenScopes, t.Scope):
+		return errors.Errorf("access token scope '%v' must be one of %v", t.Scope, ValidTokenScopes)
+	case t.Scope == TokenScopeProject && len(t.Projects) == 0:
+		return errors.New("project scoped access tokens require projects")
+	case t.Scope != TokenScopeProject && len(t.Projects) > 0:
+		return errors.Errorf("access tokens with scope '%v' cannot have projects", t.Scope)
+	default:
+		return nil
+	}
+}
+
+// IsExpired returns whether the access token has expired as of the given
+// time.
+func (t *AccessToken) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// CanWrite returns whether the access token allows requests that change the
+// given project, or, if projectId is empty, data outside of any project.
+func (t *AccessToken) CanWrite(projectId string) bool {
+	switch t.Scope {
+	case TokenScopeAdmin:
+		return true
+	case TokenScopeProject:
+		return projectId != "" && util.SliceContains(t.Projects, projectId)
+	default:
+		return false
+	}
+}
+
+// Insert inserts the access token into the database.
+func (t *AccessToken) Insert() error {
+	if err := t.Validate(); err != nil {
+		return errors.Wrap(err, "cannot insert invalid access token")
+	}
+	return errors.WithStack(db.Insert(AccessTokenCollection, t))
+}
+
+// FindAccessTokenByToken returns the access token that clients authenticate
+// with the given token, or nil if there is none.
+func FindAccessTokenByToken(token string) (*AccessToken, error) {
+	t := &AccessToken{}
+	err := db.FindOneQ(AccessTokenCollection, db.Query(bson.M{AccessTokenIdKey: HashAccessToken(token)}), t)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return t, errors.WithStack(err)
+}
+
+// FindOneAccessToken returns the user's access token with the given name, or
+// nil if there is none.
+func FindOneAccessToken(userId, name string) (*AccessToken, error) {
+	t := &AccessToken{}
+	err := db.FindOneQ(AccessTokenCollection, db.Query(bson.M{
+		AccessTokenUserIdKey: userId,
+		AccessTokenNameKey:   name,
+	}), t)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return t, errors.WithStack(err)
+}
+
+// FindAccessTokensByUser returns all of the user's access tokens, sorted by
+// name.
+func FindAccessTokensByUser(userId string) ([]AccessToken, error) {
+	tokens := []AccessToken{}
+	err := db.FindAllQ(AccessTokenCollection, db.Query(bson.M{
+		AccessTokenUserIdKey: userId,
+	}).Sort([]string{AccessTokenNameKey}), &tokens)
+	return tokens, errors.WithStack(err)
+}
+
+// RemoveAccessToken revokes the user's access token with the given name.
+func RemoveAccessToken(userId, name string) error {
+	return errors.WithStack(db.Remove(AccessTokenCollection, bson.M{
+		AccessTokenUserIdKey: userId,
+		AccessTokenNameKey:   name,
+	}))
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAccessTokenScopes(t *testing.T) {
+	Convey("With access tokens of each scope", t, func() {
+		read, readToken := NewAccessToken("user", "reader", TokenScopeRead, nil, time.Time{})
+		project, _ := NewAccessToken("user", "bot", TokenScopeProject, []string{"mci"}, time.Time{})
+		admin, _ := NewAccessToken("user", "root", TokenScopeAdmin, nil, time.Time{})
+
+		Convey("only the hash of the token should be stored", func() {
+			So(read.Id, ShouldNotEqual, readToken)
+			So(read.Id, ShouldEqual, HashAccessToken(readToken))
+		})
+		Convey("they should all be valid", func() {
+			So(read.Validate(), ShouldBeNil)
+			So(project.Validate(), ShouldBeNil)
+			So(admin.Validate(), ShouldBeNil)
+		})
+		Convey("only project scoped tokens should have projects", func() {
+			read.Projects = []string{"mci"}
+			So(read.Validate(), ShouldNotBeNil)
+			project.Projects = nil
+			So(project.Validate(), ShouldNotBeNil)
+		})
+		Convey("tokens need a name and a known scope", func() {
+			read.Name = ""
+			So(read.Validate(), ShouldNotBeNil)
+			admin.Scope = "root"
+			So(admin.Validate(), ShouldNotBeNil)
+		})
+		Convey("read only tokens should not write anything", func() {
+			So(read.CanWrite("mci"), ShouldBeFalse)
+			So(read.CanWrite(""), ShouldBeFalse)
+		})
+		Convey("project scoped tokens should only write their projects", func() {
+			So(project.CanWrite("mci"), ShouldBeTrue)
+			So(project.CanWrite("other"), ShouldBeFalse)
+			So(project.CanWrite(""), ShouldBeFalse)
+		})
+		Convey("admin tokens should write anything", func() {
+			So(admin.CanWrite("mci"), ShouldBeTrue)
+			So(admin.CanWrite(""), ShouldBeTrue)
+		})
+		Convey("tokens should only expire if they have an expiry", func() {
+			now := time.Now()
+			So(read.IsExpired(now.AddDate(10, 0, 0)), ShouldBeFalse)
+			read.ExpiresAt = now
+			So(read.IsExpired(now.Add(-time.Second)), ShouldBeFalse)
+			So(read.IsExpired(now), ShouldBeTrue)
+		})
+	})
+}
@@ -29,8 +29,16 @@ type PubKey struct {
 }
 
 type UserSettings struct {
-	Timezone     string `json:"timezone" bson:"timezone"`
-	NewWaterfall bool   `json:"new_waterfall" bson:"new_waterfall"`
+	Timezone           string                    `json:"timezone" bson:"timezone"`
+	NewWaterfall       bool                      `json:"new_waterfall" bson:"new_waterfall"`
+	PatchNotifications PatchNotificationSettings `json:"patch_notifications" bson:"patch_notifications"`
+}
+
+// PatchNotificationSettings control how a user is told that their patches
+// have finished. Users are emailed unless they opt out.
+type PatchNotificationSettings struct {
+	Disabled   bool   `json:"disabled" bson:"disabled"`
+	WebhookURL string `json:"webhook_url" bson:"webhook_url,omitempty"`
 }
 
 func (u *DBUser) Username() string {
@@ -99,6 +99,22 @@ func ByProjectIdAndOrder(projectId string, revisionOrderNumber int) db.Q {
 		})
 }
 
+// ByProjectIdFromOrder finds non-patch versions for the given project with
+// revision order numbers less than or equal to revisionOrderNumber, most
+// recent first. If dir is negative, it instead finds those with greater
+// revision order numbers, least recent first.
+func ByProjectIdFromOrder(projectId string, revisionOrderNumber int, dir int) db.Q {
+	if dir < 0 {
+		return db.Query(
+			bson.M{
+				IdentifierKey:          projectId,
+				RevisionOrderNumberKey: bson.M{"$gt": revisionOrderNumber},
+				RequesterKey:           evergreen.RepotrackerVersionRequester,
+			}).Sort([]string{RevisionOrderNumberKey})
+	}
+	return ByProjectIdAndOrder(projectId, revisionOrderNumber).Sort([]string{"-" + RevisionOrderNumberKey})
+}
+
 // ByLastVariantActivation finds the most recent non-patch, non-ignored
 // versions in a project that have a particular variant activated.
 func ByLastVariantActivation(projectId, variant string) db.Q {
@@ -0,0 +1,51 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var versionTestConfig = testutil.TestConfig()
+
+func init() {
+	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(versionTestConfig))
+}
+
+func TestByProjectIdFromOrder(t *testing.T) {
+	Convey("With versions of several projects and requesters", t, func() {
+		testutil.HandleTestingErr(db.Clear(Collection), t, "Error clearing '%v' collection", Collection)
+		versions := []Version{
+			{Id: "v1", Identifier: "proj", RevisionOrderNumber: 1, Requester: evergreen.RepotrackerVersionRequester},
+			{Id: "v2", Identifier: "proj", RevisionOrderNumber: 2, Requester: evergreen.RepotrackerVersionRequester},
+			{Id: "v3", Identifier: "proj", RevisionOrderNumber: 3, Requester: evergreen.RepotrackerVersionRequester},
+			{Id: "v4", Identifier: "proj", RevisionOrderNumber: 4, Requester: evergreen.RepotrackerVersionRequester},
+			{Id: "patch", Identifier: "proj", RevisionOrderNumber: 5, Requester: evergreen.PatchVersionRequester},
+			{Id: "other", Identifier: "other", RevisionOrderNumber: 2, Requester: evergreen.RepotrackerVersionRequester},
+		}
+		for _, v := range versions {
+			So(v.Insert(), ShouldBeNil)
+		}
+		ids := func(q db.Q) []string {
+			found, err := Find(q)
+			So(err, ShouldBeNil)
+			res := []string{}
+			for _, v := range found {
+				res = append(res, v.Id)
+			}
+			return res
+		}
+
+		Convey("paging forward should return the version and older ones, most recent first", func() {
+			So(ids(ByProjectIdFromOrder("proj", 3, 1)), ShouldResemble, []string{"v3", "v2", "v1"})
+			So(ids(ByProjectIdFromOrder("proj", 3, 1).Limit(2)), ShouldResemble, []string{"v3", "v2"})
+		})
+		Convey("paging back should return the more recent versions, nearest first", func() {
+			So(ids(ByProjectIdFromOrder("proj", 2, -1)), ShouldResemble, []string{"v3", "v4"})
+			So(ids(ByProjectIdFromOrder("proj", 4, -1)), ShouldBeEmpty)
+		})
+	})
+}
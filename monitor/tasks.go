@@ -125,6 +125,7 @@ func cleanUpTimedOutHeartbeat(t task.Task, project model.Project, host *host.Hos
 	// mock up the failure details of the task
 	detail := &apimodels.TaskEndDetail{
 		Description: task.AgentHeartbeat,
+		Type:        model.SystemCommandType,
 		TimedOut:    true,
 		Status:      evergreen.TaskFailed,
 	}
@@ -0,0 +1,225 @@
+package notify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/subscription"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+//**********************************\/
+//   Notifications File Structs     \/
+//**********************************\/
+
+// stores supported notifications
+type Notification struct {
+	Name         string   `yaml:"name"`
+	Project      string   `yaml:"project"`
+	Recipients   []string `yaml:"recipients"`
+	SkipVariants []string `yaml:"skip_variants"`
+}
+
+// stores notifications subscription for a team
+type Subscription struct {
+	Project      string   `yaml:"project"`
+	SkipVariants []string `yaml:"skip_variants"`
+	NotifyOn     []string `yaml:"notify_on"`
+}
+
+// stores 10gen team information
+type Team struct {
+	Name          string         `yaml:"name"`
+	Address       string         `yaml:"address"`
+	Subscriptions []Subscription `yaml:"subscriptions"`
+}
+
+// store notifications file
+type MCINotification struct {
+	Notifications      []Notification `yaml:"notifications"`
+	Teams              []Team         `yaml:"teams"`
+	PatchNotifications []Subscription `yaml:"patch_notifications"`
+}
+
+// MigrateNotificationsFile creates subscriptions for the recipients and teams
+// in the notifications file, which the notifier no longer reads. Subscriptions
+// that already exist are not created again, so it can be run more than once.
+// Returns the number of subscriptions created.
+func MigrateNotificationsFile(configName string) (int, error) {
+	mciNotification, err := parseNotificationsFile(configName)
+	if err != nil {
+		return 0, err
+	}
+
+	projectNameToBuildVariants, err := findProjectBuildVariants()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error loading project build variants")
+	}
+
+	subs, err := notificationsToSubscriptions(mciNotification, projectNameToBuildVariants)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, sub := range subs {
+		existing, err := subscription.FindOne(
+			subscription.ByTriggerSelectorAndSubscriber(sub.Trigger, sub.Selector, sub.Subscriber))
+		if err != nil {
+			return created, errors.Wrap(err, "error finding existing subscriptions")
+		}
+		if existing != nil {
+			continue
+		}
+		if err = sub.Insert(); err != nil {
+			return created, errors.Wrapf(err, "error inserting %v subscription for %v",
+				sub.Trigger, sub.Subscriber.Name)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// This function is responsible for reading the notifications file
+func parseNotificationsFile(configName string) (*MCINotification, error) {
+	grip.Info("Parsing notifications...")
+
+	evgHome := evergreen.FindEvergreenHome()
+	configs := []string{
+		filepath.Join(evgHome, configName, evergreen.NotificationsFile),
+		filepath.Join(evgHome, evergreen.NotificationsFile),
+		DefaultNotificationsConfig,
+	}
+
+	var notificationsFile string
+	for _, fn := range configs {
+		if _, err := os.Stat(fn); os.IsNotExist(err) {
+			continue
+		}
+
+		notificationsFile = fn
+	}
+
+	data, err := ioutil.ReadFile(notificationsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// unmarshal file contents into MCINotification struct
+	mciNotification := &MCINotification{}
+
+	err = yaml.Unmarshal(data, mciNotification)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Parse error unmarshalling notifications %v", notificationsFile)
+	}
+	return mciNotification, nil
+}
+
+// notificationsToSubscriptions returns the subscriptions that send the same
+// emails as the notifications file. Recipients who are users become person
+// subscribers; other recipients become teams of one. Patch notifications are
+// not migrated, since the notifier never sent them.
+func notificationsToSubscriptions(mciNotification *MCINotification,
+	projectNameToBuildVariants map[string][]string) ([]subscription.Subscription, error) {
+	subs := []subscription.Subscription{}
+
+	for _, notification := range mciNotification.Notifications {
+		if !canMigrateTrigger(notification.Name) {
+			continue
+		}
+		selectors := selectorsWithoutVariants(notification.Project, notification.SkipVariants,
+			projectNameToBuildVariants)
+		for _, recipient := range notification.Recipients {
+			subscriber, err := recipientSubscriber(recipient)
+			if err != nil {
+				return nil, err
+			}
+			subs = append(subs, newSubscriptions(notification.Name, selectors, subscriber)...)
+		}
+	}
+
+	for _, team := range mciNotification.Teams {
+		subscriber := subscription.Subscriber{
+			Type:    subscription.TeamSubscriber,
+			Name:    team.Name,
+			Address: team.Address,
+		}
+		for _, teamSubscription := range team.Subscriptions {
+			selectors := selectorsWithoutVariants(teamSubscription.Project,
+				teamSubscription.SkipVariants, projectNameToBuildVariants)
+			for _, name := range teamSubscription.NotifyOn {
+				if !canMigrateTrigger(name) {
+					continue
+				}
+				subs = append(subs, newSubscriptions(name, selectors, subscriber)...)
+			}
+		}
+	}
+
+	return subs, nil
+}
+
+// canMigrateTrigger returns whether a notification can be subscribed to,
+// warning that it will be dropped if it cannot.
+func canMigrateTrigger(name string) bool {
+	if util.SliceContains(subscriptionTriggers, name) {
+		return true
+	}
+	grip.Warningf("Not migrating '%v' notifications: they cannot be subscribed to", name)
+	return false
+}
+
+// selectorsWithoutVariants returns the selectors for all of a project's
+// variants except the skipped ones, since a selector can't exclude a variant.
+func selectorsWithoutVariants(project string, skipVariants []string,
+	projectNameToBuildVariants map[string][]string) []subscription.Selector {
+	if len(skipVariants) == 0 {
+		return []subscription.Selector{{Project: project}}
+	}
+	selectors := []subscription.Selector{}
+	for _, buildVariant := range projectNameToBuildVariants[project] {
+		if !util.SliceContains(skipVariants, buildVariant) {
+			selectors = append(selectors, subscription.Selector{Project: project, Variant: buildVariant})
+		}
+	}
+	return selectors
+}
+
+// newSubscriptions returns a subscription to the trigger for each selector,
+// owned by the Evergreen user.
+func newSubscriptions(trigger string, selectors []subscription.Selector,
+	subscriber subscription.Subscriber) []subscription.Subscription {
+	subs := []subscription.Subscription{}
+	for _, selector := range selectors {
+		subs = append(subs, subscription.Subscription{
+			Trigger:    trigger,
+			Selector:   selector,
+			Subscriber: subscriber,
+			Owner:      evergreen.User,
+		})
+	}
+	return subs
+}
+
+// recipientSubscriber returns the user with the recipient's email address as
+// a subscriber, or the address as a team if no user has it.
+func recipientSubscriber(recipient string) (subscription.Subscriber, error) {
+	dbUser, err := user.FindOne(user.ByEmail(recipient))
+	if err != nil {
+		return subscription.Subscriber{}, errors.Wrapf(err, "Error finding user with address %v", recipient)
+	}
+	if dbUser != nil {
+		return subscription.Subscriber{Type: subscription.PersonSubscriber, Name: dbUser.Id}, nil
+	}
+	return subscription.Subscriber{
+		Type:    subscription.TeamSubscriber,
+		Name:    recipient,
+		Address: recipient,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/subscription"
+	"github.com/evergreen-ci/evergreen/model/user"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNotificationsToSubscriptions(t *testing.T) {
+	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(TestConfig))
+
+	Convey("With a notifications file and a user with one of its recipients' addresses", t, func() {
+		So(db.Clear(user.Collection), ShouldBeNil)
+		So((&user.DBUser{Id: "me", EmailAddress: "me@example.com"}).Insert(), ShouldBeNil)
+
+		mciNotification := &MCINotification{
+			Notifications: []Notification{
+				{
+					Name:         taskFailureKey,
+					Project:      projectId,
+					Recipients:   []string{"me@example.com", "you@example.com"},
+					SkipVariants: []string{"v2"},
+				},
+				{Name: taskSuccessKey, Project: projectId, Recipients: []string{"me@example.com"}},
+			},
+			Teams: []Team{
+				{
+					Name:    "myteam",
+					Address: "myteam@example.com",
+					Subscriptions: []Subscription{
+						{Project: projectId, NotifyOn: []string{buildFailureKey, buildSuccessKey}},
+					},
+				},
+			},
+			PatchNotifications: []Subscription{
+				{Project: projectId, NotifyOn: []string{taskFailureKey}},
+			},
+		}
+		projectNameToBuildVariants := map[string][]string{projectId: {"v1", "v2", "v3"}}
+
+		subs, err := notificationsToSubscriptions(mciNotification, projectNameToBuildVariants)
+		So(err, ShouldBeNil)
+
+		me := subscription.Subscriber{Type: subscription.PersonSubscriber, Name: "me"}
+		you := subscription.Subscriber{
+			Type:    subscription.TeamSubscriber,
+			Name:    "you@example.com",
+			Address: "you@example.com",
+		}
+		team := subscription.Subscriber{
+			Type:    subscription.TeamSubscriber,
+			Name:    "myteam",
+			Address: "myteam@example.com",
+		}
+		v1 := subscription.Selector{Project: projectId, Variant: "v1"}
+		v3 := subscription.Selector{Project: projectId, Variant: "v3"}
+
+		Convey("recipients should subscribe to every variant that isn't skipped", func() {
+			So(subs[:4], ShouldResemble, []subscription.Subscription{
+				{Trigger: taskFailureKey, Selector: v1, Subscriber: me, Owner: evergreen.User},
+				{Trigger: taskFailureKey, Selector: v3, Subscriber: me, Owner: evergreen.User},
+				{Trigger: taskFailureKey, Selector: v1, Subscriber: you, Owner: evergreen.User},
+				{Trigger: taskFailureKey, Selector: v3, Subscriber: you, Owner: evergreen.User},
+			})
+		})
+
+		Convey("teams should subscribe to the whole project", func() {
+			So(subs[4:], ShouldResemble, []subscription.Subscription{
+				{
+					Trigger:    buildFailureKey,
+					Selector:   subscription.Selector{Project: projectId},
+					Subscriber: team,
+					Owner:      evergreen.User,
+				},
+			})
+		})
+
+		Convey("the subscriptions should be valid", func() {
+			for _, sub := range subs {
+				So(sub.Validate(), ShouldBeNil)
+			}
+		})
+	})
+}
@@ -2,11 +2,8 @@ package notify
 
 import (
 	"fmt"
-	"io/ioutil"
 	"net/mail"
-	"os"
 	"path"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -21,7 +18,6 @@ import (
 	"github.com/evergreen-ci/evergreen/web"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -63,6 +59,7 @@ var (
 	// within each task
 	buildType = "build"
 	taskType  = "task"
+	patchType = "patch"
 
 	// notification key types
 	buildFailureKey          = "build_failure"
@@ -73,6 +70,7 @@ var (
 	taskSuccessKey           = "task_success"
 	taskSuccessToFailureKey  = "task_success_to_failure"
 	taskCompletionKey        = "task_completion"
+	patchFinishedKey         = "patch_finished"
 	taskFailureKeys          = []string{taskFailureKey, taskSuccessToFailureKey}
 	buildFailureKeys         = []string{buildFailureKey, buildSuccessToFailureKey}
 
@@ -104,6 +102,7 @@ var (
 		taskSuccessKey:           &TaskSuccessHandler{taskNotificationHandler, taskSuccessKey},
 		taskCompletionKey:        &TaskCompletionHandler{taskNotificationHandler, taskCompletionKey},
 		taskSuccessToFailureKey:  &TaskSuccessToFailureHandler{taskNotificationHandler, taskSuccessToFailureKey},
+		patchFinishedKey:         &PatchFinishedHandler{patchFinishedKey},
 	}
 )
 
@@ -141,28 +140,19 @@ func ConstructMailer(notifyConfig evergreen.NotifyConfig) Mailer {
 
 // This function is responsible for running the notifications pipeline
 //
-// ParseNotifications
+// FindSubscriptions
 //         ↓↓
-// ValidateNotifications
+// ProcessSubscriptions
 //         ↓↓
-// ProcessNotifications
-//         ↓↓
-// SendNotifications
+// SendSubscriptionNotifications
 //         ↓↓
 // UpdateNotificationTimes
 //
 func Run(settings *evergreen.Settings) error {
-	// get the notifications
-	mciNotification, err := ParseNotifications(settings.ConfigDir)
+	// get the subscriptions
+	subscriptions, err := FindSubscriptions()
 	if err != nil {
-		grip.Errorf("parsing notifications: %+v", err)
-		return err
-	}
-
-	// validate the notifications
-	err = ValidateNotifications(mciNotification)
-	if err != nil {
-		grip.Errorf("validating notifications: %+v", err)
+		grip.Errorf("finding subscriptions: %+v", err)
 		return err
 	}
 
@@ -175,8 +165,8 @@ func Run(settings *evergreen.Settings) error {
 		return err
 	}
 
-	// process the notifications
-	emails, err := ProcessNotifications(ae, mciNotification, true)
+	// process the subscriptions
+	emails, err := ProcessSubscriptions(ae, subscriptions, true)
 	if err != nil {
 		grip.Errorf("processing notifications: %+v", err)
 		return err
@@ -188,8 +178,7 @@ func Run(settings *evergreen.Settings) error {
 	// bumps up the priority of a build/task
 
 	// send the notifications
-
-	err = SendNotifications(settings, mciNotification, emails,
+	err = SendSubscriptionNotifications(settings, subscriptions, emails,
 		ConstructMailer(settings.Notify))
 	if err != nil {
 		grip.Errorln("Error sending notifications:", err)
@@ -205,131 +194,8 @@ func Run(settings *evergreen.Settings) error {
 	return nil
 }
 
-// This function is responsible for reading the notifications file
-func ParseNotifications(configName string) (*MCINotification, error) {
-	grip.Info("Parsing notifications...")
-
-	evgHome := evergreen.FindEvergreenHome()
-	configs := []string{
-		filepath.Join(evgHome, configName, evergreen.NotificationsFile),
-		filepath.Join(evgHome, evergreen.NotificationsFile),
-		DefaultNotificationsConfig,
-	}
-
-	var notificationsFile string
-	for _, fn := range configs {
-		if _, err := os.Stat(fn); os.IsNotExist(err) {
-			continue
-		}
-
-		notificationsFile = fn
-	}
-
-	data, err := ioutil.ReadFile(notificationsFile)
-	if err != nil {
-		return nil, err
-	}
-
-	// unmarshal file contents into MCINotification struct
-	mciNotification := &MCINotification{}
-
-	err = yaml.Unmarshal(data, mciNotification)
-	if err != nil {
-		return nil, errors.Wrapf(err, "Parse error unmarshalling notifications %v", notificationsFile)
-	}
-	return mciNotification, nil
-}
-
-// This function is responsible for validating the notifications file
-func ValidateNotifications(mciNotification *MCINotification) error {
-	grip.Info("Validating notifications...")
-	allNotifications := []string{}
-
-	projectNameToBuildVariants, err := findProjectBuildVariants()
-	if err != nil {
-		return errors.Wrap(err, "Error loading project build variants")
-	}
-
-	// Validate default notification recipients
-	for _, notification := range mciNotification.Notifications {
-		if notification.Project == "" {
-			return errors.Errorf("Must specify a project for each notification - see %v", notification.Name)
-		}
-
-		buildVariants, ok := projectNameToBuildVariants[notification.Project]
-		if !ok {
-			return errors.Errorf("Notifications validation failed: "+
-				"project `%v` not found", notification.Project)
-		}
-
-		// ensure all supplied build variants are valid
-		for _, buildVariant := range notification.SkipVariants {
-			if !util.SliceContains(buildVariants, buildVariant) {
-				return errors.Errorf("Nonexistent buildvariant - ”%v” - specified for ”%v” notification", buildVariant, notification.Name)
-			}
-		}
-
-		allNotifications = append(allNotifications, notification.Name)
-	}
-
-	// Validate team name and addresses
-	for _, team := range mciNotification.Teams {
-		if team.Name == "" {
-			return errors.Errorf("Each notification team must have a name")
-		}
-
-		for _, subscription := range team.Subscriptions {
-			for _, notification := range subscription.NotifyOn {
-				if !util.SliceContains(allNotifications, notification) {
-					return errors.Errorf("Team ”%v” contains a non-existent subscription - %v", team.Name, notification)
-				}
-			}
-			for _, buildVariant := range subscription.SkipVariants {
-				buildVariants, ok := projectNameToBuildVariants[subscription.Project]
-				if !ok {
-					return errors.Errorf("Teams validation failed: project `%v` not found", subscription.Project)
-				}
-
-				if !util.SliceContains(buildVariants, buildVariant) {
-					return errors.Errorf("Nonexistent buildvariant - ”%v” - specified for team ”%v” ", buildVariant, team.Name)
-				}
-			}
-		}
-	}
-
-	// Validate patch notifications
-	for _, subscription := range mciNotification.PatchNotifications {
-		for _, notification := range subscription.NotifyOn {
-			if !util.SliceContains(allNotifications, notification) {
-				return errors.Errorf("Nonexistent patch notification - ”%v” - specified", notification)
-			}
-		}
-	}
-
-	// validate the patch notification buildvariatns
-	for _, subscription := range mciNotification.PatchNotifications {
-		buildVariants, ok := projectNameToBuildVariants[subscription.Project]
-		if !ok {
-			return errors.Errorf("Patch notification build variants validation failed: "+
-				"project `%v` not found", subscription.Project)
-		}
-
-		for _, buildVariant := range subscription.SkipVariants {
-			if !util.SliceContains(buildVariants, buildVariant) {
-				return errors.Errorf("Nonexistent buildvariant - ”%v” - specified for patch notifications", buildVariant)
-			}
-		}
-	}
-
-	// all good!
-	return nil
-}
-
-// This function is responsible for all notifications processing
-func ProcessNotifications(ae *web.App, mciNotification *MCINotification, updateTimes bool) (map[NotificationKey][]Email, error) {
-	// create MCI notifications
-	allNotificationsSlice := notificationsToStruct(mciNotification)
-
+// processNotificationKeys generates the emails triggered for each key
+func processNotificationKeys(ae *web.App, allNotificationsSlice []NotificationKey, updateTimes bool) (map[NotificationKey][]Email, error) {
 	// get the last notification time for all projects
 	if updateTimes {
 		err := getLastProjectNotificationTime(allNotificationsSlice)
@@ -353,112 +219,6 @@ func ProcessNotifications(ae *web.App, mciNotification *MCINotification, updateT
 	return emails, nil
 }
 
-// This function is responsible for managing the sending triggered email notifications
-func SendNotifications(settings *evergreen.Settings, mciNotification *MCINotification,
-	emails map[NotificationKey][]Email, mailer Mailer) (err error) {
-	grip.Info("Sending notifications...")
-
-	// parse all notifications, sending it to relevant recipients
-	for _, notification := range mciNotification.Notifications {
-		key := NotificationKey{
-			Project:               notification.Project,
-			NotificationName:      notification.Name,
-			NotificationType:      getType(notification.Name),
-			NotificationRequester: evergreen.RepotrackerVersionRequester,
-		}
-
-		for _, recipient := range notification.Recipients {
-			// send all triggered notifications
-			for _, email := range emails[key] {
-
-				// determine if this notification should be skipped - based on the buildvariant
-				if email.ShouldSkip(notification.SkipVariants) {
-					continue
-				}
-
-				// send to individual subscriber, or the admin team if it's not their fault
-				recipients := []string{}
-				if settings.Notify.SMTP != nil {
-					recipients = settings.Notify.SMTP.AdminEmail
-				}
-				if !email.IsLikelySystemFailure() {
-					recipients = email.GetRecipients(recipient)
-				}
-				err = TrySendNotification(recipients, email.GetSubject(), email.GetBody(), mailer)
-				if err != nil {
-					grip.Errorf("Unable to send individual notification %#v: %+v", key, err)
-					continue
-				}
-			}
-		}
-	}
-
-	// Send team subscribed notifications
-	for _, team := range mciNotification.Teams {
-		for _, subscription := range team.Subscriptions {
-			for _, name := range subscription.NotifyOn {
-				key := NotificationKey{
-					Project:               subscription.Project,
-					NotificationName:      name,
-					NotificationType:      getType(name),
-					NotificationRequester: evergreen.RepotrackerVersionRequester,
-				}
-
-				// send all triggered notifications for this key
-				for _, email := range emails[key] {
-					// determine if this notification should be skipped - based on the buildvariant
-					if email.ShouldSkip(subscription.SkipVariants) {
-						continue
-					}
-
-					teamEmail := fmt.Sprintf("%v <%v>", team.Name, team.Address)
-					err = TrySendNotification([]string{teamEmail}, email.GetSubject(), email.GetBody(), mailer)
-					if err != nil {
-						grip.Errorf("Unable to send notification %#v: %v", key, err)
-						continue
-					}
-				}
-			}
-		}
-	}
-
-	// send patch notifications
-	/* XXX temporarily disable patch notifications
-	for _, subscription := range mciNotification.PatchNotifications {
-		for _, notification := range subscription.NotifyOn {
-			key := NotificationKey{
-				Project:               subscription.Project,
-				NotificationName:      notification,
-				NotificationType:      getType(notification),
-				NotificationRequester: evergreen.PatchVersionRequester,
-			}
-
-			for _, email := range emails[key] {
-				// determine if this notification should be skipped -
-				// based on the buildvariant
-				if email.ShouldSkip(subscription.SkipVariants) {
-					continue
-				}
-
-				// send to the appropriate patch requester
-				for _, changeInfo := range email.GetChangeInfo() {
-					// send notification to each member of the blamelist
-					patchRequester := fmt.Sprintf("%v <%v>", changeInfo.Author,
-						changeInfo.Email)
-					err = TrySendNotification([]string{patchRequester},
-						email.GetSubject(), email.GetBody(), mailer)
-					if err != nil {
-						grip.Errorf("Unable to send notification %#v: %+v", key, err)
-						continue
-					}
-				}
-			}
-		}
-	}*/
-
-	return nil
-}
-
 // This stores the last time threshold after which
 // we search for possible new notification events
 func UpdateNotificationTimes() (err error) {
@@ -679,70 +439,13 @@ func getRecentlyFinishedTasks(notificationKey *NotificationKey) (tasks []task.Ta
 	return cachedProjectRecords[notificationKey.String()].([]task.Task), nil
 }
 
-// gets the type of notification - we support build/task level notification
+// gets the type of notification - we support build/task/patch level notification
 func getType(notification string) (nkType string) {
 	nkType = taskType
 	if strings.Contains(notification, buildType) {
 		nkType = buildType
-	}
-	return
-}
-
-// creates/returns slice of 'relevant' NotificationKeys a
-// notification is relevant if it has at least one recipient
-func notificationsToStruct(mciNotification *MCINotification) (notifyOn []NotificationKey) {
-	// Get default notifications
-	for _, notification := range mciNotification.Notifications {
-		if len(notification.Recipients) != 0 {
-			// flag the notification as needed
-			key := NotificationKey{
-				Project:               notification.Project,
-				NotificationName:      notification.Name,
-				NotificationType:      getType(notification.Name),
-				NotificationRequester: evergreen.RepotrackerVersionRequester,
-			}
-
-			// prevent duplicate notifications from being sent
-			if !util.SliceContains(notifyOn, key) {
-				notifyOn = append(notifyOn, key)
-			}
-		}
-	}
-
-	// Get team notifications
-	for _, team := range mciNotification.Teams {
-		for _, subscription := range team.Subscriptions {
-			for _, name := range subscription.NotifyOn {
-				key := NotificationKey{
-					Project:               subscription.Project,
-					NotificationName:      name,
-					NotificationType:      getType(name),
-					NotificationRequester: evergreen.RepotrackerVersionRequester,
-				}
-
-				// prevent duplicate notifications from being sent
-				if !util.SliceContains(notifyOn, key) {
-					notifyOn = append(notifyOn, key)
-				}
-			}
-		}
-	}
-
-	// Get patch notifications
-	for _, subscription := range mciNotification.PatchNotifications {
-		for _, notification := range subscription.NotifyOn {
-			key := NotificationKey{
-				Project:               subscription.Project,
-				NotificationName:      notification,
-				NotificationType:      getType(notification),
-				NotificationRequester: evergreen.PatchVersionRequester,
-			}
-
-			// prevent duplicate notifications from being sent
-			if !util.SliceContains(notifyOn, key) {
-				notifyOn = append(notifyOn, key)
-			}
-		}
+	} else if notification == patchFinishedKey {
+		nkType = patchType
 	}
 	return
 }
@@ -794,35 +497,6 @@ func TrySendNotificationToUser(userId string, subject, body string, mailer Maile
 //   Notification Structs    \/
 //***************************\/
 
-// stores supported notifications
-type Notification struct {
-	Name         string   `yaml:"name"`
-	Project      string   `yaml:"project"`
-	Recipients   []string `yaml:"recipients"`
-	SkipVariants []string `yaml:"skip_variants"`
-}
-
-// stores notifications subscription for a team
-type Subscription struct {
-	Project      string   `yaml:"project"`
-	SkipVariants []string `yaml:"skip_variants"`
-	NotifyOn     []string `yaml:"notify_on"`
-}
-
-// stores 10gen team information
-type Team struct {
-	Name          string         `yaml:"name"`
-	Address       string         `yaml:"address"`
-	Subscriptions []Subscription `yaml:"subscriptions"`
-}
-
-// store notifications file
-type MCINotification struct {
-	Notifications      []Notification `yaml:"notifications"`
-	Teams              []Team         `yaml:"teams"`
-	PatchNotifications []Subscription `yaml:"patch_notifications"`
-}
-
 // stores high level notifications key
 type NotificationKey struct {
 	Project               string
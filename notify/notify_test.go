@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/subscription"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -219,20 +220,15 @@ func TestNotify(t *testing.T) {
 
 		Convey("Should run the correct notification handlers for given "+
 			"notification keys", func() {
-			notificationSettings := &MCINotification{}
-			notificationSettings.Notifications = []Notification{
-				{"task_failure", "project", []string{"user@mongodb"}, []string{}},
-				{"task_success_to_failure", "project", []string{"user@mongodb"}, []string{}},
+			team := subscription.Subscriber{
+				Type:    subscription.TeamSubscriber,
+				Name:    "myteam",
+				Address: "myteam@me.com",
 			}
-			notificationSettings.Teams = []Team{
-				{
-					"myteam",
-					"myteam@me.com",
-					[]Subscription{{"task", []string{}, []string{"task_failure"}}},
-				},
-			}
-			notificationSettings.PatchNotifications = []Subscription{
-				{"patch_project", []string{}, []string{}},
+			subs := []subscription.Subscription{
+				{Trigger: subscription.TaskFailure, Selector: subscription.Selector{Project: "project"}, Subscriber: team},
+				{Trigger: subscription.TaskSuccessToFailure, Selector: subscription.Selector{Project: "project"}, Subscriber: team},
+				{Trigger: subscription.TaskFailure, Selector: subscription.Selector{Project: "task"}, Subscriber: team},
 			}
 
 			notificationKeyFailure := NotificationKey{"project", "task_failure", "task", "gitter_request"}
@@ -242,7 +238,7 @@ func TestNotify(t *testing.T) {
 			ae, err := createEnvironment(TestConfig, map[string]interface{}{})
 			So(err, ShouldBeNil)
 
-			emails, err := ProcessNotifications(ae, notificationSettings, false)
+			emails, err := ProcessSubscriptions(ae, subs, false)
 			So(err, ShouldBeNil)
 
 			So(len(emails[notificationKeyFailure]), ShouldEqual, 2)
@@ -257,20 +253,26 @@ func TestNotify(t *testing.T) {
 					"failure on build1)")
 		})
 
-		Convey("SendNotifications should send emails correctly", func() {
-			notificationSettings := &MCINotification{}
-			notificationSettings.Notifications = []Notification{
-				{"task_failure", "project", []string{"user@mongodb"}, []string{}},
-			}
-			notificationSettings.Teams = []Team{
+		Convey("SendSubscriptionNotifications should send emails correctly", func() {
+			subs := []subscription.Subscription{
 				{
-					"myteam",
-					"myteam@me.com",
-					[]Subscription{{"task", []string{}, []string{"task_failure"}}},
+					Trigger:  subscription.TaskFailure,
+					Selector: subscription.Selector{Project: "project"},
+					Subscriber: subscription.Subscriber{
+						Type:    subscription.TeamSubscriber,
+						Name:    "myteam",
+						Address: "myteam@me.com",
+					},
+				},
+				{
+					Trigger:  subscription.TaskFailure,
+					Selector: subscription.Selector{Project: "task"},
+					Subscriber: subscription.Subscriber{
+						Type:    subscription.TeamSubscriber,
+						Name:    "otherteam",
+						Address: "otherteam@me.com",
+					},
 				},
-			}
-			notificationSettings.PatchNotifications = []Subscription{
-				{"patch_project", []string{}, []string{}},
 			}
 
 			fakeTask, err := task.FindOne(task.ById("task8"))
@@ -300,7 +302,7 @@ func TestNotify(t *testing.T) {
 
 			mailer := MockMailer{}
 			mockSettings := evergreen.Settings{Notify: evergreen.NotifyConfig{}}
-			err = SendNotifications(&mockSettings, notificationSettings, m, mailer)
+			err = SendSubscriptionNotifications(&mockSettings, subs, m, mailer)
 			So(err, ShouldBeNil)
 
 			So(len(emailSubjects), ShouldEqual, 1)
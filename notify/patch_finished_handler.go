@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/web"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// Handler for patch finished notifications, i.e. send notifications whenever
+// a patch's version finishes. Implements NotificationHandler from
+// notification_handler.go.
+type PatchFinishedHandler struct {
+	Name string
+}
+
+type PatchNotificationForTemplate struct {
+	Patch       *patch.Patch
+	FailedTasks []task.Task
+	Subject     string
+}
+
+// Implements Email interface for patch-specific emails. Patches may span
+// several variants, so they are never skipped by variant.
+type PatchEmail struct {
+	EmailBase
+	Patch *patch.Patch
+}
+
+func (self *PatchEmail) ShouldSkip(skipVariants []string) bool {
+	return false
+}
+
+func (self *PatchEmail) IsLikelySystemFailure() bool {
+	return false
+}
+
+func (self *PatchEmail) GetRecipients(defaultRecipient string) []string {
+	return []string{defaultRecipient}
+}
+
+func (self *PatchFinishedHandler) GetNotifications(ae *web.App, key *NotificationKey) ([]Email, error) {
+	patches, err := patch.Find(patch.ByFinishedAfter(lastProjectNotificationTime[key.Project], key.Project))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding patches finished in %v", key.Project)
+	}
+
+	var emails []Email
+	for i := range patches {
+		email, err := self.TemplateNotification(ae, &patches[i])
+		if err != nil {
+			grip.Warningf("Error templating notification for patch '%s': %+v",
+				patches[i].Id.Hex(), err)
+			continue
+		}
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+func (self *PatchFinishedHandler) TemplateNotification(ae *web.App, p *patch.Patch) (Email, error) {
+	versionTasks, err := task.Find(task.ByVersion(p.Version).WithFields(
+		task.IdKey, task.DisplayNameKey, task.BuildVariantKey, task.StatusKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding tasks for patch %v", p.Id.Hex())
+	}
+	failedTasks := []task.Task{}
+	for _, t := range versionTasks {
+		if t.Status == evergreen.TaskFailed {
+			failedTasks = append(failedTasks, t)
+		}
+	}
+
+	preface := patchSuccessPreface
+	if p.Status == evergreen.PatchFailed {
+		preface = patchFailurePreface
+	}
+	branchName := UnknownProjectBranch
+	if projectRef, err := getProjectRef(p.Project); err != nil {
+		grip.Warningf("Unable to find project ref for patch '%s': %+v", p.Id.Hex(), err)
+	} else if projectRef != nil {
+		branchName = projectRef.Branch
+	}
+	subject := fmt.Sprintf("%v Patch '%v' by %v %v", fmt.Sprintf(preface, branchName),
+		p.Description, p.Author, p.Status)
+
+	body, err := TemplateEmailBody(ae, "patch_notification.html",
+		PatchNotificationForTemplate{p, failedTasks, subject})
+	if err != nil {
+		return nil, err
+	}
+	changeInfo := []ChangeInfo{{
+		Revision: p.Id.Hex(),
+		Author:   p.Author,
+		Project:  p.Project,
+		Message:  p.Description,
+	}}
+	return &PatchEmail{EmailBase{body, subject, changeInfo}, p}, nil
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/subscription"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/evergreen/web"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// subscriptionTriggers are the subscription triggers handled by the notifier.
+// Spawn host subscriptions are handled by the alerts queue processor.
+var subscriptionTriggers = []string{
+	subscription.TaskFailure,
+	subscription.TaskSuccessToFailure,
+	subscription.BuildFailure,
+	subscription.BuildSuccessToFailure,
+	subscription.BuildCompletion,
+	subscription.PatchFinished,
+}
+
+// FindSubscriptions returns all the subscriptions the notifier sends
+// notifications for.
+func FindSubscriptions() ([]subscription.Subscription, error) {
+	grip.Info("Finding subscriptions...")
+	subs, err := subscription.Find(subscription.ByTriggers(subscriptionTriggers...))
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding subscriptions")
+	}
+	return subs, nil
+}
+
+// ProcessSubscriptions generates the emails triggered for all subscriptions.
+func ProcessSubscriptions(ae *web.App, subs []subscription.Subscription,
+	updateTimes bool) (map[NotificationKey][]Email, error) {
+	return processNotificationKeys(ae, subscriptionsToStruct(subs), updateTimes)
+}
+
+// SendSubscriptionNotifications sends each subscriber the triggered emails
+// that match their subscription's selector. Patch authors who are told about
+// their own patches by the alerts queue aren't emailed about them again.
+func SendSubscriptionNotifications(settings *evergreen.Settings, subs []subscription.Subscription,
+	emails map[NotificationKey][]Email, mailer Mailer) error {
+	grip.Info("Sending notifications...")
+
+	for _, sub := range subs {
+		key := subscriptionKey(sub)
+		recipient := ""
+		for _, email := range emails[key] {
+			if !emailMatches(email, sub.Selector) {
+				continue
+			}
+			notified, err := notifiedAsPatchAuthor(email, sub.Subscriber)
+			if err != nil {
+				grip.Errorf("Unable to check patch author for subscription %s: %+v", sub.Id.Hex(), err)
+			}
+			if notified {
+				continue
+			}
+
+			if recipient == "" {
+				recipient, err = subscriberAddress(sub.Subscriber)
+				if err != nil {
+					grip.Errorf("Unable to find address for subscription %s: %+v", sub.Id.Hex(), err)
+					break
+				}
+			}
+
+			// send to individual subscribers, or the admin team if it's not their fault
+			recipients := email.GetRecipients(recipient)
+			if sub.Subscriber.Type == subscription.PersonSubscriber && email.IsLikelySystemFailure() {
+				recipients = []string{}
+				if settings.Notify.SMTP != nil {
+					recipients = settings.Notify.SMTP.AdminEmail
+				}
+			}
+			err = TrySendNotification(recipients, email.GetSubject(), email.GetBody(), mailer)
+			if err != nil {
+				grip.Errorf("Unable to send notification %#v for subscription %s: %+v",
+					key, sub.Id.Hex(), err)
+				continue
+			}
+		}
+	}
+	return nil
+}
+
+// subscriptionKey returns the key of the notifications a subscription is for.
+func subscriptionKey(sub subscription.Subscription) NotificationKey {
+	requester := evergreen.RepotrackerVersionRequester
+	if sub.Trigger == subscription.PatchFinished {
+		requester = evergreen.PatchVersionRequester
+	}
+	return NotificationKey{
+		Project:               sub.Selector.Project,
+		NotificationName:      sub.Trigger,
+		NotificationType:      getType(sub.Trigger),
+		NotificationRequester: requester,
+	}
+}
+
+// creates/returns the slice of NotificationKeys that have subscribers
+func subscriptionsToStruct(subs []subscription.Subscription) (notifyOn []NotificationKey) {
+	for _, sub := range subs {
+		key := subscriptionKey(sub)
+
+		// prevent duplicate notifications from being sent
+		if !util.SliceContains(notifyOn, key) {
+			notifyOn = append(notifyOn, key)
+		}
+	}
+	return
+}
+
+// emailMatches returns whether an email is about a variant and task selected
+// by a subscription. The project is already matched by the email's key.
+func emailMatches(email Email, selector subscription.Selector) bool {
+	switch e := email.(type) {
+	case *TaskEmail:
+		return selector.Matches(selector.Project, e.Trigger.Current.BuildVariant,
+			e.Trigger.Current.DisplayName)
+	case *BuildEmail:
+		return selector.Matches(selector.Project, e.Trigger.Current.BuildVariant, "")
+	case *PatchEmail:
+		return selector.Variant == "" || util.SliceContains(e.Patch.BuildVariants, selector.Variant)
+	default:
+		return false
+	}
+}
+
+// notifiedAsPatchAuthor returns whether the email is about a patch whose
+// author is the subscriber, and who is told that the patch finished by the
+// alerts queue.
+func notifiedAsPatchAuthor(email Email, subscriber subscription.Subscriber) (bool, error) {
+	patchEmail, ok := email.(*PatchEmail)
+	if !ok || subscriber.Type != subscription.PersonSubscriber ||
+		subscriber.Name != patchEmail.Patch.Author {
+		return false, nil
+	}
+	author, err := user.FindOne(user.ById(subscriber.Name))
+	if err != nil {
+		return false, errors.Wrapf(err, "Error finding user %v", subscriber.Name)
+	}
+	if author == nil {
+		return false, nil
+	}
+	return patchEmail.Patch.ShouldNotifyAuthor(author.Settings.PatchNotifications), nil
+}
+
+// subscriberAddress returns the email address to notify a subscriber at.
+func subscriberAddress(subscriber subscription.Subscriber) (string, error) {
+	if subscriber.Type == subscription.TeamSubscriber {
+		return subscriber.String(), nil
+	}
+	dbUser, err := user.FindOne(user.ById(subscriber.Name))
+	if err != nil {
+		return "", errors.Wrapf(err, "Error finding user %v", subscriber.Name)
+	}
+	if dbUser == nil {
+		return "", errors.Errorf("User %v not found", subscriber.Name)
+	}
+	return dbUser.Email(), nil
+}
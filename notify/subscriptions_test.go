@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/subscription"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSubscriptionKeys(t *testing.T) {
+	Convey("With subscriptions to version and patch triggers", t, func() {
+		subs := []subscription.Subscription{
+			{Trigger: subscription.TaskFailure, Selector: subscription.Selector{Project: projectId}},
+			{Trigger: subscription.TaskFailure, Selector: subscription.Selector{Project: projectId, Variant: buildVariant}},
+			{Trigger: subscription.BuildFailure, Selector: subscription.Selector{Project: projectId}},
+			{Trigger: subscription.PatchFinished, Selector: subscription.Selector{Project: projectId}},
+		}
+
+		Convey("each distinct key should be processed once", func() {
+			So(subscriptionsToStruct(subs), ShouldResemble, []NotificationKey{
+				taskFailureNotificationKey,
+				buildFailureNotificationKey,
+				{
+					Project:               projectId,
+					NotificationName:      patchFinishedKey,
+					NotificationType:      patchType,
+					NotificationRequester: evergreen.PatchVersionRequester,
+				},
+			})
+		})
+	})
+}
+
+func TestEmailMatches(t *testing.T) {
+	Convey("With emails for a task, a build and a patch", t, func() {
+		taskEmail := &TaskEmail{Trigger: TriggeredTaskNotification{
+			Current: &task.Task{BuildVariant: buildVariant, DisplayName: displayName},
+		}}
+		buildEmail := &BuildEmail{Trigger: TriggeredBuildNotification{
+			Current: &build.Build{BuildVariant: buildVariant},
+		}}
+		patchEmail := &PatchEmail{Patch: &patch.Patch{BuildVariants: []string{buildVariant}}}
+
+		Convey("a project-wide selector should match all of them", func() {
+			selector := subscription.Selector{Project: projectId}
+			So(emailMatches(taskEmail, selector), ShouldBeTrue)
+			So(emailMatches(buildEmail, selector), ShouldBeTrue)
+			So(emailMatches(patchEmail, selector), ShouldBeTrue)
+		})
+
+		Convey("a variant selector should only match emails for that variant", func() {
+			selector := subscription.Selector{Project: projectId, Variant: buildVariant}
+			So(emailMatches(taskEmail, selector), ShouldBeTrue)
+			So(emailMatches(buildEmail, selector), ShouldBeTrue)
+			So(emailMatches(patchEmail, selector), ShouldBeTrue)
+			selector.Variant = "other"
+			So(emailMatches(taskEmail, selector), ShouldBeFalse)
+			So(emailMatches(buildEmail, selector), ShouldBeFalse)
+			So(emailMatches(patchEmail, selector), ShouldBeFalse)
+		})
+
+		Convey("a task selector should only match emails for that task", func() {
+			selector := subscription.Selector{Project: projectId, Task: displayName}
+			So(emailMatches(taskEmail, selector), ShouldBeTrue)
+			selector.Task = "other"
+			So(emailMatches(taskEmail, selector), ShouldBeFalse)
+		})
+	})
+}
+
+func TestNotifiedAsPatchAuthor(t *testing.T) {
+	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(TestConfig))
+
+	Convey("With a patch email and its author", t, func() {
+		So(db.Clear(user.Collection), ShouldBeNil)
+		author := &user.DBUser{Id: "me", EmailAddress: "me@example.com"}
+		So(author.Insert(), ShouldBeNil)
+		patchEmail := &PatchEmail{Patch: &patch.Patch{Author: "me"}}
+		me := subscription.Subscriber{Type: subscription.PersonSubscriber, Name: "me"}
+
+		Convey("the author should not be emailed twice", func() {
+			notified, err := notifiedAsPatchAuthor(patchEmail, me)
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeTrue)
+		})
+		Convey("other subscribers should be emailed", func() {
+			notified, err := notifiedAsPatchAuthor(patchEmail,
+				subscription.Subscriber{Type: subscription.PersonSubscriber, Name: "you"})
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeFalse)
+			notified, err = notifiedAsPatchAuthor(patchEmail,
+				subscription.Subscriber{Type: subscription.TeamSubscriber, Name: "me"})
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeFalse)
+		})
+		Convey("an author who isn't told about the patch should be emailed", func() {
+			notify := false
+			patchEmail.Patch.Notify = &notify
+			notified, err := notifiedAsPatchAuthor(patchEmail, me)
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeFalse)
+		})
+	})
+}
@@ -25,6 +25,9 @@ const (
 	AttachResultsCmd      = "results"
 	AttachXunitResultsCmd = "xunit_results"
 
+	AttachGoTestJSONResultsCmd = "gotest_json_results"
+	AttachTAPResultsCmd        = "tap_results"
+
 	AttachResultsAPIEndpoint = "results"
 	AttachLogsAPIEndpoint    = "test_logs"
 
@@ -130,6 +133,10 @@ func (self *AttachPlugin) NewCommand(cmdName string) (plugin.Command,
 		return &AttachResultsCommand{}, nil
 	case AttachXunitResultsCmd:
 		return &AttachXUnitResultsCommand{}, nil
+	case AttachGoTestJSONResultsCmd:
+		return &AttachGoTestJSONResultsCommand{}, nil
+	case AttachTAPResultsCmd:
+		return &AttachTAPResultsCommand{}, nil
 	default:
 		return nil, errors.Errorf("No such %v command: %v", AttachPluginName, cmdName)
 	}
@@ -0,0 +1,143 @@
+package gotestjson
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// Event is one line of the event stream written by `go test -json`.
+type Event struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+// event actions that are relevant to test results
+const (
+	actionRun    = "run"
+	actionOutput = "output"
+	actionPass   = "pass"
+	actionFail   = "fail"
+	actionSkip   = "skip"
+)
+
+// TestCase is the result of a single test, built from its events.
+type TestCase struct {
+	Package string
+	Name    string
+	// Action is the event action that ended the test, or empty if the
+	// test never finished, e.g. because its test binary panicked.
+	Action string
+	Start  time.Time
+	End    time.Time
+	Output []string
+}
+
+// ParseResults reads a `go test -json` event stream and returns the tests it
+// ran, in the order they started. Lines that aren't events, such as build
+// errors, are skipped.
+func ParseResults(reader io.Reader) ([]*TestCase, error) {
+	tests := []*TestCase{}
+	byName := map[string]*TestCase{}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		event := Event{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return nil, errors.Wrap(err, "error parsing test event")
+		}
+		// events without a test name are about the package as a whole
+		if event.Test == "" {
+			continue
+		}
+
+		key := event.Package + "\x00" + event.Test
+		tc, ok := byName[key]
+		if !ok {
+			tc = &TestCase{Package: event.Package, Name: event.Test, Start: event.Time}
+			byName[key] = tc
+			tests = append(tests, tc)
+		}
+
+		switch event.Action {
+		case actionRun:
+			tc.Start = event.Time
+		case actionOutput:
+			tc.Output = append(tc.Output, strings.TrimRight(event.Output, "\r\n"))
+		case actionPass, actionFail, actionSkip:
+			tc.Action = event.Action
+			tc.End = event.Time
+			if tc.End.IsZero() {
+				tc.End = tc.Start.Add(time.Duration(event.Elapsed * float64(time.Second)))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading test events")
+	}
+
+	return tests, nil
+}
+
+// FullName returns the test's name qualified with its package, so that tests
+// with the same name in different packages don't collide.
+func (tc *TestCase) FullName() string {
+	if tc.Package == "" {
+		return tc.Name
+	}
+	return tc.Package + "." + tc.Name
+}
+
+// ToModelTestResultAndLog converts a test case into an mci task.TestResult
+// and a model.TestLog of its output. The log is nil if the test wrote no
+// output.
+func (tc *TestCase) ToModelTestResultAndLog(t *task.Task) (task.TestResult, *model.TestLog) {
+	res := task.TestResult{TestFile: tc.FullName()}
+
+	switch tc.Action {
+	case actionPass:
+		res.Status = evergreen.TestSucceededStatus
+	case actionSkip:
+		res.Status = evergreen.TestSkippedStatus
+	default:
+		// a test that never finished failed along with its test binary
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	start, end := tc.Start, tc.End
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if end.Before(start) {
+		end = start
+	}
+	res.StartTime = float64(start.UnixNano()) / float64(time.Second)
+	res.EndTime = float64(end.UnixNano()) / float64(time.Second)
+
+	if len(tc.Output) == 0 {
+		return res, nil
+	}
+	log := &model.TestLog{
+		Name:          res.TestFile,
+		Task:          t.Id,
+		TaskExecution: t.Execution,
+		Lines:         tc.Output,
+	}
+	return res, log
+}
@@ -0,0 +1,64 @@
+package gotestjson
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGoTestJSONParsing(t *testing.T) {
+	Convey("With a go test -json output file", t, func() {
+		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "gotest.json"))
+		testutil.HandleTestingErr(err, t, "Error reading file")
+		defer file.Close()
+
+		Convey("the file should parse without error", func() {
+			res, err := ParseResults(file)
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 4)
+
+			Convey("and have proper values decoded", func() {
+				So(res[0].Package, ShouldEqual, "example.com/pkg")
+				So(res[0].Name, ShouldEqual, "TestPass")
+				So(res[0].Action, ShouldEqual, "pass")
+				So(res[0].End.Sub(res[0].Start).Seconds(), ShouldEqual, 0.5)
+				So(res[1].Name, ShouldEqual, "TestFail")
+				So(res[1].Action, ShouldEqual, "fail")
+				So(res[1].Output, ShouldResemble, []string{
+					"=== RUN   TestFail",
+					"    pkg_test.go:12: expected 1, got 2",
+					"--- FAIL: TestFail (1.00s)",
+				})
+				So(res[2].Action, ShouldEqual, "skip")
+				So(res[3].Package, ShouldEqual, "example.com/other")
+				So(res[3].Action, ShouldEqual, "")
+			})
+
+			Convey("and convert to the model", func() {
+				testTask := &task.Task{Id: "TEST", Execution: 5}
+				statuses := []string{}
+				for _, tc := range res {
+					test, log := tc.ToModelTestResultAndLog(testTask)
+					So(test.TestFile, ShouldEqual, tc.Package+"."+tc.Name)
+					So(test.EndTime, ShouldBeGreaterThanOrEqualTo, test.StartTime)
+					So(log, ShouldNotBeNil)
+					So(log.Name, ShouldEqual, test.TestFile)
+					So(log.Task, ShouldEqual, "TEST")
+					So(log.TaskExecution, ShouldEqual, 5)
+					statuses = append(statuses, test.Status)
+				}
+				So(statuses, ShouldResemble, []string{
+					evergreen.TestSucceededStatus,
+					evergreen.TestFailedStatus,
+					evergreen.TestSkippedStatus,
+					evergreen.TestFailedStatus,
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package attach
+
+import (
+	"io"
+	"os"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/plugin"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/slogger"
+	"github.com/pkg/errors"
+)
+
+// resultsParser parses a file of test results, read from the file with the
+// given path, into mci test results and their logs. A test result with no log
+// has a nil entry in the logs.
+type resultsParser func(path string, reader io.Reader, t *task.Task) ([]task.TestResult, []*model.TestLog, error)
+
+// resultsFilesParams are the parameters of the commands that parse files of
+// test results with a resultsParser.
+type resultsFilesParams struct {
+	// File describes the relative path of the file to be sent. Supports globbing.
+	// Note that this can also be described via expansions.
+	File  string   `mapstructure:"file" plugin:"expand"`
+	Files []string `mapstructure:"files" plugin:"expand"`
+}
+
+// parseParams decodes and validates the parameters of the named command.
+func (p *resultsFilesParams) parseParams(name string, params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, p); err != nil {
+		return errors.Wrapf(err, "error decoding '%s' params", name)
+	}
+	if p.File == "" && len(p.Files) == 0 {
+		return errors.Errorf("error validating '%s' params: must specify at least one file", name)
+	}
+	return nil
+}
+
+// expandParams expands the file parameters and returns the files to parse.
+func (p *resultsFilesParams) expandParams(conf *model.TaskConfig) ([]string, error) {
+	files := p.Files
+	if p.File != "" {
+		files = append(files, p.File)
+	}
+
+	catcher := grip.NewCatcher()
+	expanded := make([]string, len(files))
+	for idx, f := range files {
+		var err error
+		expanded[idx], err = conf.Expansions.ExpandString(f)
+		catcher.Add(err)
+	}
+
+	return expanded, errors.Wrapf(catcher.Resolve(), "problem expanding paths")
+}
+
+// executeResultsFilesCommand parses the files of test results given to the
+// named command and uploads the results and their logs, returning early if
+// the command is told to stop.
+func executeResultsFilesCommand(name string, params *resultsFilesParams, parse resultsParser,
+	pluginLogger plugin.Logger, pluginCom plugin.PluginCommunicator, taskConfig *model.TaskConfig,
+	stop chan bool) error {
+
+	files, err := params.expandParams(taskConfig)
+	if err != nil {
+		return err
+	}
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- parseAndUploadResultsFiles(files, parse, taskConfig, pluginLogger, pluginCom)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-stop:
+		pluginLogger.LogExecution(slogger.INFO, "Received signal to terminate"+
+			" execution of attach %v command", name)
+		return nil
+	}
+}
+
+func parseAndUploadResultsFiles(files []string, parse resultsParser, taskConfig *model.TaskConfig,
+	pluginLogger plugin.Logger, pluginCom plugin.PluginCommunicator) error {
+	tests := []task.TestResult{}
+	logs := []*model.TestLog{}
+
+	reportFilePaths, err := getFilePaths(taskConfig.WorkDir, files)
+	if err != nil {
+		return err
+	}
+
+	for _, reportFileLoc := range reportFilePaths {
+		file, err := os.Open(reportFileLoc)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't open results file %v", reportFileLoc)
+		}
+
+		fileTests, fileLogs, err := parse(reportFileLoc, file, taskConfig.Task)
+		if err != nil {
+			grip.Warning(file.Close())
+			return errors.Wrapf(err, "error parsing results file %v", reportFileLoc)
+		}
+
+		if err = file.Close(); err != nil {
+			return errors.Wrapf(err, "error closing results file %v", reportFileLoc)
+		}
+		tests = append(tests, fileTests...)
+		logs = append(logs, fileLogs...)
+	}
+
+	for i, log := range logs {
+		if log == nil {
+			continue
+		}
+		logId, err := SendJSONLogs(pluginLogger, pluginCom, log)
+		if err != nil {
+			pluginLogger.LogTask(slogger.WARN, "Error uploading logs for %v", log.Name)
+			continue
+		}
+		tests[i].LogId = logId
+		tests[i].LineNum = 1
+	}
+
+	return SendJSONResults(taskConfig, pluginLogger, pluginCom, &task.TestResults{tests})
+}
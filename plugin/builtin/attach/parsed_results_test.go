@@ -0,0 +1,48 @@
+package attach_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	GoTestJSONConfig = filepath.Join(workingDirectory, "testdata", "plugin_attach_gotest_json.yml")
+	TAPConfig        = filepath.Join(workingDirectory, "testdata", "plugin_attach_tap.yml")
+)
+
+func TestAttachGoTestJSONResults(t *testing.T) {
+	runTest(t, GoTestJSONConfig, func(taskId string) {
+		testTask, err := task.FindOne(task.ById(taskId))
+		So(err, ShouldBeNil)
+		So(len(testTask.TestResults), ShouldEqual, 4)
+		So(testTask.TestResults[1].TestFile, ShouldEqual, "TestFail")
+		So(testTask.TestResults[1].Status, ShouldEqual, evergreen.TestFailedStatus)
+		So(testTask.TestResults[1].LogId, ShouldNotEqual, "")
+
+		Convey("along with the proper logs", func() {
+			tl := dBFindOneTestLog("TestFail", taskId)
+			So(tl.Lines[1], ShouldContainSubstring, "expected 1, got 2")
+		})
+	})
+}
+
+func TestAttachTAPResults(t *testing.T) {
+	runTest(t, TAPConfig, func(taskId string) {
+		testTask, err := task.FindOne(task.ById(taskId))
+		So(err, ShouldBeNil)
+		So(len(testTask.TestResults), ShouldEqual, 7)
+		So(testTask.TestResults[0].TestFile, ShouldEqual, "results.tap/input file opened")
+		So(testTask.TestResults[2].Status, ShouldEqual, evergreen.TestSkippedStatus)
+		So(testTask.TestResults[3].Status, ShouldEqual, evergreen.TestSilentlyFailedStatus)
+		So(testTask.TestResults[6].Status, ShouldEqual, evergreen.TestFailedStatus)
+
+		Convey("along with the proper logs", func() {
+			tl := dBFindOneTestLog("results.tap/first line of the input valid", taskId)
+			So(tl.Lines[0], ShouldContainSubstring, "First line invalid")
+		})
+	})
+}
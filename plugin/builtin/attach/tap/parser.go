@@ -0,0 +1,211 @@
+package tap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// TAP directives that change how a test point's status is interpreted
+const (
+	DirectiveSkip = "SKIP"
+	DirectiveTodo = "TODO"
+)
+
+var (
+	// matches test points like "not ok 2 - description # TODO reason"
+	testPointRegex = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?(.*)$`)
+	directiveRegex = regexp.MustCompile(`(?i)\s*#\s*(skip|todo)\S*\s*(.*)$`)
+	bailOutRegex   = regexp.MustCompile(`^Bail out!\s*(.*)$`)
+	planRegex      = regexp.MustCompile(`^1\.\.(\d+)`)
+)
+
+// TestCase is a single test point of a TAP stream.
+type TestCase struct {
+	// File is the base name of the file the test point was read from, if
+	// known.
+	File        string
+	Number      int
+	Description string
+	Ok          bool
+	// Directive is DirectiveSkip, DirectiveTodo, or empty.
+	Directive   string
+	Reason      string
+	Diagnostics []string
+}
+
+// ParseResults reads a TAP (Test Anything Protocol) stream and returns its
+// test points. Diagnostics, whether comments or YAML blocks, are attached to
+// the test point they follow. Parsing stops if the stream bails out. A run
+// that bails out, or whose number of test points doesn't match its plan, gets
+// an extra failed test point so that it isn't mistaken for a passing run.
+// Test points are named within the base name of the given file, since TAP
+// descriptions are only unique within a stream.
+func ParseResults(file string, reader io.Reader) ([]*TestCase, error) {
+	tests := []*TestCase{}
+	var current *TestCase
+	inYAML := false
+	planned := -1
+	bailOut := ""
+	bailedOut := false
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+
+		// YAML diagnostic blocks are indented and delimited by "---" and "..."
+		if inYAML {
+			if trimmed == "..." {
+				inYAML = false
+				continue
+			}
+			current.Diagnostics = append(current.Diagnostics, line)
+			continue
+		}
+		if trimmed == "---" && current != nil && line != trimmed {
+			inYAML = true
+			continue
+		}
+
+		if matches := bailOutRegex.FindStringSubmatch(line); matches != nil {
+			bailedOut = true
+			bailOut = strings.TrimSpace(matches[1])
+			break
+		}
+		if matches := planRegex.FindStringSubmatch(line); matches != nil && planned < 0 {
+			count, err := strconv.Atoi(matches[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid plan in line '%v'", line)
+			}
+			planned = count
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if current != nil {
+				current.Diagnostics = append(current.Diagnostics, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			}
+			continue
+		}
+
+		matches := testPointRegex.FindStringSubmatch(line)
+		if matches == nil {
+			// the version, subtests and anything unrecognized
+			continue
+		}
+		tc := &TestCase{Ok: matches[1] == "ok", Description: matches[3]}
+		if matches[2] != "" {
+			number, err := strconv.Atoi(matches[2])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid test number in line '%v'", line)
+			}
+			tc.Number = number
+		} else {
+			tc.Number = len(tests) + 1
+		}
+		if directive := directiveRegex.FindStringSubmatchIndex(tc.Description); directive != nil {
+			tc.Directive = strings.ToUpper(tc.Description[directive[2]:directive[3]])
+			tc.Reason = tc.Description[directive[4]:directive[5]]
+			tc.Description = tc.Description[:directive[0]]
+		}
+		tc.Description = strings.TrimSpace(tc.Description)
+
+		tests = append(tests, tc)
+		current = tc
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading TAP stream")
+	}
+
+	switch {
+	case bailedOut:
+		tc := &TestCase{Number: len(tests) + 1, Description: "bail out"}
+		if bailOut != "" {
+			tc.Diagnostics = []string{bailOut}
+		}
+		tests = append(tests, tc)
+	case planned < 0:
+		tests = append(tests, &TestCase{
+			Number:      len(tests) + 1,
+			Description: "missing plan",
+			Diagnostics: []string{fmt.Sprintf("ran %v tests without a plan, the run may not have finished", len(tests))},
+		})
+	case planned != len(tests):
+		tests = append(tests, &TestCase{
+			Number:      len(tests) + 1,
+			Description: "plan mismatch",
+			Diagnostics: []string{fmt.Sprintf("planned %v tests but ran %v", planned, len(tests))},
+		})
+	}
+
+	if file != "" {
+		for _, tc := range tests {
+			tc.File = filepath.Base(file)
+		}
+	}
+	return tests, nil
+}
+
+// Name returns the test point's description, or its number if it has none,
+// qualified with its file so that test points from different files don't
+// collide.
+func (tc *TestCase) Name() string {
+	name := tc.Description
+	if name == "" {
+		name = fmt.Sprintf("test %v", tc.Number)
+	}
+	if tc.File == "" {
+		return name
+	}
+	return tc.File + "/" + name
+}
+
+// ToModelTestResultAndLog converts a test point into an mci task.TestResult
+// and a model.TestLog of its diagnostics. The log is nil if the test point
+// has no diagnostics.
+func (tc *TestCase) ToModelTestResultAndLog(t *task.Task) (task.TestResult, *model.TestLog) {
+	res := task.TestResult{TestFile: tc.Name()}
+
+	// TAP has no timing information
+	res.StartTime = float64(time.Now().Unix())
+	res.EndTime = res.StartTime
+
+	switch {
+	case tc.Directive == DirectiveSkip:
+		res.Status = evergreen.TestSkippedStatus
+	case tc.Ok:
+		res.Status = evergreen.TestSucceededStatus
+	case tc.Directive == DirectiveTodo:
+		// failing TODO tests are expected to fail
+		res.Status = evergreen.TestSilentlyFailedStatus
+	default:
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	lines := []string{}
+	if tc.Directive != "" {
+		lines = append(lines, fmt.Sprintf("%v: %v", tc.Directive, tc.Reason))
+	}
+	lines = append(lines, tc.Diagnostics...)
+	if len(lines) == 0 {
+		return res, nil
+	}
+	log := &model.TestLog{
+		Name:          res.TestFile,
+		Task:          t.Id,
+		TaskExecution: t.Execution,
+		Lines:         lines,
+	}
+	return res, log
+}
@@ -0,0 +1,103 @@
+package tap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTAPParsing(t *testing.T) {
+	Convey("With a TAP output file", t, func() {
+		path := filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "results.tap")
+		file, err := os.Open(path)
+		testutil.HandleTestingErr(err, t, "Error reading file")
+		defer file.Close()
+
+		Convey("the file should parse without error, up to where it bails out", func() {
+			res, err := ParseResults(path, file)
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 7)
+
+			Convey("and have proper values decoded", func() {
+				So(res[0].Number, ShouldEqual, 1)
+				So(res[0].Description, ShouldEqual, "input file opened")
+				So(res[0].Name(), ShouldEqual, "results.tap/input file opened")
+				So(res[0].Ok, ShouldBeTrue)
+				So(res[0].Diagnostics, ShouldBeEmpty)
+				So(res[1].Ok, ShouldBeFalse)
+				So(res[1].Diagnostics, ShouldResemble, []string{
+					"  message: 'First line invalid'",
+					"  severity: fail",
+				})
+				So(res[2].Description, ShouldEqual, "read the rest of the file")
+				So(res[2].Directive, ShouldEqual, DirectiveSkip)
+				So(res[2].Reason, ShouldEqual, "no file to read")
+				So(res[3].Directive, ShouldEqual, DirectiveTodo)
+				So(len(res[3].Diagnostics), ShouldEqual, 2)
+				So(res[4].Name(), ShouldEqual, "results.tap/test 5")
+				So(res[4].Diagnostics, ShouldResemble, []string{"Failed test at t/summary.t line 20."})
+				So(res[6].Name(), ShouldEqual, "results.tap/bail out")
+				So(res[6].Ok, ShouldBeFalse)
+				So(res[6].Diagnostics, ShouldResemble, []string{"database went away"})
+			})
+
+			Convey("and convert to the model", func() {
+				testTask := &task.Task{Id: "TEST", Execution: 5}
+				statuses := []string{}
+				for _, tc := range res {
+					test, log := tc.ToModelTestResultAndLog(testTask)
+					So(test.TestFile, ShouldEqual, tc.Name())
+					if len(tc.Diagnostics) == 0 && tc.Directive == "" {
+						So(log, ShouldBeNil)
+					} else {
+						So(log, ShouldNotBeNil)
+						So(log.Name, ShouldEqual, tc.Name())
+						So(log.Task, ShouldEqual, "TEST")
+					}
+					statuses = append(statuses, test.Status)
+				}
+				So(statuses, ShouldResemble, []string{
+					evergreen.TestSucceededStatus,
+					evergreen.TestFailedStatus,
+					evergreen.TestSkippedStatus,
+					evergreen.TestSilentlyFailedStatus,
+					evergreen.TestFailedStatus,
+					evergreen.TestSucceededStatus,
+					evergreen.TestFailedStatus,
+				})
+			})
+		})
+	})
+
+	Convey("With TAP output that doesn't match its plan", t, func() {
+		Convey("a truncated run should get a failed test point", func() {
+			res, err := ParseResults("", strings.NewReader("1..3\nok 1 - first\nok 2 - second\n"))
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 3)
+			So(res[2].Name(), ShouldEqual, "plan mismatch")
+			So(res[2].Ok, ShouldBeFalse)
+			So(res[2].Diagnostics, ShouldResemble, []string{"planned 3 tests but ran 2"})
+		})
+
+		Convey("a run without a plan should get a failed test point", func() {
+			res, err := ParseResults("t/summary.t", strings.NewReader("ok 1 - first\n"))
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 2)
+			So(res[0].Name(), ShouldEqual, "summary.t/first")
+			So(res[1].Name(), ShouldEqual, "summary.t/missing plan")
+			So(res[1].Ok, ShouldBeFalse)
+		})
+
+		Convey("a plan at the end of a complete run should be accepted", func() {
+			res, err := ParseResults("", strings.NewReader("ok 1 - first\nok 2 - second\n1..2\n"))
+			So(err, ShouldBeNil)
+			So(len(res), ShouldEqual, 2)
+		})
+	})
+}
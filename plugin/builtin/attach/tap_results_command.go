@@ -0,0 +1,56 @@
+package attach
+
+import (
+	"io"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/plugin"
+	"github.com/evergreen-ci/evergreen/plugin/builtin/attach/tap"
+)
+
+// AttachTAPResultsCommand reads in files of TAP (Test Anything Protocol)
+// output and converts them to a format MCI can use, attaching each test's
+// diagnostics as its log.
+type AttachTAPResultsCommand struct {
+	resultsFilesParams
+}
+
+func (c *AttachTAPResultsCommand) Name() string {
+	return AttachTAPResultsCmd
+}
+
+func (c *AttachTAPResultsCommand) Plugin() string {
+	return AttachPluginName
+}
+
+// ParseParams reads and validates the command parameters. This is required
+// to satisfy the 'Command' interface
+func (c *AttachTAPResultsCommand) ParseParams(params map[string]interface{}) error {
+	return c.parseParams(c.Name(), params)
+}
+
+// Execute carries out the AttachTAPResultsCommand command - this is
+// required to satisfy the 'Command' interface
+func (c *AttachTAPResultsCommand) Execute(pluginLogger plugin.Logger,
+	pluginCom plugin.PluginCommunicator,
+	taskConfig *model.TaskConfig,
+	stop chan bool) error {
+	return executeResultsFilesCommand(c.Name(), &c.resultsFilesParams, parseTAPResults,
+		pluginLogger, pluginCom, taskConfig, stop)
+}
+
+func parseTAPResults(path string, reader io.Reader, t *task.Task) ([]task.TestResult, []*model.TestLog, error) {
+	testCases, err := tap.ParseResults(path, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	tests := make([]task.TestResult, 0, len(testCases))
+	logs := make([]*model.TestLog, 0, len(testCases))
+	for _, tc := range testCases {
+		test, log := tc.ToModelTestResultAndLog(t)
+		tests = append(tests, test)
+		logs = append(logs, log)
+	}
+	return tests, logs, nil
+}
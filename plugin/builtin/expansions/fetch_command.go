@@ -80,6 +80,14 @@ func FetchVarsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// this deprecated route can't mask private variables in the task's logs,
+	// so it leaves them out
+	for name, private := range projectVars.PrivateVars {
+		if private {
+			delete(projectVars.Vars, name)
+		}
+	}
+
 	plugin.WriteJSON(w, http.StatusOK, projectVars.Vars)
 	return
 }
@@ -0,0 +1,39 @@
+package generate
+
+import (
+	"github.com/evergreen-ci/evergreen/plugin"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	plugin.Publish(&GeneratePlugin{})
+}
+
+const (
+	GeneratePluginName = "generate"
+	GenerateTasksCmd   = "tasks"
+
+	// GenerateTasksAPIEndpoint is served by the API server itself rather
+	// than by this plugin, since validating the generated config needs the
+	// project validator, which in turn depends on the installed plugins.
+	GenerateTasksAPIEndpoint = "tasks"
+)
+
+// GeneratePlugin lets a running task add tasks and build variants to its
+// own version, for suites too large to list statically in the project file.
+type GeneratePlugin struct{}
+
+// Name returns the name of this plugin - satisfies 'Plugin' interface
+func (self *GeneratePlugin) Name() string {
+	return GeneratePluginName
+}
+
+// NewCommand returns requested commands by name. Fulfills the Plugin interface.
+func (self *GeneratePlugin) NewCommand(cmdName string) (plugin.Command, error) {
+	switch cmdName {
+	case GenerateTasksCmd:
+		return &GenerateTasksCommand{}, nil
+	default:
+		return nil, errors.Errorf("No such %v command: %v", GeneratePluginName, cmdName)
+	}
+}
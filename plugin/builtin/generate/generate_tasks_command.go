@@ -0,0 +1,135 @@
+package generate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/plugin"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip/slogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	GenerateTasksPostRetries   = 5
+	GenerateTasksRetrySleepSec = 5 * time.Second
+)
+
+// GenerateTasksCommand uploads JSON files describing new tasks, functions
+// and build variant task entries to be added to the task's version.
+type GenerateTasksCommand struct {
+	// Files are the paths, relative to the working directory, of the JSON
+	// files containing the generated project configuration.
+	Files []string `mapstructure:"files" plugin:"expand"`
+}
+
+func (self *GenerateTasksCommand) Name() string {
+	return GenerateTasksCmd
+}
+
+func (self *GenerateTasksCommand) Plugin() string {
+	return GeneratePluginName
+}
+
+// ParseParams decodes and validates the command's parameters.
+func (self *GenerateTasksCommand) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, self); err != nil {
+		return errors.Wrapf(err, "error decoding '%v' params", self.Name())
+	}
+	if len(self.Files) == 0 {
+		return errors.Errorf("'%v' requires at least one file", self.Name())
+	}
+	return nil
+}
+
+// readFiles returns the contents of the generated config files, checking
+// that each one is valid JSON.
+func (self *GenerateTasksCommand) readFiles(workDir string) ([]json.RawMessage, error) {
+	fragments := make([]json.RawMessage, 0, len(self.Files))
+	for _, fn := range self.Files {
+		if !filepath.IsAbs(fn) {
+			fn = filepath.Join(workDir, fn)
+		}
+		data, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading '%v'", fn)
+		}
+		var parsed interface{}
+		if err = json.Unmarshal(data, &parsed); err != nil {
+			return nil, errors.Wrapf(err, "'%v' does not contain valid JSON", fn)
+		}
+		fragments = append(fragments, json.RawMessage(data))
+	}
+	return fragments, nil
+}
+
+// Execute uploads the generated config to the API server, retrying if the
+// version's config was changed concurrently by another generator.
+func (self *GenerateTasksCommand) Execute(pluginLogger plugin.Logger,
+	pluginCom plugin.PluginCommunicator, conf *model.TaskConfig,
+	stop chan bool) error {
+
+	if err := plugin.ExpandValues(self, conf.Expansions); err != nil {
+		return errors.WithStack(err)
+	}
+	fragments, err := self.readFiles(conf.WorkDir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		retriablePost := util.RetriableFunc(
+			func() error {
+				pluginLogger.LogTask(slogger.INFO, "Posting %d generated config files", len(fragments))
+				resp, err := pluginCom.TaskPostJSON(GenerateTasksAPIEndpoint, fragments)
+				if resp != nil {
+					defer resp.Body.Close()
+				}
+				if err != nil {
+					return util.RetriableError{errors.WithStack(err)}
+				}
+				switch resp.StatusCode {
+				case http.StatusOK:
+					return nil
+				case http.StatusBadRequest:
+					verrs := []struct {
+						Message string `json:"message"`
+					}{}
+					if err = util.ReadJSONInto(resp.Body, &verrs); err != nil {
+						return errors.Wrap(err, "generated config is invalid")
+					}
+					for _, e := range verrs {
+						pluginLogger.LogTask(slogger.ERROR, "%v", e.Message)
+					}
+					return errors.New("generated config is invalid")
+				case http.StatusConflict, http.StatusInternalServerError:
+					return util.RetriableError{errors.Errorf("unexpected status code %v", resp.StatusCode)}
+				default:
+					return errors.Errorf("unexpected status code %v", resp.StatusCode)
+				}
+			},
+		)
+		_, err := util.RetryArithmeticBackoff(retriablePost, GenerateTasksPostRetries, GenerateTasksRetrySleepSec)
+		errChan <- errors.WithStack(err)
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			pluginLogger.LogTask(slogger.ERROR, "Generating tasks failed: %v", err)
+			return err
+		}
+		pluginLogger.LogTask(slogger.INFO, "Generating tasks succeeded")
+		return nil
+	case <-stop:
+		pluginLogger.LogExecution(slogger.INFO, "Received signal to terminate"+
+			" execution of generate tasks command")
+		return nil
+	}
+}
@@ -0,0 +1,43 @@
+package generate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateTasksParams(t *testing.T) {
+	Convey("With a generate.tasks command", t, func() {
+		cmd := &GenerateTasksCommand{}
+
+		Convey("files should be required", func() {
+			So(cmd.ParseParams(map[string]interface{}{}), ShouldNotBeNil)
+			So(cmd.ParseParams(map[string]interface{}{"files": []string{"a.json"}}), ShouldBeNil)
+			So(cmd.Files, ShouldResemble, []string{"a.json"})
+		})
+
+		Convey("files should be read relative to the working directory", func() {
+			dir, err := ioutil.TempDir("", "generate")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			So(ioutil.WriteFile(filepath.Join(dir, "good.json"), []byte(`{"tasks": []}`), 0644), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(dir, "bad.json"), []byte(`tasks: []`), 0644), ShouldBeNil)
+
+			cmd.Files = []string{"good.json"}
+			fragments, err := cmd.readFiles(dir)
+			So(err, ShouldBeNil)
+			So(string(fragments[0]), ShouldEqual, `{"tasks": []}`)
+
+			cmd.Files = []string{"good.json", "bad.json"}
+			_, err = cmd.readFiles(dir)
+			So(err, ShouldNotBeNil)
+
+			cmd.Files = []string{"missing.json"}
+			_, err = cmd.readFiles(dir)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -19,6 +19,7 @@ package config
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/archive"
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/attach"
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/expansions"
+import _ "github.com/evergreen-ci/evergreen/plugin/builtin/generate"
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/git"
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/helloworld"
 import _ "github.com/evergreen-ci/evergreen/plugin/builtin/gotest"
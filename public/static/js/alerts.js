@@ -13,6 +13,18 @@ function NewAlert(recipient){
       },
     }
   }
+  // webhooks are denoted with "WEBHOOK:url" or, for Slack-compatible
+  // incoming webhooks, "SLACK:url"
+  if (recipient.startsWith("WEBHOOK:") || recipient.startsWith("SLACK:")) {
+    var sep = recipient.indexOf(":")
+    return {
+      provider: "webhook",
+      settings: {
+        url: recipient.substring(sep + 1),
+        format: recipient.startsWith("SLACK:") ? "slack" : "json",
+      },
+    }
+  }
   // otherwise always default to email
   return {
     provider: "email",
@@ -24,9 +24,14 @@ mciModule.controller('BuildVariantHistoryController', function($scope, $http, $f
         build.tasks[j].status = 'unscheduled';
       }
 
+      var tooltip = build.tasks[j].display_name + " - " + $filter('statusLabel')(build.tasks[j]);
+      if (build.tasks[j].execution_tasks) {
+        tooltip += " (" + build.tasks[j].execution_tasks.length + " tasks)";
+      }
+
       $scope.buildResults[build._id].push({
         "class": $filter('statusFilter')(build.tasks[j]),
-        "tooltip": build.tasks[j].display_name + " - " + $filter('statusLabel')(build.tasks[j]),
+        "tooltip": tooltip,
         "link": '/task/' + build.tasks[j].id
       });
     }
@@ -35,4 +35,26 @@ directives.eventlogs.directive('distroevent', function() {
   };
 });
 
+directives.eventlogs.directive('projectevent', function() {
+  return {
+    scope:{
+      userTz:"=tz",
+      e:"=event"
+    },
+    restrict : 'E',
+    templateUrl : '/static/partials/projectevent.html',
+  };
+});
+
+directives.eventlogs.directive('versionevent', function() {
+  return {
+    scope:{
+      userTz:"=tz",
+      e:"=event"
+    },
+    restrict : 'E',
+    templateUrl : '/static/partials/versionevent.html',
+  };
+});
+
 
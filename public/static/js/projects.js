@@ -6,6 +6,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
 
 
   $scope.projectVars = {};
+  $scope.privateVars = {};
   $scope.projectRef = {};
   $scope.displayName = "";
 
@@ -48,6 +49,9 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
     if (spec.startsWith("JIRA:") && spec.split(":").length < 3) {
         return false
     }
+    if ((spec.startsWith("WEBHOOK:") || spec.startsWith("SLACK:")) && spec.indexOf(":") == spec.length - 1) {
+        return false
+    }
     return true
   }
 
@@ -94,6 +98,25 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
     }
   };
 
+  // the roles a project can give, from least to most privileged
+  $scope.projectRoles = ["viewer", "patch_submitter", "task_controller", "project_admin"];
+  $scope.newRole = {role: "viewer", kind: "user"};
+
+  // addRole gives the new role to a user or group in the settingsFormData
+  $scope.addRole = function(){
+    var role = {role: $scope.newRole.role};
+    role[$scope.newRole.kind] = $scope.newRole.name;
+    $scope.settingsFormData.roles.push(role);
+    $scope.newRole.name = "";
+    $scope.isDirty = true;
+  }
+
+  // removeRole removes the role located at index
+  $scope.removeRole = function(index){
+    $scope.settingsFormData.roles.splice(index, 1);
+    $scope.isDirty = true;
+  }
+
   // addAdmin adds an admin name to the settingsFormData's list of admins
   $scope.addAdmin = function(){
     $scope.settingsFormData.admins.push($scope.admin_name);
@@ -137,17 +160,21 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
 
         if (data.ProjectVars) {
          $scope.projectVars = data.ProjectVars.vars;
+         $scope.privateVars = data.ProjectVars.private_vars || {};
         }
         else {
           $scope.projectVars = {};
+          $scope.privateVars = {};
         }
 
         $scope.settingsFormData = {
           identifier : $scope.projectRef.identifier,
           project_vars: $scope.projectVars,
+          private_vars: $scope.privateVars,
           display_name : $scope.projectRef.display_name,
           remote_path:$scope.projectRef.remote_path,
           batch_time: parseInt($scope.projectRef.batch_time),
+          share_weight: parseInt($scope.projectRef.share_weight) || 1,
           deactivate_previous: $scope.projectRef.deactivate_previous,
           relative_url: $scope.projectRef.relative_url,
           branch_name: $scope.projectRef.branch_name,
@@ -158,6 +185,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
           alert_config: $scope.projectRef.alert_config || {},
           repotracker_error: $scope.projectRef.repotracker_error || {},
           admins : $scope.projectRef.admins || [],
+          roles : $scope.projectRef.roles || [],
         };
 
         $scope.displayName = $scope.projectRef.display_name ? $scope.projectRef.display_name : $scope.projectRef.identifier;
@@ -192,15 +220,25 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
 
   $scope.saveProject = function() {
     $scope.settingsFormData.batch_time = parseInt($scope.settingsFormData.batch_time)
+    $scope.settingsFormData.share_weight = parseInt($scope.settingsFormData.share_weight)
     if ($scope.proj_var) {
       $scope.addProjectVar();
     }
     if ($scope.admin_name) {
       $scope.addAdmin();
     }
+    if ($scope.newRole.name) {
+      $scope.addRole();
+    }
     $http.post('/project/' + $scope.settingsFormData.identifier, $scope.settingsFormData).
       success(function(data, status) {
         $scope.saveMessage = "Settings Saved.";
+        // the server never sends back the values of private variables
+        _.each($scope.settingsFormData.private_vars, function(isPrivate, name) {
+          if (isPrivate) {
+            $scope.settingsFormData.project_vars[name] = "";
+          }
+        });
         $scope.refreshTrackedProjects(data.AllProjects);
         $scope.settingsForm.$setPristine();
         $scope.isDirty = false;
@@ -213,13 +251,16 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
   $scope.addProjectVar = function() {
     if ($scope.proj_var.name && $scope.proj_var.value) {
       $scope.settingsFormData.project_vars[$scope.proj_var.name] = $scope.proj_var.value;
+      $scope.settingsFormData.private_vars[$scope.proj_var.name] = !!$scope.proj_var.private;
       $scope.proj_var.name="";
       $scope.proj_var.value="";
+      $scope.proj_var.private=false;
     }
   };
 
   $scope.removeProjectVar = function(name) {
     delete $scope.settingsFormData.project_vars[name];
+    delete $scope.settingsFormData.private_vars[name];
     $scope.isDirty = true;
   };
 
@@ -237,6 +278,12 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location)
     if (alertObj.provider=='jira'){
       return "File a "+alertObj.settings.issue+" JIRA ticket in "+ alertObj.settings.project
     }
+    if (alertObj.provider=='webhook'){
+      if (alertObj.settings.format=='slack'){
+        return "Post a Slack message to " + alertObj.settings.url
+      }
+      return "Post to webhook " + alertObj.settings.url
+    }
     return 'unknown'
   }
 
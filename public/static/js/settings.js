@@ -44,6 +44,7 @@ mciModule.controller('SettingsCtrl', ['$scope', '$http', '$window', 'notificatio
   $scope.user_tz = $window.user_tz;
   $scope.new_tz = $scope.user_tz || "America/New_York";
   $scope.new_waterfall = $window.new_waterfall;
+  $scope.patch_notifications = $window.patch_notifications || {disabled: false, webhook_url: ""};
   $scope.userConf = $window.userConf;
   $scope.binaries = $window.binaries;
 
@@ -61,8 +62,67 @@ mciModule.controller('SettingsCtrl', ['$scope', '$http', '$window', 'notificatio
       });
   }
 
+  $scope.subscriptionTriggers = [
+    {id: "task_failure", display: "a task fails"},
+    {id: "task_success_to_failure", display: "a task starts failing"},
+    {id: "build_failure", display: "a build fails"},
+    {id: "build_success_to_failure", display: "a build starts failing"},
+    {id: "build_completion", display: "a build finishes"},
+    {id: "patch_finished", display: "a patch finishes"},
+    {id: "spawnhost_expiring", display: "my spawn host is about to expire"},
+  ];
+  $scope.subscriptions = [];
+  $scope.newSubscription = {trigger: "task_failure", selector: {}};
+
+  $scope.loadSubscriptions = function() {
+    $http.get('/rest/v1/subscriptions')
+      .success(function(data, status) {
+        $scope.subscriptions = data;
+      })
+      .error(function(jqXHR, status, errorThrown) {
+        notifier.pushNotification("Failed to load subscriptions: " + jqXHR,'errorHeader');
+      });
+  };
+
+  $scope.triggerDisplay = function(trigger) {
+    var found = _.find($scope.subscriptionTriggers, function(t) { return t.id == trigger; });
+    return found ? found.display : trigger;
+  };
+
+  $scope.addSubscription = function() {
+    var sub = angular.copy($scope.newSubscription);
+    if (sub.trigger == "spawnhost_expiring") {
+      sub.selector = {};
+    }
+    if (sub.team_name) {
+      sub.subscriber = {type: "team", name: sub.team_name, address: sub.team_address};
+    }
+    delete sub.team_name;
+    delete sub.team_address;
+    $http.post('/rest/v1/subscriptions', sub)
+      .success(function(data, status) {
+        $scope.subscriptions.push(data);
+        $scope.newSubscription = {trigger: sub.trigger, selector: {}};
+      })
+      .error(function(jqXHR, status, errorThrown) {
+        notifier.pushNotification("Failed to add subscription: " + (jqXHR.message || jqXHR),'errorHeader');
+      });
+  };
+
+  $scope.removeSubscription = function(index) {
+    $http.delete('/rest/v1/subscriptions/' + $scope.subscriptions[index].id)
+      .success(function(data, status) {
+        $scope.subscriptions.splice(index, 1);
+      })
+      .error(function(jqXHR, status, errorThrown) {
+        notifier.pushNotification("Failed to remove subscription: " + (jqXHR.message || jqXHR),'errorHeader');
+      });
+  };
+
+  $scope.loadSubscriptions();
+
   $scope.updateUserSettings = function(new_tz, new_waterfall) {
-    data = {timezone: new_tz, new_waterfall: new_waterfall};
+    data = {timezone: new_tz, new_waterfall: new_waterfall, patch_notifications: $scope.patch_notifications};
     $http.put('/settings/', data)
       .success(function(data, status) {
         window.location.reload()
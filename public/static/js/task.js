@@ -326,10 +326,12 @@ mciModule.controller('TaskCtrl', function($scope, $rootScope, $now, $timeout, $i
     }];
 
     var totalTestTime = 0;
+    var flakyTests = task.flaky_tests || {};
     (task.test_results || []).forEach(function(testResult) {
       testResult.time_taken = testResult.end - testResult.start;
       totalTestTime += testResult.time_taken;
       testResult.display_name = $filter('endOfPath')(testResult.test_file);
+      testResult.flakiness = flakyTests[testResult.test_file];
     });
     $scope.totalTestTimeNano = totalTestTime * 1000 * 1000 * 1000;
 
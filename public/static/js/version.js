@@ -84,9 +84,13 @@ mciModule.controller('VersionController', function($scope, $rootScope, $location
       $scope.taskStatuses[version.Builds[i].Build._id] = [];
       for (var j = 0; j < version.Builds[i].Tasks.length; ++j) {
         row[version.Builds[i].Tasks[j].Task.display_name] = version.Builds[i].Tasks[j].Task;
+        var tooltip = version.Builds[i].Tasks[j].Task.display_name + " - " + $filter('statusLabel')(version.Builds[i].Tasks[j].Task);
+        if (version.Builds[i].Tasks[j].ExecutionTasks) {
+          tooltip += " (" + version.Builds[i].Tasks[j].ExecutionTasks.length + " tasks)";
+        }
         $scope.taskStatuses[version.Builds[i].Build._id].push({
           "class": $filter('statusFilter')(version.Builds[i].Tasks[j].Task),
-          "tooltip": version.Builds[i].Tasks[j].Task.display_name + " - " + $filter('statusLabel')(version.Builds[i].Tasks[j].Task),
+          "tooltip": tooltip,
           "link": "/task/" + version.Builds[i].Tasks[j].Task.id
         });
         taskNames[version.Builds[i].Tasks[j].Task.display_name] = 1;
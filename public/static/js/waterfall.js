@@ -652,6 +652,9 @@ function EmptyBuild ({}){
 
 function TooltipContent({task, eta}) {
   var topLineContent = task.display_name + " - " + labelFromTask(task);
+  if (task.execution_tasks) {
+    topLineContent += " (" + task.execution_tasks.length + " tasks)";
+  }
   if (task.status == 'success' || task.status == 'failed') {
     var dur = stringifyNanoseconds(task.time_taken);
     topLineContent += ' - ' + dur;
@@ -0,0 +1,228 @@
+package repotracker
+
+import (
+	"bytes"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// gitFieldSeparator and gitRecordSeparator delimit the fields and
+	// commits in the output of 'git log' using gitLogFormat
+	gitFieldSeparator  = "\x00"
+	gitRecordSeparator = "\x1e"
+	gitLogFormat       = "--format=%H%x00%an%x00%ae%x00%ct%x00%B%x1e"
+)
+
+// GitRepositoryPoller is a struct that implements the behavior required of a
+// RepoPoller against a local or mirrored git repository, using the git CLI
+type GitRepositoryPoller struct {
+	ProjectRef *model.ProjectRef
+	RepoPath   string
+}
+
+// NewGitRepositoryPoller constructs and returns a pointer to a
+// GitRepositoryPoller struct for the repository at the project ref's RepoPath
+func NewGitRepositoryPoller(projectRef *model.ProjectRef) *GitRepositoryPoller {
+	return &GitRepositoryPoller{
+		ProjectRef: projectRef,
+		RepoPath:   projectRef.RepoPath,
+	}
+}
+
+// git runs a git command against the poller's repository and returns its
+// standard output
+func (gRepoPoller *GitRepositoryPoller) git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", gRepoPoller.RepoPath}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "error running 'git %v' in '%v': %v",
+			strings.Join(args, " "), gRepoPoller.RepoPath, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// update fetches new commits into the repository if it has any remotes and
+// returns the name to read the ProjectRef's branch from. Fetching moves the
+// branch itself in a mirror, but only its remote-tracking branch in a regular
+// clone, so the remote-tracking branch is read whenever it exists.
+// Repositories without remotes are assumed to be kept current externally.
+func (gRepoPoller *GitRepositoryPoller) update() (string, error) {
+	branch := gRepoPoller.ProjectRef.Branch
+	remotes, err := gRepoPoller.git("remote")
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(remotes) == "" {
+		return branch, nil
+	}
+	if _, err = gRepoPoller.git("remote", "update", "--prune"); err != nil {
+		return "", err
+	}
+	remoteBranch := "refs/remotes/origin/" + branch
+	if _, err = gRepoPoller.git("rev-parse", "--verify", "--quiet", remoteBranch); err == nil {
+		return remoteBranch, nil
+	}
+	return branch, nil
+}
+
+// hasCommit returns true if the given revision names a commit in the repository
+func (gRepoPoller *GitRepositoryPoller) hasCommit(revision string) bool {
+	_, err := gRepoPoller.git("cat-file", "-e", revision+"^{commit}")
+	return err == nil
+}
+
+// log returns the revisions reported by 'git log' for the given arguments,
+// with the most recent revision first
+func (gRepoPoller *GitRepositoryPoller) log(args ...string) ([]model.Revision, error) {
+	args = append(append([]string{"log", gitLogFormat}, args...), "--")
+	out, err := gRepoPoller.git(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitLog(out)
+}
+
+// parseGitLog converts the output of 'git log' using gitLogFormat into a
+// slice of model.Revision structs
+func parseGitLog(out string) ([]model.Revision, error) {
+	revisions := []model.Revision{}
+	for _, record := range strings.Split(out, gitRecordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, gitFieldSeparator, 5)
+		if len(fields) != 5 {
+			return nil, errors.Errorf("malformed git log record: %q", record)
+		}
+		commitTime, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "malformed commit time in git log record: %q", record)
+		}
+		revisions = append(revisions, model.Revision{
+			Revision:        fields[0],
+			Author:          fields[1],
+			AuthorEmail:     fields[2],
+			RevisionMessage: strings.TrimSpace(fields[4]),
+			CreateTime:      time.Unix(commitTime, 0),
+		})
+	}
+	return revisions, nil
+}
+
+// GetRemoteConfig fetches the contents of the repository's configuration
+// data as at a given revision
+func (gRepoPoller *GitRepositoryPoller) GetRemoteConfig(
+	projectFileRevision string) (*model.Project, error) {
+	projectRef := gRepoPoller.ProjectRef
+	objectName := projectFileRevision + ":" + projectRef.RemotePath
+
+	if _, err := gRepoPoller.git("cat-file", "-e", objectName); err != nil {
+		return nil, thirdparty.NewFileNotFoundError(objectName)
+	}
+	projectFileContents, err := gRepoPoller.git("show", objectName)
+	if err != nil {
+		return nil, thirdparty.FileDecodeError{Message: err.Error()}
+	}
+
+	projectConfig := &model.Project{}
+	err = model.LoadProjectInto([]byte(projectFileContents), projectRef.Identifier, projectConfig)
+	if err != nil {
+		return nil, thirdparty.YAMLFormatError{Message: err.Error()}
+	}
+
+	return projectConfig, nil
+}
+
+// GetChangedFiles returns the paths of all files modified by the given revision
+func (gRepoPoller *GitRepositoryPoller) GetChangedFiles(commitRevision string) ([]string, error) {
+	out, err := gRepoPoller.git("diff-tree", "--no-commit-id", "--name-only", "-r", "--root", commitRevision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading commit '%v'", commitRevision)
+	}
+	files := []string{}
+	for _, f := range strings.Split(out, "\n") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// GetRevisionsSince fetches all commits on the ProjectRef's branch that were
+// made after 'revision'
+func (gRepoPoller *GitRepositoryPoller) GetRevisionsSince(
+	revision string, maxRevisionsToSearch int) ([]model.Revision, error) {
+	branchRef, err := gRepoPoller.update()
+	if err != nil {
+		return nil, errors.Wrap(err, "error updating repository")
+	}
+	branch := gRepoPoller.ProjectRef.Branch
+
+	var revisionError error
+	if !gRepoPoller.hasCommit(revision) {
+		revisionError = errors.Errorf("revision %v not found in repository", revision)
+	} else if _, err := gRepoPoller.git("merge-base", "--is-ancestor", revision, branchRef); err != nil {
+		revisionError = errors.Errorf("revision %v is not an ancestor of branch %v", revision, branch)
+	} else {
+		count, err := gRepoPoller.git("rev-list", "--count", revision+".."+branchRef)
+		if err != nil {
+			return nil, err
+		}
+		numRevisions, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing revision count")
+		}
+		if maxRevisionsToSearch > 0 && numRevisions > maxRevisionsToSearch {
+			revisionError = errors.Errorf("revision %v not found within the most recent %v revisions",
+				revision, maxRevisionsToSearch)
+		} else {
+			return gRepoPoller.log(revision + ".." + branchRef)
+		}
+	}
+
+	if len(revision) < 10 {
+		return nil, errors.Errorf("invalid revision: %v", revision)
+	}
+	revisionDetails := &model.RepositoryErrorDetails{
+		Exists:          true,
+		InvalidRevision: revision[:10],
+	}
+	// attempt to get the merge base commit
+	if baseRevision, err := gRepoPoller.git("merge-base", revision, branchRef); err != nil {
+		grip.Warningf("unable to find a merge base for revision %s in project %s: %+v",
+			revision, gRepoPoller.ProjectRef.Identifier, err)
+		revisionError = errors.Wrap(revisionError, "unable to find a suggested merge base commit, must fix on projects settings page")
+	} else {
+		revisionDetails.MergeBaseRevision = strings.TrimSpace(baseRevision)
+		revisionError = errors.Wrapf(revisionError, "suggested base revision %v found, must confirm on project settings page",
+			revisionDetails.MergeBaseRevision)
+	}
+
+	gRepoPoller.ProjectRef.RepotrackerError = revisionDetails
+	if err := gRepoPoller.ProjectRef.Upsert(); err != nil {
+		return []model.Revision{}, errors.Wrap(err, "unable to update projectRef revision details")
+	}
+
+	return []model.Revision{}, revisionError
+}
+
+// GetRecentRevisions fetches the most recent 'maxRevisions' commits on the
+// ProjectRef's branch
+func (gRepoPoller *GitRepositoryPoller) GetRecentRevisions(maxRevisions int) ([]model.Revision, error) {
+	branchRef, err := gRepoPoller.update()
+	if err != nil {
+		return nil, errors.Wrap(err, "error updating repository")
+	}
+	return gRepoPoller.log("--max-count="+strconv.Itoa(maxRevisions), branchRef)
+}
@@ -0,0 +1,264 @@
+package repotracker
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const gitFixtureConfig = `
+buildvariants:
+- name: linux
+  display_name: Linux
+  run_on:
+  - test-distro-one
+  tasks:
+  - name: compile
+tasks:
+- name: compile
+  commands:
+  - command: shell.exec
+    params:
+      script: make
+`
+
+// gitFixtureTime is when the first commit of a git fixture is committed. Each
+// later commit is committed an hour after the one before it.
+var gitFixtureTime = time.Date(2017, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// gitFixture creates a git repository in a temporary directory containing
+// an evergreen configuration file and the given number of commits, returning
+// the path to the repository and the commit hashes with the most recent first.
+func gitFixture(t *testing.T, numCommits int) (string, []string) {
+	dir, err := ioutil.TempDir("", "git-poller-test")
+	testutil.HandleTestingErr(err, t, "error creating temp dir")
+
+	commitTime := gitFixtureTime
+	run := func(args ...string) string {
+		return runGit(t, dir, commitTime, args...)
+	}
+
+	run("init", "-q")
+	run("checkout", "-q", "-b", "master")
+	testutil.HandleTestingErr(ioutil.WriteFile(filepath.Join(dir, "evergreen.yml"),
+		[]byte(gitFixtureConfig), 0644), t, "error writing config")
+
+	revisions := []string{}
+	for i := 0; i < numCommits; i++ {
+		fileName := fmt.Sprintf("file%c", 'a'+i)
+		testutil.HandleTestingErr(ioutil.WriteFile(filepath.Join(dir, fileName),
+			[]byte(fileName), 0644), t, "error writing file")
+		run("add", "-A")
+		commitTime = gitFixtureTime.Add(time.Duration(i) * time.Hour)
+		run("commit", "-q", "-m", "commit "+fileName)
+		revisions = append([]string{run("rev-parse", "HEAD")}, revisions...)
+	}
+	return dir, revisions
+}
+
+// runGit runs a git command in dir as the fixture's author, committing at
+// commitTime, and returns its trimmed output
+func runGit(t *testing.T, dir string, commitTime time.Time, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=author@example.com",
+		"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=author@example.com",
+		"GIT_COMMITTER_DATE="+commitTime.Format(time.RFC3339))
+	out, err := cmd.CombinedOutput()
+	testutil.HandleTestingErr(err, t, "error running git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
+
+func gitProjectRef(repoPath string) *model.ProjectRef {
+	return &model.ProjectRef{
+		Identifier: "git-test",
+		Branch:     "master",
+		RepoKind:   model.GitRepoType,
+		RepoPath:   repoPath,
+		RemotePath: "evergreen.yml",
+		Enabled:    true,
+		BatchTime:  60,
+		Tracked:    true,
+	}
+}
+
+func TestGitRepositoryPoller(t *testing.T) {
+	dir, revisions := gitFixture(t, 3)
+	defer os.RemoveAll(dir)
+
+	Convey("With a GitRepositoryPoller for a local repository", t, func() {
+		poller := NewGitRepositoryPoller(gitProjectRef(dir))
+
+		Convey("fetching recent revisions should return the most recent first", func() {
+			revs, err := poller.GetRecentRevisions(2)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 2)
+			So(revs[0].Revision, ShouldEqual, revisions[0])
+			So(revs[1].Revision, ShouldEqual, revisions[1])
+			So(revs[0].Author, ShouldEqual, "Test Author")
+			So(revs[0].AuthorEmail, ShouldEqual, "author@example.com")
+			So(revs[0].RevisionMessage, ShouldEqual, "commit filec")
+		})
+
+		Convey("revisions should be created when they were committed", func() {
+			revs, err := poller.GetRecentRevisions(3)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 3)
+			So(revs[0].CreateTime.Equal(gitFixtureTime.Add(2*time.Hour)), ShouldBeTrue)
+			So(revs[2].CreateTime.Equal(gitFixtureTime), ShouldBeTrue)
+		})
+
+		Convey("fetching revisions since an ancestor should return only newer commits", func() {
+			revs, err := poller.GetRevisionsSince(revisions[2], 10)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 2)
+			So(revs[0].Revision, ShouldEqual, revisions[0])
+			So(revs[1].Revision, ShouldEqual, revisions[1])
+
+			revs, err = poller.GetRevisionsSince(revisions[0], 10)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 0)
+		})
+
+		Convey("fetching changed files should list the files in the commit", func() {
+			files, err := poller.GetChangedFiles(revisions[1])
+			So(err, ShouldBeNil)
+			So(files, ShouldResemble, []string{"fileb"})
+
+			files, err = poller.GetChangedFiles(revisions[2])
+			So(err, ShouldBeNil)
+			So(files, ShouldResemble, []string{"evergreen.yml", "filea"})
+		})
+
+		Convey("fetching the remote config should parse the project file", func() {
+			project, err := poller.GetRemoteConfig(revisions[0])
+			So(err, ShouldBeNil)
+			So(project.Identifier, ShouldEqual, "git-test")
+			So(len(project.BuildVariants), ShouldEqual, 1)
+			So(len(project.Tasks), ShouldEqual, 1)
+		})
+
+		Convey("fetching a missing remote config should return a FileNotFoundError", func() {
+			poller.ProjectRef.RemotePath = "missing.yml"
+			_, err := poller.GetRemoteConfig(revisions[0])
+			So(thirdparty.IsFileNotFound(err), ShouldBeTrue)
+		})
+	})
+}
+
+func TestGitRepositoryPollerClone(t *testing.T) {
+	dir, revisions := gitFixture(t, 2)
+	defer os.RemoveAll(dir)
+	tmp, err := ioutil.TempDir("", "git-poller-clone-test")
+	testutil.HandleTestingErr(err, t, "error creating temp dir")
+	defer os.RemoveAll(tmp)
+
+	// the fixture pushes to a bare remote, which the poller's clone fetches from
+	remote := filepath.Join(tmp, "remote.git")
+	clone := filepath.Join(tmp, "clone")
+	runGit(t, tmp, gitFixtureTime, "clone", "-q", "--bare", dir, remote)
+	runGit(t, tmp, gitFixtureTime, "clone", "-q", remote, clone)
+	runGit(t, dir, gitFixtureTime, "remote", "add", "origin", remote)
+
+	Convey("With a GitRepositoryPoller for a clone of a remote repository", t, func() {
+		poller := NewGitRepositoryPoller(gitProjectRef(clone))
+
+		Convey("commits pushed to the remote should be fetched", func() {
+			revs, err := poller.GetRecentRevisions(10)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 2)
+			So(revs[0].Revision, ShouldEqual, revisions[0])
+
+			commitTime := gitFixtureTime.Add(2 * time.Hour)
+			testutil.HandleTestingErr(ioutil.WriteFile(filepath.Join(dir, "filec"),
+				[]byte("filec"), 0644), t, "error writing file")
+			runGit(t, dir, commitTime, "add", "-A")
+			runGit(t, dir, commitTime, "commit", "-q", "-m", "commit filec")
+			runGit(t, dir, commitTime, "push", "-q", "origin", "master")
+			newRevision := runGit(t, dir, commitTime, "rev-parse", "HEAD")
+
+			revs, err = poller.GetRecentRevisions(10)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 3)
+			So(revs[0].Revision, ShouldEqual, newRevision)
+			So(revs[0].RevisionMessage, ShouldEqual, "commit filec")
+
+			revs, err = poller.GetRevisionsSince(revisions[0], 10)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 1)
+			So(revs[0].Revision, ShouldEqual, newRevision)
+		})
+	})
+}
+
+func TestFetchRevisionsFromGitRepository(t *testing.T) {
+	dropTestDB(t)
+	dir, revisions := gitFixture(t, 3)
+	defer os.RemoveAll(dir)
+
+	Convey("With a RepoTracker using a GitRepositoryPoller", t, func() {
+		ref := gitProjectRef(dir)
+		So(ref.Insert(), ShouldBeNil)
+		So((&distro.Distro{Id: "test-distro-one"}).Insert(), ShouldBeNil)
+
+		poller, err := NewRepoPoller(ref, testConfig)
+		So(err, ShouldBeNil)
+		repoTracker := RepoTracker{testConfig, ref, poller}
+
+		Convey("fetching revisions should create a version for each commit", func() {
+			So(repoTracker.FetchRevisions(10), ShouldBeNil)
+			numVersions, err := version.Count(version.All)
+			So(err, ShouldBeNil)
+			So(numVersions, ShouldEqual, 3)
+
+			repository, err := model.FindRepository(ref.Identifier)
+			So(err, ShouldBeNil)
+			So(repository.LastRevision, ShouldEqual, revisions[0])
+
+			v, err := version.FindOne(version.ByProjectIdAndRevision(ref.Identifier, revisions[0]))
+			So(err, ShouldBeNil)
+			So(v, ShouldNotBeNil)
+			So(v.RepoKind, ShouldEqual, model.GitRepoType)
+			So(len(v.BuildVariants), ShouldEqual, 1)
+		})
+
+		Reset(func() {
+			dropTestDB(t)
+		})
+	})
+}
+
+func TestParseGitLog(t *testing.T) {
+	Convey("When parsing the output of git log", t, func() {
+		Convey("each record should become a revision created at its commit time", func() {
+			out := "abc\x00Test Author\x00author@example.com\x001488369600\x00first line\n\nbody\n\x1e\n" +
+				"def\x00Other Author\x00other@example.com\x001488366000\x00second\n\x1e"
+			revs, err := parseGitLog(out)
+			So(err, ShouldBeNil)
+			So(len(revs), ShouldEqual, 2)
+			So(revs[0].Revision, ShouldEqual, "abc")
+			So(revs[0].RevisionMessage, ShouldEqual, "first line\n\nbody")
+			So(revs[0].CreateTime.Equal(time.Unix(1488369600, 0)), ShouldBeTrue)
+			So(revs[1].Author, ShouldEqual, "Other Author")
+			So(revs[1].CreateTime.Equal(time.Unix(1488366000, 0)), ShouldBeTrue)
+		})
+		Convey("a record without a valid commit time should be an error", func() {
+			_, err := parseGitLog("abc\x00Test Author\x00author@example.com\x00yesterday\x00msg\x1e")
+			So(err, ShouldNotBeNil)
+			_, err = parseGitLog("abc\x00Test Author\x00msg\x1e")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -57,6 +57,23 @@ type RepoPoller interface {
 	GetRecentRevisions(numNewRepoRevisionsToFetch int) ([]model.Revision, error)
 }
 
+// NewRepoPoller returns the RepoPoller implementation matching the project
+// ref's RepoKind. Projects with no RepoKind set are assumed to be on GitHub.
+func NewRepoPoller(projectRef *model.ProjectRef, settings *evergreen.Settings) (RepoPoller, error) {
+	switch projectRef.RepoKind {
+	case model.GithubRepoType, "":
+		return NewGithubRepositoryPoller(projectRef, settings.Credentials[githubCredentialsKey]), nil
+	case model.GitRepoType:
+		if projectRef.RepoPath == "" {
+			return nil, errors.Errorf("project '%v' has no repository path", projectRef.Identifier)
+		}
+		return NewGitRepositoryPoller(projectRef), nil
+	default:
+		return nil, errors.Errorf("project '%v' has unsupported repo kind '%v'",
+			projectRef.Identifier, projectRef.RepoKind)
+	}
+}
+
 type projectConfigError struct {
 	Errors   []string
 	Warnings []string
@@ -211,6 +228,9 @@ func (repoTracker *RepoTracker) sendFailureNotification(lastRevision string,
 		projectRef.Identifier, lastRevision)
 	url := fmt.Sprintf("%v/%v/%v/commits/%v", thirdparty.GithubBase,
 		projectRef.Owner, projectRef.Repo, projectRef.Branch)
+	if projectRef.RepoKind == model.GitRepoType {
+		url = fmt.Sprintf("%v (branch %v)", projectRef.RepoPath, projectRef.Branch)
+	}
 	message := fmt.Sprintf("Could not find last known revision '%v' "+
 		"within the most recent %v revisions at %v: %v", lastRevision, max, url, err)
 	nErr := notify.NotifyAdmins(subject, message, settings)
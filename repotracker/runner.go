@@ -31,30 +31,24 @@ func (r *Runner) Description() string {
 	return Description
 }
 
-func (r *Runner) Run(config *evergreen.Settings) error {
+// checkGithubAPI returns an error if the GitHub API is unavailable or too few
+// requests remain to poll GitHub projects.
+func checkGithubAPI(config *evergreen.Settings) error {
 	status, err := thirdparty.GetGithubAPIStatus()
 	if err != nil {
-		errM := errors.Wrap(err, "contacting github")
-		grip.Error(errM)
-		return errM
+		return errors.Wrap(err, "contacting github")
 	}
 	if status != thirdparty.GithubAPIStatusGood {
-		errM := errors.Errorf("bad github api status: %v", status)
-		grip.Error(errM)
-		return errM
+		return errors.Errorf("bad github api status: %v", status)
 	}
 
 	token, ok := config.Credentials[githubCredentialsKey]
 	if !ok {
-		err = errors.New("Github credentials not specified in Evergreen credentials file")
-		grip.Error(err)
-		return err
+		return errors.New("Github credentials not specified in Evergreen credentials file")
 	}
 	remaining, err := thirdparty.CheckGithubAPILimit(token)
 	if err != nil {
-		err = errors.Wrap(err, "Error checking Github API limit")
-		grip.Error(err)
-		return err
+		return errors.Wrap(err, "Error checking Github API limit")
 	}
 	if remaining < githubAPILimitCeiling {
 		err = errors.Errorf("Too few Github API requests remaining: %d < %d", remaining, githubAPILimitCeiling)
@@ -62,7 +56,10 @@ func (r *Runner) Run(config *evergreen.Settings) error {
 		return err
 	}
 	grip.Debugf("%d Github API requests remaining", remaining)
+	return nil
+}
 
+func (r *Runner) Run(config *evergreen.Settings) error {
 	lockAcquired, err := db.WaitTillAcquireGlobalLock(RunnerName, db.LockTimeout)
 	if err != nil {
 		err = errors.Wrap(err, "Error acquiring global lock")
@@ -97,20 +94,41 @@ func (r *Runner) Run(config *evergreen.Settings) error {
 		numNewRepoRevisionsToFetch = DefaultNumNewRepoRevisionsToFetch
 	}
 
+	// only GitHub projects depend on the GitHub API, so projects tracking
+	// local repositories are still polled when it is unavailable
+	var githubErr error
+	for _, projectRef := range allProjects {
+		if projectRef.RepoKind == model.GithubRepoType || projectRef.RepoKind == "" {
+			if githubErr = checkGithubAPI(config); githubErr != nil {
+				grip.Error(githubErr)
+			}
+			break
+		}
+	}
+
 	var wg sync.WaitGroup
-	wg.Add(len(allProjects))
 	for _, projectRef := range allProjects {
+		if githubErr != nil && (projectRef.RepoKind == model.GithubRepoType || projectRef.RepoKind == "") {
+			grip.Warningf("Skipping github project %s: %v", projectRef.Identifier, githubErr)
+			continue
+		}
+
+		wg.Add(1)
 		go func(projectRef model.ProjectRef) {
 			defer wg.Done()
 
+			poller, err := NewRepoPoller(&projectRef, config)
+			if err != nil {
+				grip.Errorln("Error creating repository poller:", err)
+				return
+			}
 			tracker := &RepoTracker{
 				config,
 				&projectRef,
-				NewGithubRepositoryPoller(&projectRef, config.Credentials["github"]),
+				poller,
 			}
 
-			err = tracker.FetchRevisions(numNewRepoRevisionsToFetch)
-			if err != nil {
+			if err = tracker.FetchRevisions(numNewRepoRevisionsToFetch); err != nil {
 				grip.Errorln("Error fetching revisions:", err)
 			}
 		}(projectRef)
@@ -124,5 +142,5 @@ func (r *Runner) Run(config *evergreen.Settings) error {
 		return err
 	}
 	grip.Infof("Repository tracker took %s to run", runtime)
-	return nil
+	return githubErr
 }
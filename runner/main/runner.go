@@ -3,20 +3,24 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"text/tabwriter"
 	"text/template"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/notify"
 	_ "github.com/evergreen-ci/evergreen/plugin/config"
 	. "github.com/evergreen-ci/evergreen/runner"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/level"
@@ -40,6 +44,17 @@ Supported proccesses are:{{range .Runners}}
 Pass a single process name to run that process once,
 or leave [process name] blank to run all processes
 at regular intervals.
+
+Pass "scheduler simulate [simulation flags]" to replay
+historical tasks through the scheduler without spawning
+hosts; pass "scheduler simulate -h" to list its flags.
+
+Pass "tasklogs migrate [migration flags]" to move task logs
+out of MongoDB and into the configured log storage; pass
+"tasklogs migrate -h" to list its flags.
+
+Pass "notifications migrate" to create subscriptions for
+the recipients and teams in the notifications file.
 `))
 
 	flag.Usage = func() {
@@ -88,6 +103,24 @@ func main() {
 
 	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(settings))
 
+	// run the scheduler against historical data instead of any process
+	if flag.Arg(0) == scheduler.RunnerName && flag.Arg(1) == "simulate" {
+		grip.CatchEmergencyFatal(runSchedulerSimulation(flag.Args()[2:], settings))
+		return
+	}
+
+	// move task logs into the configured log storage instead of any process
+	if flag.Arg(0) == "tasklogs" && flag.Arg(1) == "migrate" {
+		grip.CatchEmergencyFatal(migrateTaskLogs(flag.Args()[2:], settings))
+		return
+	}
+
+	// turn the notifications file into subscriptions instead of any process
+	if flag.Arg(0) == "notifications" && flag.Arg(1) == "migrate" {
+		grip.CatchEmergencyFatal(migrateNotifications(settings))
+		return
+	}
+
 	// just run one process if an argument was passed in
 	if flag.Arg(0) != "" {
 		grip.CatchEmergencyFatal(runProcessByName(flag.Arg(0), settings))
@@ -172,3 +205,106 @@ func runProcessByName(name string, settings *evergreen.Settings) error {
 	}
 	return errors.Errorf("process '%s' does not exist", name)
 }
+
+// runSchedulerSimulation parses the simulation flags, replays the requested
+// window of tasks through the scheduler and prints the resulting report.
+func runSchedulerSimulation(args []string, settings *evergreen.Settings) error {
+	simulator := scheduler.NewSimulator(settings)
+
+	flags := flag.NewFlagSet("scheduler simulate", flag.ExitOnError)
+	start := flags.String("start", "", "start of the window of scheduled tasks to replay, in RFC 3339 format (default: -window before -end)")
+	end := flags.String("end", "", "end of the window of scheduled tasks to replay, in RFC 3339 format (default: now)")
+	window := flags.Duration("window", 24*time.Hour, "length of the window to replay when -start is not set")
+	distroId := flags.String("distro", "", "only replay tasks for this distro")
+	flags.DurationVar(&simulator.Interval, "interval", simulator.Interval, "simulated time between scheduler runs")
+	flags.DurationVar(&simulator.HostStartupTime, "host-startup", simulator.HostStartupTime, "simulated time for a new host to become available")
+	flags.DurationVar(&simulator.IdleHostTimeout, "idle-timeout", simulator.IdleHostTimeout, "simulated time before an idle host is terminated")
+	flags.DurationVar(&simulator.MaxDrainTime, "max-drain", simulator.MaxDrainTime, "maximum simulated time after the window to finish queued tasks")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	verbose := flags.Bool("verbose", false, "log the scheduler's decisions during the simulation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	endTime := time.Now()
+	if *end != "" {
+		var err error
+		if endTime, err = time.Parse(time.RFC3339, *end); err != nil {
+			return errors.Wrap(err, "invalid -end")
+		}
+	}
+	startTime := endTime.Add(-*window)
+	if *start != "" {
+		var err error
+		if startTime, err = time.Parse(time.RFC3339, *start); err != nil {
+			return errors.Wrap(err, "invalid -start")
+		}
+	}
+
+	if !*verbose {
+		grip.SetThreshold(level.Warning)
+	}
+
+	report, err := simulator.Simulate(startTime, endTime, *distroId)
+	if err != nil {
+		return errors.Wrap(err, "error running scheduler simulation")
+	}
+
+	if *asJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "error marshalling simulation report")
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Printf("Simulated tasks scheduled between %v and %v (scheduler interval %v)\n\n",
+		report.Start.Format(time.RFC3339), report.End.Format(time.RFC3339), report.Interval)
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "DISTRO\tTASKS\tNOT STARTED\tMAKESPAN (ACTUAL/SIMULATED/PREDICTED)\t"+
+		"AVG WAIT (ACTUAL/SIMULATED)\tMAX WAIT (ACTUAL/SIMULATED)\tHOSTS (MAX/AVG/SPAWNED)")
+	for _, d := range report.Distros {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%v / %v / %v\t%v / %v\t%v / %v\t%d / %.1f / %d\n",
+			d.DistroId, d.NumTasks, d.NumNotStarted,
+			d.ActualMakespan, d.SimulatedMakespan, d.PredictedMakespan,
+			d.ActualAvgWait, d.SimulatedAvgWait,
+			d.ActualMaxWait, d.SimulatedMaxWait,
+			d.MaxHosts, d.AvgHosts, d.HostsSpawned)
+	}
+	return w.Flush()
+}
+
+// migrateTaskLogs moves task logs stored in MongoDB into the log storage
+// the settings configure.
+func migrateTaskLogs(args []string, settings *evergreen.Settings) error {
+	flags := flag.NewFlagSet("tasklogs migrate", flag.ExitOnError)
+	olderThan := flags.Duration("older-than", 0, "only migrate logs written longer ago than this")
+	limit := flags.Int("limit", 0, "maximum number of log chunks to migrate (default: all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	storage, err := model.NewTaskLogStorage(settings.LogStorage)
+	if err != nil {
+		return errors.Wrap(err, "error configuring log storage")
+	}
+
+	migrated, err := model.MigrateTaskLogs(storage, time.Now().Add(-*olderThan), *limit)
+	if err != nil {
+		return errors.Wrapf(err, "error migrating task logs after migrating %d chunks", migrated)
+	}
+	fmt.Printf("Migrated %d task log chunks to %s log storage\n", migrated, settings.LogStorage.Backend)
+	return nil
+}
+
+// migrateNotifications creates subscriptions for the notifications file,
+// which the notifier no longer reads.
+func migrateNotifications(settings *evergreen.Settings) error {
+	created, err := notify.MigrateNotificationsFile(settings.ConfigDir)
+	if err != nil {
+		return errors.Wrapf(err, "error migrating notifications after creating %d subscriptions", created)
+	}
+	fmt.Printf("Created %d subscriptions from the notifications file\n", created)
+	return nil
+}
@@ -6,6 +6,7 @@ package runner
 import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/alerts"
+	"github.com/evergreen-ci/evergreen/flakytests"
 	"github.com/evergreen-ci/evergreen/hostinit"
 	"github.com/evergreen-ci/evergreen/monitor"
 	"github.com/evergreen-ci/evergreen/notify"
@@ -35,5 +36,6 @@ var (
 		&taskrunner.Runner{},
 		&alerts.QueueProcessor{},
 		&scheduler.Runner{},
+		&flakytests.Runner{},
 	}
 )
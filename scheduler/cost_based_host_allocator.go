@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// CostBasedHostAllocator asks the duration-based allocator how many hosts
+// each distro needs, then limits that number so that the distro's hosts stay
+// within its hourly budget. Distros that do not select the cost-based
+// allocator, or whose provider cannot compute costs, are not limited.
+type CostBasedHostAllocator struct {
+	projectedCosts map[string]event.HostCostInfo
+}
+
+// NewHostsNeeded returns the number of hosts to spawn for each distro,
+// limited by the distros' hourly budgets.
+func (self *CostBasedHostAllocator) NewHostsNeeded(
+	hostAllocatorData HostAllocatorData, settings *evergreen.Settings) (map[string]int, error) {
+
+	newHostsNeeded, err := (&DurationBasedHostAllocator{}).NewHostsNeeded(hostAllocatorData, settings)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	self.projectedCosts = make(map[string]event.HostCostInfo)
+	for distroId, numNewHosts := range newHostsNeeded {
+		d := hostAllocatorData.distros[distroId]
+		if d.HostAllocatorSettings.Name != distro.HostAllocatorCost {
+			continue
+		}
+		newHostsNeeded[distroId] = self.numNewHostsForDistro(&hostAllocatorData, d, numNewHosts, settings)
+	}
+	return newHostsNeeded, nil
+}
+
+// ProjectedCosts returns the hourly spend projected for each distro limited
+// by a budget in the last call to NewHostsNeeded.
+func (self *CostBasedHostAllocator) ProjectedCosts() map[string]event.HostCostInfo {
+	return self.projectedCosts
+}
+
+// numNewHostsForDistro limits the number of hosts wanted for a distro to what
+// its budget allows, and records the distro's projected spend.
+func (self *CostBasedHostAllocator) numNewHostsForDistro(hostAllocatorData *HostAllocatorData,
+	d distro.Distro, numWanted int, settings *evergreen.Settings) int {
+
+	if self.projectedCosts == nil {
+		self.projectedCosts = make(map[string]event.HostCostInfo)
+	}
+
+	cloudManager, err := hostAllocatorData.getCloudManager(d, settings)
+	if err != nil {
+		grip.Errorf("Couldn't get cloud manager for distro %s with provider %s: %+v",
+			d.Id, d.Provider, err)
+		return 0
+	}
+	calc, ok := cloudManager.(cloud.CloudCostCalculator)
+	if !ok {
+		grip.Warningf("Distro %s has an hourly budget, but provider %s cannot compute costs; "+
+			"not limiting its hosts", d.Id, d.Provider)
+		return numWanted
+	}
+
+	now := hostAllocatorData.currentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	existingDistroHosts := hostAllocatorData.existingDistroHosts[d.Id]
+	hostCosts := make([]float64, 0, len(existingDistroHosts))
+	var paidTime time.Duration
+	for i := range existingDistroHosts {
+		h := &existingDistroHosts[i]
+		cost, err := hourlyCost(calc, h, now)
+		if err != nil {
+			grip.Errorf("Error computing the hourly cost of host %s: %+v", h.Id, err)
+			continue
+		}
+		hostCosts = append(hostCosts, cost)
+		paidTime += cloudManager.TimeTilNextPayment(h)
+	}
+
+	var queuedWork time.Duration
+	for _, item := range hostAllocatorData.taskQueueItems[d.Id] {
+		queuedWork += item.ExpectedDuration
+	}
+
+	costInfo := allowedNewHosts(numWanted, d.HostAllocatorSettings.HourlyBudget,
+		hostCosts, paidTime, queuedWork)
+	self.projectedCosts[d.Id] = costInfo
+
+	grip.Infof("Distro %s wants %d new hosts, its budget of $%.2f/hour allows %d "+
+		"(currently $%.2f/hour, projected $%.2f/hour)", d.Id, numWanted, costInfo.HourlyBudget,
+		costInfo.NewHostsAllowed, costInfo.CurrentHourlyCost, costInfo.ProjectedHourlyCost)
+	return costInfo.NewHostsAllowed
+}
+
+// allowedNewHosts decides how many of the wanted hosts a distro may spawn.
+// No hosts are spawned once the distro's hourly cost has reached its budget.
+// Since a new host is billed for a full payment period as soon as it is
+// spawned, no hosts are spawned while the queued work fits in the time already
+// paid for on the existing hosts either. Otherwise new hosts, assumed to cost
+// as much as the most expensive existing host, are spawned while the distro's
+// hourly cost stays within its budget. With no existing hosts to estimate the
+// cost from, a single host is spawned.
+func allowedNewHosts(numWanted int, budget float64, hostCosts []float64,
+	paidTime, queuedWork time.Duration) event.HostCostInfo {
+
+	costInfo := event.HostCostInfo{
+		HourlyBudget:   budget,
+		NewHostsWanted: numWanted,
+	}
+	var newHostCost float64
+	for _, cost := range hostCosts {
+		costInfo.CurrentHourlyCost += cost
+		if cost > newHostCost {
+			newHostCost = cost
+		}
+	}
+	costInfo.ProjectedHourlyCost = costInfo.CurrentHourlyCost
+
+	if numWanted <= 0 || costInfo.CurrentHourlyCost >= budget {
+		return costInfo
+	}
+	if len(hostCosts) > 0 && queuedWork <= paidTime {
+		costInfo.Waiting = true
+		return costInfo
+	}
+
+	numAllowed := numWanted
+	if len(hostCosts) == 0 {
+		numAllowed = 1
+	} else if newHostCost > 0 {
+		// allow for rounding errors in the costs
+		numAllowed = int((budget-costInfo.CurrentHourlyCost)/newHostCost + 1e-9)
+	}
+	if numAllowed < 0 {
+		numAllowed = 0
+	}
+	costInfo.NewHostsAllowed = util.Min(numWanted, numAllowed)
+	costInfo.ProjectedHourlyCost += float64(costInfo.NewHostsAllowed) * newHostCost
+	return costInfo
+}
+
+// hourlyCost estimates what a host costs per hour from its cost over the last
+// hour, or over its lifetime if it is younger than that.
+func hourlyCost(calc cloud.CloudCostCalculator, h *host.Host, now time.Time) (float64, error) {
+	start := now.Add(-time.Hour)
+	if h.CreationTime.After(start) {
+		start = h.CreationTime
+	}
+	if !now.After(start) {
+		return 0, errors.Errorf("host %s was created in the future", h.Id)
+	}
+	cost, err := calc.CostForDuration(h, start, now)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return cost * float64(time.Hour) / float64(now.Sub(start)), nil
+}
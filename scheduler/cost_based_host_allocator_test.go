@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAllowedNewHosts(t *testing.T) {
+	Convey("When limiting new hosts to a distro's hourly budget", t, func() {
+
+		Convey("hosts should be spawned while the budget allows it", func() {
+			info := allowedNewHosts(5, 10, []float64{2, 3}, time.Hour, 3*time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 1)
+			So(info.NewHostsWanted, ShouldEqual, 5)
+			So(info.CurrentHourlyCost, ShouldEqual, 5)
+			So(info.ProjectedHourlyCost, ShouldEqual, 8)
+			So(info.Waiting, ShouldBeFalse)
+
+			info = allowedNewHosts(2, 100, []float64{2, 3}, time.Hour, 3*time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 2)
+			So(info.ProjectedHourlyCost, ShouldEqual, 11)
+		})
+
+		Convey("no hosts should be spawned over budget", func() {
+			info := allowedNewHosts(3, 4, []float64{2, 3}, time.Hour, 3*time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 0)
+			So(info.ProjectedHourlyCost, ShouldEqual, 5)
+		})
+
+		Convey("no hosts should be spawned once the budget is spent", func() {
+			info := allowedNewHosts(3, 5, []float64{2, 3}, time.Hour, 3*time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 0)
+			info = allowedNewHosts(3, 0, nil, 0, time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 0)
+			info = allowedNewHosts(3, 0, []float64{0}, 0, time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 0)
+		})
+
+		Convey("no hosts should be spawned while the queue fits in already paid time", func() {
+			info := allowedNewHosts(3, 100, []float64{2}, 2*time.Hour, time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 0)
+			So(info.Waiting, ShouldBeTrue)
+		})
+
+		Convey("a single host should be spawned when there are no hosts to estimate from", func() {
+			info := allowedNewHosts(3, 1, nil, 0, time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 1)
+		})
+
+		Convey("free hosts should not be limited", func() {
+			info := allowedNewHosts(3, 1, []float64{0}, 0, time.Hour)
+			So(info.NewHostsAllowed, ShouldEqual, 3)
+			So(info.ProjectedHourlyCost, ShouldEqual, 0)
+		})
+	})
+}
@@ -2,7 +2,6 @@ package scheduler
 
 import (
 	"github.com/evergreen-ci/evergreen"
-	"github.com/evergreen-ci/evergreen/cloud/providers"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/util"
@@ -48,7 +47,7 @@ func (self *DeficitBasedHostAllocator) NewHostsNeeded(
 func (self *DeficitBasedHostAllocator) numNewHostsForDistro(
 	hostAllocatorData *HostAllocatorData, distro distro.Distro, settings *evergreen.Settings) int {
 
-	cloudManager, err := providers.GetCloudManager(distro.Provider, settings)
+	cloudManager, err := hostAllocatorData.getCloudManager(distro, settings)
 
 	if err != nil {
 		grip.Errorf("Couldn't get cloud manager for distro %s with provider %s: %+v",
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
-	"github.com/evergreen-ci/evergreen/cloud/providers"
 	"github.com/evergreen-ci/evergreen/cloud/providers/static"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
@@ -192,6 +191,17 @@ func computeScheduledTasksDuration(
 func computeRunningTasksDuration(existingDistroHosts []host.Host,
 	taskDurations model.ProjectTaskDurations) (runningTasksDuration float64,
 	err error) {
+	return computeRunningTasksDurationAt(existingDistroHosts, taskDurations,
+		nil, time.Now())
+}
+
+// computeRunningTasksDurationAt returns the estimated time to completion, as
+// of the given time, of all currently running tasks for a given distro given
+// its hosts. If runningTasksMap is nil, the running tasks are fetched from
+// the database.
+func computeRunningTasksDurationAt(existingDistroHosts []host.Host,
+	taskDurations model.ProjectTaskDurations, runningTasksMap map[string]task.Task,
+	now time.Time) (runningTasksDuration float64, err error) {
 
 	runningTaskIds := []string{}
 
@@ -207,15 +217,17 @@ func computeRunningTasksDuration(existingDistroHosts []host.Host,
 		return
 	}
 
-	runningTasksMap := make(map[string]task.Task)
-	runningTasks, err := task.Find(task.ByIds(runningTaskIds))
-	if err != nil {
-		return runningTasksDuration, err
-	}
+	if runningTasksMap == nil {
+		runningTasks, err := task.Find(task.ByIds(runningTaskIds))
+		if err != nil {
+			return runningTasksDuration, err
+		}
 
-	// build a map of task id => task
-	for _, runningTask := range runningTasks {
-		runningTasksMap[runningTask.Id] = runningTask
+		// build a map of task id => task
+		runningTasksMap = make(map[string]task.Task)
+		for _, runningTask := range runningTasks {
+			runningTasksMap[runningTask.Id] = runningTask
+		}
 	}
 
 	// compute the total time to completion for running tasks
@@ -227,7 +239,7 @@ func computeRunningTasksDuration(existingDistroHosts []host.Host,
 		}
 		expectedDuration := model.GetTaskExpectedDuration(runningTask,
 			taskDurations)
-		elapsedTime := time.Since(runningTask.StartTime)
+		elapsedTime := now.Sub(runningTask.StartTime)
 		if elapsedTime > expectedDuration {
 			// probably an outlier; or an unknown data point
 			continue
@@ -433,8 +445,12 @@ func (self *DurationBasedHostAllocator) numNewHostsForDistro(
 
 	// determine the total remaining running time of all
 	// tasks currently running on the hosts for this distro
-	runningTasksDuration, err := computeRunningTasksDuration(
-		existingDistroHosts, projectTaskDurations)
+	now := hostAllocatorData.currentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	runningTasksDuration, err := computeRunningTasksDurationAt(
+		existingDistroHosts, projectTaskDurations, hostAllocatorData.runningTasks, now)
 
 	if err != nil {
 		return numNewHosts, err
@@ -476,7 +492,7 @@ func (self *DurationBasedHostAllocator) numNewHostsForDistro(
 		totalTasksDuration:   scheduledTasksDuration + runningTasksDuration,
 	}
 
-	cloudManager, err := providers.GetCloudManager(distro.Provider, settings)
+	cloudManager, err := hostAllocatorData.getCloudManager(distro, settings)
 	if err != nil {
 		err = errors.Wrapf(err, "Couldn't get cloud manager for %s (%s)",
 			distro.Provider, distro.Id)
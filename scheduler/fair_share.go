@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// defaultFairShareWindow is the window of completed tasks used to compute
+// each project's recent host time if none is configured
+const defaultFairShareWindow = 24 * time.Hour
+
+// fairShareData holds the per-project information needed to interleave a
+// distro's queue across projects in fair-share mode.
+type fairShareData struct {
+	// weights maps project identifier to its share weight
+	weights map[string]int
+	// usage maps project identifier to the host time its tasks have
+	// recently consumed
+	usage map[string]time.Duration
+}
+
+// weight returns the share weight of the project, defaulting to 1 for
+// projects without a project ref.
+func (fs *fairShareData) weight(project string) int {
+	if w, ok := fs.weights[project]; ok {
+		return w
+	}
+	return 1
+}
+
+// findFairShareData fetches the share weight of every project and the host
+// time used by each project's tasks over the configured window.
+func findFairShareData(settings *evergreen.Settings) (*fairShareData, error) {
+	window := defaultFairShareWindow
+	if settings.Scheduler.FairShareWindowHours > 0 {
+		window = time.Duration(settings.Scheduler.FairShareWindowHours) * time.Hour
+	}
+
+	projectRefs, err := model.FindAllProjectRefs()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding project refs")
+	}
+	usage, err := task.TimeTakenByProject(window)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding host time used by projects")
+	}
+
+	data := &fairShareData{
+		weights: make(map[string]int),
+		usage:   usage,
+	}
+	for _, ref := range projectRefs {
+		data.weights[ref.Identifier] = ref.GetShareWeight()
+	}
+	return data, nil
+}
+
+// fairShareOrder interleaves prioritized tasks across projects so that each
+// project receives host time in proportion to its share weight. Each project
+// starts with a virtual time of its recent usage divided by its weight; the
+// project with the lowest virtual time contributes its next task, in
+// prioritized order, and its virtual time advances by that task's expected
+// duration divided by its weight. Tasks above the maximum priority stay at
+// the front of the queue. Returns the reordered tasks along with the share
+// and usage of every project with tasks in the queue.
+func fairShareOrder(prioritizedTasks []task.Task, data *fairShareData,
+	durations model.ProjectTaskDurations) ([]task.Task, []event.ProjectShareInfo) {
+
+	ordered := make([]task.Task, 0, len(prioritizedTasks))
+	tasksByProject := make(map[string][]task.Task)
+	projects := []string{}
+	for _, t := range prioritizedTasks {
+		if t.Priority > evergreen.MaxTaskPriority {
+			ordered = append(ordered, t)
+			continue
+		}
+		if _, ok := tasksByProject[t.Project]; !ok {
+			projects = append(projects, t.Project)
+		}
+		tasksByProject[t.Project] = append(tasksByProject[t.Project], t)
+	}
+
+	var totalWeight int
+	var totalUsage time.Duration
+	virtualTime := make(map[string]float64)
+	for _, project := range projects {
+		weight := data.weight(project)
+		totalWeight += weight
+		totalUsage += data.usage[project]
+		virtualTime[project] = float64(data.usage[project]) / float64(weight)
+	}
+
+	shares := make([]event.ProjectShareInfo, 0, len(projects))
+	for _, project := range projects {
+		info := event.ProjectShareInfo{
+			Project:      project,
+			ShareWeight:  data.weight(project),
+			Share:        float64(data.weight(project)) / float64(totalWeight),
+			HostTimeUsed: data.usage[project],
+			NumTasks:     len(tasksByProject[project]),
+		}
+		if totalUsage > 0 {
+			info.Usage = float64(data.usage[project]) / float64(totalUsage)
+		}
+		shares = append(shares, info)
+	}
+
+	for len(ordered) < len(prioritizedTasks) {
+		next := ""
+		for _, project := range projects {
+			if len(tasksByProject[project]) == 0 {
+				continue
+			}
+			if next == "" || virtualTime[project] < virtualTime[next] {
+				next = project
+			}
+		}
+
+		t := tasksByProject[next][0]
+		tasksByProject[next] = tasksByProject[next][1:]
+		ordered = append(ordered, t)
+		virtualTime[next] += float64(model.GetTaskExpectedDuration(t, durations)) /
+			float64(data.weight(next))
+	}
+
+	return ordered, shares
+}
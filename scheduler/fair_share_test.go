@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFairShareOrder(t *testing.T) {
+
+	Convey("With tasks from two projects prioritized back to back", t, func() {
+		tasks := []task.Task{
+			{Id: "a1", Project: "a", DisplayName: "t"},
+			{Id: "a2", Project: "a", DisplayName: "t"},
+			{Id: "a3", Project: "a", DisplayName: "t"},
+			{Id: "a4", Project: "a", DisplayName: "t"},
+			{Id: "b1", Project: "b", DisplayName: "t"},
+			{Id: "b2", Project: "b", DisplayName: "t"},
+		}
+		durations := model.ProjectTaskDurations{}
+		ids := func(tasks []task.Task) []string {
+			out := []string{}
+			for _, t := range tasks {
+				out = append(out, t.Id)
+			}
+			return out
+		}
+
+		Convey("equal weights and no usage should alternate between projects", func() {
+			data := &fairShareData{weights: map[string]int{}, usage: map[string]time.Duration{}}
+			ordered, shares := fairShareOrder(tasks, data, durations)
+			So(ids(ordered), ShouldResemble, []string{"a1", "b1", "a2", "b2", "a3", "a4"})
+			So(len(shares), ShouldEqual, 2)
+			So(shares[0].Project, ShouldEqual, "a")
+			So(shares[0].Share, ShouldEqual, 0.5)
+			So(shares[0].NumTasks, ShouldEqual, 4)
+			So(shares[1].NumTasks, ShouldEqual, 2)
+		})
+
+		Convey("a project with a higher weight should receive more of the queue", func() {
+			data := &fairShareData{weights: map[string]int{"b": 2}, usage: map[string]time.Duration{}}
+			ordered, shares := fairShareOrder(tasks, data, durations)
+			So(ids(ordered), ShouldResemble, []string{"a1", "b1", "b2", "a2", "a3", "a4"})
+			So(shares[1].ShareWeight, ShouldEqual, 2)
+			So(shares[1].Share, ShouldAlmostEqual, 2.0/3.0)
+		})
+
+		Convey("a project that has recently used more host time should wait", func() {
+			data := &fairShareData{
+				weights: map[string]int{},
+				usage:   map[string]time.Duration{"a": 20 * time.Minute},
+			}
+			ordered, shares := fairShareOrder(tasks, data, durations)
+			So(ids(ordered), ShouldResemble, []string{"b1", "b2", "a1", "a2", "a3", "a4"})
+			So(shares[0].Usage, ShouldEqual, 1.0)
+			So(shares[0].HostTimeUsed, ShouldEqual, 20*time.Minute)
+			So(shares[1].Usage, ShouldEqual, 0.0)
+		})
+
+		Convey("tasks above the maximum priority should stay at the front", func() {
+			tasks[5].Priority = 101
+			data := &fairShareData{weights: map[string]int{}, usage: map[string]time.Duration{}}
+			ordered, _ := fairShareOrder(tasks, data, durations)
+			So(ids(ordered), ShouldResemble, []string{"b2", "a1", "b1", "a2", "a3", "a4"})
+		})
+	})
+}
@@ -1,10 +1,17 @@
 package scheduler
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/cloud/providers"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
 )
 
 // HostAllocator is responsible for determining how many new hosts should be spun up.
@@ -27,4 +34,66 @@ type HostAllocatorData struct {
 	taskRunDistros       map[string][]string
 	distros              map[string]distro.Distro
 	projectTaskDurations model.ProjectTaskDurations
+
+	// runningTasks and currentTime are only set when simulating against
+	// historical data; otherwise the tasks running on existing hosts are
+	// fetched from the database and measured against the current time
+	runningTasks map[string]task.Task
+	currentTime  time.Time
+
+	// cloudManager is only set when simulating, so that the allocators get
+	// simulated cloud managers instead of calling out to the real providers
+	cloudManager func(distro.Distro, *evergreen.Settings) (cloud.CloudManager, error)
+}
+
+// getCloudManager returns the cloud manager the allocators should use for
+// the given distro.
+func (self *HostAllocatorData) getCloudManager(d distro.Distro,
+	settings *evergreen.Settings) (cloud.CloudManager, error) {
+	if self.cloudManager != nil {
+		return self.cloudManager(d, settings)
+	}
+	return providers.GetCloudManager(d.Provider, settings)
+}
+
+// HostCostEstimator is implemented by host allocators that project the hourly
+// spend of the distros they allocate hosts for, so that it can be recorded in
+// the scheduler's events.
+type HostCostEstimator interface {
+	ProjectedCosts() map[string]event.HostCostInfo
+}
+
+// DistroHostAllocator uses the host allocator selected in each distro's
+// host allocator settings, defaulting to the duration-based allocator.
+type DistroHostAllocator struct {
+	cost CostBasedHostAllocator
+}
+
+func (self *DistroHostAllocator) NewHostsNeeded(
+	hostAllocatorData HostAllocatorData, settings *evergreen.Settings) (map[string]int, error) {
+
+	newHostsNeeded, err := (&DurationBasedHostAllocator{}).NewHostsNeeded(hostAllocatorData, settings)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	self.cost.projectedCosts = make(map[string]event.HostCostInfo)
+	for distroId, numNewHosts := range newHostsNeeded {
+		d := hostAllocatorData.distros[distroId]
+		switch d.HostAllocatorSettings.Name {
+		case distro.HostAllocatorDeficit:
+			newHostsNeeded[distroId] = (&DeficitBasedHostAllocator{}).
+				numNewHostsForDistro(&hostAllocatorData, d, settings)
+		case distro.HostAllocatorCost:
+			newHostsNeeded[distroId] = self.cost.
+				numNewHostsForDistro(&hostAllocatorData, d, numNewHosts, settings)
+		}
+	}
+	return newHostsNeeded, nil
+}
+
+// ProjectedCosts returns the hourly spend projected for each distro using the
+// cost-based allocator in the last call to NewHostsNeeded.
+func (self *DistroHostAllocator) ProjectedCosts() map[string]event.HostCostInfo {
+	return self.cost.ProjectedCosts()
 }
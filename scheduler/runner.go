@@ -35,7 +35,7 @@ func (r *Runner) Run(config *evergreen.Settings) error {
 		&CmpBasedTaskPrioritizer{},
 		&DBTaskDurationEstimator{},
 		&DBTaskQueuePersister{},
-		&DurationBasedHostAllocator{},
+		&DistroHostAllocator{},
 	}
 
 	if err := schedulerInstance.Schedule(); err != nil {
@@ -78,6 +78,15 @@ func (s *Scheduler) Schedule() error {
 		return errors.Wrap(err, "Error getting expected task durations")
 	}
 
+	// in fair-share mode, find each project's weight and recent usage
+	var shareData *fairShareData
+	if s.Settings.Scheduler.FairShare {
+		shareData, err = findFairShareData(s.Settings)
+		if err != nil {
+			return errors.Wrap(err, "Error finding fair-share data")
+		}
+	}
+
 	distroInputChan := make(chan distroSchedulerInput, len(distros))
 
 	// put all of the needed input for the distro scheduler into a channel to be read by the
@@ -88,7 +97,7 @@ func (s *Scheduler) Schedule() error {
 			continue
 		}
 		distroInputChan <- distroSchedulerInput{
-			distroId:               d.Id,
+			distro:                 d,
 			runnableTasksForDistro: runnableTasksForDistro,
 		}
 
@@ -111,7 +120,7 @@ func (s *Scheduler) Schedule() error {
 			// read the inputs for scheduling this distro
 			for d := range distroInputChan {
 				// schedule the distro
-				res := s.scheduleDistro(d.distroId, d.runnableTasksForDistro, taskExpectedDuration)
+				res := s.scheduleDistro(&d.distro, d.runnableTasksForDistro, taskExpectedDuration, shareData)
 				if res.err != nil {
 					grip.Error(err)
 				}
@@ -196,6 +205,14 @@ func (s *Scheduler) Schedule() error {
 	if err != nil {
 		return errors.Wrap(err, "Error determining how many new hosts are needed")
 	}
+	if estimator, ok := s.HostAllocator.(HostCostEstimator); ok {
+		for distroId, cost := range estimator.ProjectedCosts() {
+			cost := cost
+			taskQueueInfo := schedulerEvents[distroId]
+			taskQueueInfo.HostCost = &cost
+			schedulerEvents[distroId] = taskQueueInfo
+		}
+	}
 
 	// spawn up the hosts
 	hostsSpawned, err := s.spawnHosts(newHostsNeeded)
@@ -232,7 +249,7 @@ func (s *Scheduler) Schedule() error {
 }
 
 type distroSchedulerInput struct {
-	distroId               string
+	distro                 distro.Distro
 	runnableTasksForDistro []task.Task
 }
 
@@ -243,27 +260,34 @@ type distroSchedulerResult struct {
 	err            error
 }
 
-func (s *Scheduler) scheduleDistro(distroId string, runnableTasksForDistro []task.Task,
-	taskExpectedDuration model.ProjectTaskDurations) *distroSchedulerResult {
+func (s *Scheduler) scheduleDistro(d *distro.Distro, runnableTasksForDistro []task.Task,
+	taskExpectedDuration model.ProjectTaskDurations, shareData *fairShareData) *distroSchedulerResult {
 
 	res := distroSchedulerResult{
-		distroId: distroId,
+		distroId: d.Id,
 	}
-	grip.Infof("Prioritizing %d tasks for distro: %s", len(runnableTasksForDistro), distroId)
+	grip.Infof("Prioritizing %d tasks for distro: %s", len(runnableTasksForDistro), d.Id)
 
-	prioritizedTasks, err := s.PrioritizeTasks(s.Settings,
+	prioritizedTasks, err := s.PrioritizeTasks(s.Settings, d,
 		runnableTasksForDistro)
 	if err != nil {
 		res.err = errors.Wrap(err, "Error prioritizing tasks")
 		return &res
 	}
 
+	// interleave the tasks across projects if running in fair-share mode
+	var projectShares []event.ProjectShareInfo
+	if shareData != nil {
+		prioritizedTasks, projectShares = fairShareOrder(prioritizedTasks, shareData,
+			taskExpectedDuration)
+	}
+
 	// persist the queue of tasks
-	grip.Infoln("Saving task queue for distro", distroId)
-	queuedTasks, err := s.PersistTaskQueue(distroId, prioritizedTasks,
+	grip.Infoln("Saving task queue for distro", d.Id)
+	queuedTasks, err := s.PersistTaskQueue(d.Id, prioritizedTasks,
 		taskExpectedDuration)
 	if err != nil {
-		res.err = errors.Wrapf(err, "Error processing distro %s saving task queue", distroId)
+		res.err = errors.Wrapf(err, "Error processing distro %s saving task queue", d.Id)
 		return &res
 	}
 
@@ -272,7 +296,7 @@ func (s *Scheduler) scheduleDistro(distroId string, runnableTasksForDistro []tas
 	if err != nil {
 		res.err = errors.Wrapf(err,
 			"Error processing distro %s setting scheduled time for prioritized tasks",
-			distroId)
+			d.Id)
 		return &res
 	}
 	res.taskQueueItem = queuedTasks
@@ -286,6 +310,7 @@ func (s *Scheduler) scheduleDistro(distroId string, runnableTasksForDistro []tas
 		TaskQueueLength:  len(queuedTasks),
 		NumHostsRunning:  0,
 		ExpectedDuration: totalDuration,
+		ProjectShares:    projectShares,
 	}
 	return &res
 
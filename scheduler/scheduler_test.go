@@ -53,7 +53,7 @@ func (self *MockTaskFinder) FindRunnableTasks() ([]task.Task, error) {
 type MockTaskPrioritizer struct{}
 
 func (self *MockTaskPrioritizer) PrioritizeTasks(settings *evergreen.Settings,
-	tasks []task.Task) ([]task.Task, error) {
+	d *distro.Distro, tasks []task.Task) ([]task.Task, error) {
 	return nil, errors.New("PrioritizeTasks not implemented")
 }
 
@@ -1,7 +1,10 @@
 package scheduler
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/pkg/errors"
 )
@@ -53,3 +56,19 @@ func cacheSimilarFailing(comparator *CmpBasedTaskComparator) (err error) {
 	}
 	return nil
 }
+
+// cacheExpectedDurations fetches the expected duration of each task, as
+// estimated by the DBTaskDurationEstimator
+func cacheExpectedDurations(comparator *CmpBasedTaskComparator) error {
+	estimator := &DBTaskDurationEstimator{}
+	durations, err := estimator.GetExpectedDurations(comparator.tasks)
+	if err != nil {
+		return errors.Wrap(err, "cacheExpectedDurations")
+	}
+
+	comparator.expectedDurations = make(map[string]time.Duration)
+	for _, t := range comparator.tasks {
+		comparator.expectedDurations[t.Id] = model.GetTaskExpectedDuration(t, durations)
+	}
+	return nil
+}
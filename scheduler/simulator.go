@@ -0,0 +1,671 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/cloud/providers/static"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// default time between simulated scheduler runs
+	DefaultSimulationInterval = time.Minute
+
+	// default time for a simulated dynamic host to become available for tasks
+	DefaultSimulatedHostStartupTime = 5 * time.Minute
+
+	// default time a simulated dynamic host may sit idle before it is
+	// terminated, matching the monitor's idle time cutoff
+	DefaultSimulatedIdleHostTimeout = 15 * time.Minute
+
+	// default time the simulation keeps running after the end of the window
+	// to finish tasks that are still queued
+	DefaultSimulationMaxDrainTime = 24 * time.Hour
+)
+
+// Simulator replays a historical window of tasks through a TaskPrioritizer
+// and HostAllocator to predict how the scheduler would have performed. It
+// never spawns hosts, never calls out to cloud providers and never modifies
+// tasks, hosts or task queues, so it can be used to evaluate prioritizer and
+// allocator changes against real workloads before rolling them out. Hosts of
+// distros with an hourly budget are charged the distro's hourly host cost.
+//
+// Every task that was scheduled in the window and has since succeeded or
+// failed is replayed: it becomes runnable at its recorded scheduled time once
+// its dependencies have finished in the simulation, and runs for as long as it
+// actually took. Each distro starts with its static hosts and no dynamic hosts.
+type Simulator struct {
+	*evergreen.Settings
+	TaskPrioritizer
+	TaskDurationEstimator
+	HostAllocator
+
+	// Interval is the simulated time between scheduler runs
+	Interval time.Duration
+
+	// HostStartupTime is how long a new dynamic host takes to become
+	// available for tasks
+	HostStartupTime time.Duration
+
+	// IdleHostTimeout is how long a dynamic host may sit idle before it is
+	// terminated
+	IdleHostTimeout time.Duration
+
+	// MaxDrainTime bounds how long the simulation keeps running after the
+	// end of the window to finish queued tasks
+	MaxDrainTime time.Duration
+}
+
+// NewSimulator returns a Simulator that uses the same prioritizer, duration
+// estimator and host allocator as the scheduler runner, with default timings.
+func NewSimulator(settings *evergreen.Settings) *Simulator {
+	return &Simulator{
+		Settings:              settings,
+		TaskPrioritizer:       &CmpBasedTaskPrioritizer{},
+		TaskDurationEstimator: &DBTaskDurationEstimator{},
+		HostAllocator:         &DistroHostAllocator{},
+		Interval:              DefaultSimulationInterval,
+		HostStartupTime:       DefaultSimulatedHostStartupTime,
+		IdleHostTimeout:       DefaultSimulatedIdleHostTimeout,
+		MaxDrainTime:          DefaultSimulationMaxDrainTime,
+	}
+}
+
+// SimulationReport holds the results of replaying a window of tasks.
+type SimulationReport struct {
+	Start    time.Time                `json:"start"`
+	End      time.Time                `json:"end"`
+	Interval time.Duration            `json:"interval"`
+	Distros  []DistroSimulationReport `json:"distros"`
+}
+
+// DistroSimulationReport compares the simulated schedule for a single distro
+// against what actually happened.
+type DistroSimulationReport struct {
+	DistroId string `json:"distro_id"`
+	NumTasks int    `json:"num_tasks"`
+
+	// NumNotStarted is the number of tasks the simulation had not started
+	// when it stopped
+	NumNotStarted int `json:"num_not_started"`
+
+	// ActualMakespan is the makespan of the tasks as they actually ran, and
+	// SimulatedMakespan is the makespan of the finished simulated tasks
+	ActualMakespan    time.Duration `json:"actual_makespan"`
+	SimulatedMakespan time.Duration `json:"simulated_makespan"`
+
+	// PredictedMakespan is the length of the longest dependency path through
+	// the tasks, the makespan with an unlimited number of hosts
+	PredictedMakespan time.Duration `json:"predicted_makespan"`
+
+	// queue wait is the time between a task being scheduled and starting
+	ActualAvgWait    time.Duration `json:"actual_avg_wait"`
+	ActualMaxWait    time.Duration `json:"actual_max_wait"`
+	SimulatedAvgWait time.Duration `json:"simulated_avg_wait"`
+	SimulatedMaxWait time.Duration `json:"simulated_max_wait"`
+
+	// host counts over the simulated scheduler runs
+	MaxHosts     int     `json:"max_hosts"`
+	AvgHosts     float64 `json:"avg_hosts"`
+	HostsSpawned int     `json:"hosts_spawned"`
+}
+
+// tasksByScheduledTime sorts tasks by the time they were first scheduled.
+type tasksByScheduledTime []task.Task
+
+func (t tasksByScheduledTime) Len() int { return len(t) }
+func (t tasksByScheduledTime) Less(i, j int) bool {
+	return t[i].ScheduledTime.Before(t[j].ScheduledTime)
+}
+func (t tasksByScheduledTime) Swap(i, j int) { t[i], t[j] = t[j], t[i] }
+
+// distroSimulationReportsById sorts distro reports by distro id.
+type distroSimulationReportsById []DistroSimulationReport
+
+func (r distroSimulationReportsById) Len() int           { return len(r) }
+func (r distroSimulationReportsById) Less(i, j int) bool { return r[i].DistroId < r[j].DistroId }
+func (r distroSimulationReportsById) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+// simulatedTask is a historical task along with its simulated run.
+type simulatedTask struct {
+	task.Task
+	started bool
+	start   time.Time
+	finish  time.Time
+}
+
+// runTime returns how long the task actually took to run.
+func (t *simulatedTask) runTime() time.Duration {
+	if t.TimeTaken > 0 {
+		return t.TimeTaken
+	}
+	return t.FinishTime.Sub(t.StartTime)
+}
+
+// finishedBy returns true if the simulated task finished at or before the
+// given time.
+func (t *simulatedTask) finishedBy(now time.Time) bool {
+	return t.started && !t.finish.After(now)
+}
+
+// simulatedHost is a host in the simulation along with the task, if any,
+// it was last assigned.
+type simulatedHost struct {
+	host.Host
+	// readyAt is when the host becomes available for tasks
+	readyAt time.Time
+	// freeAt is when the host finishes its last assigned task
+	freeAt time.Time
+	task   *simulatedTask
+}
+
+// availableAt returns the earliest time from now that the host can start a
+// new task.
+func (h *simulatedHost) availableAt(now time.Time) time.Time {
+	available := now
+	if h.readyAt.After(available) {
+		available = h.readyAt
+	}
+	if h.freeAt.After(available) {
+		available = h.freeAt
+	}
+	return available
+}
+
+// simulation holds the state of the tasks and hosts being simulated.
+type simulation struct {
+	distros map[string]distro.Distro
+	tasks   map[string]*simulatedTask
+	// tasksByDistro holds the ids of each distro's tasks, ordered by
+	// scheduled time
+	tasksByDistro map[string][]string
+	// executions maps a task's original id to all of its executions in the
+	// simulation, for resolving dependencies
+	executions map[string][]*simulatedTask
+	hosts      map[string][]*simulatedHost
+
+	numHosts     int
+	hostsSpawned map[string]int
+	hostSamples  map[string][]int
+}
+
+// newSimulation sets up the simulation state for the given historical tasks,
+// skipping tasks whose distro no longer exists, and creates the static hosts
+// of each distro.
+func newSimulation(tasks []task.Task, distros []distro.Distro, start time.Time) *simulation {
+	sim := &simulation{
+		distros:       make(map[string]distro.Distro),
+		tasks:         make(map[string]*simulatedTask),
+		tasksByDistro: make(map[string][]string),
+		executions:    make(map[string][]*simulatedTask),
+		hosts:         make(map[string][]*simulatedHost),
+		hostsSpawned:  make(map[string]int),
+		hostSamples:   make(map[string][]int),
+	}
+	for _, d := range distros {
+		sim.distros[d.Id] = d
+	}
+
+	sort.Stable(tasksByScheduledTime(tasks))
+	for _, t := range tasks {
+		if _, ok := sim.distros[t.DistroId]; !ok {
+			grip.Warningf("skipping simulation of task %s: distro '%s' not found",
+				t.Id, t.DistroId)
+			continue
+		}
+		simTask := &simulatedTask{Task: t}
+		sim.tasks[t.Id] = simTask
+		sim.tasksByDistro[t.DistroId] = append(sim.tasksByDistro[t.DistroId], t.Id)
+
+		originalId := t.Id
+		if t.Archived {
+			originalId = t.OldTaskId
+		}
+		sim.executions[originalId] = append(sim.executions[originalId], simTask)
+	}
+
+	for distroId := range sim.tasksByDistro {
+		d := sim.distros[distroId]
+		if d.Provider != evergreen.HostTypeStatic {
+			continue
+		}
+		settings := &static.Settings{}
+		if err := mapstructure.Decode(d.ProviderSettings, settings); err != nil {
+			grip.Warningf("error decoding static hosts for distro '%s': %+v", d.Id, err)
+			continue
+		}
+		for _, h := range settings.Hosts {
+			sim.hosts[d.Id] = append(sim.hosts[d.Id], &simulatedHost{
+				Host:    host.Host{Id: h.Name, Distro: d, Provider: evergreen.HostTypeStatic},
+				readyAt: start,
+			})
+		}
+	}
+
+	return sim
+}
+
+// done returns true if every task has finished by the given time.
+func (sim *simulation) done(now time.Time) bool {
+	for _, t := range sim.tasks {
+		if !t.finishedBy(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// dependenciesMet returns true if every dependency of the task that is part
+// of the simulation has finished by the given time.
+func (sim *simulation) dependenciesMet(t *simulatedTask, now time.Time) bool {
+	for _, dep := range t.DependsOn {
+		executions, ok := sim.executions[dep.TaskId]
+		if !ok {
+			continue
+		}
+		finished := false
+		for _, execution := range executions {
+			if execution.finishedBy(now) {
+				finished = true
+				break
+			}
+		}
+		if !finished {
+			return false
+		}
+	}
+	return true
+}
+
+// runnableTasks returns, by distro, the tasks that have been scheduled by the
+// given time, have not yet started and whose dependencies have finished.
+func (sim *simulation) runnableTasks(now time.Time) map[string][]task.Task {
+	runnable := make(map[string][]task.Task)
+	for distroId, taskIds := range sim.tasksByDistro {
+		for _, id := range taskIds {
+			t := sim.tasks[id]
+			if t.ScheduledTime.After(now) {
+				break
+			}
+			if t.started || !sim.dependenciesMet(t, now) {
+				continue
+			}
+			runnable[distroId] = append(runnable[distroId], t.Task)
+		}
+	}
+	return runnable
+}
+
+// existingHosts returns the hosts of each distro as the host allocator would
+// see them at the given time, along with the tasks running on them.
+func (sim *simulation) existingHosts(now time.Time) (map[string][]host.Host, map[string]task.Task) {
+	hostsByDistro := make(map[string][]host.Host)
+	runningTasks := make(map[string]task.Task)
+	for distroId, hosts := range sim.hosts {
+		for _, h := range hosts {
+			existing := h.Host
+			if h.task != nil && h.task.finish.After(now) {
+				existing.RunningTask = h.task.Id
+				running := h.task.Task
+				running.StartTime = h.task.start
+				runningTasks[h.task.Id] = running
+			}
+			hostsByDistro[distroId] = append(hostsByDistro[distroId], existing)
+		}
+	}
+	return hostsByDistro, runningTasks
+}
+
+// spawnHosts adds new dynamic hosts to a distro, up to its pool size, which
+// are created at the given time and become available for tasks at readyAt.
+func (sim *simulation) spawnHosts(distroId string, numHosts int, now, readyAt time.Time) {
+	d := sim.distros[distroId]
+	for i := 0; i < numHosts; i++ {
+		if d.PoolSize > 0 && len(sim.hosts[distroId]) >= d.PoolSize {
+			return
+		}
+		sim.numHosts++
+		sim.hostsSpawned[distroId]++
+		sim.hosts[distroId] = append(sim.hosts[distroId], &simulatedHost{
+			Host: host.Host{
+				Id:           fmt.Sprintf("%s-simulated-%d", distroId, sim.numHosts),
+				Distro:       d,
+				Provider:     d.Provider,
+				CreationTime: now,
+			},
+			readyAt: readyAt,
+		})
+	}
+}
+
+// terminateIdleHosts removes dynamic hosts that have been available but
+// unused for longer than the timeout.
+func (sim *simulation) terminateIdleHosts(now time.Time, timeout time.Duration) {
+	for distroId, hosts := range sim.hosts {
+		remaining := hosts[:0]
+		for _, h := range hosts {
+			idleSince := h.readyAt
+			if h.freeAt.After(idleSince) {
+				idleSince = h.freeAt
+			}
+			if h.Provider != evergreen.HostTypeStatic && now.Sub(idleSince) > timeout {
+				continue
+			}
+			remaining = append(remaining, h)
+		}
+		sim.hosts[distroId] = remaining
+	}
+}
+
+// dispatch assigns the queued tasks, in order, to the distro's hosts as they
+// become available before the next scheduler run, in the same way hosts take
+// the next task from the persisted queue.
+func (sim *simulation) dispatch(distroId string, queue []model.TaskQueueItem, now, next time.Time) {
+	hosts := sim.hosts[distroId]
+	for _, item := range queue {
+		var nextHost *simulatedHost
+		for _, h := range hosts {
+			if !h.availableAt(now).Before(next) {
+				continue
+			}
+			if nextHost == nil || h.availableAt(now).Before(nextHost.availableAt(now)) {
+				nextHost = h
+			}
+		}
+		if nextHost == nil {
+			return
+		}
+
+		t := sim.tasks[item.Id]
+		t.started = true
+		t.start = nextHost.availableAt(now)
+		t.finish = t.start.Add(t.runTime())
+		nextHost.task = t
+		nextHost.freeAt = t.finish
+	}
+}
+
+// recordHosts samples the number of hosts each distro has.
+func (sim *simulation) recordHosts() {
+	for distroId := range sim.tasksByDistro {
+		sim.hostSamples[distroId] = append(sim.hostSamples[distroId], len(sim.hosts[distroId]))
+	}
+}
+
+// report compares the simulated run of each distro's tasks against the
+// actual run.
+func (sim *simulation) report() []DistroSimulationReport {
+	reports := []DistroSimulationReport{}
+	for distroId, taskIds := range sim.tasksByDistro {
+		report := DistroSimulationReport{
+			DistroId:     distroId,
+			NumTasks:     len(taskIds),
+			HostsSpawned: sim.hostsSpawned[distroId],
+		}
+
+		actual := make([]task.Task, 0, len(taskIds))
+		simulated := make([]task.Task, 0, len(taskIds))
+		var actualWait, simulatedWait time.Duration
+		for _, id := range taskIds {
+			t := sim.tasks[id]
+			actual = append(actual, t.Task)
+			wait := t.StartTime.Sub(t.ScheduledTime)
+			actualWait += wait
+			if wait > report.ActualMaxWait {
+				report.ActualMaxWait = wait
+			}
+
+			if !t.started {
+				report.NumNotStarted++
+				continue
+			}
+			simTask := t.Task
+			simTask.StartTime = t.start
+			simTask.FinishTime = t.finish
+			simulated = append(simulated, simTask)
+			wait = t.start.Sub(t.ScheduledTime)
+			simulatedWait += wait
+			if wait > report.SimulatedMaxWait {
+				report.SimulatedMaxWait = wait
+			}
+		}
+
+		report.ActualMakespan = model.CalculateActualMakespan(actual)
+		report.SimulatedMakespan = model.CalculateActualMakespan(simulated)
+		report.PredictedMakespan = model.FindPredictedMakespan(actual).TotalTime
+		report.ActualAvgWait = actualWait / time.Duration(len(actual))
+		if len(simulated) > 0 {
+			report.SimulatedAvgWait = simulatedWait / time.Duration(len(simulated))
+		}
+
+		var totalHosts int
+		for _, numHosts := range sim.hostSamples[distroId] {
+			totalHosts += numHosts
+			if numHosts > report.MaxHosts {
+				report.MaxHosts = numHosts
+			}
+		}
+		if len(sim.hostSamples[distroId]) > 0 {
+			report.AvgHosts = float64(totalHosts) / float64(len(sim.hostSamples[distroId]))
+		}
+
+		reports = append(reports, report)
+	}
+
+	sort.Sort(distroSimulationReportsById(reports))
+	return reports
+}
+
+// simulatedTaskQueue builds the queue the scheduler would persist for the
+// prioritized tasks, without saving it or the tasks' expected durations.
+func simulatedTaskQueue(tasks []task.Task, durations model.ProjectTaskDurations) []model.TaskQueueItem {
+	queue := make([]model.TaskQueueItem, 0, len(tasks))
+	for _, t := range tasks {
+		queue = append(queue, model.TaskQueueItem{
+			Id:                  t.Id,
+			DisplayName:         t.DisplayName,
+			BuildVariant:        t.BuildVariant,
+			RevisionOrderNumber: t.RevisionOrderNumber,
+			Requester:           t.Requester,
+			Revision:            t.Revision,
+			Project:             t.Project,
+			ExpectedDuration:    model.GetTaskExpectedDuration(t, durations),
+			Priority:            t.Priority,
+		})
+	}
+	return queue
+}
+
+// findSimulationTasks returns the current and archived executions of all
+// tasks scheduled in the window that have since succeeded or failed.
+func findSimulationTasks(start, end time.Time, distroId string) ([]task.Task, error) {
+	query := task.ByFinishedScheduledBetween(start, end, distroId)
+	tasks, err := task.Find(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding tasks")
+	}
+	oldTasks, err := task.FindOld(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding old tasks")
+	}
+	return append(tasks, oldTasks...), nil
+}
+
+// simulatedCloudManager stands in for a distro's cloud manager when
+// simulating, so that host allocators never call out to the real providers.
+// Static distros cannot spawn hosts, and dynamic hosts cost the distro's
+// hourly host cost for their simulated uptime, paid by the hour from when
+// they were created.
+type simulatedCloudManager struct {
+	distro distro.Distro
+	now    time.Time
+}
+
+// simulatedCloudManagers returns a function that gives each distro a
+// simulatedCloudManager at the given simulated time.
+func simulatedCloudManagers(now time.Time) func(distro.Distro, *evergreen.Settings) (cloud.CloudManager, error) {
+	return func(d distro.Distro, _ *evergreen.Settings) (cloud.CloudManager, error) {
+		return &simulatedCloudManager{distro: d, now: now}, nil
+	}
+}
+
+func (m *simulatedCloudManager) isStatic() bool {
+	return m.distro.Provider == evergreen.HostTypeStatic
+}
+
+func (m *simulatedCloudManager) GetSettings() cloud.ProviderSettings { return nil }
+
+func (m *simulatedCloudManager) Configure(*evergreen.Settings) error { return nil }
+
+func (m *simulatedCloudManager) SpawnInstance(*distro.Distro, cloud.HostOptions) (*host.Host, error) {
+	return nil, errors.New("simulated cloud managers cannot spawn hosts")
+}
+
+func (m *simulatedCloudManager) CanSpawn() (bool, error) { return !m.isStatic(), nil }
+
+func (m *simulatedCloudManager) GetInstanceStatus(*host.Host) (cloud.CloudStatus, error) {
+	return cloud.StatusRunning, nil
+}
+
+func (m *simulatedCloudManager) TerminateInstance(*host.Host) error {
+	return errors.New("simulated cloud managers cannot terminate hosts")
+}
+
+func (m *simulatedCloudManager) IsUp(*host.Host) (bool, error) { return true, nil }
+
+func (m *simulatedCloudManager) OnUp(*host.Host) error { return nil }
+
+func (m *simulatedCloudManager) IsSSHReachable(*host.Host, string) (bool, error) {
+	return false, errors.New("simulated hosts are not reachable")
+}
+
+func (m *simulatedCloudManager) GetDNSName(*host.Host) (string, error) { return "", nil }
+
+func (m *simulatedCloudManager) GetSSHOptions(*host.Host, string) ([]string, error) {
+	return nil, errors.New("simulated hosts are not reachable")
+}
+
+// TimeTilNextPayment returns the time left in the host's current hour.
+func (m *simulatedCloudManager) TimeTilNextPayment(h *host.Host) time.Duration {
+	if m.isStatic() || m.now.Before(h.CreationTime) {
+		return 0
+	}
+	return time.Hour - m.now.Sub(h.CreationTime)%time.Hour
+}
+
+// CostForDuration charges the distro's hourly host cost for the part of the
+// given span that the host was up for.
+func (m *simulatedCloudManager) CostForDuration(h *host.Host, start, end time.Time) (float64, error) {
+	if end.Before(start) {
+		return 0, errors.Errorf("span end %v is before its start %v", end, start)
+	}
+	if m.isStatic() {
+		return 0, nil
+	}
+	if start.Before(h.CreationTime) {
+		start = h.CreationTime
+	}
+	if !end.After(start) {
+		return 0, nil
+	}
+	return m.distro.HostAllocatorSettings.HourlyHostCost * end.Sub(start).Hours(), nil
+}
+
+// Simulate replays the tasks scheduled between start and end, optionally
+// only those for a single distro, and reports how the simulated schedule
+// compares to what actually happened.
+func (s *Simulator) Simulate(start, end time.Time, distroId string) (*SimulationReport, error) {
+	if !start.Before(end) {
+		return nil, errors.Errorf("simulation start %v must be before end %v", start, end)
+	}
+	if s.Interval <= 0 {
+		return nil, errors.New("simulation interval must be positive")
+	}
+
+	tasks, err := findSimulationTasks(start, end, distroId)
+	if err != nil {
+		return nil, err
+	}
+	grip.Infof("Simulating %d tasks scheduled between %v and %v", len(tasks), start, end)
+
+	distros, err := distro.Find(distro.All)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding distros")
+	}
+
+	taskExpectedDuration, err := s.GetExpectedDurations(tasks)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting expected task durations")
+	}
+
+	var shareData *fairShareData
+	if s.Settings.Scheduler.FairShare {
+		shareData, err = findFairShareData(s.Settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error finding fair-share data")
+		}
+	}
+
+	sim := newSimulation(tasks, distros, start)
+	stop := end.Add(s.MaxDrainTime)
+	for now := start; !sim.done(now) && now.Before(stop); now = now.Add(s.Interval) {
+		sim.terminateIdleHosts(now, s.IdleHostTimeout)
+
+		taskQueueItems := make(map[string][]model.TaskQueueItem)
+		for distroId, runnableTasksForDistro := range sim.runnableTasks(now) {
+			d := sim.distros[distroId]
+			prioritizedTasks, err := s.PrioritizeTasks(s.Settings, &d, runnableTasksForDistro)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error prioritizing tasks for distro %v", distroId)
+			}
+			if shareData != nil {
+				prioritizedTasks, _ = fairShareOrder(prioritizedTasks, shareData,
+					taskExpectedDuration)
+			}
+			taskQueueItems[distroId] = simulatedTaskQueue(prioritizedTasks, taskExpectedDuration)
+		}
+
+		if len(taskQueueItems) != 0 {
+			hostsByDistro, runningTasks := sim.existingHosts(now)
+			hostAllocatorData := HostAllocatorData{
+				existingDistroHosts:  hostsByDistro,
+				distros:              sim.distros,
+				taskQueueItems:       taskQueueItems,
+				taskRunDistros:       map[string][]string{},
+				projectTaskDurations: taskExpectedDuration,
+				runningTasks:         runningTasks,
+				currentTime:          now,
+				cloudManager:         simulatedCloudManagers(now),
+			}
+			newHostsNeeded, err := s.NewHostsNeeded(hostAllocatorData, s.Settings)
+			if err != nil {
+				return nil, errors.Wrap(err, "Error determining how many new hosts are needed")
+			}
+			for distroId, numHosts := range newHostsNeeded {
+				sim.spawnHosts(distroId, numHosts, now, now.Add(s.HostStartupTime))
+			}
+		}
+
+		for distroId, queue := range taskQueueItems {
+			sim.dispatch(distroId, queue, now, now.Add(s.Interval))
+		}
+		sim.recordHosts()
+	}
+
+	return &SimulationReport{
+		Start:    start,
+		End:      end,
+		Interval: s.Interval,
+		Distros:  sim.report(),
+	}, nil
+}
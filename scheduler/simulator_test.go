@@ -0,0 +1,248 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSimulation(t *testing.T) {
+
+	Convey("With a simulation of historical tasks", t, func() {
+		start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		historicalTask := func(id string, scheduled, ran time.Duration, wait time.Duration) task.Task {
+			return task.Task{
+				Id:            id,
+				DistroId:      "d1",
+				ScheduledTime: start.Add(scheduled),
+				StartTime:     start.Add(scheduled + wait),
+				FinishTime:    start.Add(scheduled + wait + ran),
+				TimeTaken:     ran,
+			}
+		}
+		tasks := []task.Task{
+			historicalTask("compile", 0, 10*time.Minute, 0),
+			historicalTask("test", 0, 20*time.Minute, 15*time.Minute),
+			historicalTask("missing", 0, time.Minute, 0),
+			historicalTask("late", time.Hour, 5*time.Minute, 5*time.Minute),
+		}
+		tasks[1].DependsOn = []task.Dependency{{TaskId: "compile"}}
+		tasks[2].DistroId = "gone"
+		distros := []distro.Distro{{Id: "d1", PoolSize: 2}}
+		sim := newSimulation(tasks, distros, start)
+
+		Convey("tasks on unknown distros should be skipped", func() {
+			So(len(sim.tasks), ShouldEqual, 3)
+			So(sim.tasksByDistro["d1"], ShouldResemble, []string{"compile", "test", "late"})
+		})
+
+		Convey("only scheduled tasks with finished dependencies should be runnable", func() {
+			runnable := sim.runnableTasks(start)
+			So(len(runnable["d1"]), ShouldEqual, 1)
+			So(runnable["d1"][0].Id, ShouldEqual, "compile")
+
+			sim.spawnHosts("d1", 1, start, start)
+			sim.dispatch("d1", simulatedTaskQueue(runnable["d1"], model.ProjectTaskDurations{}),
+				start, start.Add(time.Minute))
+			So(sim.tasks["compile"].start, ShouldResemble, start)
+			So(sim.tasks["compile"].finish, ShouldResemble, start.Add(10*time.Minute))
+			So(len(sim.runnableTasks(start.Add(5 * time.Minute))["d1"]), ShouldEqual, 0)
+
+			runnable = sim.runnableTasks(start.Add(10 * time.Minute))
+			So(len(runnable["d1"]), ShouldEqual, 1)
+			So(runnable["d1"][0].Id, ShouldEqual, "test")
+		})
+
+		Convey("hosts should run queued tasks as they become available", func() {
+			sim.spawnHosts("d1", 5, start, start.Add(2*time.Minute))
+			So(len(sim.hosts["d1"]), ShouldEqual, 2)
+			So(sim.hostsSpawned["d1"], ShouldEqual, 2)
+			So(sim.hosts["d1"][0].CreationTime, ShouldResemble, start)
+
+			queue := []model.TaskQueueItem{{Id: "compile"}, {Id: "late"}}
+			sim.dispatch("d1", queue, start, start.Add(time.Minute))
+			So(sim.tasks["compile"].started, ShouldBeFalse)
+
+			sim.dispatch("d1", queue, start, start.Add(5*time.Minute))
+			So(sim.tasks["compile"].start, ShouldResemble, start.Add(2*time.Minute))
+			So(sim.tasks["late"].start, ShouldResemble, start.Add(2*time.Minute))
+
+			hosts, running := sim.existingHosts(start.Add(5 * time.Minute))
+			So(len(hosts["d1"]), ShouldEqual, 2)
+			So(hosts["d1"][0].RunningTask, ShouldEqual, "compile")
+			So(running["compile"].StartTime, ShouldResemble, start.Add(2*time.Minute))
+
+			hosts, running = sim.existingHosts(start.Add(10 * time.Minute))
+			So(hosts["d1"][1].RunningTask, ShouldEqual, "")
+			So(len(running), ShouldEqual, 1)
+		})
+
+		Convey("idle dynamic hosts should be terminated", func() {
+			sim.spawnHosts("d1", 2, start, start)
+			sim.dispatch("d1", []model.TaskQueueItem{{Id: "compile"}}, start, start.Add(time.Minute))
+			sim.terminateIdleHosts(start.Add(20*time.Minute), 15*time.Minute)
+			So(len(sim.hosts["d1"]), ShouldEqual, 1)
+			So(sim.hosts["d1"][0].task.Id, ShouldEqual, "compile")
+			sim.terminateIdleHosts(start.Add(26*time.Minute), 15*time.Minute)
+			So(len(sim.hosts["d1"]), ShouldEqual, 0)
+		})
+
+		Convey("static hosts should exist from the start and never be terminated", func() {
+			distros[0].Provider = evergreen.HostTypeStatic
+			distros[0].ProviderSettings = &map[string]interface{}{
+				"hosts": []interface{}{map[string]interface{}{"name": "static1"}},
+			}
+			sim = newSimulation(tasks, distros, start)
+			So(len(sim.hosts["d1"]), ShouldEqual, 1)
+			So(sim.hosts["d1"][0].Id, ShouldEqual, "static1")
+			sim.terminateIdleHosts(start.Add(time.Hour), 15*time.Minute)
+			So(len(sim.hosts["d1"]), ShouldEqual, 1)
+		})
+
+		Convey("the report should compare the simulated and actual runs", func() {
+			sim.spawnHosts("d1", 2, start, start)
+			sim.recordHosts()
+			sim.dispatch("d1", []model.TaskQueueItem{{Id: "compile"}}, start, start.Add(time.Minute))
+			sim.dispatch("d1", []model.TaskQueueItem{{Id: "test"}}, start.Add(10*time.Minute),
+				start.Add(11*time.Minute))
+			sim.recordHosts()
+
+			reports := sim.report()
+			So(len(reports), ShouldEqual, 1)
+			report := reports[0]
+			So(report.DistroId, ShouldEqual, "d1")
+			So(report.NumTasks, ShouldEqual, 3)
+			So(report.NumNotStarted, ShouldEqual, 1)
+			So(report.ActualMakespan, ShouldEqual, time.Hour+10*time.Minute)
+			So(report.SimulatedMakespan, ShouldEqual, 30*time.Minute)
+			So(report.PredictedMakespan, ShouldEqual, 30*time.Minute)
+			So(report.ActualMaxWait, ShouldEqual, 15*time.Minute)
+			So(report.SimulatedAvgWait, ShouldEqual, 5*time.Minute)
+			So(report.SimulatedMaxWait, ShouldEqual, 10*time.Minute)
+			So(report.MaxHosts, ShouldEqual, 2)
+			So(report.HostsSpawned, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestNewSimulator(t *testing.T) {
+
+	Convey("A new simulator should allocate hosts the way the scheduler does", t, func() {
+		simulator := NewSimulator(&evergreen.Settings{})
+		So(simulator.HostAllocator, ShouldHaveSameTypeAs, &DistroHostAllocator{})
+	})
+}
+
+func TestSimulatorMakesNoProviderCalls(t *testing.T) {
+	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(hostAllocatorTestConf))
+
+	Convey("When simulating a cost-limited distro whose provider can't be reached", t, func() {
+		testutil.HandleTestingErr(db.ClearCollections(distro.Collection, task.Collection, task.OldCollection),
+			t, "error clearing collections")
+		// the provider factory doesn't know this provider, so asking it for
+		// a cloud manager would leave the distro without any hosts
+		d := distro.Distro{
+			Id:       "d1",
+			Provider: "not-a-provider",
+			PoolSize: 10,
+			HostAllocatorSettings: distro.HostAllocatorSettings{
+				Name:           distro.HostAllocatorCost,
+				HourlyBudget:   2,
+				HourlyHostCost: 1,
+			},
+		}
+		So(d.Insert(), ShouldBeNil)
+		// recent enough for the tasks' durations to be estimated from them
+		start := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+		for i := 0; i < 4; i++ {
+			historical := &task.Task{
+				Id:            fmt.Sprintf("t%d", i),
+				DisplayName:   fmt.Sprintf("t%d", i),
+				Project:       "proj",
+				BuildVariant:  "bv",
+				DistroId:      "d1",
+				Status:        evergreen.TaskSucceeded,
+				ScheduledTime: start,
+				StartTime:     start,
+				FinishTime:    start.Add(time.Hour),
+				TimeTaken:     time.Hour,
+			}
+			So(historical.Insert(), ShouldBeNil)
+		}
+
+		Convey("hosts should be spawned within the distro's budget", func() {
+			report, err := NewSimulator(hostAllocatorTestConf).Simulate(start, start.Add(time.Hour), "")
+			So(err, ShouldBeNil)
+			So(len(report.Distros), ShouldEqual, 1)
+			So(report.Distros[0].NumNotStarted, ShouldEqual, 0)
+			So(report.Distros[0].MaxHosts, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestSimulatedCloudManager(t *testing.T) {
+	Convey("With a simulated cloud manager for a distro", t, func() {
+		now := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+		d := distro.Distro{Id: "d1", Provider: "ec2",
+			HostAllocatorSettings: distro.HostAllocatorSettings{HourlyHostCost: 2}}
+		m := &simulatedCloudManager{distro: d, now: now}
+		h := &host.Host{Id: "h1", CreationTime: now.Add(-90 * time.Minute)}
+
+		Convey("hosts should cost the hourly host cost while they are up", func() {
+			cost, err := m.CostForDuration(h, now.Add(-time.Hour), now)
+			So(err, ShouldBeNil)
+			So(cost, ShouldEqual, 2)
+			cost, err = m.CostForDuration(h, now.Add(-2*time.Hour), now)
+			So(err, ShouldBeNil)
+			So(cost, ShouldEqual, 3)
+			_, err = m.CostForDuration(h, now, now.Add(-time.Hour))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("hosts should be paid for by the hour from their creation", func() {
+			So(m.TimeTilNextPayment(h), ShouldEqual, 30*time.Minute)
+		})
+
+		Convey("static distros should be free and unable to spawn hosts", func() {
+			m.distro.Provider = evergreen.HostTypeStatic
+			can, err := m.CanSpawn()
+			So(err, ShouldBeNil)
+			So(can, ShouldBeFalse)
+			cost, err := m.CostForDuration(h, now.Add(-time.Hour), now)
+			So(err, ShouldBeNil)
+			So(cost, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestComputeRunningTasksDurationAt(t *testing.T) {
+
+	Convey("When computing the running tasks duration with known running tasks", t, func() {
+		now := time.Now()
+		durations := model.ProjectTaskDurations{}
+		hosts := []host.Host{{Id: "h1", RunningTask: "t1"}, {Id: "h2"}}
+
+		Convey("the remaining duration should be measured from the given time", func() {
+			running := map[string]task.Task{
+				"t1": {Id: "t1", StartTime: now.Add(-model.DefaultTaskDuration / 2)},
+			}
+			duration, err := computeRunningTasksDurationAt(hosts, durations, running, now)
+			So(err, ShouldBeNil)
+			So(duration, ShouldEqual, (model.DefaultTaskDuration / 2).Seconds())
+		})
+
+		Convey("a running task missing from the known tasks should be an error", func() {
+			_, err := computeRunningTasksDurationAt(hosts, durations, map[string]task.Task{}, now)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
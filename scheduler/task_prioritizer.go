@@ -3,8 +3,10 @@ package scheduler
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
@@ -13,11 +15,12 @@ import (
 // TaskPrioritizer is responsible for taking in a slice of tasks, and ordering them
 // according to which should be run first.
 type TaskPrioritizer interface {
-	// Takes in a slice of tasks and the current MCI settings.
+	// Takes in a slice of tasks, the distro they are queued on and the
+	// current MCI settings.
 	// Returns the slice of tasks, sorted in the order in which they should
 	// be run, as well as an error if appropriate.
-	PrioritizeTasks(settings *evergreen.Settings, tasks []task.Task) (
-		[]task.Task, error)
+	PrioritizeTasks(settings *evergreen.Settings, d *distro.Distro,
+		tasks []task.Task) ([]task.Task, error)
 }
 
 // CmpBasedTaskComparator runs the tasks through a slice of comparator functions
@@ -28,9 +31,16 @@ type CmpBasedTaskComparator struct {
 	setupFuncs     []sortSetupFunc
 	comparators    []taskPriorityCmp
 
+	// weights of the comparators, if the comparators' decisions are to be
+	// combined by weighted sum rather than applied in order
+	weights []int
+
 	// caches for sorting
 	previousTasksCache map[string]task.Task
 
+	// cache the expected duration of each task
+	expectedDurations map[string]time.Duration
+
 	// cache the number of tasks that have failed in other buildvariants; tasks
 	// with the same revision, project, display name and requester
 	similarFailingCount map[string]int
@@ -64,16 +74,76 @@ func NewCmpBasedTaskComparator() *CmpBasedTaskComparator {
 	}
 }
 
+// namedComparator pairs a comparator that may be configured on a distro with
+// the setup function it depends on, if any.
+type namedComparator struct {
+	cmp   taskPriorityCmp
+	setup sortSetupFunc
+}
+
+var namedComparators = map[string]namedComparator{
+	distro.ComparatorByPriority:            {cmp: byPriority},
+	distro.ComparatorByNumDeps:             {cmp: byNumDeps},
+	distro.ComparatorByRevisionOrderNumber: {cmp: byRevisionOrderNumber},
+	distro.ComparatorByCreateTime:          {cmp: byCreateTime},
+	distro.ComparatorBySimilarFailing:      {cmp: bySimilarFailing, setup: cacheSimilarFailing},
+	distro.ComparatorByRecentlyFailing:     {cmp: byRecentlyFailing, setup: cachePreviousTasks},
+	distro.ComparatorByExpectedDuration:    {cmp: byExpectedDuration, setup: cacheExpectedDurations},
+	distro.ComparatorByWaitTime:            {cmp: byWaitTime},
+}
+
+// NewCmpBasedTaskComparatorFromSettings returns a new task prioritizer using
+// the comparators, and weights, configured in a distro's prioritizer
+// settings, along with the setup functions necessary for those comparators.
+// If no comparators are configured the default set is used.
+func NewCmpBasedTaskComparatorFromSettings(settings distro.PrioritizerSettings) (
+	*CmpBasedTaskComparator, error) {
+	if len(settings.Comparators) == 0 {
+		return NewCmpBasedTaskComparator(), nil
+	}
+
+	comparator := &CmpBasedTaskComparator{}
+	weighted := false
+	hasSetup := map[string]bool{}
+	for _, c := range settings.Comparators {
+		named, ok := namedComparators[c.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown task comparator '%v'", c.Name)
+		}
+		comparator.comparators = append(comparator.comparators, named.cmp)
+		comparator.weights = append(comparator.weights, c.Weight)
+		if c.Weight != 0 {
+			weighted = true
+		}
+		if named.setup != nil && !hasSetup[c.Name] {
+			comparator.setupFuncs = append(comparator.setupFuncs, named.setup)
+			hasSetup[c.Name] = true
+		}
+	}
+	if !weighted {
+		comparator.weights = nil
+	}
+	return comparator, nil
+}
+
 type CmpBasedTaskPrioritizer struct{}
 
-// PrioritizeTask prioritizes the tasks to run. First splits the tasks into slices based on
+// PrioritizeTask prioritizes the tasks to run, using the comparators configured
+// for the distro. First splits the tasks into slices based on
 // whether they are part of patch versions or automatically created versions.
 // Then prioritizes each slice, and merges them.
 // Returns a full slice of the prioritized tasks, and an error if one occurs.
 func (prioritizer *CmpBasedTaskPrioritizer) PrioritizeTasks(
-	settings *evergreen.Settings, tasks []task.Task) ([]task.Task, error) {
+	settings *evergreen.Settings, d *distro.Distro, tasks []task.Task) ([]task.Task, error) {
 
 	comparator := NewCmpBasedTaskComparator()
+	if d != nil {
+		var err error
+		comparator, err = NewCmpBasedTaskComparatorFromSettings(d.PrioritizerSettings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error configuring prioritizer for distro %v", d.Id)
+		}
+	}
 	// split the tasks into repotracker tasks and patch tasks, then prioritize
 	// individually and merge
 	taskQueues := comparator.splitTasksByRequester(tasks)
@@ -123,10 +193,31 @@ func (self *CmpBasedTaskComparator) setupForSortingTasks() error {
 
 // Determine which of two tasks is more important, by running the tasks through
 // the comparator functions and returning the first definitive decision on which
-// is more important.
+// is more important. If the comparators are weighted, the sign of the weighted
+// sum of their decisions is used instead, with ties decided as above.
 func (self *CmpBasedTaskComparator) taskMoreImportantThan(task1,
 	task2 task.Task) (bool, error) {
 
+	if len(self.weights) != 0 {
+		score := 0
+		for i, cmp := range self.comparators {
+			ret, err := cmp(task1, task2, self)
+			if err != nil {
+				return false, errors.WithStack(err)
+			}
+			if ret < -1 || ret > 1 {
+				panic("Unexpected return value from task comparator")
+			}
+			score += ret * self.weights[i]
+		}
+		if score > 0 {
+			return true, nil
+		}
+		if score < 0 {
+			return false, nil
+		}
+	}
+
 	// run through the comparators, and return the first definitive decision on
 	// which task is more important
 	for _, cmp := range self.comparators {
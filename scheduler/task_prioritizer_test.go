@@ -2,9 +2,11 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/mongodb/grip"
@@ -355,3 +357,68 @@ func TestCmpBasedTaskComparator(t *testing.T) {
 	})
 
 }
+
+func TestCmpBasedTaskComparatorFromSettings(t *testing.T) {
+
+	Convey("When creating a CmpBasedTaskComparator from distro settings", t, func() {
+
+		Convey("empty settings should use the default comparators", func() {
+			comparator, err := NewCmpBasedTaskComparatorFromSettings(distro.PrioritizerSettings{})
+			So(err, ShouldBeNil)
+			So(len(comparator.comparators), ShouldEqual, len(NewCmpBasedTaskComparator().comparators))
+			So(comparator.weights, ShouldBeNil)
+		})
+
+		Convey("an unknown comparator should return an error", func() {
+			_, err := NewCmpBasedTaskComparatorFromSettings(distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{{Name: "fastest_first"}},
+			})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("unweighted comparators should be applied in order with their setup functions", func() {
+			comparator, err := NewCmpBasedTaskComparatorFromSettings(distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{
+					{Name: distro.ComparatorByExpectedDuration},
+					{Name: distro.ComparatorByPriority},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(len(comparator.comparators), ShouldEqual, 2)
+			So(len(comparator.setupFuncs), ShouldEqual, 1)
+			So(comparator.weights, ShouldBeNil)
+
+			comparator.expectedDurations = map[string]time.Duration{"short": time.Minute, "long": time.Hour}
+			moreImportant, err := comparator.taskMoreImportantThan(
+				task.Task{Id: "short"}, task.Task{Id: "long", Priority: 10})
+			So(err, ShouldBeNil)
+			So(moreImportant, ShouldBeTrue)
+		})
+
+		Convey("weighted comparators should be combined by weighted sum", func() {
+			comparator, err := NewCmpBasedTaskComparatorFromSettings(distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{
+					{Name: distro.ComparatorByExpectedDuration, Weight: 1},
+					{Name: distro.ComparatorByPriority, Weight: 3},
+					{Name: distro.ComparatorByNumDeps},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(comparator.weights, ShouldResemble, []int{1, 3, 0})
+
+			comparator.expectedDurations = map[string]time.Duration{"short": time.Minute, "long": time.Hour}
+			moreImportant, err := comparator.taskMoreImportantThan(
+				task.Task{Id: "short"}, task.Task{Id: "long", Priority: 10})
+			So(err, ShouldBeNil)
+			So(moreImportant, ShouldBeFalse)
+
+			Convey("and ties should be broken by the first comparator to reach a decision", func() {
+				comparator.expectedDurations["long"] = time.Minute
+				moreImportant, err := comparator.taskMoreImportantThan(
+					task.Task{Id: "short", NumDependents: 2}, task.Task{Id: "long"})
+				So(err, ShouldBeNil)
+				So(moreImportant, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/pkg/errors"
 )
 
@@ -144,3 +145,53 @@ func bySimilarFailing(t1, t2 task.Task,
 	}
 	return 0, nil
 }
+
+// byExpectedDuration compares the expected durations of the two tasks, as
+// estimated by the DBTaskDurationEstimator, and considers the task that is
+// expected to finish sooner to be more important.
+func byExpectedDuration(t1, t2 task.Task,
+	comparator *CmpBasedTaskComparator) (int, error) {
+	firstDuration, ok := comparator.expectedDurations[t1.Id]
+	if !ok {
+		return 0, errors.Errorf("No expected duration available for task with "+
+			"id %v", t1.Id)
+	}
+	secondDuration, ok := comparator.expectedDurations[t2.Id]
+	if !ok {
+		return 0, errors.Errorf("No expected duration available for task with "+
+			"id %v", t2.Id)
+	}
+
+	if firstDuration < secondDuration {
+		return 1, nil
+	}
+	if firstDuration > secondDuration {
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// byWaitTime compares the ScheduledTime fields of the two tasks, and
+// considers the task that has been waiting in a queue the longest to be more
+// important. Tasks that have never been scheduled are considered to have just
+// started waiting.
+func byWaitTime(t1, t2 task.Task, comparator *CmpBasedTaskComparator) (int,
+	error) {
+	if util.IsZeroTime(t1.ScheduledTime) && util.IsZeroTime(t2.ScheduledTime) {
+		return 0, nil
+	}
+	if util.IsZeroTime(t1.ScheduledTime) {
+		return -1, nil
+	}
+	if util.IsZeroTime(t2.ScheduledTime) {
+		return 1, nil
+	}
+
+	if t1.ScheduledTime.Before(t2.ScheduledTime) {
+		return 1, nil
+	}
+	if t2.ScheduledTime.Before(t1.ScheduledTime) {
+		return -1, nil
+	}
+	return 0, nil
+}
@@ -228,6 +228,53 @@ func TestTaskImportanceComparators(t *testing.T) {
 			So(cmpResult, ShouldEqual, 0)
 
 		})
+
+		Convey("the expected duration comparator should prioritize a task"+
+			" if it is expected to finish sooner", func() {
+
+			taskComparator.expectedDurations = map[string]time.Duration{
+				taskIds[0]: time.Minute,
+				taskIds[1]: time.Minute,
+			}
+			cmpResult, err := byExpectedDuration(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, 0)
+
+			taskComparator.expectedDurations[taskIds[1]] = time.Hour
+			cmpResult, err = byExpectedDuration(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, 1)
+
+			cmpResult, err = byExpectedDuration(tasks[1], tasks[0], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, -1)
+
+			delete(taskComparator.expectedDurations, taskIds[1])
+			_, err = byExpectedDuration(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("the wait time comparator should prioritize a task"+
+			" if it has been waiting longer", func() {
+
+			cmpResult, err := byWaitTime(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, 0)
+
+			tasks[1].ScheduledTime = time.Now()
+			cmpResult, err = byWaitTime(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, -1)
+
+			tasks[0].ScheduledTime = tasks[1].ScheduledTime.Add(-time.Minute)
+			cmpResult, err = byWaitTime(tasks[0], tasks[1], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, 1)
+
+			cmpResult, err = byWaitTime(tasks[1], tasks[0], taskComparator)
+			So(err, ShouldBeNil)
+			So(cmpResult, ShouldEqual, -1)
+		})
 	})
 
 }
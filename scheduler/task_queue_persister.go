@@ -35,6 +35,9 @@ func (self *DBTaskQueuePersister) PersistTaskQueue(distro string,
 			Project:             t.Project,
 			ExpectedDuration:    expectedTaskDuration,
 			Priority:            t.Priority,
+			BuildId:             t.BuildId,
+			Group:               t.TaskGroup,
+			GroupOrder:          t.TaskGroupOrder,
 		})
 
 		if err := t.SetExpectedDuration(expectedTaskDuration); err != nil {
@@ -15,6 +15,7 @@ db.patchfiles.files.ensureIndex({"filename":1})
 
 //======event_log======//
 db.event_log.ensureIndex({ "r_id" : 1, "data.r_type" : 1, "ts" : 1 })
+db.event_log.ensureIndex({ "data.p_id" : 1, "data.r_type" : 1, "ts" : 1 })
 
 //======hosts======//
 db.hosts.ensureIndex({ "status": 1 })
@@ -80,3 +81,6 @@ db.test_logs.ensureIndex({ "execution" : 1, "name" : 1, "task" : 1 })
 db.json.ensureIndex({ "task_id" : 1 })
 db.json.ensureIndex({ "project_id" : 1, "tag" : 1 })
 db.json.ensureIndex({ "name" : 1, "task_id" : 1 })
+
+//======access_tokens======//
+db.access_tokens.ensureIndex({ "user_id" : 1, "name" : 1 }, { unique : true })
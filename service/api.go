@@ -77,6 +77,12 @@ func NewAPIServer(settings *evergreen.Settings, plugins []plugin.APIPlugin) (*AP
 		return nil, errors.WithStack(err)
 	}
 
+	logStorage, err := model.NewTaskLogStorage(settings.LogStorage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	model.SetTaskLogStorage(logStorage)
+
 	as := &APIServer{
 		Render:       render.New(render.Options{}),
 		UserManager:  authManager,
@@ -176,7 +182,8 @@ func (as *APIServer) checkTask(checkSecret bool, next http.HandlerFunc) http.Han
 }
 
 // checkProject finds the projectId in the request and adds the
-// project and project ref to the request context.
+// project and project ref to the request context. Projects that the
+// user can't view are not found.
 func (as *APIServer) checkProject(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projectId := mux.Vars(r)["projectId"]
@@ -189,7 +196,8 @@ func (as *APIServer) checkProject(next http.HandlerFunc) http.HandlerFunc {
 		if err != nil {
 			as.LoggedError(w, r, http.StatusInternalServerError, err)
 		}
-		if projectRef == nil {
+		if projectRef == nil ||
+			!hasProjectRole(&as.Settings, GetUser(r), projectRef, model.ProjectRoleViewer) {
 			as.LoggedError(w, r, http.StatusNotFound, errors.New("project not found"))
 			return
 		}
@@ -212,6 +220,36 @@ func (as *APIServer) checkProject(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// checkPatchProject finds the patch in the request and verifies that the
+// user has at least the given role in the patch's project. Patches in
+// projects that the user can't view are not found.
+func (as *APIServer) checkPatchProject(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, err := getPatchFromRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		projectRef, err := model.FindOneProjectRef(p.Project)
+		if err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		u := GetUser(r)
+		if !hasProjectRole(&as.Settings, u, projectRef, model.ProjectRoleViewer) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if !hasProjectRole(&as.Settings, u, projectRef, role) {
+			as.LoggedError(w, r, http.StatusForbidden,
+				errors.Errorf("the %v role in project '%v' is required", role, p.Project))
+			return
+		}
+		next(w, r)
+	}
+}
+
 func (as *APIServer) checkHost(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		hostId := mux.Vars(r)["hostId"]
@@ -356,8 +394,15 @@ func (as *APIServer) FetchProjectVars(w http.ResponseWriter, r *http.Request) {
 		as.WriteJSON(w, http.StatusOK, apimodels.ExpansionVars{})
 		return
 	}
+	if err = projectVars.DecryptPrivateVars(as.Settings.ProjectVarsKey); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
 
-	as.WriteJSON(w, http.StatusOK, projectVars.Vars)
+	as.WriteJSON(w, http.StatusOK, apimodels.ExpansionVars{
+		Vars:        projectVars.Vars,
+		PrivateVars: projectVars.PrivateVars,
+	})
 }
 
 // AttachFiles updates file mappings for a task or build
@@ -400,10 +445,11 @@ func (as *APIServer) AppendTaskLog(w http.ResponseWriter, r *http.Request) {
 	taskLog.TaskId = t.Id
 	taskLog.Execution = t.Execution
 
-	if err := taskLog.Insert(); err != nil {
+	if err := model.GetTaskLogStorage().Insert(taskLog); err != nil {
 		as.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	taskLogUpdates.notify(t.Id)
 
 	as.WriteJSON(w, http.StatusOK, "Logs added")
 }
@@ -642,7 +688,8 @@ func (as *APIServer) hostReady(w http.ResponseWriter, r *http.Request) {
 	grip.Infof("Successfully marked host '%s' with dns '%s' as provisioned", hostObj.Id, dns)
 }
 
-// fetchProjectRef returns a project ref given the project identifier
+// fetchProjectRef returns a project ref given the project identifier, if the
+// user can view the project.
 func (as *APIServer) fetchProjectRef(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["identifier"]
@@ -651,20 +698,29 @@ func (as *APIServer) fetchProjectRef(w http.ResponseWriter, r *http.Request) {
 		as.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
-	if projectRef == nil {
+	if projectRef == nil ||
+		!hasProjectRole(&as.Settings, GetUser(r), projectRef, model.ProjectRoleViewer) {
 		http.Error(w, fmt.Sprintf("no project found named '%v'", id), http.StatusNotFound)
 		return
 	}
 	as.WriteJSON(w, http.StatusOK, projectRef)
 }
 
+// listProjects returns the tracked projects that the user can view.
 func (as *APIServer) listProjects(w http.ResponseWriter, r *http.Request) {
 	allProjs, err := model.FindAllTrackedProjectRefs()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	as.WriteJSON(w, http.StatusOK, allProjs)
+	u := GetUser(r)
+	projs := []model.ProjectRef{}
+	for _, p := range allProjs {
+		if hasProjectRole(&as.Settings, u, &p, model.ProjectRoleViewer) {
+			projs = append(projs, p)
+		}
+	}
+	as.WriteJSON(w, http.StatusOK, projs)
 }
 
 func (as *APIServer) listTasks(w http.ResponseWriter, r *http.Request) {
@@ -778,7 +834,7 @@ func (as *APIServer) Handler() (http.Handler, error) {
 	// attaches the /rest/v1 routes
 	AttachRESTHandler(root, as)
 	// attaches /rest/v2 routes
-	route.AttachHandler(root, as.Settings.SuperUsers, as.Settings.ApiUrl, evergreen.RestRoutePrefix)
+	route.AttachHandler(root, &as.Settings, as.Settings.ApiUrl, evergreen.RestRoutePrefix)
 
 	r := root.PathPrefix("/api/2/").Subrouter()
 	r.HandleFunc("/", home)
@@ -806,11 +862,11 @@ func (as *APIServer) Handler() (http.Handler, error) {
 	patchPath := apiRootOld.PathPrefix("/patches").Subrouter()
 	patchPath.HandleFunc("/", requireUser(as.submitPatch, nil)).Methods("PUT")
 	patchPath.HandleFunc("/mine", requireUser(as.listPatches, nil)).Methods("GET")
-	patchPath.HandleFunc("/{patchId:\\w+}", requireUser(as.summarizePatch, nil)).Methods("GET")
-	patchPath.HandleFunc("/{patchId:\\w+}", requireUser(as.existingPatchRequest, nil)).Methods("POST")
+	patchPath.HandleFunc("/{patchId:\\w+}", requireUser(as.checkPatchProject(model.ProjectRoleViewer, as.summarizePatch), nil)).Methods("GET")
+	patchPath.HandleFunc("/{patchId:\\w+}", requireUser(as.checkPatchProject(model.ProjectRolePatchSubmitter, as.existingPatchRequest), nil)).Methods("POST")
 	patchPath.HandleFunc("/{patchId:\\w+}/{projectId}/modules", requireUser(as.checkProject(as.listPatchModules), nil)).Methods("GET")
-	patchPath.HandleFunc("/{patchId:\\w+}/modules", requireUser(as.deletePatchModule, nil)).Methods("DELETE")
-	patchPath.HandleFunc("/{patchId:\\w+}/modules", requireUser(as.updatePatchModule, nil)).Methods("POST")
+	patchPath.HandleFunc("/{patchId:\\w+}/modules", requireUser(as.checkPatchProject(model.ProjectRolePatchSubmitter, as.deletePatchModule), nil)).Methods("DELETE")
+	patchPath.HandleFunc("/{patchId:\\w+}/modules", requireUser(as.checkPatchProject(model.ProjectRolePatchSubmitter, as.updatePatchModule), nil)).Methods("POST")
 
 	// Routes for operating on existing spawn hosts - get info, terminate, etc.
 	spawn := apiRootOld.PathPrefix("/spawn/").Subrouter()
@@ -860,6 +916,7 @@ func (as *APIServer) Handler() (http.Handler, error) {
 	taskRouter.HandleFunc("/version", as.checkTask(false, as.GetVersion)).Methods("GET")
 	taskRouter.HandleFunc("/project_ref", as.checkTask(false, as.GetProjectRef)).Methods("GET")
 	taskRouter.HandleFunc("/fetch_vars", as.checkTask(true, as.FetchProjectVars)).Methods("GET")
+	taskRouter.HandleFunc("/generate/tasks", as.checkTask(true, as.checkHost(as.GenerateTasks))).Methods("POST")
 
 	// Install plugin routes
 	for _, pl := range as.plugins {
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/evergreen/validator"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GenerateTasks merges the project fragments uploaded by the generate.tasks
+// command into the config of the task's version, validates the result,
+// creates and activates the new tasks and then stores the merged config.
+// Validation errors are returned to the agent with a 400, and a 409 is
+// returned if the version's config changed while merging so that the agent
+// can try again. Tasks that already exist are not created again on a retry.
+func (as *APIServer) GenerateTasks(w http.ResponseWriter, r *http.Request) {
+	t := MustHaveTask(r)
+
+	fragments := []json.RawMessage{}
+	if err := util.ReadJSONInto(util.NewRequestReader(r), &fragments); err != nil {
+		as.LoggedError(w, r, http.StatusBadRequest, errors.Wrap(err, "error reading generated config"))
+		return
+	}
+	data := make([][]byte, 0, len(fragments))
+	for _, f := range fragments {
+		data = append(data, []byte(f))
+	}
+
+	v, err := version.FindOne(version.ById(t.Version))
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrapf(err, "error finding version %v", t.Version))
+		return
+	}
+	if v == nil {
+		http.Error(w, fmt.Sprintf("version %v not found", t.Version), http.StatusNotFound)
+		return
+	}
+
+	config, err := model.MergeGeneratedConfig(v.Config, data...)
+	if err != nil {
+		as.WriteJSON(w, http.StatusBadRequest, []validator.ValidationError{{Message: err.Error()}})
+		return
+	}
+
+	oldProject := &model.Project{}
+	if err = model.LoadProjectInto([]byte(v.Config), t.Project, oldProject); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error loading project for version %v", v.Id))
+		return
+	}
+	project := &model.Project{}
+	if err = model.LoadProjectInto([]byte(config), t.Project, project); err != nil {
+		as.WriteJSON(w, http.StatusBadRequest, []validator.ValidationError{{Message: err.Error()}})
+		return
+	}
+
+	syntaxErrs, err := validator.CheckProjectSyntax(project)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	verrs := []validator.ValidationError{}
+	for _, e := range append(syntaxErrs, validator.CheckProjectSemantics(project)...) {
+		if e.Level == validator.Error {
+			verrs = append(verrs, e)
+		}
+	}
+	if len(verrs) != 0 {
+		as.WriteJSON(w, http.StatusBadRequest, verrs)
+		return
+	}
+
+	pairs := model.NewGeneratedPairs(oldProject, project)
+	if len(pairs) == 0 {
+		as.WriteJSON(w, http.StatusOK, "no new tasks generated")
+		return
+	}
+
+	// create the tasks before storing the config they come from, so that if
+	// creating them fails, generating again still finds them missing
+	if err = model.AddGeneratedTasks(v, project, pairs); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error adding generated tasks from %v to version %v", t.Id, v.Id))
+		return
+	}
+
+	// only replace the config we merged into, so concurrent generators
+	// cannot overwrite each other's tasks
+	err = version.UpdateOne(
+		bson.M{version.IdKey: v.Id, version.ConfigKey: v.Config},
+		bson.M{"$set": bson.M{version.ConfigKey: config}},
+	)
+	if err == mgo.ErrNotFound {
+		http.Error(w, fmt.Sprintf("config for version %v changed while generating tasks", v.Id),
+			http.StatusConflict)
+		return
+	}
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error updating config for version %v", v.Id))
+		return
+	}
+	grip.Infof("task %s generated %d tasks in version %s", t.Id, len(pairs), v.Id)
+	as.WriteJSON(w, http.StatusOK, fmt.Sprintf("generated %d tasks", len(pairs)))
+}
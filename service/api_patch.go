@@ -186,6 +186,7 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 	dbUser := MustHaveUser(r)
 	var apiRequest PatchAPIRequest
 	var finalize bool
+	var notify *bool
 	if r.Header.Get("Content-Type") == formMimeType {
 		patchContent := r.FormValue("patch")
 		if patchContent == "" {
@@ -201,6 +202,10 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 			Description:   r.FormValue("desc"),
 		}
 		finalize = strings.ToLower(r.FormValue("finalize")) == "true"
+		if notifyValue := r.FormValue("notify"); notifyValue != "" {
+			n := strings.ToLower(notifyValue) == "true"
+			notify = &n
+		}
 	} else {
 		data := struct {
 			Description string   `json:"desc"`
@@ -210,6 +215,7 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 			Variants    string   `json:"buildvariants"`
 			Tasks       []string `json:"tasks"`
 			Finalize    bool     `json:"finalize"`
+			Notify      *bool    `json:"notify"`
 		}{}
 		if err := util.ReadJSONInto(util.NewRequestReader(r), &data); err != nil {
 			as.LoggedError(w, r, http.StatusBadRequest, err)
@@ -219,6 +225,7 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 			as.LoggedError(w, r, http.StatusBadRequest, errors.New("Patch is too large."))
 		}
 		finalize = data.Finalize
+		notify = data.Notify
 
 		apiRequest = PatchAPIRequest{
 			ProjectId:     data.Project,
@@ -231,6 +238,19 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	projectRef, err := model.FindOneProjectRef(apiRequest.ProjectId)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	// leave projects that don't exist for CreatePatch to report
+	if projectRef != nil &&
+		!hasProjectRole(&as.Settings, dbUser, projectRef, model.ProjectRolePatchSubmitter) {
+		as.LoggedError(w, r, http.StatusForbidden, errors.Errorf(
+			"the %v role in project '%v' is required", model.ProjectRolePatchSubmitter, apiRequest.ProjectId))
+		return
+	}
+
 	project, patchDoc, err := apiRequest.CreatePatch(
 		finalize, as.Settings.Credentials["github"], dbUser, &as.Settings)
 	if err != nil {
@@ -273,6 +293,8 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 
 	patchDoc.SyncVariantsTasks(model.TVPairsToVariantTasks(pairs))
 
+	patchDoc.Notify = notify
+
 	if err = patchDoc.Insert(); err != nil {
 		as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "error inserting patch"))
 		return
@@ -231,7 +231,14 @@ func assignNextAvailableTask(taskQueue *model.TaskQueue, currentHost *host.Host)
 	}
 	// only proceed if there are pending tasks left
 	for !taskQueue.IsEmpty() {
-		nextTaskId := taskQueue.NextTask().Id
+		queueItem, err := taskQueue.NextTaskForHost(currentHost)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if queueItem == nil {
+			break
+		}
+		nextTaskId := queueItem.Id
 
 		nextTask, err := task.FindOne(task.ById(nextTaskId))
 		if err != nil {
@@ -34,10 +34,14 @@ func getUiTaskCache(build *build.Build) ([]uiTask, error) {
 		idToTask[task.Id] = task
 	}
 
-	// Insert the tasks in the same order as the task cache
+	// Insert the tasks in the same order as the task cache, showing display
+	// tasks in place of their execution tasks
 	uiTasks := make([]uiTask, 0, len(build.Tasks))
-	for _, taskCache := range build.Tasks {
+	for _, taskCache := range build.DisplayedTasks() {
 		taskAsUI := uiTask{Task: idToTask[taskCache.Id]}
+		if len(taskCache.ExecutionTasks) > 0 {
+			taskAsUI = uiTaskFromCache(taskCache)
+		}
 		uiTasks = append(uiTasks, taskAsUI)
 	}
 	return uiTasks, nil
@@ -234,6 +238,7 @@ func (uis *UIServer) buildHistory(w http.ResponseWriter, r *http.Request) {
 
 	history.Builds = make([]*uiBuild, len(builds))
 	for i := 0; i < len(builds); i++ {
+		builds[i].Tasks = builds[i].DisplayedTasks()
 		v, err := version.FindOne(version.ById(builds[i].Version))
 		if err != nil {
 			http.Error(w, fmt.Sprintf("error getting version for build %v: %v", builds[i].Id, err), http.StatusInternalServerError)
@@ -266,6 +271,7 @@ func (uis *UIServer) buildHistory(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("no version '%v' found", lastSuccess.Version), http.StatusNotFound)
 			return
 		}
+		lastSuccess.Tasks = lastSuccess.DisplayedTasks()
 		history.LastSuccess = &uiBuild{
 			Build:       *lastSuccess,
 			CurrentTime: time.Now().UnixNano(),
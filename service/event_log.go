@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/gorilla/mux"
@@ -35,6 +36,25 @@ func (uis *UIServer) fullEventLogs(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		eventQuery = event.MostRecentDistroEvents(resourceId, 200)
+	case event.ResourceTypeProject:
+		if u == nil {
+			uis.RedirectToLogin(w, r)
+			return
+		}
+		projectRef, err := model.FindOneProjectRef(resourceId)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if projectRef == nil {
+			http.Error(w, "Project not found", http.StatusNotFound)
+			return
+		}
+		if !hasProjectRole(&uis.Settings, u, projectRef, model.ProjectRoleAdmin) {
+			http.Error(w, "Unauthorized", http.StatusForbidden)
+			return
+		}
+		eventQuery = event.MostRecentProjectAuditEvents(resourceId, 500)
 	default:
 		http.Error(w, fmt.Sprintf("Unknown resource: %v", resourceType), http.StatusBadRequest)
 		return
@@ -47,8 +67,9 @@ func (uis *UIServer) fullEventLogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	uis.WriteHTML(w, http.StatusOK, struct {
-		ProjectData projectContext
-		User        *user.DBUser
-		Data        []event.Event
-	}{projCtx, u, loggedEvents}, "base", "event_log.html", "base_angular.html")
+		ProjectData  projectContext
+		User         *user.DBUser
+		Data         []event.Event
+		ResourceType string
+	}{projCtx, u, loggedEvents, resourceType}, "base", "event_log.html", "base_angular.html")
 }
@@ -128,13 +128,13 @@ func withPluginUser(next http.Handler) http.HandlerFunc {
 }
 
 // requireAdmin takes in a request handler and returns a wrapped version which verifies that requests are
-// authenticated and that the user is either a super user or is part of the project context's project's admins.
+// authenticated and that the user is either a super user or a project_admin of the project context's project.
 func (uis *UIServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// get the project context
 		projCtx := MustHaveProjectContext(r)
 		if dbUser := GetUser(r); dbUser != nil {
-			if uis.isSuperUser(dbUser) || isAdmin(dbUser, projCtx.ProjectRef) {
+			if hasProjectRole(&uis.Settings, dbUser, projCtx.ProjectRef, model.ProjectRoleAdmin) {
 				next(w, r)
 				return
 			}
@@ -145,6 +145,25 @@ func (uis *UIServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// requireProjectRole takes in a request handler and returns a wrapped version which verifies that
+// the user has at least the given role in the project context's project. Requests which aren't
+// authenticated are redirected to the login page, and other users are forbidden.
+func (uis *UIServer) requireProjectRole(role string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projCtx := MustHaveProjectContext(r)
+		dbUser := GetUser(r)
+		if dbUser == nil {
+			uis.RedirectToLogin(w, r)
+			return
+		}
+		if !hasProjectRole(&uis.Settings, dbUser, projCtx.ProjectRef, role) {
+			http.Error(w, fmt.Sprintf("Forbidden - requires the %v role", role), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
 // requireUser takes a request handler and returns a wrapped version which verifies that requests
 // request are authenticated before proceeding. For a request which is not authenticated, it will
 // execute the onFail handler. If onFail is nil, a simple "unauthorized" error will be sent.
@@ -203,13 +222,26 @@ func (uis *UIServer) isSuperUser(u *user.DBUser) bool {
 
 }
 
-// isAdmin returns false if the user is nil or if its id is not
-// located in ProjectRef's Admins field.
-func isAdmin(u *user.DBUser, project *model.ProjectRef) bool {
+// projectRole returns the role that the user has in the project, given the super users and user
+// groups in the settings. Super users are admins of every project, and a nil user is one who isn't
+// logged in.
+func projectRole(settings *evergreen.Settings, u *user.DBUser, project *model.ProjectRef) string {
 	if u == nil {
-		return false
+		return project.UserRole("", nil)
 	}
-	return util.SliceContains(project.Admins, u.Id)
+	if auth.IsSuperUser(settings.SuperUsers, u) {
+		return model.ProjectRoleAdmin
+	}
+	return project.UserRole(u.Id, settings.UserGroups.GroupsForUser(u.Id))
+}
+
+// hasProjectRole returns whether the user may do what the given role allows in the project. If
+// there is no project, only super users may, so that they can create it.
+func hasProjectRole(settings *evergreen.Settings, u *user.DBUser, project *model.ProjectRef, role string) bool {
+	if project == nil {
+		return u != nil && auth.IsSuperUser(settings.SuperUsers, u)
+	}
+	return model.ProjectRoleAllows(projectRole(settings, u, project), role)
 }
 
 // RedirectToLogin forces a redirect to the login page. The redirect param is set on the query
@@ -233,7 +265,8 @@ func (uis *UIServer) RedirectToLogin(w http.ResponseWriter, r *http.Request) {
 }
 
 // Loads all Task/Build/Version/Patch/Project metadata and attaches it to the request.
-// If the project is private but the user is not logged in, redirects to the login page.
+// If the user can't view the project, redirects to the login page if they are not logged in,
+// and responds that the project was not found otherwise.
 func (uis *UIServer) loadCtx(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		projCtx, err := uis.LoadProjectContext(w, r)
@@ -242,8 +275,13 @@ func (uis *UIServer) loadCtx(next http.HandlerFunc) http.HandlerFunc {
 			uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error loading project context"))
 			return
 		}
-		if projCtx.ProjectRef != nil && projCtx.ProjectRef.Private && GetUser(r) == nil {
-			uis.RedirectToLogin(w, r)
+		if projCtx.ProjectRef != nil &&
+			!hasProjectRole(&uis.Settings, GetUser(r), projCtx.ProjectRef, model.ProjectRoleViewer) {
+			if GetUser(r) == nil {
+				uis.RedirectToLogin(w, r)
+				return
+			}
+			http.Error(w, "Project not found", http.StatusNotFound)
 			return
 		}
 
@@ -257,21 +295,19 @@ func (uis *UIServer) loadCtx(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// populateProjectRefs loads all project refs into the context. If includePrivate is true,
-// all available projects will be included, otherwise only public projects will be loaded.
-// Sets IsAdmin to true if the user id is located in a project's admin list.
-func (pc *projectContext) populateProjectRefs(includePrivate, isSuperUser bool, dbUser *user.DBUser) error {
+// populateProjectRefs loads all of the project refs that the user can view into the context.
+// Sets IsAdmin to true if the user is a project_admin of any of them.
+func (pc *projectContext) populateProjectRefs(settings *evergreen.Settings, dbUser *user.DBUser) error {
 	allProjs, err := model.FindAllTrackedProjectRefs()
 	if err != nil {
 		return err
 	}
 	pc.AllProjects = make([]UIProjectFields, 0, len(allProjs))
-	// User is not logged in, so only include public projects.
 	for _, p := range allProjs {
 		if !p.Enabled {
 			continue
 		}
-		if !p.Private || includePrivate {
+		if hasProjectRole(settings, dbUser, &p, model.ProjectRoleViewer) {
 			uiProj := UIProjectFields{
 				DisplayName: p.DisplayName,
 				Identifier:  p.Identifier,
@@ -281,7 +317,7 @@ func (pc *projectContext) populateProjectRefs(includePrivate, isSuperUser bool,
 			pc.AllProjects = append(pc.AllProjects, uiProj)
 		}
 
-		if includePrivate && (isSuperUser || isAdmin(dbUser, &p)) {
+		if hasProjectRole(settings, dbUser, &p, model.ProjectRoleAdmin) {
 			pc.IsAdmin = true
 		}
 	}
@@ -326,8 +362,7 @@ func (uis *UIServer) LoadProjectContext(rw http.ResponseWriter, r *http.Request)
 	projectId := uis.getRequestProjectId(r)
 
 	pc := projectContext{AuthRedirect: uis.UserManager.IsRedirect()}
-	isSuperUser := (dbUser != nil) && auth.IsSuperUser(uis.Settings.SuperUsers, dbUser)
-	err := pc.populateProjectRefs(dbUser != nil, isSuperUser, dbUser)
+	err := pc.populateProjectRefs(&uis.Settings, dbUser)
 	if err != nil {
 		return pc, err
 	}
@@ -340,6 +375,19 @@ func (uis *UIServer) LoadProjectContext(rw http.ResponseWriter, r *http.Request)
 
 	// Build a model.Context using the data available.
 	ctx, err := model.LoadContext(taskId, buildId, versionId, patchId, projectId)
+	if err != nil {
+		pc.Context = ctx
+		return pc, err
+	}
+
+	// Don't default to a project that the user can't view, so that pages which don't
+	// name a project still load for them.
+	if ctx.ProjectRef != nil && len(vars["project_id"]) == 0 &&
+		len(taskId)+len(buildId)+len(versionId)+len(patchId) == 0 &&
+		!hasProjectRole(&uis.Settings, dbUser, ctx.ProjectRef, model.ProjectRoleViewer) &&
+		len(pc.AllProjects) > 0 {
+		ctx, err = model.LoadContext("", "", "", "", pc.AllProjects[0].Identifier)
+	}
 	pc.Context = ctx
 	if err != nil {
 		return pc, err
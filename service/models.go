@@ -82,6 +82,26 @@ type uiTask struct {
 	PreviousTasks []task.Task
 	Elapsed       time.Duration
 	StartTime     int64
+
+	// ExecutionTasks holds the ids of the tasks that make up a display task
+	ExecutionTasks []string
+}
+
+// uiTaskFromCache creates a uiTask from a build's cached task, which may be
+// a display task shown in place of its execution tasks.
+func uiTaskFromCache(t build.TaskCache) uiTask {
+	return uiTask{
+		Task: task.Task{
+			Id:          t.Id,
+			Activated:   t.Activated,
+			StartTime:   t.StartTime,
+			TimeTaken:   t.TimeTaken,
+			Status:      t.Status,
+			Details:     t.StatusDetails,
+			DisplayName: t.DisplayName,
+		},
+		ExecutionTasks: t.ExecutionTasks,
+	}
 }
 
 func PopulateUIVersion(version *version.Version) (*uiVersion, error) {
@@ -102,16 +122,10 @@ func PopulateUIVersion(version *version.Version) (*uiVersion, error) {
 		buildAsUI := uiBuild{Build: build}
 
 		//Use the build's task cache, instead of querying for each individual task.
-		uiTasks := make([]uiTask, len(build.Tasks))
-		for i, t := range build.Tasks {
-			uiTasks[i] = uiTask{
-				Task: task.Task{
-					Id:          t.Id,
-					Status:      t.Status,
-					Details:     t.StatusDetails,
-					DisplayName: t.DisplayName,
-				},
-			}
+		displayedTasks := build.DisplayedTasks()
+		uiTasks := make([]uiTask, len(displayedTasks))
+		for i, t := range displayedTasks {
+			uiTasks[i] = uiTaskFromCache(t)
 		}
 
 		buildAsUI.Tasks = uiTasks
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 
 	"github.com/evergreen-ci/evergreen/alerts"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/gorilla/mux"
@@ -33,7 +35,7 @@ func (uis *UIServer) filterAuthorizedProjects(u *user.DBUser) ([]model.ProjectRe
 	authorizedProjects := []model.ProjectRef{}
 	// only returns projects for which the user is authorized to see.
 	for _, project := range allProjects {
-		if uis.isSuperUser(u) || isAdmin(u, &project) {
+		if hasProjectRole(&uis.Settings, u, &project, model.ProjectRoleAdmin) {
 			authorizedProjects = append(authorizedProjects, project)
 		}
 	}
@@ -52,7 +54,8 @@ func (uis *UIServer) projectsPage(w http.ResponseWriter, r *http.Request) {
 
 	// construct a json-marshaling friendly representation of our supported triggers
 	allTaskTriggers := []interface{}{}
-	for _, taskTrigger := range alerts.AvailableTaskFailTriggers {
+	taskTriggers := append([]alerts.Trigger{}, alerts.AvailableTaskFailTriggers...)
+	for _, taskTrigger := range append(taskTriggers, alerts.AvailableBisectTriggers...) {
 		allTaskTriggers = append(allTaskTriggers, struct {
 			Id      string `json:"id"`
 			Display string `json:"display"`
@@ -89,6 +92,9 @@ func (uis *UIServer) projectPage(w http.ResponseWriter, r *http.Request) {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	if projVars != nil {
+		projVars.RedactPrivateVars()
+	}
 
 	data := struct {
 		ProjectRef  *model.ProjectRef
@@ -129,18 +135,21 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	responseRef := struct {
-		Identifier         string            `json:"id"`
-		DisplayName        string            `json:"display_name"`
-		RemotePath         string            `json:"remote_path"`
-		BatchTime          int               `json:"batch_time"`
-		DeactivatePrevious bool              `json:"deactivate_previous"`
-		Branch             string            `json:"branch_name"`
-		ProjVarsMap        map[string]string `json:"project_vars"`
-		Enabled            bool              `json:"enabled"`
-		Private            bool              `json:"private"`
-		Owner              string            `json:"owner_name"`
-		Repo               string            `json:"repo_name"`
-		Admins             []string          `json:"admins"`
+		Identifier         string              `json:"id"`
+		DisplayName        string              `json:"display_name"`
+		RemotePath         string              `json:"remote_path"`
+		BatchTime          int                 `json:"batch_time"`
+		DeactivatePrevious bool                `json:"deactivate_previous"`
+		Branch             string              `json:"branch_name"`
+		ProjVarsMap        map[string]string   `json:"project_vars"`
+		PrivateVarsMap     map[string]bool     `json:"private_vars"`
+		Enabled            bool                `json:"enabled"`
+		Private            bool                `json:"private"`
+		Owner              string              `json:"owner_name"`
+		Repo               string              `json:"repo_name"`
+		Admins             []string            `json:"admins"`
+		Roles              []model.ProjectRole `json:"roles"`
+		ShareWeight        int                 `json:"share_weight"`
 		AlertConfig        map[string][]struct {
 			Provider string                 `json:"provider"`
 			Settings map[string]interface{} `json:"settings"`
@@ -152,6 +161,7 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	before := *projectRef
 	projectRef.DisplayName = responseRef.DisplayName
 	projectRef.RemotePath = responseRef.RemotePath
 	projectRef.BatchTime = responseRef.BatchTime
@@ -162,8 +172,20 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	projectRef.DeactivatePrevious = responseRef.DeactivatePrevious
 	projectRef.Repo = responseRef.Repo
 	projectRef.Admins = responseRef.Admins
+	projectRef.Roles = responseRef.Roles
 	projectRef.Identifier = id
 
+	// only superusers may change a project's share of host time, and an unset
+	// share weight keeps the stored one
+	requested := model.ProjectRef{ShareWeight: responseRef.ShareWeight}
+	if requested.ShareWeight != 0 && requested.GetShareWeight() != projectRef.GetShareWeight() {
+		if !uis.isSuperUser(dbUser) {
+			http.Error(w, "Only superusers may change the share weight", http.StatusForbidden)
+			return
+		}
+		projectRef.ShareWeight = responseRef.ShareWeight
+	}
+
 	projectRef.Alerts = map[string][]model.AlertConfig{}
 	for triggerId, alerts := range responseRef.AlertConfig {
 		//TODO validate the triggerID, provider, and settings.
@@ -175,6 +197,43 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err = projectRef.ValidateRoles(); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid roles: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err = projectRef.ValidateShareWeight(); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid share weight: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// encrypt the vars before anything is saved, so that a failure doesn't
+	// leave the project half-updated
+	oldVars, err := model.FindOneProjectVars(id)
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	projectVars := model.ProjectVars{id, responseRef.ProjVarsMap, responseRef.PrivateVarsMap}
+	if err = projectVars.EncryptPrivateVars(uis.Settings.ProjectVarsKey, oldVars); err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if oldVars == nil {
+		oldVars = &model.ProjectVars{}
+	}
+	// compare hashes of the private values, since their ciphertexts differ
+	// every time they're encrypted
+	oldHashedVars, err := oldVars.HashedVars(uis.Settings.ProjectVarsKey)
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	newHashedVars, err := projectVars.HashedVars(uis.Settings.ProjectVarsKey)
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
 	err = projectRef.Upsert()
 
 	if err != nil {
@@ -182,14 +241,18 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !reflect.DeepEqual(before, *projectRef) {
+		event.LogProjectModified(id, dbUser.Id, before.WithRedactedSecrets(), projectRef.WithRedactedSecrets())
+	}
+
 	//modify project vars if necessary
-	projectVars := model.ProjectVars{id, responseRef.ProjVarsMap}
 	_, err = projectVars.Upsert()
 
 	if err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	event.LogProjectVarsModified(id, dbUser.Id, oldHashedVars, newHashedVars)
 
 	allProjects, err := uis.filterAuthorizedProjects(dbUser)
 	if err != nil {
@@ -233,6 +296,7 @@ func (uis *UIServer) addProject(w http.ResponseWriter, r *http.Request) {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	event.LogProjectAdded(id, dbUser.Id)
 
 	allProjects, err := uis.filterAuthorizedProjects(dbUser)
 
@@ -33,9 +33,10 @@ type restBuild struct {
 }
 
 type buildStatus struct {
-	Id        string        `json:"task_id"`
-	Status    string        `json:"status"`
-	TimeTaken time.Duration `json:"time_taken"`
+	Id             string        `json:"task_id"`
+	Status         string        `json:"status"`
+	TimeTaken      time.Duration `json:"time_taken"`
+	ExecutionTasks []string      `json:"execution_tasks,omitempty"`
 }
 
 type buildStatusByTask map[string]buildStatus
@@ -70,11 +71,12 @@ func (restapi *restAPI) getBuildInfo(w http.ResponseWriter, r *http.Request) {
 	destBuild.Requester = b.Requester
 
 	destBuild.Tasks = make(buildStatusByTask, len(b.Tasks))
-	for _, task := range b.Tasks {
+	for _, task := range b.DisplayedTasks() {
 		status := buildStatus{
-			Id:        task.Id,
-			Status:    task.Status,
-			TimeTaken: task.TimeTaken,
+			Id:             task.Id,
+			Status:         task.Status,
+			TimeTaken:      task.TimeTaken,
+			ExecutionTasks: task.ExecutionTasks,
 		}
 		destBuild.Tasks[task.DisplayName] = status
 	}
@@ -99,11 +101,12 @@ func (restapi restAPI) getBuildStatus(w http.ResponseWriter, r *http.Request) {
 		Tasks:        make(buildStatusByTask, len(b.Tasks)),
 	}
 
-	for _, task := range b.Tasks {
+	for _, task := range b.DisplayedTasks() {
 		status := buildStatus{
-			Id:        task.Id,
-			Status:    task.Status,
-			TimeTaken: task.TimeTaken,
+			Id:             task.Id,
+			Status:         task.Status,
+			TimeTaken:      task.TimeTaken,
+			ExecutionTasks: task.ExecutionTasks,
 		}
 		result.Tasks[task.DisplayName] = status
 	}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model/flaky"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// getFlakyTests returns the flakiness of a project's tests, most flaky
+// first. Only tests considered flaky are included unless "all" is set, in
+// which case every test that flipped is. The results can be narrowed down by
+// "variants", "tasks" and "tests", and exported as CSV with "csv".
+func (restapi restAPI) getFlakyTests(w http.ResponseWriter, r *http.Request) {
+	projCtx := MustHaveRESTContext(r)
+	if projCtx.ProjectRef == nil {
+		restapi.WriteJSON(w, http.StatusNotFound, responseError{Message: "error finding project"})
+		return
+	}
+
+	all, err := util.GetBoolValue(r, "all", false)
+	if err != nil {
+		restapi.WriteJSON(w, http.StatusBadRequest, responseError{Message: err.Error()})
+		return
+	}
+	isCSV, err := util.GetBoolValue(r, "csv", false)
+	if err != nil {
+		restapi.WriteJSON(w, http.StatusBadRequest, responseError{Message: err.Error()})
+		return
+	}
+	variants := util.GetStringArrayValue(r, "variants", []string{})
+	tasks := util.GetStringArrayValue(r, "tasks", []string{})
+	tests := util.GetStringArrayValue(r, "tests", []string{})
+
+	flakiness, err := flaky.Find(flaky.ByProject(projCtx.ProjectRef.Identifier, !all))
+	if err != nil {
+		restapi.WriteJSON(w, http.StatusInternalServerError,
+			responseError{Message: fmt.Sprintf("error finding flaky tests: %v", err)})
+		return
+	}
+
+	results := []flaky.TestFlakiness{}
+	for _, f := range flakiness {
+		if (len(variants) == 0 || util.SliceContains(variants, f.BuildVariant)) &&
+			(len(tasks) == 0 || util.SliceContains(tasks, f.TaskName)) &&
+			(len(tests) == 0 || util.SliceContains(tests, f.TestFile)) {
+			results = append(results, f)
+		}
+	}
+
+	if isCSV {
+		util.WriteCSVResponse(w, http.StatusOK, results)
+		return
+	}
+	restapi.WriteJSON(w, http.StatusOK, results)
+}
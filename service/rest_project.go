@@ -3,10 +3,16 @@ package service
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
 )
 
+// maxProjectEvents is the default and maximum number of events returned
+// from a project's audit trail.
+const maxProjectEvents = 500
+
 // Returns a JSON response of an array with the ref information for the requested project_id.
 func (restapi restAPI) getProject(w http.ResponseWriter, r *http.Request) {
 	projCtx := MustHaveRESTContext(r)
@@ -22,9 +28,10 @@ func (restapi restAPI) getProject(w http.ResponseWriter, r *http.Request) {
 }
 
 // getProjectsIds returns a JSON response of an array of active project Ids.
-// Users must use credentials to see private projects.
+// Only the projects that the user can view are included.
 func (restapi restAPI) getProjectIds(w http.ResponseWriter, r *http.Request) {
 	u := GetUser(r)
+	settings := restapi.GetSettings()
 	refs, err := model.FindAllProjectRefs()
 	if err != nil {
 		restapi.WriteJSON(w, http.StatusNotFound, responseError{
@@ -34,7 +41,7 @@ func (restapi restAPI) getProjectIds(w http.ResponseWriter, r *http.Request) {
 	}
 	projects := []string{}
 	for _, r := range refs {
-		if r.Enabled && (!r.Private || u != nil) {
+		if r.Enabled && hasProjectRole(&settings, u, &r, model.ProjectRoleViewer) {
 			projects = append(projects, r.Identifier)
 		}
 	}
@@ -43,3 +50,42 @@ func (restapi restAPI) getProjectIds(w http.ResponseWriter, r *http.Request) {
 	}{projects})
 	return
 }
+
+// getProjectEvents returns a JSON response of the most recent events in the
+// project's audit trail, newest first. Only project admins may see it.
+func (restapi restAPI) getProjectEvents(w http.ResponseWriter, r *http.Request) {
+	projCtx := MustHaveRESTContext(r)
+	if projCtx.ProjectRef == nil {
+		restapi.WriteJSON(w, http.StatusNotFound, responseError{Message: "error finding project"})
+		return
+	}
+	settings := restapi.GetSettings()
+	if !hasProjectRole(&settings, GetUser(r), projCtx.ProjectRef, model.ProjectRoleAdmin) {
+		restapi.WriteJSON(w, http.StatusForbidden, responseError{Message: fmt.Sprintf(
+			"the %v role in project '%v' is required", model.ProjectRoleAdmin, projCtx.ProjectRef.Identifier)})
+		return
+	}
+
+	limit := maxProjectEvents
+	if limitValue := r.FormValue("limit"); limitValue != "" {
+		var err error
+		limit, err = strconv.Atoi(limitValue)
+		if err != nil || limit <= 0 {
+			restapi.WriteJSON(w, http.StatusBadRequest,
+				responseError{Message: fmt.Sprintf("invalid limit '%v'", limitValue)})
+			return
+		}
+		if limit > maxProjectEvents {
+			limit = maxProjectEvents
+		}
+	}
+
+	events, err := event.Find(event.AllLogCollection,
+		event.MostRecentProjectAuditEvents(projCtx.ProjectRef.Identifier, limit))
+	if err != nil {
+		restapi.WriteJSON(w, http.StatusInternalServerError,
+			responseError{Message: fmt.Sprintf("error finding project events: %v", err)})
+		return
+	}
+	restapi.WriteJSON(w, http.StatusOK, events)
+}
@@ -160,6 +160,39 @@ func TestProjectRoutes(t *testing.T) {
 		})
 	})
 
+	Convey("When loading a project's audit trail", t, func() {
+		testutil.HandleTestingErr(db.Clear(model.ProjectRefCollection), t,
+			"Error clearing '%v' collection", model.ProjectRefCollection)
+
+		projectId := "audited"
+		project := &model.ProjectRef{
+			Identifier: projectId,
+			Enabled:    true,
+			Repo:       "repo1",
+			Admins:     []string{},
+		}
+		So(project.Insert(), ShouldBeNil)
+
+		url, err := router.Get("project_events").URL("project_id", projectId)
+		So(err, ShouldBeNil)
+		request, err := http.NewRequest("GET", url.String(), nil)
+		So(err, ShouldBeNil)
+		request.AddCookie(&http.Cookie{Name: evergreen.AuthTokenCookie, Value: "token"})
+		response := httptest.NewRecorder()
+
+		Convey("users who are not project admins should be denied with a 403", func() {
+			n.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("project admins should be able to see it", func() {
+			project.Admins = []string{serviceutil.MockUser.Id}
+			So(project.Upsert(), ShouldBeNil)
+			n.ServeHTTP(response, request)
+			So(response.Code, ShouldEqual, http.StatusOK)
+		})
+	})
+
 	Convey("When finding info on a nonexistent project", t, func() {
 		url, err := router.Get("project_info").URL("project_id", "nope")
 		So(err, ShouldBeNil)
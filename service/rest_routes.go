@@ -43,7 +43,9 @@ func (ra *restAPI) loadCtx(next http.HandlerFunc) http.HandlerFunc {
 				errors.Wrap(err, "Error loading project context"))
 			return
 		}
-		if ctx.ProjectRef != nil && ctx.ProjectRef.Private && GetUser(r) == nil {
+		settings := ra.GetSettings()
+		if ctx.ProjectRef != nil &&
+			!hasProjectRole(&settings, GetUser(r), ctx.ProjectRef, model.ProjectRoleViewer) {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
@@ -90,7 +92,9 @@ func AttachRESTHandler(root *mux.Router, service restAPIService) http.Handler {
 	rtr.HandleFunc("/projects/{project_id}/versions", rest.loadCtx(rest.getRecentVersions)).Name("recent_versions").Methods("GET")
 	rtr.HandleFunc("/projects/{project_id}/revisions/{revision}", rest.loadCtx(rest.getVersionInfoViaRevision)).Name("version_info_via_revision").Methods("GET")
 	rtr.HandleFunc("/projects/{project_id}/test_history", rest.loadCtx(rest.GetTestHistory)).Name("test_history").Methods("GET")
+	rtr.HandleFunc("/projects/{project_id}/flaky_tests", rest.loadCtx(rest.getFlakyTests)).Name("flaky_tests").Methods("GET")
 	rtr.HandleFunc("/projects/{project_id}/last_green", rest.loadCtx(rest.lastGreen)).Name("last_green_version").Methods("GET")
+	rtr.HandleFunc("/projects/{project_id}/events", requireUser(rest.loadCtx(rest.getProjectEvents), nil)).Name("project_events").Methods("GET")
 	rtr.HandleFunc("/patches/{patch_id}", rest.loadCtx(rest.getPatch)).Name("patch_info").Methods("GET")
 	rtr.HandleFunc("/patches/{patch_id}/config", rest.loadCtx(rest.getPatchConfig)).Name("patch_config").Methods("GET")
 	rtr.HandleFunc("/versions/{version_id}", rest.loadCtx(rest.getVersionInfo)).Name("version_info").Methods("GET")
@@ -101,7 +105,11 @@ func AttachRESTHandler(root *mux.Router, service restAPIService) http.Handler {
 	rtr.HandleFunc("/builds/{build_id}/status", rest.loadCtx(rest.getBuildStatus)).Name("build_status").Methods("GET")
 	rtr.HandleFunc("/tasks/{task_id}", rest.loadCtx(rest.getTaskInfo)).Name("task_info").Methods("GET")
 	rtr.HandleFunc("/tasks/{task_id}/status", rest.loadCtx(rest.getTaskStatus)).Name("task_status").Methods("GET")
+	rtr.HandleFunc("/tasks/{task_id}/log/stream", rest.loadCtx(rest.streamTaskLog)).Name("task_log_stream").Methods("GET")
 	rtr.HandleFunc("/tasks/{task_name}/history", rest.loadCtx(rest.getTaskHistory)).Name("task_history").Methods("GET")
+	rtr.HandleFunc("/subscriptions", requireUser(rest.getSubscriptions, nil)).Name("subscriptions").Methods("GET")
+	rtr.HandleFunc("/subscriptions", requireUser(rest.addSubscription, nil)).Name("add_subscription").Methods("POST")
+	rtr.HandleFunc("/subscriptions/{subscription_id}", requireUser(rest.removeSubscription, nil)).Name("remove_subscription").Methods("DELETE")
 	rtr.HandleFunc("/scheduler/host_utilization", rest.loadCtx(rest.getHostUtilizationStats)).Name("host_utilization").Methods("GET")
 	rtr.HandleFunc("/scheduler/distro/{distro_id}/stats", rest.loadCtx(rest.getAverageSchedulerStats)).Name("avg_stats").Methods("GET")
 	rtr.HandleFunc("/scheduler/makespans", rest.loadCtx(rest.getOptimalAndActualMakespans)).Name("makespan").Methods("GET")
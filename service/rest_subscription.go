@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/subscription"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Returns a JSON response with the subscriptions created by the current user.
+func (restapi restAPI) getSubscriptions(w http.ResponseWriter, r *http.Request) {
+	u := MustHaveUser(r)
+	subs, err := subscription.Find(subscription.ByOwner(u.Id))
+	if err != nil {
+		restapi.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error finding subscriptions for %v", u.Id))
+		return
+	}
+	restapi.WriteJSON(w, http.StatusOK, subs)
+}
+
+// Creates a subscription owned by the current user. People can only
+// subscribe themselves, and subscribe themselves if no subscriber is given.
+// They must be able to view the selected project, and must be its admins to
+// subscribe a team.
+func (restapi restAPI) addSubscription(w http.ResponseWriter, r *http.Request) {
+	u := MustHaveUser(r)
+	sub := subscription.Subscription{}
+	if err := util.ReadJSONInto(util.NewRequestReader(r), &sub); err != nil {
+		restapi.WriteJSON(w, http.StatusBadRequest,
+			responseError{Message: fmt.Sprintf("problem parsing input: %v", err)})
+		return
+	}
+
+	sub.Owner = u.Id
+	if sub.Subscriber.Type == "" {
+		sub.Subscriber = subscription.Subscriber{Type: subscription.PersonSubscriber, Name: u.Id}
+	}
+	if sub.Subscriber.Type == subscription.PersonSubscriber && sub.Subscriber.Name != u.Id {
+		restapi.WriteJSON(w, http.StatusForbidden,
+			responseError{Message: "users can only subscribe themselves"})
+		return
+	}
+	if err := sub.Validate(); err != nil {
+		restapi.WriteJSON(w, http.StatusBadRequest, responseError{Message: err.Error()})
+		return
+	}
+	if sub.Selector.Project != "" {
+		projectRef, err := model.FindOneProjectRef(sub.Selector.Project)
+		if err != nil {
+			restapi.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		settings := restapi.GetSettings()
+		if projectRef == nil || !hasProjectRole(&settings, u, projectRef, model.ProjectRoleViewer) {
+			restapi.WriteJSON(w, http.StatusBadRequest, responseError{
+				Message: fmt.Sprintf("project '%v' not found", sub.Selector.Project)})
+			return
+		}
+		if sub.Subscriber.Type == subscription.TeamSubscriber &&
+			!hasProjectRole(&settings, u, projectRef, model.ProjectRoleAdmin) {
+			restapi.WriteJSON(w, http.StatusForbidden, responseError{
+				Message: fmt.Sprintf("only admins of project '%v' can subscribe teams", sub.Selector.Project)})
+			return
+		}
+	}
+
+	if err := sub.Insert(); err != nil {
+		restapi.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrap(err, "error saving subscription"))
+		return
+	}
+	restapi.WriteJSON(w, http.StatusOK, sub)
+}
+
+// Removes one of the current user's subscriptions.
+func (restapi restAPI) removeSubscription(w http.ResponseWriter, r *http.Request) {
+	u := MustHaveUser(r)
+	id := mux.Vars(r)["subscription_id"]
+	if !bson.IsObjectIdHex(id) {
+		restapi.WriteJSON(w, http.StatusBadRequest,
+			responseError{Message: fmt.Sprintf("invalid subscription id '%v'", id)})
+		return
+	}
+
+	sub, err := subscription.FindOne(subscription.ById(bson.ObjectIdHex(id)))
+	if err != nil {
+		restapi.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if sub == nil || sub.Owner != u.Id {
+		restapi.WriteJSON(w, http.StatusNotFound, responseError{Message: "error finding subscription"})
+		return
+	}
+
+	if err = subscription.Remove(sub.Id); err != nil {
+		restapi.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrap(err, "error removing subscription"))
+		return
+	}
+	restapi.WriteJSON(w, http.StatusOK, sub)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// how often a task log stream checks for logs that were posted to
+	// other servers, which don't wake it up
+	taskLogStreamPollInterval = 2 * time.Second
+	// how often a task log stream without new logs sends a comment, so
+	// that proxies don't close the connection
+	taskLogStreamKeepAliveInterval = 15 * time.Second
+)
+
+// taskLogNotifier wakes up the task log streams of a task whenever the
+// agent posts new logs for it to this server.
+type taskLogNotifier struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]bool
+}
+
+var taskLogUpdates = &taskLogNotifier{subscribers: map[string]map[chan struct{}]bool{}}
+
+// subscribe returns a channel that receives a value when new logs are
+// posted for the task. Values are dropped while one is already pending.
+func (n *taskLogNotifier) subscribe(taskId string) chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	if n.subscribers[taskId] == nil {
+		n.subscribers[taskId] = map[chan struct{}]bool{}
+	}
+	n.subscribers[taskId][ch] = true
+	return ch
+}
+
+func (n *taskLogNotifier) unsubscribe(taskId string, ch chan struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.subscribers[taskId], ch)
+	if len(n.subscribers[taskId]) == 0 {
+		delete(n.subscribers, taskId)
+	}
+}
+
+func (n *taskLogNotifier) notify(taskId string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subscribers[taskId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// taskLogStreamEnd is the data of the event that ends a task log stream.
+type taskLogStreamEnd struct {
+	Status string `json:"status"`
+}
+
+// streamTaskLog streams a task execution's log messages as server-sent
+// events: one "message" event per log message, followed by an "end" event
+// with the task's status once the task is finished. Unless follow=false
+// is given, the stream waits for new messages until then.
+func (restapi restAPI) streamTaskLog(w http.ResponseWriter, r *http.Request) {
+	projCtx := MustHaveRESTContext(r)
+	t := projCtx.Task
+	if t == nil {
+		restapi.WriteJSON(w, http.StatusNotFound, responseError{Message: "error finding task"})
+		return
+	}
+
+	execution := t.Execution
+	if executionStr := r.FormValue("execution"); executionStr != "" {
+		var err error
+		if execution, err = strconv.Atoi(executionStr); err != nil {
+			restapi.WriteJSON(w, http.StatusBadRequest, responseError{Message: "invalid execution"})
+			return
+		}
+	}
+	follow := r.FormValue("follow") != "false"
+
+	logType := r.FormValue("type")
+	if logType == "" {
+		logType = AllLogsType
+	}
+	logTypeFilter := []string{}
+	if logType != AllLogsType {
+		logTypeFilter = []string{logType}
+	}
+	// restrict access if the user is not logged in
+	if GetUser(r) == nil {
+		if logType == AllLogsType {
+			logTypeFilter = []string{model.TaskLogPrefix}
+		}
+		if logType == model.AgentLogPrefix || logType == model.SystemLogPrefix {
+			restapi.WriteJSON(w, http.StatusUnauthorized, responseError{Message: "must be logged in to view these logs"})
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		restapi.WriteJSON(w, http.StatusInternalServerError, responseError{Message: "streaming is not supported"})
+		return
+	}
+
+	updates := taskLogUpdates.subscribe(t.Id)
+	defer taskLogUpdates.unsubscribe(t.Id, updates)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := &taskLogStream{
+		w:             w,
+		taskId:        t.Id,
+		execution:     execution,
+		logTypeFilter: logTypeFilter,
+		sent:          map[bson.ObjectId]bool{},
+	}
+	lastWrite := time.Now()
+	for {
+		status, finished, err := taskLogStreamStatus(t.Id, execution)
+		if err != nil {
+			grip.Error(errors.Wrapf(err, "error streaming logs of task %v", t.Id))
+			return
+		}
+
+		wrote, err := stream.sendNewMessages()
+		if err != nil {
+			grip.Error(errors.Wrapf(err, "error streaming logs of task %v", t.Id))
+			return
+		}
+		if finished || !follow {
+			if err = writeServerSentEvent(w, "end", taskLogStreamEnd{status}); err != nil {
+				grip.Error(errors.Wrapf(err, "error streaming logs of task %v", t.Id))
+			}
+			flusher.Flush()
+			return
+		}
+
+		if wrote {
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= taskLogStreamKeepAliveInterval {
+			if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-updates:
+		case <-time.After(taskLogStreamPollInterval):
+		}
+	}
+}
+
+// taskLogStreamStatus returns the status of a task execution, and whether
+// it is finished, in which case no more logs will be posted for it.
+func taskLogStreamStatus(taskId string, execution int) (string, bool, error) {
+	t, err := task.FindOne(task.ById(taskId))
+	if err != nil {
+		return "", false, err
+	}
+	if t == nil {
+		return "", false, errors.Errorf("task %v not found", taskId)
+	}
+	if t.Execution == execution {
+		return t.Status, task.IsFinished(*t), nil
+	}
+
+	// earlier executions are archived, and later ones haven't started
+	oldTask, err := task.FindOneOld(task.ById(fmt.Sprintf("%v_%v", taskId, execution)))
+	if err != nil {
+		return "", false, err
+	}
+	if oldTask == nil {
+		return "", true, nil
+	}
+	return oldTask.Status, true, nil
+}
+
+// taskLogStream keeps track of which log messages of a task execution have
+// been sent. Log chunks are read in the order of their timestamps, so only
+// the chunks with the latest timestamp sent need remembering.
+type taskLogStream struct {
+	w             http.ResponseWriter
+	taskId        string
+	execution     int
+	logTypeFilter []string
+	since         time.Time
+	sent          map[bson.ObjectId]bool
+}
+
+// sendNewMessages sends the messages of the chunks that haven't been sent
+// yet, returning whether any were sent.
+func (s *taskLogStream) sendNewMessages() (bool, error) {
+	chunks, err := model.GetTaskLogStorage().FindSinceTime(s.taskId, s.execution, s.since)
+	if err != nil {
+		return false, errors.Wrap(err, "error finding new logs")
+	}
+
+	wrote := false
+	for _, chunk := range chunks {
+		if s.sent[chunk.Id] {
+			continue
+		}
+		for _, msg := range chunk.Messages {
+			if len(s.logTypeFilter) > 0 && !util.SliceContains(s.logTypeFilter, msg.Type) {
+				continue
+			}
+			if err = writeServerSentEvent(s.w, "message", msg); err != nil {
+				return wrote, err
+			}
+			wrote = true
+		}
+
+		if chunk.Timestamp.After(s.since) {
+			s.since = chunk.Timestamp
+			s.sent = map[bson.ObjectId]bool{}
+		}
+		s.sent[chunk.Id] = true
+	}
+	return wrote, nil
+}
+
+// writeServerSentEvent writes an event of the given type with the data
+// encoded as JSON.
+func writeServerSentEvent(w http.ResponseWriter, event string, data interface{}) error {
+	out, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "error encoding event")
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, out)
+	return errors.WithStack(err)
+}
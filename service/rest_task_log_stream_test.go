@@ -0,0 +1,89 @@
+package service
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestTaskLogNotifier(t *testing.T) {
+	Convey("With a task log notifier", t, func() {
+		notifier := &taskLogNotifier{subscribers: map[string]map[chan struct{}]bool{}}
+		updates := notifier.subscribe("task")
+
+		Convey("notifications of the task should wake up its subscribers once", func() {
+			notifier.notify("task")
+			notifier.notify("task")
+			notifier.notify("other_task")
+			So(len(updates), ShouldEqual, 1)
+		})
+
+		Convey("unsubscribing should remove the task's subscribers", func() {
+			notifier.unsubscribe("task", updates)
+			notifier.notify("task")
+			So(len(updates), ShouldEqual, 0)
+			So(notifier.subscribers, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestTaskLogStreamSendsEachMessageOnce(t *testing.T) {
+	Convey("With task logs in blob storage", t, func() {
+		dir, err := ioutil.TempDir("", "task_log_stream")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		bucket, err := thirdparty.NewLocalBlobBucket(dir)
+		So(err, ShouldBeNil)
+		storage := &model.BlobTaskLogStorage{Bucket: bucket}
+		model.SetTaskLogStorage(storage)
+		defer model.SetTaskLogStorage(model.MongoTaskLogStorage{})
+
+		now := time.Now()
+		insert := func(ts time.Time, logType, message string) {
+			So(storage.Insert(&model.TaskLog{
+				TaskId:    "task",
+				Timestamp: ts,
+				Messages:  []model.LogMessage{{Type: logType, Message: message, Timestamp: ts}},
+			}), ShouldBeNil)
+		}
+
+		w := httptest.NewRecorder()
+		stream := &taskLogStream{
+			w:             w,
+			taskId:        "task",
+			logTypeFilter: []string{model.TaskLogPrefix},
+			sent:          map[bson.ObjectId]bool{},
+		}
+
+		Convey("new messages should be sent once, even if they share a timestamp", func() {
+			insert(now, model.TaskLogPrefix, "first")
+			insert(now, model.AgentLogPrefix, "agent")
+			wrote, err := stream.sendNewMessages()
+			So(err, ShouldBeNil)
+			So(wrote, ShouldBeTrue)
+
+			wrote, err = stream.sendNewMessages()
+			So(err, ShouldBeNil)
+			So(wrote, ShouldBeFalse)
+
+			insert(now, model.TaskLogPrefix, "second")
+			insert(now.Add(time.Second), model.TaskLogPrefix, "third")
+			wrote, err = stream.sendNewMessages()
+			So(err, ShouldBeNil)
+			So(wrote, ShouldBeTrue)
+
+			body := w.Body.String()
+			So(strings.Count(body, "event: message"), ShouldEqual, 3)
+			So(body, ShouldNotContainSubstring, "agent")
+			So(strings.Index(body, "first"), ShouldBeLessThan, strings.Index(body, "third"))
+		})
+	})
+}
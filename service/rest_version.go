@@ -11,6 +11,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/gorilla/mux"
@@ -72,9 +73,10 @@ type versionLessInfo struct {
 }
 
 type versionStatus struct {
-	Id        string        `json:"task_id"`
-	Status    string        `json:"status"`
-	TimeTaken time.Duration `json:"time_taken"`
+	Id             string        `json:"task_id"`
+	Status         string        `json:"status"`
+	TimeTaken      time.Duration `json:"time_taken"`
+	ExecutionTasks []string      `json:"execution_tasks,omitempty"`
 }
 
 type versionByBuild map[string]versionBuildInfo
@@ -175,11 +177,12 @@ func (restapi restAPI) getRecentVersions(w http.ResponseWriter, r *http.Request)
 			Tasks: make(versionByBuildByTask, len(build.Tasks)),
 		}
 
-		for _, task := range build.Tasks {
+		for _, task := range build.DisplayedTasks() {
 			buildInfo.Tasks[task.DisplayName] = versionStatus{
-				Id:        task.Id,
-				Status:    task.Status,
-				TimeTaken: task.TimeTaken,
+				Id:             task.Id,
+				Status:         task.Status,
+				TimeTaken:      task.TimeTaken,
+				ExecutionTasks: task.ExecutionTasks,
 			}
 		}
 
@@ -272,9 +275,17 @@ func (restapi restAPI) modifyVersionInfo(w http.ResponseWriter, r *http.Request)
 		restapi.WriteJSON(w, http.StatusNotFound, responseError{Message: "error finding version"})
 		return
 	}
+	settings := restapi.GetSettings()
+	if !hasProjectRole(&settings, user, projCtx.ProjectRef, model.ProjectRoleTaskController) {
+		restapi.WriteJSON(w, http.StatusForbidden, responseError{
+			Message: fmt.Sprintf("the %v role is required to modify versions", model.ProjectRoleTaskController),
+		})
+		return
+	}
 
 	input := struct {
-		Activated *bool `json:"activated"`
+		Activated *bool  `json:"activated"`
+		Priority  *int64 `json:"priority"`
 	}{}
 
 	body := util.NewRequestReader(r)
@@ -296,6 +307,22 @@ func (restapi restAPI) modifyVersionInfo(w http.ResponseWriter, r *http.Request)
 			restapi.WriteJSON(w, http.StatusInternalServerError, responseError{Message: msg})
 			return
 		}
+		event.LogVersionActivation(v.Id, v.Identifier, v.Requester, user.Id, *input.Activated)
+	}
+
+	if input.Priority != nil {
+		if *input.Priority > evergreen.MaxTaskPriority && !util.SliceContains(restapi.GetSettings().SuperUsers, user.Id) {
+			msg := fmt.Sprintf("Insufficient access to set priority %v, can only set priority less than or equal to %v",
+				*input.Priority, evergreen.MaxTaskPriority)
+			restapi.WriteJSON(w, http.StatusForbidden, responseError{Message: msg})
+			return
+		}
+		if err := model.SetVersionPriority(v.Id, *input.Priority); err != nil {
+			msg := fmt.Sprintf("Error setting priority of version '%v'", v.Id)
+			restapi.WriteJSON(w, http.StatusInternalServerError, responseError{Message: msg})
+			return
+		}
+		event.LogVersionPriorityChanged(v.Id, v.Identifier, v.Requester, user.Id, *input.Priority)
 	}
 
 	restapi.getVersionInfo(w, r)
@@ -340,11 +367,18 @@ func (restapi *restAPI) getVersionStatusByTask(versionId string, w http.Response
 				build.VersionKey: versionId,
 			},
 		},
-		// 2. Loop through each task run on a particular build variant
+		// 2. Show display tasks in place of their execution tasks
+		{
+			"$project": bson.M{
+				build.BuildVariantKey: 1,
+				build.TasksKey:        build.DisplayedTasksExpression(),
+			},
+		},
+		// 3. Loop through each task run on a particular build variant
 		{
 			"$unwind": fmt.Sprintf("$%v", build.TasksKey),
 		},
-		// 3. Group on the task name and construct a new document containing
+		// 4. Group on the task name and construct a new document containing
 		//    all of the relevant info about the task status
 		{
 			"$group": bson.M{
@@ -357,11 +391,13 @@ func (restapi *restAPI) getVersionStatusByTask(versionId string, w http.Response
 						build.TaskCacheStartTimeKey: fmt.Sprintf("$%v.%v", build.TasksKey, build.TaskCacheStartTimeKey),
 						build.TaskCacheTimeTakenKey: fmt.Sprintf("$%v.%v", build.TasksKey, build.TaskCacheTimeTakenKey),
 						build.TaskCacheActivatedKey: fmt.Sprintf("$%v.%v", build.TasksKey, build.TaskCacheActivatedKey),
+						build.TaskCacheExecutionTasksKey: fmt.Sprintf("$%v.%v", build.TasksKey,
+							build.TaskCacheExecutionTasksKey),
 					},
 				},
 			},
 		},
-		// 4. Rename the "_id" field to "task_name"
+		// 5. Rename the "_id" field to "task_name"
 		{
 			"$project": bson.M{
 				id:       0,
@@ -398,9 +434,10 @@ func (restapi *restAPI) getVersionStatusByTask(versionId string, w http.Response
 		statuses := make(versionStatusByTask, len(task.Statuses))
 		for _, task := range task.Statuses {
 			status := versionStatus{
-				Id:        task.Id,
-				Status:    task.Status,
-				TimeTaken: task.TimeTaken,
+				Id:             task.Id,
+				Status:         task.Status,
+				TimeTaken:      task.TimeTaken,
+				ExecutionTasks: task.ExecutionTasks,
 			}
 			statuses[task.BuildVariant] = status
 		}
@@ -435,11 +472,12 @@ func (restapi restAPI) getVersionStatusByBuild(versionId string, w http.Response
 
 	for _, build := range builds {
 		statuses := make(versionStatusByBuild, len(build.Tasks))
-		for _, task := range build.Tasks {
+		for _, task := range build.DisplayedTasks() {
 			status := versionStatus{
-				Id:        task.Id,
-				Status:    task.Status,
-				TimeTaken: task.TimeTaken,
+				Id:             task.Id,
+				Status:         task.Status,
+				TimeTaken:      task.TimeTaken,
+				ExecutionTasks: task.ExecutionTasks,
 			}
 			statuses[task.DisplayName] = status
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flaky"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
@@ -64,6 +65,9 @@ type uiTaskData struct {
 	// from the host doc (the host id)
 	HostId string `json:"host_id,omitempty"`
 
+	// the failed tests that are known to be flaky, by test file
+	FlakyTests map[string]flaky.TestFlakiness `json:"flaky_tests"`
+
 	// for breadcrumb
 	BuildVariantDisplay string `json:"build_variant_display"`
 
@@ -218,6 +222,11 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 
 	task.DependsOn = deps
 	task.TaskWaiting = taskWaiting
+	task.FlakyTests, err = findFlakyFailures(projCtx.Task)
+	if err != nil {
+		// the annotations are only a hint, so the page is still useful without them
+		grip.Warningf("error finding flaky tests for task %v: %+v", projCtx.Task.Id, err)
+	}
 	task.MinQueuePos, err = model.FindMinimumQueuePositionForTask(task.Id)
 	if err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
@@ -315,6 +324,32 @@ func getTaskLogs(taskId string, execution int, limit int, logType string,
 		logTypeFilter)
 }
 
+// findFlakyFailures returns the flakiness of the task's failed tests that are
+// known to be flaky, by test file.
+func findFlakyFailures(t *task.Task) (map[string]flaky.TestFlakiness, error) {
+	flakyFailures := map[string]flaky.TestFlakiness{}
+	failed := map[string]bool{}
+	for _, result := range t.TestResults {
+		if result.Status == evergreen.TestFailedStatus {
+			failed[result.TestFile] = true
+		}
+	}
+	if len(failed) == 0 {
+		return flakyFailures, nil
+	}
+
+	flakiness, err := flaky.Find(flaky.ByTask(t.Project, t.BuildVariant, t.DisplayName))
+	if err != nil {
+		return flakyFailures, err
+	}
+	for _, f := range flakiness {
+		if f.Flaky && failed[f.TestFile] {
+			flakyFailures[f.TestFile] = f
+		}
+	}
+	return flakyFailures, nil
+}
+
 // getTaskDependencies returns the uiDeps for the task and its status (either its original status,
 // "blocked", or "pending")
 func getTaskDependencies(t *task.Task) ([]uiDep, string, error) {
@@ -639,7 +674,7 @@ func (uis *UIServer) taskModify(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
-		if err = projCtx.Task.SetPriority(priority); err != nil {
+		if err = projCtx.Task.SetPriority(priority, authUser.Username()); err != nil {
 			http.Error(w, fmt.Sprintf("Error setting task priority %v: %v", projCtx.Task.Id, err), http.StatusInternalServerError)
 			return
 		}
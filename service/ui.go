@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/apiv3/route"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/plugin"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/render"
@@ -58,6 +59,12 @@ func NewUIServer(settings *evergreen.Settings, home string) (*UIServer, error) {
 	}
 	db.SetGlobalSessionProvider(db.SessionFactoryFromConfig(settings))
 
+	logStorage, err := model.NewTaskLogStorage(settings.LogStorage)
+	if err != nil {
+		return nil, err
+	}
+	model.SetTaskLogStorage(logStorage)
+
 	uis.Settings = *settings
 	uis.Home = home
 
@@ -129,7 +136,7 @@ func (uis *UIServer) NewRouter() (*mux.Router, error) {
 	// Task page (and related routes)
 	r.HandleFunc("/task/{task_id}", uis.loadCtx(uis.taskPage)).Methods("GET")
 	r.HandleFunc("/task/{task_id}/{execution}", uis.loadCtx(uis.taskPage)).Methods("GET")
-	r.HandleFunc("/tasks/{task_id}", requireLogin(uis.loadCtx(uis.taskModify))).Methods("PUT")
+	r.HandleFunc("/tasks/{task_id}", requireLogin(uis.loadCtx(uis.requireProjectRole(model.ProjectRoleTaskController, uis.taskModify)))).Methods("PUT")
 	r.HandleFunc("/json/task_log/{task_id}", uis.loadCtx(uis.taskLog))
 	r.HandleFunc("/json/task_log/{task_id}/{execution}", uis.loadCtx(uis.taskLog))
 	r.HandleFunc("/task_log_raw/{task_id}/{execution}", uis.loadCtx(uis.taskLogRaw))
@@ -140,12 +147,12 @@ func (uis *UIServer) NewRouter() (*mux.Router, error) {
 
 	// Build page
 	r.HandleFunc("/build/{build_id}", uis.loadCtx(uis.buildPage)).Methods("GET")
-	r.HandleFunc("/builds/{build_id}", requireLogin(uis.loadCtx(uis.modifyBuild))).Methods("PUT")
+	r.HandleFunc("/builds/{build_id}", requireLogin(uis.loadCtx(uis.requireProjectRole(model.ProjectRoleTaskController, uis.modifyBuild)))).Methods("PUT")
 	r.HandleFunc("/json/build_history/{build_id}", uis.loadCtx(uis.buildHistory)).Methods("GET")
 
 	// Version page
 	r.HandleFunc("/version/{version_id}", uis.loadCtx(uis.versionPage)).Methods("GET")
-	r.HandleFunc("/version/{version_id}", requireLogin(uis.loadCtx(uis.modifyVersion))).Methods("PUT")
+	r.HandleFunc("/version/{version_id}", requireLogin(uis.loadCtx(uis.requireProjectRole(model.ProjectRoleTaskController, uis.modifyVersion)))).Methods("PUT")
 	r.HandleFunc("/json/version_history/{version_id}", uis.loadCtx(uis.versionHistory))
 	r.HandleFunc("/version/{project_id}/{revision}", uis.loadCtx(uis.versionFind)).Methods("GET")
 
@@ -191,7 +198,7 @@ func (uis *UIServer) NewRouter() (*mux.Router, error) {
 
 	// Patch pages
 	r.HandleFunc("/patch/{patch_id}", requireLogin(uis.loadCtx(uis.patchPage))).Methods("GET")
-	r.HandleFunc("/patch/{patch_id}", requireLogin(uis.loadCtx(uis.schedulePatch))).Methods("POST")
+	r.HandleFunc("/patch/{patch_id}", requireLogin(uis.loadCtx(uis.requireProjectRole(model.ProjectRolePatchSubmitter, uis.schedulePatch)))).Methods("POST")
 	r.HandleFunc("/diff/{patch_id}/", requireLogin(uis.loadCtx(uis.diffPage)))
 	r.HandleFunc("/filediff/{patch_id}/", requireLogin(uis.loadCtx(uis.fileDiffPage)))
 	r.HandleFunc("/rawdiff/{patch_id}/", requireLogin(uis.loadCtx(uis.rawDiffPage)))
@@ -230,7 +237,7 @@ func (uis *UIServer) NewRouter() (*mux.Router, error) {
 	AttachRESTHandler(r, uis)
 
 	// attaches /rest/v2 routes
-	route.AttachHandler(r, uis.Settings.SuperUsers, uis.Settings.Ui.Url, evergreen.RestRoutePrefix)
+	route.AttachHandler(r, &uis.Settings, uis.Settings.Ui.Url, evergreen.RestRoutePrefix)
 
 	// Static Path handlers
 	r.PathPrefix("/clients").Handler(http.StripPrefix("/clients", http.FileServer(http.Dir(filepath.Join(uis.Home, evergreen.ClientDirectory)))))
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
@@ -117,6 +118,13 @@ func (uis *UIServer) userSettingsModify(w http.ResponseWriter, r *http.Request)
 		uis.LoggedError(w, r, http.StatusBadRequest, err)
 		return
 	}
+	if webhook := userSettings.PatchNotifications.WebhookURL; webhook != "" {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			uis.LoggedError(w, r, http.StatusBadRequest,
+				errors.Errorf("patch webhook '%v' is not an http(s) URL", webhook))
+			return
+		}
+	}
 
 	if err := model.SaveUserSettings(currentUser.Username(), userSettings); err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError,
@@ -7,7 +7,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
-	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/plugin"
@@ -75,13 +75,8 @@ func (uis *UIServer) versionPage(w http.ResponseWriter, r *http.Request) {
 		buildAsUI := uiBuild{Build: build}
 
 		uiTasks := make([]uiTask, 0, len(build.Tasks))
-		for _, t := range build.Tasks {
-			uiTasks = append(uiTasks,
-				uiTask{
-					Task: task.Task{
-						Id: t.Id, Activated: t.Activated, StartTime: t.StartTime, TimeTaken: t.TimeTaken,
-						Status: t.Status, Details: t.StatusDetails, DisplayName: t.DisplayName,
-					}})
+		for _, t := range build.DisplayedTasks() {
+			uiTasks = append(uiTasks, uiTaskFromCache(t))
 			if t.Activated {
 				versionAsUI.ActiveTasks++
 			}
@@ -147,6 +142,8 @@ func (uis *UIServer) modifyVersion(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		event.LogVersionActivation(projCtx.Version.Id, projCtx.Version.Identifier,
+			projCtx.Version.Requester, user.Id, jsonMap.Active)
 	case "set_priority":
 		if jsonMap.Priority > evergreen.MaxTaskPriority {
 			if !uis.isSuperUser(user) {
@@ -159,6 +156,8 @@ func (uis *UIServer) modifyVersion(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		event.LogVersionPriorityChanged(projCtx.Version.Id, projCtx.Version.Identifier,
+			projCtx.Version.Requester, user.Id, jsonMap.Priority)
 	default:
 		uis.WriteJSON(w, http.StatusBadRequest, fmt.Sprintf("Unrecognized action: %v", jsonMap.Action))
 		return
@@ -187,13 +186,8 @@ func (uis *UIServer) modifyVersion(w http.ResponseWriter, r *http.Request) {
 	for _, build := range dbBuilds {
 		buildAsUI := uiBuild{Build: build}
 		uiTasks := make([]uiTask, 0, len(build.Tasks))
-		for _, t := range build.Tasks {
-			uiTasks = append(uiTasks,
-				uiTask{
-					Task: task.Task{Id: t.Id, Activated: t.Activated,
-						StartTime: t.StartTime, TimeTaken: t.TimeTaken, Status: t.Status,
-						Details: t.StatusDetails, DisplayName: t.DisplayName},
-				})
+		for _, t := range build.DisplayedTasks() {
+			uiTasks = append(uiTasks, uiTaskFromCache(t))
 			if t.Activated {
 				versionAsUI.ActiveTasks++
 			}
@@ -220,7 +214,7 @@ func (uis *UIServer) versionHistory(w http.ResponseWriter, r *http.Request) {
 		// Check whether the project associated with the particular version
 		// is accessible to this user. If not, we exclude it from the version
 		// history. This is done to hide the existence of the private project.
-		if projCtx.ProjectRef.Private && user == nil {
+		if !hasProjectRole(&uis.Settings, user, projCtx.ProjectRef, model.ProjectRoleViewer) {
 			continue
 		}
 
@@ -241,16 +235,8 @@ func (uis *UIServer) versionHistory(w http.ResponseWriter, r *http.Request) {
 		for _, b := range dbBuilds {
 			buildAsUI := uiBuild{Build: b}
 			uiTasks := make([]uiTask, 0, len(b.Tasks))
-			for _, t := range b.Tasks {
-				uiTasks = append(uiTasks,
-					uiTask{
-						Task: task.Task{
-							Id:          t.Id,
-							Status:      t.Status,
-							Activated:   t.Activated,
-							DisplayName: t.DisplayName,
-						},
-					})
+			for _, t := range b.DisplayedTasks() {
+				uiTasks = append(uiTasks, uiTaskFromCache(t))
 				if t.Activated {
 					versionAsUI.ActiveTasks++
 				}
@@ -104,6 +104,7 @@ type waterfallTask struct {
 	FailedTestNames  []string                `json:"failed_test_names,omitempty"`
 	ExpectedDuration time.Duration           `json:"expected_duration,omitempty"`
 	StartTime        int64                   `json:"start_time"`
+	ExecutionTasks   []string                `json:"execution_tasks,omitempty"`
 }
 
 // taskStatusCount holds all the counts for task statuses for a given build.
@@ -190,13 +191,14 @@ func createWaterfallTasks(tasks []build.TaskCache) ([]waterfallTask, taskStatusC
 	// add the tasks to the build
 	for _, t := range tasks {
 		taskForWaterfall := waterfallTask{
-			Id:            t.Id,
-			Status:        t.Status,
-			StatusDetails: t.StatusDetails,
-			DisplayName:   t.DisplayName,
-			Activated:     t.Activated,
-			TimeTaken:     t.TimeTaken,
-			StartTime:     t.StartTime.UnixNano(),
+			Id:             t.Id,
+			Status:         t.Status,
+			StatusDetails:  t.StatusDetails,
+			DisplayName:    t.DisplayName,
+			Activated:      t.Activated,
+			TimeTaken:      t.TimeTaken,
+			StartTime:      t.StartTime.UnixNano(),
+			ExecutionTasks: t.ExecutionTasks,
 		}
 		taskForWaterfall.Status = uiStatus(taskForWaterfall)
 
@@ -371,7 +373,7 @@ func getVersionsAndVariants(skip, numVersionElements int, project *model.Project
 					Version: versionFromDB.Id,
 				}
 
-				tasks, statusCount := createWaterfallTasks(b.Tasks)
+				tasks, statusCount := createWaterfallTasks(b.DisplayedTasks())
 				buildForWaterfall.Tasks = tasks
 				buildForWaterfall.TaskStatusCount = statusCount
 				currentRow.Builds[versionFromDB.Id] = buildForWaterfall
@@ -9,8 +9,10 @@ import (
 	"github.com/pkg/errors"
 )
 
-// DispatchTaskForHost assigns the task at the head of the task queue to the
-// given host, dequeues the task and then marks it as dispatched for the host
+// DispatchTaskForHost assigns the next task in the task queue to the given
+// host, dequeues the task and then marks it as dispatched for the host. Tasks
+// in a task group are preferentially dispatched to the host that ran the
+// previous member of the group.
 func DispatchTaskForHost(taskQueue *model.TaskQueue, assignedHost *host.Host) (
 	nextTask *task.Task, err error) {
 	if assignedHost == nil {
@@ -19,7 +21,15 @@ func DispatchTaskForHost(taskQueue *model.TaskQueue, assignedHost *host.Host) (
 
 	// only proceed if there are pending tasks left
 	for !taskQueue.IsEmpty() {
-		queueItem := taskQueue.NextTask()
+		var queueItem *model.TaskQueueItem
+		queueItem, err = taskQueue.NextTaskForHost(assignedHost)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error finding next task for host %v",
+				assignedHost.Id)
+		}
+		if queueItem == nil {
+			break
+		}
 		// pin the task to the given host and fetch the full task document from
 		// the database
 		nextTask, err = task.FindOne(task.ById(queueItem.Id))
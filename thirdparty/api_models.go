@@ -41,6 +41,12 @@ func (nfe FileNotFoundError) Error() string {
 	return fmt.Sprintf("Requested file at %v not found", nfe.filepath)
 }
 
+// NewFileNotFoundError returns a FileNotFoundError for the given file path,
+// for use by pollers that do not fetch configuration through the GitHub API.
+func NewFileNotFoundError(filepath string) FileNotFoundError {
+	return FileNotFoundError{filepath}
+}
+
 func IsFileNotFound(err error) bool {
 	_, ok := err.(FileNotFoundError)
 	return ok
@@ -0,0 +1,190 @@
+package thirdparty
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goamz/goamz/aws"
+	"github.com/goamz/goamz/s3"
+	"github.com/pkg/errors"
+)
+
+// BlobBucket is a flat store of blobs by key, such as an S3 bucket or a
+// local directory. Keys use slashes to separate their parts.
+type BlobBucket interface {
+	// Put stores the data under the key, replacing any blob stored there.
+	Put(key string, data []byte) error
+	// Get returns a reader of the blob stored under the key.
+	Get(key string) (io.ReadCloser, error)
+	// List returns the keys that start with the prefix, in lexical order.
+	List(prefix string) ([]string, error)
+	// Delete removes the blob stored under the key.
+	Delete(key string) error
+}
+
+// LocalBlobBucket is a BlobBucket that stores each blob as a file under a
+// directory, with the parts of its key as subdirectories.
+type LocalBlobBucket struct {
+	Path string
+}
+
+// NewLocalBlobBucket returns a BlobBucket for the directory, creating it if
+// it doesn't exist.
+func NewLocalBlobBucket(path string) (*LocalBlobBucket, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error creating blob directory %v", path)
+	}
+	return &LocalBlobBucket{Path: path}, nil
+}
+
+func (b *LocalBlobBucket) filePath(key string) (string, error) {
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." {
+			return "", errors.Errorf("invalid blob key '%v'", key)
+		}
+	}
+	return filepath.Join(b.Path, filepath.FromSlash(key)), nil
+}
+
+func (b *LocalBlobBucket) Put(key string, data []byte) error {
+	path, err := b.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "error creating directory for blob %v", key)
+	}
+
+	// write to a temporary file first so readers never see a partial blob
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return errors.Wrapf(err, "error creating file for blob %v", key)
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "error writing blob %v", key)
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "error writing blob %v", key)
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "error storing blob %v", key)
+}
+
+func (b *LocalBlobBucket) Get(key string) (io.ReadCloser, error) {
+	path, err := b.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	return file, errors.Wrapf(err, "error opening blob %v", key)
+}
+
+func (b *LocalBlobBucket) List(prefix string) ([]string, error) {
+	// only walk the directory the prefix's last complete part names
+	dir := ""
+	if idx := strings.LastIndex(prefix, "/"); idx >= 0 {
+		dir = prefix[:idx]
+	}
+	root, err := b.filePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(b.Path, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing blobs with prefix %v", prefix)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *LocalBlobBucket) Delete(key string) error {
+	path, err := b.filePath(key)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(os.Remove(path), "error deleting blob %v", key)
+}
+
+// S3BlobBucket is a BlobBucket backed by an S3 bucket, or a bucket of any
+// server with an S3-compatible API.
+type S3BlobBucket struct {
+	bucket *s3.Bucket
+}
+
+// NewS3BlobBucket returns a BlobBucket for the named S3 bucket. If endpoint
+// is set, the bucket is on the S3-compatible server at that URL instead.
+func NewS3BlobBucket(auth *aws.Auth, regionName, endpoint, name string) (*S3BlobBucket, error) {
+	region := aws.USEast
+	if regionName != "" {
+		var ok bool
+		if region, ok = aws.Regions[regionName]; !ok {
+			return nil, errors.Errorf("unknown S3 region '%v'", regionName)
+		}
+	}
+	if endpoint != "" {
+		region.Name = "custom"
+		region.S3Endpoint = endpoint
+		region.S3BucketEndpoint = ""
+	}
+	return &S3BlobBucket{bucket: NewS3Session(auth, region).Bucket(name)}, nil
+}
+
+func (b *S3BlobBucket) Put(key string, data []byte) error {
+	err := b.bucket.PutReader(key, bytes.NewReader(data), int64(len(data)),
+		"application/octet-stream", s3.Private, s3.Options{})
+	return errors.Wrapf(err, "error uploading blob %v", key)
+}
+
+func (b *S3BlobBucket) Get(key string) (io.ReadCloser, error) {
+	reader, err := b.bucket.GetReader(key)
+	return reader, errors.Wrapf(err, "error downloading blob %v", key)
+}
+
+func (b *S3BlobBucket) List(prefix string) ([]string, error) {
+	keys := []string{}
+	marker := ""
+	for {
+		resp, err := b.bucket.List(prefix, "", marker, 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing blobs with prefix %v", prefix)
+		}
+		for _, key := range resp.Contents {
+			keys = append(keys, key.Key)
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			return keys, nil
+		}
+		marker = resp.Contents[len(resp.Contents)-1].Key
+	}
+}
+
+func (b *S3BlobBucket) Delete(key string) error {
+	return errors.Wrapf(b.bucket.Del(key), "error deleting blob %v", key)
+}
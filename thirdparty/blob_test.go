@@ -0,0 +1,70 @@
+package thirdparty
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocalBlobBucket(t *testing.T) {
+	Convey("With a local blob bucket", t, func() {
+		dir, err := ioutil.TempDir("", "blobs")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		bucket, err := NewLocalBlobBucket(dir)
+		So(err, ShouldBeNil)
+
+		So(bucket.Put("task/0/b", []byte("second")), ShouldBeNil)
+		So(bucket.Put("task/0/a", []byte("first")), ShouldBeNil)
+		So(bucket.Put("task/1/a", []byte("retry")), ShouldBeNil)
+		So(bucket.Put("task_two/0/a", []byte("other")), ShouldBeNil)
+
+		Convey("blobs should be read back", func() {
+			reader, err := bucket.Get("task/0/a")
+			So(err, ShouldBeNil)
+			defer reader.Close()
+			data, err := ioutil.ReadAll(reader)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "first")
+		})
+
+		Convey("putting a blob should replace it", func() {
+			So(bucket.Put("task/0/a", []byte("replaced")), ShouldBeNil)
+			reader, err := bucket.Get("task/0/a")
+			So(err, ShouldBeNil)
+			defer reader.Close()
+			data, err := ioutil.ReadAll(reader)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "replaced")
+		})
+
+		Convey("listing should return the keys with the prefix in order", func() {
+			keys, err := bucket.List("task/0/")
+			So(err, ShouldBeNil)
+			So(keys, ShouldResemble, []string{"task/0/a", "task/0/b"})
+
+			keys, err = bucket.List("task")
+			So(err, ShouldBeNil)
+			So(keys, ShouldResemble, []string{"task/0/a", "task/0/b", "task/1/a", "task_two/0/a"})
+
+			keys, err = bucket.List("missing/0/")
+			So(err, ShouldBeNil)
+			So(keys, ShouldBeEmpty)
+		})
+
+		Convey("deleted blobs should no longer be listed", func() {
+			So(bucket.Delete("task/0/a"), ShouldBeNil)
+			keys, err := bucket.List("task/0/")
+			So(err, ShouldBeNil)
+			So(keys, ShouldResemble, []string{"task/0/b"})
+			_, err = bucket.Get("task/0/a")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("keys should not escape the directory", func() {
+			So(bucket.Put("../escaped", []byte("data")), ShouldNotBeNil)
+		})
+	})
+}
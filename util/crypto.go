@@ -0,0 +1,76 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// newGCM returns an AES-256 GCM cipher keyed with the SHA-256 digest of the
+// given key, so that keys of any length may be used.
+func newGCM(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, errors.New("encryption key must not be empty")
+	}
+	digest := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(digest[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return gcm, nil
+}
+
+// EncryptString encrypts a string with the given key, returning the base64
+// encoded nonce and ciphertext.
+func EncryptString(key, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "error generating nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString decrypts a string produced by EncryptString with the same key.
+func DecryptString(key, ciphertext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", errors.Wrap(err, "error decoding ciphertext")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error decrypting ciphertext")
+	}
+	return string(plaintext), nil
+}
+
+// HashString returns the hex encoded HMAC-SHA256 digest of a string keyed with
+// the given key. Unlike ciphertexts, equal strings have equal digests.
+func HashString(key, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
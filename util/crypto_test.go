@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEncryptString(t *testing.T) {
+	Convey("With a key and a secret", t, func() {
+		key := "server key"
+		secret := "hunter2"
+
+		Convey("an encrypted secret should decrypt with the same key", func() {
+			ciphertext, err := EncryptString(key, secret)
+			So(err, ShouldBeNil)
+			So(ciphertext, ShouldNotContainSubstring, secret)
+
+			plaintext, err := DecryptString(key, ciphertext)
+			So(err, ShouldBeNil)
+			So(plaintext, ShouldEqual, secret)
+		})
+
+		Convey("encrypting the same secret twice should give different ciphertexts", func() {
+			first, err := EncryptString(key, secret)
+			So(err, ShouldBeNil)
+			second, err := EncryptString(key, secret)
+			So(err, ShouldBeNil)
+			So(first, ShouldNotEqual, second)
+		})
+
+		Convey("an encrypted secret should not decrypt with a different key", func() {
+			ciphertext, err := EncryptString(key, secret)
+			So(err, ShouldBeNil)
+			_, err = DecryptString("other key", ciphertext)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("an empty key should be rejected", func() {
+			_, err := EncryptString("", secret)
+			So(err, ShouldNotBeNil)
+			_, err = DecryptString("", secret)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestHashString(t *testing.T) {
+	Convey("With a key and a secret", t, func() {
+		key := "server key"
+		secret := "hunter2"
+
+		Convey("hashing the same secret twice should give the same digest", func() {
+			So(HashString(key, secret), ShouldEqual, HashString(key, secret))
+			So(HashString(key, secret), ShouldNotContainSubstring, secret)
+		})
+
+		Convey("different secrets or keys should give different digests", func() {
+			So(HashString(key, secret), ShouldNotEqual, HashString(key, "hunter3"))
+			So(HashString(key, secret), ShouldNotEqual, HashString("other key", secret))
+		})
+	})
+}
@@ -19,6 +19,8 @@ var distroSyntaxValidators = []distroValidator{
 	ensureValidSSHOptions,
 	ensureValidExpansions,
 	ensureStaticHostsAreNotSpawnable,
+	ensureValidComparators,
+	ensureValidHostAllocator,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -156,3 +158,50 @@ func ensureValidSSHOptions(d *distro.Distro, s *evergreen.Settings) []Validation
 	}
 	return nil
 }
+
+// ensureValidComparators checks that the distro's prioritizer settings only
+// name known comparators, each at most once, with non-negative weights.
+func ensureValidComparators(d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	errs := []ValidationError{}
+	seen := map[string]bool{}
+	for _, c := range d.PrioritizerSettings.Comparators {
+		if !util.SliceContains(distro.ValidComparators, c.Name) {
+			errs = append(errs, ValidationError{Error,
+				fmt.Sprintf("distro '%v' has unknown task comparator '%v'", distro.PrioritizerSettingsKey, c.Name)})
+		}
+		if seen[c.Name] {
+			errs = append(errs, ValidationError{Error,
+				fmt.Sprintf("distro '%v' lists task comparator '%v' more than once", distro.PrioritizerSettingsKey, c.Name)})
+		}
+		if c.Weight < 0 {
+			errs = append(errs, ValidationError{Error,
+				fmt.Sprintf("distro '%v' task comparator '%v' cannot have a negative weight", distro.PrioritizerSettingsKey, c.Name)})
+		}
+		seen[c.Name] = true
+	}
+	return errs
+}
+
+// ensureValidHostAllocator checks that the distro names a known host
+// allocator, and that the cost-based allocator is given a budget.
+func ensureValidHostAllocator(d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	settings := d.HostAllocatorSettings
+	if settings.Name != "" && !util.SliceContains(distro.ValidHostAllocators, settings.Name) {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro '%v' has unknown host allocator '%v'", distro.HostAllocatorSettingsKey, settings.Name)}}
+	}
+	if settings.HourlyBudget < 0 {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro '%v' hourly budget cannot be negative", distro.HostAllocatorSettingsKey)}}
+	}
+	if settings.HourlyHostCost < 0 {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro '%v' hourly host cost cannot be negative", distro.HostAllocatorSettingsKey)}}
+	}
+	if settings.Name == distro.HostAllocatorCost && settings.HourlyBudget == 0 {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro '%v' must set an hourly budget to use the cost-based host allocator",
+				distro.HostAllocatorSettingsKey)}}
+	}
+	return nil
+}
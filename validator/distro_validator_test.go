@@ -238,3 +238,67 @@ func TestEnsureValidSSHOptions(t *testing.T) {
 		})
 	})
 }
+
+func TestEnsureValidComparators(t *testing.T) {
+	Convey("When validating a distro's task comparators...", t, func() {
+		Convey("no comparators should be valid", func() {
+			So(ensureValidComparators(&distro.Distro{}, conf), ShouldBeEmpty)
+		})
+		Convey("known comparators with non-negative weights should be valid", func() {
+			d := &distro.Distro{PrioritizerSettings: distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{
+					{Name: distro.ComparatorByPriority, Weight: 2},
+					{Name: distro.ComparatorByWaitTime},
+				},
+			}}
+			So(ensureValidComparators(d, conf), ShouldBeEmpty)
+		})
+		Convey("an unknown comparator should be an error", func() {
+			d := &distro.Distro{PrioritizerSettings: distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{{Name: "by_luck"}},
+			}}
+			So(len(ensureValidComparators(d, conf)), ShouldEqual, 1)
+		})
+		Convey("a comparator listed more than once should be an error", func() {
+			d := &distro.Distro{PrioritizerSettings: distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{
+					{Name: distro.ComparatorByNumDeps},
+					{Name: distro.ComparatorByNumDeps},
+				},
+			}}
+			So(len(ensureValidComparators(d, conf)), ShouldEqual, 1)
+		})
+		Convey("a negative weight should be an error", func() {
+			d := &distro.Distro{PrioritizerSettings: distro.PrioritizerSettings{
+				Comparators: []distro.ComparatorSetting{{Name: distro.ComparatorByCreateTime, Weight: -1}},
+			}}
+			So(len(ensureValidComparators(d, conf)), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestEnsureValidHostAllocator(t *testing.T) {
+	Convey("When validating a distro's host allocator settings...", t, func() {
+		Convey("no settings should be valid", func() {
+			So(ensureValidHostAllocator(&distro.Distro{}, conf), ShouldBeNil)
+		})
+		Convey("an unknown allocator should be an error", func() {
+			d := &distro.Distro{HostAllocatorSettings: distro.HostAllocatorSettings{Name: "cheapest"}}
+			So(len(ensureValidHostAllocator(d, conf)), ShouldEqual, 1)
+		})
+		Convey("the cost-based allocator should require a positive budget", func() {
+			d := &distro.Distro{HostAllocatorSettings: distro.HostAllocatorSettings{Name: distro.HostAllocatorCost}}
+			So(len(ensureValidHostAllocator(d, conf)), ShouldEqual, 1)
+			d.HostAllocatorSettings.HourlyBudget = -1
+			So(len(ensureValidHostAllocator(d, conf)), ShouldEqual, 1)
+			d.HostAllocatorSettings.HourlyBudget = 2.5
+			So(ensureValidHostAllocator(d, conf), ShouldBeNil)
+		})
+		Convey("a negative hourly host cost should be an error", func() {
+			d := &distro.Distro{HostAllocatorSettings: distro.HostAllocatorSettings{HourlyHostCost: -0.5}}
+			So(len(ensureValidHostAllocator(d, conf)), ShouldEqual, 1)
+			d.HostAllocatorSettings.HourlyHostCost = 0.5
+			So(ensureValidHostAllocator(d, conf), ShouldBeNil)
+		})
+	})
+}
@@ -52,6 +52,9 @@ var projectSyntaxValidators = []projectValidator{
 	checkAllDependenciesSpec,
 	validateProjectTaskNames,
 	validateProjectTaskIdsAndTags,
+	validateTaskRetries,
+	validateTaskGroups,
+	validateDisplayTasks,
 }
 
 // Functions used to validate the semantics of a project configuration file.
@@ -59,6 +62,7 @@ var projectSyntaxValidators = []projectValidator{
 // suggested corrections are applied.
 var projectSemanticValidators = []projectValidator{
 	checkTaskCommands,
+	checkBisectStepback,
 }
 
 func (vr ValidationError) Error() string {
@@ -313,6 +317,7 @@ func ensureHasNecessaryProjectFields(project *model.Project) []ValidationError {
 
 	if project.CommandType != "" {
 		if project.CommandType != model.SystemCommandType &&
+			project.CommandType != model.SetupCommandType &&
 			project.CommandType != model.TestCommandType {
 			errs = append(errs,
 				ValidationError{
@@ -454,6 +459,31 @@ func checkTaskCommands(project *model.Project) []ValidationError {
 	return errs
 }
 
+// Checks that a project that bisects failures steps back on some of its
+// tasks, since bisection only replaces stepback
+func checkBisectStepback(project *model.Project) []ValidationError {
+	if !project.Bisect || project.Stepback {
+		return []ValidationError{}
+	}
+	for _, task := range project.Tasks {
+		if task.Stepback != nil && *task.Stepback {
+			return []ValidationError{}
+		}
+	}
+	for _, buildVariant := range project.BuildVariants {
+		if buildVariant.Stepback != nil && *buildVariant.Stepback {
+			return []ValidationError{}
+		}
+	}
+	return []ValidationError{
+		{
+			Message: fmt.Sprintf("project '%v' bisects failures but doesn't step back "+
+				"on any tasks, so no failures will be bisected", project.Identifier),
+			Level: Warning,
+		},
+	}
+}
+
 // Ensures there aren't any duplicate task names specified for any buildvariant
 // in this project
 func validateBVTaskNames(project *model.Project) []ValidationError {
@@ -492,6 +522,7 @@ func validateCommands(section string, project *model.Project, registry plugin.Re
 		}
 		if cmd.Type != "" {
 			if cmd.Type != model.SystemCommandType &&
+				cmd.Type != model.SetupCommandType &&
 				cmd.Type != model.TestCommandType {
 				msg := fmt.Sprintf("%v section in '%v': invalid command type: '%v'", section, command, cmd.Type)
 				errs = append(errs, ValidationError{Message: msg})
@@ -571,6 +602,16 @@ func validatePluginCommands(project *model.Project) []ValidationError {
 		errs = append(errs, validateCommands("timeout", project, pluginRegistry, project.Timeout.List())...)
 	}
 
+	// validate task group setup and teardown sections
+	for _, tg := range project.TaskGroups {
+		if tg.SetupGroup != nil {
+			errs = append(errs, validateCommands("setup_group", project, pluginRegistry, tg.SetupGroup.List())...)
+		}
+		if tg.TeardownGroup != nil {
+			errs = append(errs, validateCommands("teardown_group", project, pluginRegistry, tg.TeardownGroup.List())...)
+		}
+	}
+
 	// validate project tasks section
 	for _, task := range project.Tasks {
 		errs = append(errs, validateCommands("tasks", project, pluginRegistry, task.Commands)...)
@@ -625,6 +666,125 @@ func validateProjectTaskIdsAndTags(project *model.Project) []ValidationError {
 	return errs
 }
 
+// validateTaskRetries ensures that the automatic retry settings of tasks and
+// build variant tasks are within the task execution limit and only retry on
+// system or setup failures.
+func validateTaskRetries(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	validate := func(name string, maxRetries *int, retryOn []string) {
+		if maxRetries != nil && (*maxRetries < 0 || *maxRetries > evergreen.MaxTaskExecution) {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("%v has invalid max_retries %v: must be between 0 and %v",
+					name, *maxRetries, evergreen.MaxTaskExecution)})
+		}
+		for _, failureType := range retryOn {
+			if failureType != model.SystemCommandType && failureType != model.SetupCommandType {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("%v has invalid retry_on type '%v': must be '%v' or '%v'",
+						name, failureType, model.SystemCommandType, model.SetupCommandType)})
+			}
+		}
+	}
+
+	for _, task := range project.Tasks {
+		validate(fmt.Sprintf("task '%v'", task.Name), task.MaxRetries, task.RetryOn)
+	}
+	for _, bv := range project.BuildVariants {
+		for _, bvt := range bv.Tasks {
+			validate(fmt.Sprintf("task '%v' in build variant '%v'", bvt.Name, bv.Name),
+				bvt.MaxRetries, bvt.RetryOn)
+		}
+	}
+	return errs
+}
+
+// validateTaskGroups ensures that task groups are uniquely named and only
+// contain existing tasks, each of which is a member of at most one group.
+func validateTaskGroups(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	groupNames := map[string]bool{}
+	taskGroups := map[string]string{}
+	for _, tg := range project.TaskGroups {
+		if tg.Name == "" {
+			errs = append(errs, ValidationError{Message: "task group must have a name"})
+		} else if groupNames[tg.Name] {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("task group '%v' is defined more than once", tg.Name)})
+		}
+		groupNames[tg.Name] = true
+
+		if len(tg.Tasks) == 0 {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("task group '%v' does not contain any tasks", tg.Name)})
+		}
+		for _, t := range tg.Tasks {
+			if project.FindProjectTask(t) == nil {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task group '%v' contains non-existent task '%v'", tg.Name, t)})
+			}
+			if other, ok := taskGroups[t]; ok && other == tg.Name {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task group '%v' contains task '%v' more than once", tg.Name, t)})
+				continue
+			} else if ok {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%v' is in both task group '%v' and task group '%v'",
+						t, other, tg.Name)})
+				continue
+			}
+			taskGroups[t] = tg.Name
+		}
+	}
+	return errs
+}
+
+// validateDisplayTasks ensures that each build variant's display tasks are
+// uniquely named and only group tasks that run on the variant, each of which
+// is part of at most one display task.
+func validateDisplayTasks(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	for _, bv := range project.BuildVariants {
+		bvTasks := map[string]bool{}
+		for _, t := range bv.Tasks {
+			bvTasks[t.Name] = true
+		}
+		displayNames := map[string]bool{}
+		displayTasks := map[string]string{}
+		for _, dt := range bv.DisplayTasks {
+			if dt.Name == "" {
+				errs = append(errs, ValidationError{Message: fmt.Sprintf(
+					"display task in build variant '%v' must have a name", bv.Name)})
+			} else if displayNames[dt.Name] || bvTasks[dt.Name] {
+				errs = append(errs, ValidationError{Message: fmt.Sprintf(
+					"display task '%v' in build variant '%v' has the name of another task",
+					dt.Name, bv.Name)})
+			}
+			displayNames[dt.Name] = true
+
+			if len(dt.ExecutionTasks) == 0 {
+				errs = append(errs, ValidationError{Message: fmt.Sprintf(
+					"display task '%v' in build variant '%v' does not contain any execution tasks",
+					dt.Name, bv.Name)})
+			}
+			for _, et := range dt.ExecutionTasks {
+				if !bvTasks[et] {
+					errs = append(errs, ValidationError{Message: fmt.Sprintf(
+						"display task '%v' in build variant '%v' contains task '%v', "+
+							"which does not run on the variant", dt.Name, bv.Name, et)})
+				}
+				if other, ok := displayTasks[et]; ok {
+					errs = append(errs, ValidationError{Message: fmt.Sprintf(
+						"task '%v' in build variant '%v' is in both display task '%v' and display task '%v'",
+						et, bv.Name, other, dt.Name)})
+					continue
+				}
+				displayTasks[et] = dt.Name
+			}
+		}
+	}
+	return errs
+}
+
 // Makes sure that the dependencies for the tasks have the correct fields,
 // and that the fields reference valid tasks.
 func verifyTaskRequirements(project *model.Project) []ValidationError {
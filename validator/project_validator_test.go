@@ -825,6 +825,34 @@ func TestCheckTaskCommands(t *testing.T) {
 	})
 }
 
+func TestCheckBisectStepback(t *testing.T) {
+	Convey("When validating a project that bisects failures", t, func() {
+		stepback := true
+		project := &model.Project{
+			Bisect: true,
+			Tasks:  []model.ProjectTask{{Name: "compile"}},
+		}
+
+		Convey("a warning should be returned if no tasks step back", func() {
+			errs := checkBisectStepback(project)
+			So(len(errs), ShouldEqual, 1)
+			So(errs[0].Level, ShouldEqual, Warning)
+		})
+		Convey("no warning should be returned if the project steps back", func() {
+			project.Stepback = true
+			So(checkBisectStepback(project), ShouldResemble, []ValidationError{})
+		})
+		Convey("no warning should be returned if a task steps back", func() {
+			project.Tasks[0].Stepback = &stepback
+			So(checkBisectStepback(project), ShouldResemble, []ValidationError{})
+		})
+		Convey("no warning should be returned if a build variant steps back", func() {
+			project.BuildVariants = []model.BuildVariant{{Name: "linux", Stepback: &stepback}}
+			So(checkBisectStepback(project), ShouldResemble, []ValidationError{})
+		})
+	})
+}
+
 func TestEnsureReferentialIntegrity(t *testing.T) {
 	Convey("When validating a project", t, func() {
 		distroIds := []string{"rhel55"}
@@ -1440,3 +1468,110 @@ func TestEnsureHasNecessaryBVFields(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateTaskRetries(t *testing.T) {
+	Convey("When validating a project's task retries", t, func() {
+		retries := func(n int) *int { return &n }
+		Convey("valid retry settings should not throw an error", func() {
+			project := &model.Project{
+				Tasks: []model.ProjectTask{
+					{Name: "compile", MaxRetries: retries(2), RetryOn: []string{model.SystemCommandType, model.SetupCommandType}},
+					{Name: "test"},
+				},
+				BuildVariants: []model.BuildVariant{
+					{Name: "bv", Tasks: []model.BuildVariantTask{{Name: "compile", MaxRetries: retries(evergreen.MaxTaskExecution)}}},
+				},
+			}
+			So(validateTaskRetries(project), ShouldResemble, []ValidationError{})
+		})
+		Convey("out of range retry counts should throw an error", func() {
+			project := &model.Project{
+				Tasks: []model.ProjectTask{
+					{Name: "compile", MaxRetries: retries(-1)},
+				},
+				BuildVariants: []model.BuildVariant{
+					{Name: "bv", Tasks: []model.BuildVariantTask{{Name: "compile", MaxRetries: retries(evergreen.MaxTaskExecution + 1)}}},
+				},
+			}
+			So(len(validateTaskRetries(project)), ShouldEqual, 2)
+		})
+		Convey("retrying on test failures should throw an error", func() {
+			project := &model.Project{
+				Tasks: []model.ProjectTask{
+					{Name: "compile", MaxRetries: retries(1), RetryOn: []string{model.TestCommandType}},
+				},
+			}
+			So(len(validateTaskRetries(project)), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestValidateTaskGroups(t *testing.T) {
+	Convey("When validating a project's task groups", t, func() {
+		project := &model.Project{
+			Tasks: []model.ProjectTask{{Name: "compile"}, {Name: "test"}, {Name: "lint"}},
+		}
+		Convey("groups of existing tasks should not throw an error", func() {
+			project.TaskGroups = []model.TaskGroup{
+				{Name: "g1", Tasks: []string{"compile", "test"}},
+				{Name: "g2", Tasks: []string{"lint"}},
+			}
+			So(validateTaskGroups(project), ShouldResemble, []ValidationError{})
+		})
+		Convey("unnamed, duplicate and empty groups should throw an error", func() {
+			project.TaskGroups = []model.TaskGroup{
+				{Tasks: []string{"compile"}},
+				{Name: "g1", Tasks: []string{"test"}},
+				{Name: "g1", Tasks: []string{"lint"}},
+				{Name: "g2"},
+			}
+			So(len(validateTaskGroups(project)), ShouldEqual, 3)
+		})
+		Convey("non-existent and repeated tasks should throw an error", func() {
+			project.TaskGroups = []model.TaskGroup{
+				{Name: "g1", Tasks: []string{"compile", "compile", "missing"}},
+				{Name: "g2", Tasks: []string{"compile"}},
+			}
+			errs := validateTaskGroups(project)
+			So(len(errs), ShouldEqual, 3)
+			So(errs[0].Message, ShouldContainSubstring, "more than once")
+			So(errs[1].Message, ShouldContainSubstring, "non-existent task 'missing'")
+			So(errs[2].Message, ShouldContainSubstring, "task group 'g1' and task group 'g2'")
+		})
+	})
+}
+
+func TestValidateDisplayTasks(t *testing.T) {
+	Convey("When validating a project's display tasks", t, func() {
+		project := &model.Project{
+			BuildVariants: []model.BuildVariant{{
+				Name:  "bv",
+				Tasks: []model.BuildVariantTask{{Name: "compile"}, {Name: "test1"}, {Name: "test2"}},
+			}},
+		}
+		Convey("display tasks grouping the variant's tasks should not throw an error", func() {
+			project.BuildVariants[0].DisplayTasks = []model.DisplayTask{
+				{Name: "tests", ExecutionTasks: []string{"test1", "test2"}},
+			}
+			So(validateDisplayTasks(project), ShouldResemble, []ValidationError{})
+		})
+		Convey("display tasks named like another task should throw an error", func() {
+			project.BuildVariants[0].DisplayTasks = []model.DisplayTask{
+				{Name: "compile", ExecutionTasks: []string{"test1"}},
+				{Name: "tests", ExecutionTasks: []string{"test2"}},
+				{Name: "tests", ExecutionTasks: []string{}},
+			}
+			So(len(validateDisplayTasks(project)), ShouldEqual, 3)
+		})
+		Convey("display tasks with missing or shared execution tasks should throw an error", func() {
+			project.BuildVariants[0].DisplayTasks = []model.DisplayTask{
+				{Name: "tests", ExecutionTasks: []string{"test1", "lint"}},
+				{Name: "more_tests", ExecutionTasks: []string{"test1"}},
+			}
+			errs := validateDisplayTasks(project)
+			So(len(errs), ShouldEqual, 2)
+			So(errs[0].Message, ShouldContainSubstring, "'lint'")
+			So(errs[1].Message, ShouldContainSubstring, "both display task 'tests' and display task 'more_tests'")
+		})
+	})
+}